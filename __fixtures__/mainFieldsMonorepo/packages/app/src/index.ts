@@ -0,0 +1,4 @@
+import { render } from 'web-lib'
+import { platform } from 'native-lib'
+
+export const app = render(platform)
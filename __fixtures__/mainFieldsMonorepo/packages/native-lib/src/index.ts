@@ -0,0 +1 @@
+export const platform = 'web'
@@ -0,0 +1,6 @@
+import { readFileSync } from 'fs'
+import { randomId } from 'crypto'
+import './legacy'
+
+export { render } from './server'
+export const browserApi = { readFileSync, randomId }
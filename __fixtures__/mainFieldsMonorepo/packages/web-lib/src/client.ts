@@ -0,0 +1 @@
+export const render = (value: string) => `client:${value}`
@@ -0,0 +1 @@
+export const randomId = () => Math.random().toString(36)
@@ -0,0 +1 @@
+export { render } from './server'
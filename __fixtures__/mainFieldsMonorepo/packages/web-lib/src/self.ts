@@ -0,0 +1,5 @@
+import { render } from 'web-lib'
+import { render as serverRender } from 'web-lib/src/server'
+import 'web-lib/src/legacy'
+
+export const selfApi = { render, serverRender }
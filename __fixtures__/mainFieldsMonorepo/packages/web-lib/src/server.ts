@@ -0,0 +1 @@
+export const render = (value: string) => `server:${value}`
@@ -0,0 +1,34 @@
+#!/usr/bin/env node
+/* eslint-disable */
+"use strict";
+
+function $$SETUP_STATE(hydrateRuntimeState, basePath) {
+  return hydrateRuntimeState(JSON.parse(RAW_RUNTIME_STATE), {basePath: basePath || __dirname});
+}
+
+const RAW_RUNTIME_STATE =
+'{"__info":["This file is automatically generated. Do not touch it, or risk","your modifications bein\
+g lost."],"dependencyTreeRoots":[{"name":"pnp-app","reference":"workspace:."}],"enableTopLevelFallba\
+ck":true,"ignorePatternData":null,"fallbackExclusionList":[["pnp-app",["workspace:."]]],"fallbackPoo\
+l":[],"packageRegistryData":[[null,[[null,{"packageLocation":"./","packageDependencies":[["@repo/tsc\
+onfig","npm:1.0.0"],["esbuild","npm:0.19.0"],["eslint","npm:8.0.0"],["left-pad","npm:1.3.0"],["lodas\
+h","npm:4.17.21"]],"linkType":"SOFT"}]]],["@repo/tsconfig",[["npm:1.0.0",{"packageLocation":"./.yarn\
+/cache/@repo-tsconfig-npm-1.0.0-9f8e7d6c5b-1a2b3c4d5e.zip/node_modules/@repo/tsconfig/","packageDepe\
+ndencies":[["@repo/tsconfig","npm:1.0.0"]],"linkType":"HARD"}]]],["debug",[["npm:4.3.4",{"packageLoc\
+ation":"./.yarn/cache/debug-npm-4.3.4-4e2f1c3b5a-3dbad3f94e.zip/node_modules/debug/","packageDepende\
+ncies":[["debug","npm:4.3.4"]],"linkType":"HARD"}],["virtual:0123456789abcdef#npm:4.3.4",{"packageLo\
+cation":"./.yarn/__virtual__/debug-virtual-8a9b0c1d2e/0/cache/debug-npm-4.3.4-4e2f1c3b5a-3dbad3f94e.\
+zip/node_modules/debug/","packageDependencies":[["debug","virtual:0123456789abcdef#npm:4.3.4"],["sup\
+ports-color",null]],"linkType":"HARD"}]]],["esbuild",[["npm:0.19.0",{"packageLocation":"./.yarn/unpl\
+ugged/esbuild-npm-0.19.0-5f6e7d8c9b/node_modules/esbuild/","packageDependencies":[["esbuild","npm:0.\
+19.0"]],"linkType":"HARD"}]]],["eslint",[["npm:8.0.0",{"packageLocation":"./.yarn/cache/eslint-npm-8\
+.0.0-1234567890-abcdef0123.zip/node_modules/eslint/","packageDependencies":[["eslint","npm:8.0.0"],[\
+"debug","virtual:0123456789abcdef#npm:4.3.4"]],"linkType":"HARD"}]]],["left-pad",[["npm:1.3.0",{"pac\
+kageLocation":"./.yarn/cache/left-pad-npm-1.3.0-ab1c2d3e4f-0a1b2c3d4e.zip/node_modules/left-pad/","p\
+ackageDependencies":[["left-pad","npm:1.3.0"]],"linkType":"HARD"}]]],["lodash",[["npm:4.17.21",{"pac\
+kageLocation":"./.yarn/cache/lodash-npm-4.17.21-6382451519-eb835a2e51.zip/node_modules/lodash/","pac\
+kageDependencies":[["lodash","npm:4.17.21"]],"linkType":"HARD"}]]],["pnp-app",[["workspace:.",{"pack\
+ageLocation":"./","packageDependencies":[["@repo/tsconfig","npm:1.0.0"],["esbuild","npm:0.19.0"],["e\
+slint","npm:8.0.0"],["left-pad","npm:1.3.0"],["lodash","npm:4.17.21"]],"linkType":"SOFT"}]]]]}';
+
+module.exports = {};
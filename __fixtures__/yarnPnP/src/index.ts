@@ -0,0 +1,4 @@
+import get from "lodash/get";
+import leftPad from "left-pad";
+
+export const value = leftPad(String(get({}, "a")), 4);
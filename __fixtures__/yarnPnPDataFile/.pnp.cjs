@@ -0,0 +1,5 @@
+#!/usr/bin/env node
+/* eslint-disable */
+"use strict";
+
+module.exports = {};
@@ -23,10 +23,13 @@ const sidebars: SidebarsConfig = {
           label: 'Checks',
           items: [
             'config-based-checks/checks/module-boundaries',
+            'config-based-checks/checks/layers',
+            'config-based-checks/checks/barrel-files',
             'config-based-checks/checks/restricted-imports',
             'config-based-checks/checks/restricted-importers',
             'config-based-checks/checks/restricted-direct-importers',
             'config-based-checks/checks/import-conventions',
+            'config-based-checks/checks/type-imports',
             'config-based-checks/checks/circular-imports',
             'config-based-checks/checks/orphan-files',
             'config-based-checks/checks/unused-exports',
@@ -34,6 +37,14 @@ const sidebars: SidebarsConfig = {
             'config-based-checks/checks/missing-node-modules',
             'config-based-checks/checks/dev-deps-on-prod',
             'config-based-checks/checks/unresolved-imports',
+            'config-based-checks/checks/workspace-protocol',
+            'config-based-checks/checks/unused-workspace-packages',
+            'config-based-checks/checks/deep-imports',
+            'config-based-checks/checks/complexity-budgets',
+            'config-based-checks/checks/ownership-boundaries',
+            'config-based-checks/checks/test-isolation',
+            'config-based-checks/checks/peer-dependencies',
+            'config-based-checks/checks/version-consistency',
           ],
         },
         'config-based-checks/running-checks-and-autofix',
@@ -50,6 +61,7 @@ const sidebars: SidebarsConfig = {
         'exploratory-toolkit/files',
         'exploratory-toolkit/imported-by',
         'exploratory-toolkit/resolve',
+        'exploratory-toolkit/why-resolve',
         'exploratory-toolkit/circular',
         'exploratory-toolkit/node-modules',
         'exploratory-toolkit/lines-of-code',
@@ -0,0 +1,185 @@
+package checks
+
+import (
+	"testing"
+
+	"rev-dep-go/internal/rules"
+)
+
+// barrelReexport is an `export { names } from request` entry resolved to id.
+func barrelReexport(id, request string, names ...string) MinimalDependency {
+	dep := userDep(id, request)
+	dep.Keywords = &KeywordMap{}
+	for _, name := range names {
+		dep.Keywords.Add(KeywordInfo{Name: name})
+	}
+	dep.ExportKeyStart = 0
+	dep.ExportKeyEnd = 6
+	return dep
+}
+
+// barrelLocalExport is a local `export const name` declaration.
+func barrelLocalExport(name string) MinimalDependency {
+	return mkLocalExport([]KeywordInfo{{Name: name}}, 0, 6, 7)
+}
+
+// barrelNamedImport is an `import { names } from request` entry with request offsets set.
+func barrelNamedImport(id, request string, requestStart int, names ...string) MinimalDependency {
+	dep := userDep(id, request)
+	dep.Keywords = &KeywordMap{}
+	for _, name := range names {
+		dep.Keywords.Add(KeywordInfo{Name: name})
+	}
+	dep.RequestStart = uint32(requestStart)
+	dep.RequestEnd = uint32(requestStart + len(request) + 2)
+	return dep
+}
+
+// barrelFixture has a feature barrel re-exporting two files (one through a nested star barrel) and
+// importers inside and outside the feature.
+func barrelFixture() (MinimalDependencyTree, []string) {
+	tree := MinimalDependencyTree{
+		"/repo/src/auth/index.ts": {
+			barrelReexport("/repo/src/auth/login.ts", "./login", "login"),
+			barrelReexport("/repo/src/auth/internal/index.ts", "./internal", "*"),
+		},
+		"/repo/src/auth/internal/index.ts": {
+			barrelReexport("/repo/src/auth/internal/session.ts", "./session", "*"),
+		},
+		"/repo/src/auth/login.ts":            {barrelLocalExport("login")},
+		"/repo/src/auth/internal/session.ts": {barrelLocalExport("session")},
+		"/repo/src/auth/logout.ts": {
+			barrelNamedImport("/repo/src/auth/index.ts", "./index", 20, "session"),
+			barrelLocalExport("logout"),
+		},
+		"/repo/src/auth/profile.ts": {
+			barrelNamedImport("/repo/src/auth/index.ts", "./index", 20, "session", "login"),
+		},
+		"/repo/src/app.ts": {
+			barrelNamedImport("/repo/src/auth/index.ts", "./auth", 20, "login"),
+			barrelNamedImport("/repo/src/auth/internal/session.ts", "./auth/internal/session", 60, "session"),
+		},
+	}
+	files := make([]string, 0, len(tree))
+	for file := range tree {
+		files = append(files, file)
+	}
+	return tree, files
+}
+
+func TestFindBarrelFiles(t *testing.T) {
+	tree, files := barrelFixture()
+
+	barrels := FindBarrelFiles(tree, files)
+
+	if len(barrels) != 2 {
+		t.Fatalf("expected 2 barrels, got %v", barrels)
+	}
+	if barrels["/repo/src/auth/index.ts"] != 2 {
+		t.Errorf("expected fan-out 2 for auth/index.ts, got %d", barrels["/repo/src/auth/index.ts"])
+	}
+	if barrels["/repo/src/auth/internal/index.ts"] != 1 {
+		t.Errorf("expected fan-out 1 for auth/internal/index.ts, got %d", barrels["/repo/src/auth/internal/index.ts"])
+	}
+}
+
+func TestFindBarrelFileViolations_ReportBarrelFiles(t *testing.T) {
+	tree, files := barrelFixture()
+
+	violations := FindBarrelFileViolations(tree, files, &rules.BarrelFilesDetectionOptions{
+		Enabled:           true,
+		ReportBarrelFiles: true,
+		MaxFanOut:         1,
+	}, "/repo")
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", violations)
+	}
+	if violations[0].ViolationType != BarrelFileViolationBarrel || violations[0].FilePath != "/repo/src/auth/index.ts" || violations[0].FanOut != 2 {
+		t.Errorf("unexpected violation: %+v", violations[0])
+	}
+}
+
+func TestFindBarrelFileViolations_PublicBarrelBypass(t *testing.T) {
+	tree, files := barrelFixture()
+
+	violations := FindBarrelFileViolations(tree, files, &rules.BarrelFilesDetectionOptions{
+		Enabled:       true,
+		PublicBarrels: []string{"src/*/index.ts"},
+	}, "/repo")
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", violations)
+	}
+	expected := BarrelFileViolation{
+		ViolationType: BarrelFileViolationBypass,
+		FilePath:      "/repo/src/app.ts",
+		ImportPath:    "/repo/src/auth/internal/session.ts",
+		ImportRequest: "./auth/internal/session",
+		BarrelPath:    "/repo/src/auth/index.ts",
+	}
+	if violations[0] != expected {
+		t.Errorf("got: %+v\nwant: %+v", violations[0], expected)
+	}
+}
+
+func TestFindBarrelFileViolations_WithinFeatureAutofix(t *testing.T) {
+	tree, files := barrelFixture()
+
+	violations := FindBarrelFileViolations(tree, files, &rules.BarrelFilesDetectionOptions{
+		Enabled:                      true,
+		NoBarrelImportsWithinFeature: true,
+		IgnoreFiles:                  []string{"src/app.ts"},
+		Autofix:                      true,
+	}, "/repo")
+
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %+v", violations)
+	}
+
+	logout := violations[0]
+	if logout.FilePath != "/repo/src/auth/logout.ts" || logout.ViolationType != BarrelFileViolationWithinFeature {
+		t.Fatalf("unexpected violation: %+v", logout)
+	}
+	if logout.Fix == nil {
+		t.Fatal("expected a fix for a name traced through star re-exports")
+	}
+	if logout.Fix.Text != "./internal/session" || logout.Fix.Start != 20 || logout.Fix.End != 29 {
+		t.Errorf("unexpected fix: %+v", *logout.Fix)
+	}
+
+	// Names declared in different files cannot be fixed by rewriting the request.
+	profile := violations[1]
+	if profile.FilePath != "/repo/src/auth/profile.ts" {
+		t.Fatalf("unexpected violation: %+v", profile)
+	}
+	if profile.Fix != nil {
+		t.Errorf("expected no fix for names spread over several files, got %+v", *profile.Fix)
+	}
+}
+
+func TestFindBarrelFileViolations_RenamedReexportIsNotFixable(t *testing.T) {
+	tree := MinimalDependencyTree{
+		"/repo/src/auth/index.ts": {func() MinimalDependency {
+			dep := barrelReexport("/repo/src/auth/login.ts", "./login")
+			dep.Keywords.Add(KeywordInfo{Name: "login", Alias: "signIn"})
+			return dep
+		}()},
+		"/repo/src/auth/login.ts":  {barrelLocalExport("login")},
+		"/repo/src/auth/logout.ts": {barrelNamedImport("/repo/src/auth/index.ts", "./index", 20, "signIn")},
+	}
+	files := []string{"/repo/src/auth/index.ts", "/repo/src/auth/login.ts", "/repo/src/auth/logout.ts"}
+
+	violations := FindBarrelFileViolations(tree, files, &rules.BarrelFilesDetectionOptions{
+		Enabled:                      true,
+		NoBarrelImportsWithinFeature: true,
+		Autofix:                      true,
+	}, "/repo")
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", violations)
+	}
+	if violations[0].Fix != nil {
+		t.Errorf("expected no fix through a renamed re-export, got %+v", *violations[0].Fix)
+	}
+}
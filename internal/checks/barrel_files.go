@@ -0,0 +1,278 @@
+package checks
+
+import (
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/rules"
+	"rev-dep-go/internal/sourceedit"
+)
+
+// Barrel file violation types.
+const (
+	// BarrelFileViolationBarrel: a re-export-only file, reported with its fan-out.
+	BarrelFileViolationBarrel = "barrel-file"
+	// BarrelFileViolationBypass: a file outside a public barrel's directory imports a file inside
+	// it directly instead of going through the barrel.
+	BarrelFileViolationBypass = "barrel-bypass"
+	// BarrelFileViolationWithinFeature: a file imports through a barrel of a directory it belongs to.
+	BarrelFileViolationWithinFeature = "barrel-within-feature"
+)
+
+// BarrelFileViolation represents a barrel file or an import that breaks a barrel policy
+type BarrelFileViolation struct {
+	ViolationType string
+	FilePath      string // The barrel file for "barrel-file", the importing file otherwise
+	ImportPath    string // Imported file (empty for "barrel-file")
+	ImportRequest string
+	BarrelPath    string
+	FanOut        int                // Number of distinct modules the barrel re-exports from
+	Fix           *sourceedit.Change // Rewrite to the direct source file, nil if not auto-fixable
+}
+
+// isReExport reports whether dep is an `export ... from` entry (detailed parse mode).
+func isReExport(dep *MinimalDependency) bool {
+	return dep.ExportKeyEnd > 0 && !dep.IsLocalExport
+}
+
+// FindBarrelFiles returns the re-export-only files among files, mapped to their fan-out (the
+// number of distinct modules they re-export from). Requires a tree built in detailed parse mode.
+func FindBarrelFiles(minimalTree MinimalDependencyTree, files []string) map[string]int {
+	barrels := make(map[string]int)
+	for _, file := range files {
+		deps := minimalTree[file]
+		if len(deps) == 0 {
+			continue
+		}
+		sources := make(map[string]bool)
+		isBarrel := true
+		for i := range deps {
+			if !isReExport(&deps[i]) {
+				isBarrel = false
+				break
+			}
+			source := deps[i].ID
+			if source == "" {
+				source = deps[i].Request
+			}
+			sources[source] = true
+		}
+		if isBarrel {
+			barrels[file] = len(sources)
+		}
+	}
+	return barrels
+}
+
+// FindBarrelFileViolations reports barrel files (with ReportBarrelFiles) and imports that break the
+// barrel policies of opts: bypassing a public barrel from outside its directory, and (with
+// NoBarrelImportsWithinFeature) importing through a barrel of the importer's own directory. The
+// latter gets a fix rewriting the request to the direct source file when Autofix is set and every
+// imported name traces, through `export { x } from` / `export * from`, to the same file.
+func FindBarrelFileViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	opts *rules.BarrelFilesDetectionOptions,
+	cwd string,
+) []BarrelFileViolation {
+	var violations []BarrelFileViolation
+
+	barrels := FindBarrelFiles(minimalTree, files)
+	ignoreMatchers := globutil.CreateGlobMatchers(opts.IgnoreFiles, cwd)
+
+	if opts.ReportBarrelFiles {
+		for barrel, fanOut := range barrels {
+			if fanOut <= opts.MaxFanOut {
+				continue
+			}
+			if globutil.MatchesAnyGlobMatcher(barrel, ignoreMatchers, false) {
+				continue
+			}
+			violations = append(violations, BarrelFileViolation{
+				ViolationType: BarrelFileViolationBarrel,
+				FilePath:      barrel,
+				BarrelPath:    barrel,
+				FanOut:        fanOut,
+			})
+		}
+	}
+
+	publicBarrelMatchers := globutil.CreateGlobMatchers(opts.PublicBarrels, cwd)
+	var publicBarrels []string
+	for _, file := range files {
+		if globutil.MatchesAnyGlobMatcher(file, publicBarrelMatchers, false) {
+			publicBarrels = append(publicBarrels, file)
+		}
+	}
+	// Outermost directories first, so a bypass is reported against the widest barrel it skips.
+	slices.SortFunc(publicBarrels, func(a, b string) int {
+		if depthA, depthB := strings.Count(a, "/"), strings.Count(b, "/"); depthA != depthB {
+			return depthA - depthB
+		}
+		return strings.Compare(a, b)
+	})
+
+	isInDir := func(file, dir string) bool {
+		return strings.HasPrefix(file, dir+"/")
+	}
+
+	for _, file := range files {
+		if globutil.MatchesAnyGlobMatcher(file, ignoreMatchers, false) {
+			continue
+		}
+		deps := minimalTree[file]
+		for i := range deps {
+			dep := &deps[i]
+			if dep.ID == "" || dep.IsLocalExport || (dep.ResolvedType != UserModule && dep.ResolvedType != MonorepoModule) {
+				continue
+			}
+			target := dep.ID
+
+			bypassed := ""
+			for _, barrel := range publicBarrels {
+				barrelDir := path.Dir(barrel)
+				if target != barrel && isInDir(target, barrelDir) && !isInDir(file, barrelDir) {
+					bypassed = barrel
+					break
+				}
+			}
+			if bypassed != "" {
+				violations = append(violations, BarrelFileViolation{
+					ViolationType: BarrelFileViolationBypass,
+					FilePath:      file,
+					ImportPath:    target,
+					ImportRequest: dep.Request,
+					BarrelPath:    bypassed,
+				})
+				continue
+			}
+
+			if !opts.NoBarrelImportsWithinFeature || file == target {
+				continue
+			}
+			_, isBarrel := barrels[target]
+			if !isBarrel && !globutil.MatchesAnyGlobMatcher(target, publicBarrelMatchers, false) {
+				continue
+			}
+			if !isInDir(file, path.Dir(target)) {
+				continue
+			}
+			violation := BarrelFileViolation{
+				ViolationType: BarrelFileViolationWithinFeature,
+				FilePath:      file,
+				ImportPath:    target,
+				ImportRequest: dep.Request,
+				BarrelPath:    target,
+			}
+			if opts.Autofix {
+				violation.Fix = barrelImportFix(minimalTree, file, dep)
+			}
+			violations = append(violations, violation)
+		}
+	}
+
+	slices.SortFunc(violations, func(a, b BarrelFileViolation) int {
+		if a.FilePath != b.FilePath {
+			return strings.Compare(a.FilePath, b.FilePath)
+		}
+		if a.ImportPath != b.ImportPath {
+			return strings.Compare(a.ImportPath, b.ImportPath)
+		}
+		return strings.Compare(a.ViolationType, b.ViolationType)
+	})
+
+	return violations
+}
+
+// barrelImportFix rewrites an import through a barrel to the file that declares the imported
+// names. Only named imports whose names all trace to one file are fixable; namespace, side-effect
+// and dynamic imports, and names spread over several files, are not.
+func barrelImportFix(minimalTree MinimalDependencyTree, file string, dep *MinimalDependency) *sourceedit.Change {
+	if dep.IsDynamicImport || isReExport(dep) || dep.Keywords == nil || dep.Keywords.Len() == 0 || dep.RequestEnd <= dep.RequestStart {
+		return nil
+	}
+
+	source := ""
+	for _, kw := range dep.Keywords.Keywords {
+		if kw.Name == "*" {
+			return nil
+		}
+		declaringFile := traceExportedName(minimalTree, dep.ID, kw.Name, map[string]bool{})
+		if declaringFile == "" || (source != "" && declaringFile != source) {
+			return nil
+		}
+		source = declaringFile
+	}
+	if source == "" || source == dep.ID {
+		return nil
+	}
+
+	newRequest, err := filepath.Rel(filepath.Dir(file), source)
+	if err != nil {
+		return nil
+	}
+	newRequest = filepath.ToSlash(newRequest)
+	if !strings.HasPrefix(newRequest, "./") && !strings.HasPrefix(newRequest, "../") {
+		newRequest = "./" + newRequest
+	}
+	newRequest = adjustImportPathStyle(newRequest, dep.Request)
+
+	return &sourceedit.Change{
+		Start: int32(dep.RequestStart),
+		End:   int32(dep.RequestEnd),
+		Text:  newRequest,
+	}
+}
+
+// traceExportedName follows re-exports from file until it reaches the file declaring name locally.
+// Renamed re-exports (`export { a as b } from`) stop the trace, since rewriting the request alone
+// would import the wrong name. Returns "" when the name cannot be traced.
+func traceExportedName(minimalTree MinimalDependencyTree, file, name string, visited map[string]bool) string {
+	if visited[file] {
+		return ""
+	}
+	visited[file] = true
+
+	deps := minimalTree[file]
+	for i := range deps {
+		dep := &deps[i]
+		if dep.Keywords == nil {
+			continue
+		}
+		for _, kw := range dep.Keywords.Keywords {
+			exported := kw.Name
+			if kw.Alias != "" {
+				exported = kw.Alias
+			}
+			if exported != name {
+				continue
+			}
+			if dep.IsLocalExport {
+				return file
+			}
+			if isReExport(dep) && kw.Alias == "" && dep.ID != "" {
+				return traceExportedName(minimalTree, dep.ID, name, visited)
+			}
+			return ""
+		}
+	}
+
+	// Not re-exported by name: try the star re-exports.
+	for i := range deps {
+		dep := &deps[i]
+		if !isReExport(dep) || dep.ID == "" || dep.Keywords == nil {
+			continue
+		}
+		for _, kw := range dep.Keywords.Keywords {
+			if kw.Name == "*" && kw.Alias == "" {
+				if declaringFile := traceExportedName(minimalTree, dep.ID, name, visited); declaringFile != "" {
+					return declaringFile
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -86,6 +86,40 @@ func FindCircularDependencies(deps MinimalDependencyTree, sortedFilesList []stri
 // FindCircularDependenciesSCC detects circular dependencies using strongly connected components (SCCs).
 // It returns one deterministic cycle representation per SCC.
 func FindCircularDependenciesSCC(deps MinimalDependencyTree, sortedFilesList []string, ignoreTypeImports bool) [][]string {
+	adj := circularAdjacency(deps, sortedFilesList, ignoreTypeImports)
+	sccs := stronglyConnectedComponents(adj, sortedFilesList)
+
+	cycles := make([][]string, 0, len(sccs))
+	for _, scc := range sccs {
+		if len(scc) == 1 {
+			if !hasSelfLoop(adj, scc[0]) {
+				continue
+			}
+		}
+
+		sort.Strings(scc)
+		inSCC := make(map[string]struct{}, len(scc))
+		for _, n := range scc {
+			inSCC[n] = struct{}{}
+		}
+
+		start := scc[0]
+		cycle := findDeterministicCycle(start, adj, inSCC)
+		if len(cycle) == 0 {
+			cycle = []string{start, start}
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return strings.Join(cycles[i], "\x00") < strings.Join(cycles[j], "\x00")
+	})
+
+	return cycles
+}
+
+// circularAdjacency returns the sorted import edges between files of sortedFilesList.
+func circularAdjacency(deps MinimalDependencyTree, sortedFilesList []string, ignoreTypeImports bool) map[string][]string {
 	nodeSet := make(map[string]struct{}, len(sortedFilesList))
 	for _, node := range sortedFilesList {
 		nodeSet[node] = struct{}{}
@@ -109,7 +143,11 @@ func FindCircularDependenciesSCC(deps MinimalDependencyTree, sortedFilesList []s
 			sort.Strings(adj[node])
 		}
 	}
+	return adj
+}
 
+// stronglyConnectedComponents returns the SCCs of adj (Tarjan), visiting nodes in sortedFilesList order.
+func stronglyConnectedComponents(adj map[string][]string, sortedFilesList []string) [][]string {
 	index := 0
 	indices := make(map[string]int, len(sortedFilesList))
 	lowlink := make(map[string]int, len(sortedFilesList))
@@ -159,34 +197,7 @@ func FindCircularDependenciesSCC(deps MinimalDependencyTree, sortedFilesList []s
 			strongconnect(v)
 		}
 	}
-
-	cycles := make([][]string, 0, len(sccs))
-	for _, scc := range sccs {
-		if len(scc) == 1 {
-			if !hasSelfLoop(adj, scc[0]) {
-				continue
-			}
-		}
-
-		sort.Strings(scc)
-		inSCC := make(map[string]struct{}, len(scc))
-		for _, n := range scc {
-			inSCC[n] = struct{}{}
-		}
-
-		start := scc[0]
-		cycle := findDeterministicCycle(start, adj, inSCC)
-		if len(cycle) == 0 {
-			cycle = []string{start, start}
-		}
-		cycles = append(cycles, cycle)
-	}
-
-	sort.Slice(cycles, func(i, j int) bool {
-		return strings.Join(cycles[i], "\x00") < strings.Join(cycles[j], "\x00")
-	})
-
-	return cycles
+	return sccs
 }
 
 func hasSelfLoop(adj map[string][]string, node string) bool {
@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/rules"
+)
+
+func TestApplyCycleBudgets(t *testing.T) {
+	legacy := []string{"/repo/src/legacy/a.ts", "/repo/src/legacy/b.ts", "/repo/src/legacy/a.ts"}
+	fresh := []string{"/repo/src/app/x.ts", "/repo/src/app/y.ts", "/repo/src/app/x.ts"}
+	mixed := []string{"/repo/src/legacy/a.ts", "/repo/src/app/x.ts", "/repo/src/legacy/a.ts"}
+	cycles := [][]string{legacy, fresh, mixed}
+	budgets := []rules.CycleBudget{{Files: []string{"src/legacy/**"}, MaxCycles: 1}}
+
+	failing, usage := ApplyCycleBudgets(cycles, 2, budgets, "/repo")
+	if len(failing) != 0 {
+		t.Errorf("expected every budget to hold, got failing cycles %v", failing)
+	}
+	expectedUsage := []CycleBudgetUsage{
+		{MaxCycles: 2, Cycles: 2},
+		{Files: []string{"src/legacy/**"}, MaxCycles: 1, Cycles: 1},
+	}
+	if !reflect.DeepEqual(usage, expectedUsage) {
+		t.Errorf("unexpected usage:\n got: %+v\nwant: %+v", usage, expectedUsage)
+	}
+
+	// The mixed cycle is not fully inside src/legacy, so it counts against the rule-wide limit.
+	failing, _ = ApplyCycleBudgets(cycles, 1, budgets, "/repo")
+	if !reflect.DeepEqual(failing, [][]string{fresh, mixed}) {
+		t.Errorf("expected the cycles of the exceeded rule-wide budget, got %v", failing)
+	}
+
+	failing, _ = ApplyCycleBudgets(cycles, 0, nil, "/repo")
+	if len(failing) != 3 {
+		t.Errorf("expected every cycle to fail without budgets, got %v", failing)
+	}
+}
+
+func TestFindCycleBreakingEdges(t *testing.T) {
+	tree := MinimalDependencyTree{
+		// a -> b -> a and a -> b -> c -> a: a -> b breaks both.
+		"/repo/a.ts": {userDep("/repo/b.ts", "./b")},
+		"/repo/b.ts": {userDep("/repo/a.ts", "./a"), userDep("/repo/c.ts", "./c")},
+		"/repo/c.ts": {userDep("/repo/a.ts", "./a")},
+		// d -> e -> d, broken at its first edge.
+		"/repo/d.ts": {userDep("/repo/e.ts", "./e")},
+		"/repo/e.ts": {userDep("/repo/d.ts", "./d")},
+		// Self import.
+		"/repo/f.ts": {userDep("/repo/f.ts", "./f")},
+		// No cycle.
+		"/repo/g.ts": {userDep("/repo/a.ts", "./a")},
+	}
+	files := []string{"/repo/a.ts", "/repo/b.ts", "/repo/c.ts", "/repo/d.ts", "/repo/e.ts", "/repo/f.ts", "/repo/g.ts"}
+
+	suggestions := FindCycleBreakingEdges(tree, files, false)
+
+	expected := []CycleBreakingSuggestion{
+		{
+			Files:  []string{"/repo/a.ts", "/repo/b.ts", "/repo/c.ts"},
+			Cycles: 2,
+			Edges:  []CycleBreakingEdge{{From: "/repo/a.ts", To: "/repo/b.ts", Request: "./b", Cycles: 2}},
+		},
+		{
+			Files:  []string{"/repo/d.ts", "/repo/e.ts"},
+			Cycles: 1,
+			Edges:  []CycleBreakingEdge{{From: "/repo/d.ts", To: "/repo/e.ts", Request: "./e", Cycles: 1}},
+		},
+		{
+			Files:  []string{"/repo/f.ts"},
+			Cycles: 1,
+			Edges:  []CycleBreakingEdge{{From: "/repo/f.ts", To: "/repo/f.ts", Request: "./f", Cycles: 1}},
+		},
+	}
+	if !reflect.DeepEqual(suggestions, expected) {
+		t.Errorf("unexpected suggestions:\n got: %+v\nwant: %+v", suggestions, expected)
+	}
+}
+
+// A complete graph on four files has 20 elementary cycles; the suggested imports must break them
+// all, and none of them may be redundant.
+func TestFindCycleBreakingEdges_BreaksEveryCycleWithoutRedundantImports(t *testing.T) {
+	files := []string{"/repo/a.ts", "/repo/b.ts", "/repo/c.ts", "/repo/d.ts"}
+	tree := MinimalDependencyTree{}
+	for _, from := range files {
+		for _, to := range files {
+			if from != to {
+				tree[from] = append(tree[from], userDep(to, to))
+			}
+		}
+	}
+
+	suggestions := FindCycleBreakingEdges(tree, files, false)
+	if len(suggestions) != 1 || suggestions[0].Cycles != 20 || suggestions[0].Truncated {
+		t.Fatalf("expected one component with 20 cycles, got %+v", suggestions)
+	}
+
+	adj := circularAdjacency(tree, files, false)
+	removed := map[[2]string]bool{}
+	for _, edge := range suggestions[0].Edges {
+		removed[[2]string{edge.From, edge.To}] = true
+	}
+	if hasCycle(files, adj, removed) {
+		t.Fatalf("suggested edges %+v do not break every cycle", suggestions[0].Edges)
+	}
+	for edge := range removed {
+		delete(removed, edge)
+		if !hasCycle(files, adj, removed) {
+			t.Errorf("suggested edge %v is redundant", edge)
+		}
+		removed[edge] = true
+	}
+	// Making four files acyclic requires removing one direction of each of the 6 pairs.
+	if len(suggestions[0].Edges) != 6 {
+		t.Errorf("expected 6 edges, got %d: %+v", len(suggestions[0].Edges), suggestions[0].Edges)
+	}
+}
@@ -0,0 +1,336 @@
+package checks
+
+import (
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/rules"
+)
+
+// maxEnumeratedCyclesPerComponent caps the elementary cycles enumerated per strongly connected
+// component when ranking cycle-breaking edges; their number grows exponentially with the size of
+// tangled components.
+const maxEnumeratedCyclesPerComponent = 1000
+
+// CycleBudgetUsage is the number of cycles counted against a cycle budget. Files is empty for the
+// rule-wide maxCycles budget.
+type CycleBudgetUsage struct {
+	Files     []string
+	MaxCycles int
+	Cycles    int
+}
+
+// Exceeded reports whether the budget has more cycles than it allows.
+func (u CycleBudgetUsage) Exceeded() bool { return u.Cycles > u.MaxCycles }
+
+// ApplyCycleBudgets assigns each cycle to the first budget whose Files match every file of the
+// cycle, or to the rule-wide maxCycles budget otherwise. It returns the cycles of the budgets that
+// are exceeded, which fail the check, and the usage of every budget (the rule-wide one first).
+func ApplyCycleBudgets(cycles [][]string, maxCycles int, budgets []rules.CycleBudget, cwd string) ([][]string, []CycleBudgetUsage) {
+	usage := make([]CycleBudgetUsage, 0, len(budgets)+1)
+	usage = append(usage, CycleBudgetUsage{MaxCycles: maxCycles})
+	matchers := make([][]globutil.GlobMatcher, len(budgets))
+	for i, budget := range budgets {
+		usage = append(usage, CycleBudgetUsage{Files: budget.Files, MaxCycles: budget.MaxCycles})
+		matchers[i] = globutil.CreateGlobMatchers(budget.Files, cwd)
+	}
+
+	budgetOf := make([]int, len(cycles))
+	for i, cycle := range cycles {
+		for j := range budgets {
+			inBudget := true
+			for _, file := range cycle {
+				if !globutil.MatchesAnyGlobMatcher(file, matchers[j], false) {
+					inBudget = false
+					break
+				}
+			}
+			if inBudget {
+				budgetOf[i] = j + 1
+				break
+			}
+		}
+		usage[budgetOf[i]].Cycles++
+	}
+
+	exceeded := make([][]string, 0)
+	for i, cycle := range cycles {
+		if usage[budgetOf[i]].Exceeded() {
+			exceeded = append(exceeded, cycle)
+		}
+	}
+	return exceeded, usage
+}
+
+// CycleBreakingEdge is an import suggested for removal to break circular dependencies. Cycles is
+// the number of enumerated cycles of the component that go through it.
+type CycleBreakingEdge struct {
+	From    string
+	To      string
+	Request string
+	Cycles  int
+}
+
+// CycleBreakingSuggestion is a set of imports whose removal breaks every cycle of a strongly
+// connected component. Cycles is the number of elementary cycles in the component, a lower bound
+// when Truncated is set.
+type CycleBreakingSuggestion struct {
+	Files     []string
+	Cycles    int
+	Truncated bool
+	Edges     []CycleBreakingEdge
+}
+
+// FindCycleBreakingEdges computes, for each strongly connected component with a cycle, a set of
+// imports whose removal makes the component acyclic (a feedback arc set). It is a greedy heuristic,
+// so the set is small but not necessarily the smallest one: the elementary cycles of the component
+// are enumerated (Johnson's algorithm, up to maxEnumeratedCyclesPerComponent), the import taking
+// part in most of the cycles not broken yet is picked until none is left, and picked imports that
+// turn out to be redundant are dropped. Enumerating cycles is expensive on large tangled
+// components, so callers only run it on request. Edges are ranked by the number of cycles they
+// take part in.
+func FindCycleBreakingEdges(deps MinimalDependencyTree, sortedFilesList []string, ignoreTypeImports bool) []CycleBreakingSuggestion {
+	adj := circularAdjacency(deps, sortedFilesList, ignoreTypeImports)
+	for node, targets := range adj {
+		adj[node] = slices.Compact(targets)
+	}
+
+	suggestions := []CycleBreakingSuggestion{}
+	for _, scc := range stronglyConnectedComponents(adj, sortedFilesList) {
+		if len(scc) == 1 && !hasSelfLoop(adj, scc[0]) {
+			continue
+		}
+		slices.Sort(scc)
+		inSCC := make(map[string]bool, len(scc))
+		for _, node := range scc {
+			inSCC[node] = true
+		}
+		componentAdj := make(map[string][]string, len(scc))
+		for _, node := range scc {
+			for _, target := range adj[node] {
+				if inSCC[target] {
+					componentAdj[node] = append(componentAdj[node], target)
+				}
+			}
+		}
+
+		suggestion := CycleBreakingSuggestion{Files: scc}
+		removed := map[[2]string]bool{}
+		cyclesByEdge := map[[2]string]int{}
+		var picked [][2]string
+		for round := 0; hasCycle(scc, componentAdj, removed); round++ {
+			cycles, truncated := enumerateCycles(scc, componentAdj, removed, maxEnumeratedCyclesPerComponent)
+			if round == 0 {
+				suggestion.Cycles, suggestion.Truncated = len(cycles), truncated
+			}
+			for _, edge := range pickFeedbackEdges(cycles, cyclesByEdge) {
+				removed[edge] = true
+				picked = append(picked, edge)
+			}
+		}
+
+		// Drop picked edges the others already cover, latest first.
+		for i := len(picked) - 1; i >= 0; i-- {
+			delete(removed, picked[i])
+			if hasCycle(scc, componentAdj, removed) {
+				removed[picked[i]] = true
+			}
+		}
+
+		for _, edge := range picked {
+			if !removed[edge] {
+				continue
+			}
+			suggestion.Edges = append(suggestion.Edges, CycleBreakingEdge{
+				From:    edge[0],
+				To:      edge[1],
+				Request: importRequest(deps, edge[0], edge[1]),
+				Cycles:  cyclesByEdge[edge],
+			})
+		}
+		slices.SortFunc(suggestion.Edges, func(a, b CycleBreakingEdge) int {
+			if a.Cycles != b.Cycles {
+				return b.Cycles - a.Cycles
+			}
+			if a.From != b.From {
+				return strings.Compare(a.From, b.From)
+			}
+			return strings.Compare(a.To, b.To)
+		})
+		suggestions = append(suggestions, suggestion)
+	}
+
+	slices.SortFunc(suggestions, func(a, b CycleBreakingSuggestion) int {
+		return strings.Compare(a.Files[0], b.Files[0])
+	})
+	return suggestions
+}
+
+// pickFeedbackEdges greedily picks the edge taking part in most of the remaining cycles until every
+// cycle contains a picked edge. cyclesByEdge receives the number of cycles of each picked edge.
+func pickFeedbackEdges(cycles [][]string, cyclesByEdge map[[2]string]int) [][2]string {
+	counts := map[[2]string]int{}
+	for _, cycle := range cycles {
+		for _, edge := range cycleEdges(cycle) {
+			counts[edge]++
+		}
+	}
+	for edge, count := range counts {
+		if cyclesByEdge[edge] == 0 {
+			cyclesByEdge[edge] = count
+		}
+	}
+
+	var picked [][2]string
+	broken := make([]bool, len(cycles))
+	for {
+		remaining := map[[2]string]int{}
+		for i, cycle := range cycles {
+			if broken[i] {
+				continue
+			}
+			for _, edge := range cycleEdges(cycle) {
+				remaining[edge]++
+			}
+		}
+		if len(remaining) == 0 {
+			return picked
+		}
+
+		var best [2]string
+		bestCount := 0
+		for edge, count := range remaining {
+			if count > bestCount || (count == bestCount && (edge[0] < best[0] || (edge[0] == best[0] && edge[1] < best[1]))) {
+				best, bestCount = edge, count
+			}
+		}
+		picked = append(picked, best)
+		for i, cycle := range cycles {
+			if !broken[i] && slices.Contains(cycleEdges(cycle), best) {
+				broken[i] = true
+			}
+		}
+	}
+}
+
+// cycleEdges returns the edges of a closed cycle (first file repeated at the end).
+func cycleEdges(cycle []string) [][2]string {
+	edges := make([][2]string, 0, len(cycle)-1)
+	for i := 0; i+1 < len(cycle); i++ {
+		edges = append(edges, [2]string{cycle[i], cycle[i+1]})
+	}
+	return edges
+}
+
+// enumerateCycles lists the elementary cycles of the graph without the removed edges (Johnson's
+// algorithm), each closed by repeating its first file, stopping after limit cycles.
+func enumerateCycles(nodes []string, adj map[string][]string, removed map[[2]string]bool, limit int) ([][]string, bool) {
+	var cycles [][]string
+	truncated := false
+
+	for i, start := range nodes {
+		allowed := make(map[string]bool, len(nodes)-i)
+		for _, node := range nodes[i:] {
+			allowed[node] = true
+		}
+		blocked := map[string]bool{}
+		blockedBy := map[string]map[string]bool{}
+		stack := []string{}
+
+		var unblock func(node string)
+		unblock = func(node string) {
+			blocked[node] = false
+			for waiting := range blockedBy[node] {
+				delete(blockedBy[node], waiting)
+				if blocked[waiting] {
+					unblock(waiting)
+				}
+			}
+		}
+
+		var circuit func(node string) bool
+		circuit = func(node string) bool {
+			found := false
+			stack = append(stack, node)
+			blocked[node] = true
+			for _, next := range adj[node] {
+				if truncated {
+					break
+				}
+				if !allowed[next] || removed[[2]string{node, next}] {
+					continue
+				}
+				if next == start {
+					cycles = append(cycles, append(slices.Clone(stack), start))
+					found = true
+					if len(cycles) >= limit {
+						truncated = true
+					}
+				} else if !blocked[next] && circuit(next) {
+					found = true
+				}
+			}
+			if found {
+				unblock(node)
+			} else {
+				for _, next := range adj[node] {
+					if allowed[next] && !removed[[2]string{node, next}] {
+						if blockedBy[next] == nil {
+							blockedBy[next] = map[string]bool{}
+						}
+						blockedBy[next][node] = true
+					}
+				}
+			}
+			stack = stack[:len(stack)-1]
+			return found
+		}
+
+		circuit(start)
+		if truncated {
+			break
+		}
+	}
+	return cycles, truncated
+}
+
+// hasCycle reports whether the graph without the removed edges still has a cycle.
+func hasCycle(nodes []string, adj map[string][]string, removed map[[2]string]bool) bool {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int, len(nodes))
+	var visit func(node string) bool
+	visit = func(node string) bool {
+		state[node] = inProgress
+		for _, next := range adj[node] {
+			if removed[[2]string{node, next}] {
+				continue
+			}
+			if state[next] == inProgress || (state[next] == unvisited && visit(next)) {
+				return true
+			}
+		}
+		state[node] = done
+		return false
+	}
+	for _, node := range nodes {
+		if state[node] == unvisited && visit(node) {
+			return true
+		}
+	}
+	return false
+}
+
+// importRequest returns the request with which from imports to.
+func importRequest(deps MinimalDependencyTree, from, to string) string {
+	for _, dep := range deps[from] {
+		if dep.ID == to {
+			return dep.Request
+		}
+	}
+	return ""
+}
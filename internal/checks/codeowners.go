@@ -0,0 +1,116 @@
+package checks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultCodeownersLocations are the paths, relative to the repository root, where GitHub looks
+// for a CODEOWNERS file, in order.
+var DefaultCodeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeownersRule is one pattern line of a CODEOWNERS file. A rule without owners makes the files
+// it matches unowned.
+type CodeownersRule struct {
+	Pattern string
+	Owners  []string
+	Line    int
+	matcher *regexp.Regexp
+}
+
+// Codeowners maps files to their owners. Patterns are matched against paths relative to Root.
+type Codeowners struct {
+	Root  string
+	Rules []CodeownersRule
+}
+
+// ParseCodeowners parses the content of a CODEOWNERS file. Comments, blank lines, GitLab section
+// headers and negated patterns (not supported by GitHub) are skipped.
+func ParseCodeowners(content []byte, root string) *Codeowners {
+	codeowners := &Codeowners{Root: strings.TrimSuffix(root, "/")}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+		fields := strings.Fields(line)
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		codeowners.Rules = append(codeowners.Rules, CodeownersRule{
+			Pattern: pattern,
+			Owners:  fields[1:],
+			Line:    i + 1,
+			matcher: codeownersPatternToRegexp(pattern),
+		})
+	}
+	return codeowners
+}
+
+// OwnersOf returns the owners of a file: those of the last rule matching it, as in GitHub. It
+// returns nil for files outside Root and for unowned files.
+func (c *Codeowners) OwnersOf(filePath string) []string {
+	relativePath, isUnderRoot := strings.CutPrefix(filePath, c.Root+"/")
+	if !isUnderRoot {
+		return nil
+	}
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].matcher.MatchString(relativePath) {
+			if len(c.Rules[i].Owners) == 0 {
+				return nil
+			}
+			return c.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// codeownersPatternToRegexp converts a CODEOWNERS (gitignore-style) pattern to a regular
+// expression matching root-relative file paths:
+//   - a pattern starting with or containing a `/` (other than a trailing one) is anchored to the
+//     root, any other pattern matches at any depth;
+//   - `*` and `?` do not cross directories, `**` does;
+//   - a pattern matches the files inside the directories it matches, except a trailing `/*`,
+//     which only matches the files directly inside the directory;
+//   - `/` is the root directory and matches every file.
+func codeownersPatternToRegexp(pattern string) *regexp.Regexp {
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return regexp.MustCompile("^.*$")
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	switch {
+	case directoryOnly:
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "/**"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.MustCompile(expr.String())
+}
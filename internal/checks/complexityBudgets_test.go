@@ -0,0 +1,121 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/rules"
+)
+
+// complexityFixture has two entry points: src/main.ts, reaching 9 files with a deepest chain of 4
+// imports (main -> router -> pages/b -> deep/x -> deep/y), and scripts/tool.ts. src/utils.ts has 6
+// importers, one of them type-only.
+func complexityFixture() (MinimalDependencyTree, []string) {
+	typeDep := userDep("/repo/src/utils.ts", "./utils")
+	typeDep.ImportKind = OnlyTypeImport
+
+	tree := MinimalDependencyTree{
+		"/repo/src/main.ts": {
+			userDep("/repo/src/app.ts", "./app"),
+			userDep("/repo/src/router.ts", "./router"),
+			{Request: "react", ResolvedType: NodeModule},
+		},
+		"/repo/src/app.ts":     {userDep("/repo/src/store.ts", "./store"), userDep("/repo/src/utils.ts", "./utils")},
+		"/repo/src/router.ts":  {userDep("/repo/src/pages/a.ts", "./pages/a"), userDep("/repo/src/pages/b.ts", "./pages/b"), userDep("/repo/src/utils.ts", "./utils")},
+		"/repo/src/store.ts":   {typeDep},
+		"/repo/src/pages/a.ts": {userDep("/repo/src/utils.ts", "../utils")},
+		"/repo/src/pages/b.ts": {userDep("/repo/src/utils.ts", "../utils"), userDep("/repo/src/deep/x.ts", "../deep/x")},
+		"/repo/src/deep/x.ts":  {userDep("/repo/src/deep/y.ts", "./y")},
+		"/repo/src/deep/y.ts":  {},
+		"/repo/src/utils.ts":   {},
+		"/repo/scripts/tool.ts": {
+			userDep("/repo/src/utils.ts", "../src/utils"),
+			{Request: "fs", ResolvedType: BuiltInModule},
+			{Request: "lodash/get", ResolvedType: NodeModule},
+		},
+	}
+	files := make([]string, 0, len(tree))
+	for file := range tree {
+		files = append(files, file)
+	}
+	return tree, files
+}
+
+func TestFindComplexityBudgetViolations(t *testing.T) {
+	tree, files := complexityFixture()
+	opts := &rules.ComplexityBudgetsDetectionOptions{
+		Enabled: true,
+		Budgets: []rules.ComplexityBudget{
+			{Files: []string{"src/main.ts"}, MaxTransitiveDependencies: 8, MaxImportChainDepth: 3},
+			{Files: []string{"src/**"}, MaxTransitiveDependencies: 100, MaxDirectImports: 2, MaxImporters: 4},
+		},
+	}
+
+	violations := FindComplexityBudgetViolations(tree, files, opts, "/repo")
+
+	expected := []ComplexityBudgetViolation{
+		{
+			FilePath: "/repo/src/main.ts", Metric: ComplexityMetricTransitiveDependencies, Actual: 9, Budget: 8,
+			Contributors: []ComplexityContributor{{Path: "/repo/src/router.ts", Count: 6}, {Path: "/repo/src/app.ts", Count: 3}},
+		},
+		{
+			FilePath: "/repo/src/main.ts", Metric: ComplexityMetricImportChainDepth, Actual: 4, Budget: 3,
+			Chain: []string{"/repo/src/main.ts", "/repo/src/router.ts", "/repo/src/pages/b.ts", "/repo/src/deep/x.ts", "/repo/src/deep/y.ts"},
+		},
+		{
+			FilePath: "/repo/src/main.ts", Metric: ComplexityMetricDirectImports, Actual: 3, Budget: 2,
+			Contributors: []ComplexityContributor{{Path: "/repo/src/router.ts", Count: 6}, {Path: "/repo/src/app.ts", Count: 3}, {Path: "react", Count: 0}},
+		},
+		{
+			FilePath: "/repo/src/router.ts", Metric: ComplexityMetricDirectImports, Actual: 3, Budget: 2,
+			Contributors: []ComplexityContributor{{Path: "/repo/src/pages/b.ts", Count: 4}, {Path: "/repo/src/pages/a.ts", Count: 2}, {Path: "/repo/src/utils.ts", Count: 1}},
+		},
+		{
+			FilePath: "/repo/src/utils.ts", Metric: ComplexityMetricImporters, Actual: 6, Budget: 4,
+			Contributors: []ComplexityContributor{{Path: "/repo/src", Count: 3}, {Path: "/repo/src/pages", Count: 2}, {Path: "/repo/scripts", Count: 1}},
+		},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+}
+
+func TestFindComplexityBudgetViolations_EntryPointsAndOptions(t *testing.T) {
+	tree, files := complexityFixture()
+	opts := &rules.ComplexityBudgetsDetectionOptions{
+		Enabled:           true,
+		EntryPoints:       []string{"src/router.ts"},
+		IgnoreFiles:       []string{"scripts/**"},
+		IgnoreTypeImports: true,
+		TopContributors:   1,
+		Budgets: []rules.ComplexityBudget{
+			{Files: []string{"**/*"}, MaxTransitiveDependencies: 5, MaxImporters: 4, MaxDirectImports: 2},
+		},
+	}
+
+	violations := FindComplexityBudgetViolations(tree, files, opts, "/repo")
+
+	// main.ts is no longer an entry point and scripts/tool.ts is ignored; without the type-only
+	// import utils.ts has 5 importers, tied between src and src/pages.
+	expected := []ComplexityBudgetViolation{
+		{
+			FilePath: "/repo/src/main.ts", Metric: ComplexityMetricDirectImports, Actual: 3, Budget: 2,
+			Contributors: []ComplexityContributor{{Path: "/repo/src/router.ts", Count: 6}},
+		},
+		{
+			FilePath: "/repo/src/router.ts", Metric: ComplexityMetricTransitiveDependencies, Actual: 6, Budget: 5,
+			Contributors: []ComplexityContributor{{Path: "/repo/src/pages/b.ts", Count: 4}},
+		},
+		{
+			FilePath: "/repo/src/router.ts", Metric: ComplexityMetricDirectImports, Actual: 3, Budget: 2,
+			Contributors: []ComplexityContributor{{Path: "/repo/src/pages/b.ts", Count: 4}},
+		},
+		{
+			FilePath: "/repo/src/utils.ts", Metric: ComplexityMetricImporters, Actual: 5, Budget: 4,
+			Contributors: []ComplexityContributor{{Path: "/repo/src", Count: 2}},
+		},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+}
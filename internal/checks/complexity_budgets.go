@@ -0,0 +1,279 @@
+package checks
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/rules"
+)
+
+// Complexity budget metrics.
+const (
+	// ComplexityMetricTransitiveDependencies: files reachable from an entry point, the entry point included.
+	ComplexityMetricTransitiveDependencies = "transitive-dependencies"
+	// ComplexityMetricImportChainDepth: import hops from an entry point to the deepest file it reaches.
+	ComplexityMetricImportChainDepth = "import-chain-depth"
+	// ComplexityMetricDirectImports: distinct files and node modules a file imports.
+	ComplexityMetricDirectImports = "direct-imports"
+	// ComplexityMetricImporters: distinct files importing a file.
+	ComplexityMetricImporters = "importers"
+)
+
+const defaultTopContributors = 5
+
+// ComplexityContributor is one of the largest contributors to a budget violation:
+//   - transitive-dependencies: a direct import of the entry point and the files reachable through it;
+//   - direct-imports: an imported file (or node module) and the files reachable through it;
+//   - importers: a directory and the number of importers it contains.
+type ComplexityContributor struct {
+	Path  string
+	Count int
+}
+
+// ComplexityBudgetViolation represents a file exceeding one threshold of its complexity budget
+type ComplexityBudgetViolation struct {
+	FilePath     string
+	Metric       string
+	Actual       int
+	Budget       int
+	Contributors []ComplexityContributor
+	Chain        []string // import-chain-depth only: the chain from the entry point to the deepest file
+}
+
+// FindComplexityBudgetViolations checks files against the thresholds of their complexity budgets.
+// For each threshold a file uses the first budget that matches it and sets the threshold. The entry
+// point thresholds apply to the files matching opts.EntryPoints, or, when it is empty, to the files
+// that no other file of the tree imports.
+//
+// Only user and monorepo modules are followed when walking the graph; node modules count as direct
+// imports but are not traversed.
+func FindComplexityBudgetViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	opts *rules.ComplexityBudgetsDetectionOptions,
+	cwd string,
+) []ComplexityBudgetViolation {
+	violations := []ComplexityBudgetViolation{}
+	if opts == nil || !opts.Enabled || len(opts.Budgets) == 0 {
+		return violations
+	}
+
+	topContributors := opts.TopContributors
+	if topContributors <= 0 {
+		topContributors = defaultTopContributors
+	}
+
+	budgetMatchers := make([][]globutil.GlobMatcher, len(opts.Budgets))
+	for i, budget := range opts.Budgets {
+		budgetMatchers[i] = globutil.CreateGlobMatchers(budget.Files, cwd)
+	}
+	// thresholdFor returns the threshold of the first budget matching filePath that sets it, 0 if none.
+	thresholdFor := func(filePath string, threshold func(rules.ComplexityBudget) int) int {
+		for i, budget := range opts.Budgets {
+			if value := threshold(budget); value > 0 && globutil.MatchesAnyGlobMatcher(filePath, budgetMatchers[i], false) {
+				return value
+			}
+		}
+		return 0
+	}
+
+	// Followed edges (files) and direct import keys (files and node modules) of each file.
+	children := make(map[string][]string, len(minimalTree))
+	directImports := make(map[string][]string, len(minimalTree))
+	importers := map[string]map[string]bool{}
+	for filePath, deps := range minimalTree {
+		seenChildren := map[string]bool{}
+		seenImports := map[string]bool{}
+		for _, dep := range deps {
+			if dep.IsLocalExport || dep.ResolvedType == LocalExportDeclaration || dep.ResolvedType == ExcludedByUser {
+				continue
+			}
+			if opts.IgnoreTypeImports && dep.ImportKind == OnlyTypeImport {
+				continue
+			}
+			key := dep.ID
+			switch {
+			case dep.ResolvedType == NodeModule || dep.ResolvedType == NotResolvedModule:
+				if name := module.GetNodeModuleName(dep.Request); name != "" {
+					key = name
+				} else {
+					key = dep.Request
+				}
+			case key == "":
+				key = dep.Request
+			}
+			if key != "" && !seenImports[key] {
+				seenImports[key] = true
+				directImports[filePath] = append(directImports[filePath], key)
+			}
+
+			if dep.ID == "" || (dep.ResolvedType != UserModule && dep.ResolvedType != MonorepoModule) {
+				continue
+			}
+			if _, inTree := minimalTree[dep.ID]; !inTree || dep.ID == filePath || seenChildren[dep.ID] {
+				continue
+			}
+			seenChildren[dep.ID] = true
+			children[filePath] = append(children[filePath], dep.ID)
+			if importers[dep.ID] == nil {
+				importers[dep.ID] = map[string]bool{}
+			}
+			importers[dep.ID][filePath] = true
+		}
+	}
+
+	// reachableCount memoizes the number of files reachable from a file, the file included.
+	reachable := map[string]int{}
+	reachableCount := func(filePath string) int {
+		if count, ok := reachable[filePath]; ok {
+			return count
+		}
+		visited := map[string]bool{filePath: true}
+		queue := []string{filePath}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, child := range children[current] {
+				if !visited[child] {
+					visited[child] = true
+					queue = append(queue, child)
+				}
+			}
+		}
+		reachable[filePath] = len(visited)
+		return len(visited)
+	}
+	// topByCount sorts contributors by count (largest first), then path, and keeps the top ones.
+	topByCount := func(contributors []ComplexityContributor) []ComplexityContributor {
+		slices.SortFunc(contributors, func(a, b ComplexityContributor) int {
+			if a.Count != b.Count {
+				return b.Count - a.Count
+			}
+			return strings.Compare(a.Path, b.Path)
+		})
+		if len(contributors) > topContributors {
+			contributors = contributors[:topContributors]
+		}
+		return contributors
+	}
+
+	ignoreMatchers := globutil.CreateGlobMatchers(opts.IgnoreFiles, cwd)
+	entryPointMatchers := globutil.CreateGlobMatchers(opts.EntryPoints, cwd)
+	isEntryPoint := func(filePath string) bool {
+		if len(entryPointMatchers) > 0 {
+			return globutil.MatchesAnyGlobMatcher(filePath, entryPointMatchers, false)
+		}
+		return len(importers[filePath]) == 0
+	}
+
+	sortedFiles := slices.Clone(files)
+	slices.Sort(sortedFiles)
+
+	for _, filePath := range sortedFiles {
+		if _, inTree := minimalTree[filePath]; !inTree || globutil.MatchesAnyGlobMatcher(filePath, ignoreMatchers, false) {
+			continue
+		}
+
+		if isEntryPoint(filePath) {
+			if budget := thresholdFor(filePath, func(b rules.ComplexityBudget) int { return b.MaxTransitiveDependencies }); budget > 0 {
+				if actual := reachableCount(filePath); actual > budget {
+					contributors := make([]ComplexityContributor, 0, len(children[filePath]))
+					for _, child := range children[filePath] {
+						contributors = append(contributors, ComplexityContributor{Path: child, Count: reachableCount(child)})
+					}
+					violations = append(violations, ComplexityBudgetViolation{
+						FilePath:     filePath,
+						Metric:       ComplexityMetricTransitiveDependencies,
+						Actual:       actual,
+						Budget:       budget,
+						Contributors: topByCount(contributors),
+					})
+				}
+			}
+
+			if budget := thresholdFor(filePath, func(b rules.ComplexityBudget) int { return b.MaxImportChainDepth }); budget > 0 {
+				if chain := deepestImportChain(filePath, children); len(chain)-1 > budget {
+					violations = append(violations, ComplexityBudgetViolation{
+						FilePath: filePath,
+						Metric:   ComplexityMetricImportChainDepth,
+						Actual:   len(chain) - 1,
+						Budget:   budget,
+						Chain:    chain,
+					})
+				}
+			}
+		}
+
+		if budget := thresholdFor(filePath, func(b rules.ComplexityBudget) int { return b.MaxDirectImports }); budget > 0 && len(directImports[filePath]) > budget {
+			contributors := make([]ComplexityContributor, 0, len(directImports[filePath]))
+			for _, imported := range directImports[filePath] {
+				count := 0
+				if _, isFile := minimalTree[imported]; isFile {
+					count = reachableCount(imported)
+				}
+				contributors = append(contributors, ComplexityContributor{Path: imported, Count: count})
+			}
+			violations = append(violations, ComplexityBudgetViolation{
+				FilePath:     filePath,
+				Metric:       ComplexityMetricDirectImports,
+				Actual:       len(directImports[filePath]),
+				Budget:       budget,
+				Contributors: topByCount(contributors),
+			})
+		}
+
+		if budget := thresholdFor(filePath, func(b rules.ComplexityBudget) int { return b.MaxImporters }); budget > 0 && len(importers[filePath]) > budget {
+			importersByDir := map[string]int{}
+			for importer := range importers[filePath] {
+				importersByDir[path.Dir(importer)]++
+			}
+			contributors := make([]ComplexityContributor, 0, len(importersByDir))
+			for dir, count := range importersByDir {
+				contributors = append(contributors, ComplexityContributor{Path: dir, Count: count})
+			}
+			violations = append(violations, ComplexityBudgetViolation{
+				FilePath:     filePath,
+				Metric:       ComplexityMetricImporters,
+				Actual:       len(importers[filePath]),
+				Budget:       budget,
+				Contributors: topByCount(contributors),
+			})
+		}
+	}
+
+	return violations
+}
+
+// deepestImportChain returns the shortest import chain from entryPoint to the file farthest from
+// it, entry point first. Among files at the same depth the one with the smallest path wins.
+func deepestImportChain(entryPoint string, children map[string][]string) []string {
+	parents := map[string]string{entryPoint: ""}
+	depths := map[string]int{entryPoint: 0}
+	deepest := entryPoint
+	queue := []string{entryPoint}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if _, visited := depths[child]; visited {
+				continue
+			}
+			parents[child] = current
+			depths[child] = depths[current] + 1
+			if depths[child] > depths[deepest] || (depths[child] == depths[deepest] && child < deepest) {
+				deepest = child
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	chain := []string{}
+	for file := deepest; file != ""; file = parents[file] {
+		chain = append(chain, file)
+	}
+	slices.Reverse(chain)
+	return chain
+}
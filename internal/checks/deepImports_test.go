@@ -0,0 +1,96 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+// fakeWorkspaceExports maps package path -> subpath -> file; packages missing from the map have no exports.
+type fakeWorkspaceExports map[string]map[string]string
+
+func (f fakeWorkspaceExports) ResolveWorkspaceExport(importerPath, packagePath, subpath string) (string, bool) {
+	exports, ok := f[packagePath]
+	return exports[subpath], ok
+}
+
+func (f fakeWorkspaceExports) WorkspaceExportSubpath(importerPath, packagePath, filePath string) string {
+	for subpath, file := range f[packagePath] {
+		if file == filePath {
+			return subpath
+		}
+	}
+	return ""
+}
+
+func TestFindDeepImportViolations(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	write("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	write("package.json", `{"name":"root","private":true}`)
+	write("packages/app/package.json", `{"name":"app"}`)
+	write("packages/ui/package.json", `{"name":"@org/ui"}`)
+	write("packages/utils/package.json", `{"name":"utils"}`)
+
+	root = pathutil.NormalizePathForInternal(filepath.Clean(root))
+	ctx := monorepo.NewMonorepoContext(root)
+	ctx.FindWorkspacePackages(nil, nil)
+
+	ui := root + "/packages/ui"
+	exportsResolver := fakeWorkspaceExports{
+		ui: {
+			".":        ui + "/src/index.ts",
+			"./button": ui + "/src/button.ts",
+		},
+	}
+	monorepoDep := func(id, request string) MinimalDependency {
+		dep := userDep(id, request)
+		dep.ResolvedType = MonorepoModule
+		return dep
+	}
+
+	app := root + "/packages/app/src/main.ts"
+	tree := MinimalDependencyTree{
+		app: {
+			monorepoDep(ui+"/src/index.ts", "@org/ui"),
+			monorepoDep(ui+"/src/button.ts", "@org/ui/button"),
+			monorepoDep(ui+"/src/button.ts", "@org/ui/src/button"),
+			userDep(ui+"/src/internal/theme.ts", "../../ui/src/internal/theme"),
+			userDep(root+"/packages/utils/src/format.ts", "../../utils/src/format"),
+		},
+		// Imports within the package itself are not checked.
+		ui + "/src/index.ts": {userDep(ui+"/src/internal/theme.ts", "./internal/theme")},
+	}
+	files := []string{app, ui + "/src/index.ts"}
+
+	violations := FindDeepImportViolations(tree, files, ctx, exportsResolver, &rules.DeepImportsDetectionOptions{Enabled: true}, root)
+
+	expected := []DeepImportViolation{
+		{FilePath: app, ImportPath: ui + "/src/internal/theme.ts", ImportRequest: "../../ui/src/internal/theme", PackageName: "@org/ui"},
+		{FilePath: app, ImportPath: ui + "/src/button.ts", ImportRequest: "@org/ui/src/button", PackageName: "@org/ui", SuggestedRequest: "@org/ui/button"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+
+	ignored := FindDeepImportViolations(tree, files, ctx, exportsResolver, &rules.DeepImportsDetectionOptions{
+		Enabled:        true,
+		IgnorePackages: []string{"@org/*"},
+	}, root)
+	if len(ignored) != 0 {
+		t.Errorf("expected ignored packages to be skipped, got %+v", ignored)
+	}
+}
@@ -0,0 +1,99 @@
+package checks
+
+import (
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/rules"
+)
+
+// WorkspaceExportsResolver resolves subpaths of workspace packages through their `exports`, with
+// the conditions that apply to the importing file. Implemented by resolve.ResolverManager.
+type WorkspaceExportsResolver interface {
+	ResolveWorkspaceExport(importerPath, packagePath, subpath string) (filePath string, hasExports bool)
+	WorkspaceExportSubpath(importerPath, packagePath, filePath string) string
+}
+
+// DeepImportViolation represents an import of a workspace package file that the package's
+// `exports` do not expose
+type DeepImportViolation struct {
+	FilePath         string
+	ImportPath       string
+	ImportRequest    string
+	PackageName      string
+	SuggestedRequest string // Public request resolving to the same file, empty if there is none
+}
+
+// FindDeepImportViolations reports imports that resolve to a file of another workspace package
+// without going through that package's `exports`: bare requests whose subpath is not exported (or
+// exported to a different file), and relative or aliased paths into the package. Packages without
+// an `exports` field are not checked.
+func FindDeepImportViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	monorepoContext *monorepo.MonorepoContext,
+	exportsResolver WorkspaceExportsResolver,
+	opts *rules.DeepImportsDetectionOptions,
+	cwd string,
+) []DeepImportViolation {
+	violations := []DeepImportViolation{}
+	if opts == nil || !opts.Enabled || monorepoContext == nil || exportsResolver == nil {
+		return violations
+	}
+
+	packageOf := workspacePackageResolver(monorepoContext)
+
+	ignoreFileMatchers := globutil.CreateGlobMatchers(opts.IgnoreFiles, cwd)
+	ignorePackageMatchers := compileModuleGlobMatchers(opts.IgnorePackages)
+
+	for _, file := range files {
+		if globutil.MatchesAnyGlobMatcher(file, ignoreFileMatchers, false) {
+			continue
+		}
+		importer := packageOf(file)
+		for _, dep := range minimalTree[file] {
+			if dep.ID == "" || dep.IsLocalExport || (dep.ResolvedType != UserModule && dep.ResolvedType != MonorepoModule) {
+				continue
+			}
+			target := packageOf(dep.ID)
+			if target == "" || target == importer || matchesAnyModulePattern(ignorePackageMatchers, target, target) {
+				continue
+			}
+			packagePath := monorepoContext.PackageToPath[target]
+
+			// Relative and aliased requests never go through exports, bare ones must land on the same file.
+			isPackageRequest := !strings.HasPrefix(dep.Request, ".") && module.GetNodeModuleName(dep.Request) == target
+			subpath := "."
+			if isPackageRequest {
+				subpath += dep.Request[len(target):]
+			}
+			resolved, hasExports := exportsResolver.ResolveWorkspaceExport(file, packagePath, subpath)
+			if !hasExports || (isPackageRequest && resolved == dep.ID) {
+				continue
+			}
+
+			violation := DeepImportViolation{
+				FilePath:      file,
+				ImportPath:    dep.ID,
+				ImportRequest: dep.Request,
+				PackageName:   target,
+			}
+			if publicSubpath := exportsResolver.WorkspaceExportSubpath(file, packagePath, dep.ID); publicSubpath != "" {
+				violation.SuggestedRequest = target + strings.TrimPrefix(publicSubpath, ".")
+			}
+			violations = append(violations, violation)
+		}
+	}
+
+	slices.SortFunc(violations, func(a, b DeepImportViolation) int {
+		if a.FilePath != b.FilePath {
+			return strings.Compare(a.FilePath, b.FilePath)
+		}
+		return strings.Compare(a.ImportRequest, b.ImportRequest)
+	})
+
+	return violations
+}
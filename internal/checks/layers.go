@@ -0,0 +1,130 @@
+package checks
+
+import (
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/rules"
+)
+
+// Layer violation types.
+const (
+	// LayerViolationUpwardImport: a file imports from a layer above its own.
+	LayerViolationUpwardImport = "upward-import"
+	// LayerViolationSameLayer: a file imports from its own layer without allowSameLayer.
+	LayerViolationSameLayer = "same-layer"
+	// LayerViolationSkippedLayer: in strict mode, a file imports from a layer below the immediate lower one.
+	LayerViolationSkippedLayer = "skipped-layer"
+)
+
+// LayerViolation represents an import that breaks the layer order
+type LayerViolation struct {
+	FilePath      string
+	ImportPath    string
+	ImportRequest string
+	SourceLayer   string
+	TargetLayer   string
+	ViolationType string
+}
+
+// CheckLayersFromTree checks the imports of files against an ordered list of layers using a
+// pre-built dependency tree. Layers are ordered top to bottom and a layer may import only from
+// layers below it (only the next one with Strict), plus its own layer with AllowSameLayer.
+func CheckLayersFromTree(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	opts *rules.LayersDetectionOptions,
+	cwd string,
+) []LayerViolation {
+	var violations []LayerViolation
+
+	type CompiledLayer struct {
+		Name                string
+		PatternMatchers     []globutil.GlobMatcher
+		AllowImportMatchers []globutil.GlobMatcher
+	}
+
+	compiledLayers := make([]CompiledLayer, 0, len(opts.Layers))
+	for _, layer := range opts.Layers {
+		compiledLayers = append(compiledLayers, CompiledLayer{
+			Name:                layer.Name,
+			PatternMatchers:     globutil.CreateGlobMatchers(layer.Patterns, cwd),
+			AllowImportMatchers: globutil.CreateGlobMatchers(layer.AllowImports, cwd),
+		})
+	}
+
+	// A file belongs to the first layer whose patterns match it; -1 when it is in no layer.
+	layerIndexCache := make(map[string]int)
+	layerIndexOf := func(filePath string) int {
+		if index, ok := layerIndexCache[filePath]; ok {
+			return index
+		}
+		index := -1
+		for i, layer := range compiledLayers {
+			if globutil.MatchesAnyGlobMatcher(filePath, layer.PatternMatchers, false) {
+				index = i
+				break
+			}
+		}
+		layerIndexCache[filePath] = index
+		return index
+	}
+
+	for _, filePath := range files {
+		sourceIndex := layerIndexOf(filePath)
+		if sourceIndex == -1 {
+			continue
+		}
+		sourceLayer := compiledLayers[sourceIndex]
+
+		for _, dep := range minimalTree[filePath] {
+			if dep.ID == "" || (dep.ResolvedType != UserModule && dep.ResolvedType != MonorepoModule) {
+				continue
+			}
+			targetIndex := layerIndexOf(dep.ID)
+			if targetIndex == -1 {
+				continue
+			}
+
+			violationType := ""
+			switch {
+			case targetIndex < sourceIndex:
+				violationType = LayerViolationUpwardImport
+			case targetIndex == sourceIndex:
+				if !opts.AllowSameLayer {
+					violationType = LayerViolationSameLayer
+				}
+			case opts.Strict && targetIndex > sourceIndex+1:
+				violationType = LayerViolationSkippedLayer
+			}
+			if violationType == "" {
+				continue
+			}
+
+			// Per-layer exceptions
+			if len(sourceLayer.AllowImportMatchers) > 0 && globutil.MatchesAnyGlobMatcher(dep.ID, sourceLayer.AllowImportMatchers, false) {
+				continue
+			}
+
+			violations = append(violations, LayerViolation{
+				FilePath:      filePath,
+				ImportPath:    dep.ID,
+				ImportRequest: dep.Request,
+				SourceLayer:   sourceLayer.Name,
+				TargetLayer:   compiledLayers[targetIndex].Name,
+				ViolationType: violationType,
+			})
+		}
+	}
+
+	// Sort violations for consistent output
+	slices.SortFunc(violations, func(a, b LayerViolation) int {
+		if a.FilePath != b.FilePath {
+			return strings.Compare(a.FilePath, b.FilePath)
+		}
+		return strings.Compare(a.ImportPath, b.ImportPath)
+	})
+
+	return violations
+}
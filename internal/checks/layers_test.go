@@ -0,0 +1,104 @@
+package checks
+
+import (
+	"testing"
+
+	"rev-dep-go/internal/rules"
+)
+
+// layersFixture is a classic ui -> application -> domain -> infrastructure layering. Every file
+// imports from a single other file so each entry exercises one layer relation.
+func layersFixture() (MinimalDependencyTree, []string) {
+	tree := MinimalDependencyTree{
+		"/repo/src/ui/page.ts":           {userDep("/repo/src/application/service.ts", "../application/service")}, // one layer down -> ok
+		"/repo/src/ui/widget.ts":         {userDep("/repo/src/infrastructure/db.ts", "../infrastructure/db")},     // skips layers
+		"/repo/src/ui/theme.ts":          {userDep("/repo/src/ui/colors.ts", "./colors")},                         // same layer
+		"/repo/src/domain/user.ts":       {userDep("/repo/src/application/service.ts", "../application/service")}, // upward
+		"/repo/src/domain/order.ts":      {userDep("/repo/src/shared/log.ts", "../shared/log")},                   // target in no layer -> ok
+		"/repo/src/application/cmd.ts":   {{ID: "react", Request: "react", ResolvedType: NodeModule}},             // node module -> ok
+		"/repo/src/shared/helpers.ts":    {userDep("/repo/src/ui/page.ts", "../ui/page")},                         // source in no layer -> ok
+		"/repo/src/infrastructure/db.ts": {userDep("/repo/src/application/events.ts", "../application/events")},   // upward, excepted below
+	}
+	files := make([]string, 0, len(tree))
+	for file := range tree {
+		files = append(files, file)
+	}
+	return tree, files
+}
+
+func layersOptions() *rules.LayersDetectionOptions {
+	return &rules.LayersDetectionOptions{
+		Enabled: true,
+		Layers: []rules.LayerDefinition{
+			{Name: "ui", Patterns: []string{"src/ui/**"}},
+			{Name: "application", Patterns: []string{"src/application/**"}},
+			{Name: "domain", Patterns: []string{"src/domain/**"}},
+			{Name: "infrastructure", Patterns: []string{"src/infrastructure/**"}, AllowImports: []string{"src/application/events.ts"}},
+		},
+	}
+}
+
+func TestCheckLayers(t *testing.T) {
+	tree, files := layersFixture()
+
+	violations := CheckLayersFromTree(tree, files, layersOptions(), "/repo")
+
+	expected := []LayerViolation{
+		{FilePath: "/repo/src/domain/user.ts", ImportPath: "/repo/src/application/service.ts", ImportRequest: "../application/service", SourceLayer: "domain", TargetLayer: "application", ViolationType: LayerViolationUpwardImport},
+		{FilePath: "/repo/src/ui/theme.ts", ImportPath: "/repo/src/ui/colors.ts", ImportRequest: "./colors", SourceLayer: "ui", TargetLayer: "ui", ViolationType: LayerViolationSameLayer},
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("expected %d violations, got %d: %+v", len(expected), len(violations), violations)
+	}
+	for i := range expected {
+		if violations[i] != expected[i] {
+			t.Errorf("violation %d:\n got: %+v\nwant: %+v", i, violations[i], expected[i])
+		}
+	}
+}
+
+func TestCheckLayers_AllowSameLayerAndStrict(t *testing.T) {
+	tree, files := layersFixture()
+	opts := layersOptions()
+	opts.AllowSameLayer = true
+	opts.Strict = true
+
+	violations := CheckLayersFromTree(tree, files, opts, "/repo")
+
+	got := map[string]string{}
+	for _, v := range violations {
+		got[v.FilePath] = v.ViolationType
+	}
+	expected := map[string]string{
+		"/repo/src/domain/user.ts": LayerViolationUpwardImport,
+		"/repo/src/ui/widget.ts":   LayerViolationSkippedLayer,
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected violations %v, got %+v", expected, violations)
+	}
+	for file, violationType := range expected {
+		if got[file] != violationType {
+			t.Errorf("expected %s to be reported as %s, got %q", file, violationType, got[file])
+		}
+	}
+}
+
+// A file matching several layers belongs to the first one listed.
+func TestCheckLayers_FirstMatchingLayerWins(t *testing.T) {
+	tree := MinimalDependencyTree{
+		"/repo/src/core/api.ts": {userDep("/repo/src/core/internal/impl.ts", "./internal/impl")},
+	}
+	opts := &rules.LayersDetectionOptions{
+		Enabled: true,
+		Layers: []rules.LayerDefinition{
+			{Name: "internal", Patterns: []string{"src/core/internal/**"}},
+			{Name: "core", Patterns: []string{"src/core/**"}},
+		},
+	}
+
+	violations := CheckLayersFromTree(tree, []string{"/repo/src/core/api.ts"}, opts, "/repo")
+
+	if len(violations) != 1 || violations[0].SourceLayer != "core" || violations[0].TargetLayer != "internal" || violations[0].ViolationType != LayerViolationUpwardImport {
+		t.Fatalf("expected core -> internal upward import, got %+v", violations)
+	}
+}
@@ -0,0 +1,138 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/rules"
+)
+
+func TestParseCodeowners_OwnersOf(t *testing.T) {
+	codeowners := ParseCodeowners([]byte(`# Default owners
+*                   @org/platform
+
+[Frontend]
+*.css               @org/design
+/apps/web/          @org/web  # the web app
+apps/web/legacy/**  @org/web @org/legacy
+docs/*              @org/docs
+/packages/billing   @org/billing
+/packages/billing/generated
+\#notes.md          @org/docs
+!/apps/web/README.md @org/nobody
+`), "/repo")
+
+	cases := []struct {
+		file   string
+		owners []string
+	}{
+		{"/repo/tools/build.ts", []string{"@org/platform"}},
+		{"/repo/apps/web/src/page.tsx", []string{"@org/web"}},
+		{"/repo/apps/web/src/page.css", []string{"@org/web"}},
+		{"/repo/packages/ui/button.css", []string{"@org/design"}},
+		{"/repo/apps/web/legacy/deep/old.ts", []string{"@org/web", "@org/legacy"}},
+		{"/repo/docs/intro.md", []string{"@org/docs"}},
+		// `docs/*` does not match nested files, which keep the default owners.
+		{"/repo/docs/guides/setup.md", []string{"@org/platform"}},
+		{"/repo/packages/billing/src/api.ts", []string{"@org/billing"}},
+		// A pattern without owners makes its files unowned.
+		{"/repo/packages/billing/generated/client.ts", nil},
+		{"/repo/#notes.md", []string{"@org/docs"}},
+		{"/repo/apps/web/README.md", []string{"@org/web"}},
+		{"/elsewhere/file.ts", nil},
+	}
+	for _, tc := range cases {
+		if owners := codeowners.OwnersOf(tc.file); !reflect.DeepEqual(owners, tc.owners) {
+			t.Errorf("OwnersOf(%s) = %v, want %v", tc.file, owners, tc.owners)
+		}
+	}
+}
+
+func TestParseCodeowners_RootPattern(t *testing.T) {
+	codeowners := ParseCodeowners([]byte("/ @org/platform\n/apps/ @org/apps\n"), "/repo")
+
+	if owners := codeowners.OwnersOf("/repo/tools/build.ts"); !reflect.DeepEqual(owners, []string{"@org/platform"}) {
+		t.Errorf("expected `/` to match every file, got %v", owners)
+	}
+	if owners := codeowners.OwnersOf("/repo/apps/web/page.ts"); !reflect.DeepEqual(owners, []string{"@org/apps"}) {
+		t.Errorf("expected the later /apps/ pattern to win, got %v", owners)
+	}
+}
+
+func TestFindOwnershipBoundaryViolations(t *testing.T) {
+	codeowners := ParseCodeowners([]byte(`
+/apps/web/         @org/web
+/apps/admin/       @org/admin
+/packages/ui/      @org/design
+/packages/api/     @org/platform
+/packages/shared/  @org/platform @org/web
+`), "/repo")
+
+	typeDep := userDep("/repo/apps/admin/types.ts", "../admin/types")
+	typeDep.ImportKind = OnlyTypeImport
+
+	tree := MinimalDependencyTree{
+		"/repo/apps/web/page.ts": {
+			userDep("/repo/apps/web/hooks.ts", "./hooks"),
+			userDep("/repo/packages/ui/button.ts", "@org/ui/button"),
+			userDep("/repo/packages/ui/button.ts", "@org/ui/button"),
+			userDep("/repo/packages/api/client.ts", "@org/api"),
+			userDep("/repo/packages/shared/format.ts", "@org/shared"),
+			typeDep,
+			{Request: "react", ResolvedType: NodeModule},
+		},
+		"/repo/apps/web/hooks.ts": {
+			userDep("/repo/apps/admin/store.ts", "../admin/store"),
+			userDep("/repo/scripts/unowned.ts", "../../scripts/unowned"),
+		},
+		"/repo/apps/admin/store.ts":       {userDep("/repo/packages/ui/button.ts", "@org/ui/button")},
+		"/repo/apps/admin/types.ts":       {},
+		"/repo/packages/ui/button.ts":     {},
+		"/repo/packages/api/client.ts":    {userDep("/repo/packages/shared/format.ts", "@org/shared")},
+		"/repo/packages/shared/format.ts": {},
+		"/repo/scripts/unowned.ts":        {userDep("/repo/apps/web/page.ts", "../apps/web/page")},
+	}
+	files := []string{
+		"/repo/apps/web/page.ts", "/repo/apps/web/hooks.ts", "/repo/apps/admin/store.ts", "/repo/apps/admin/types.ts",
+		"/repo/packages/ui/button.ts", "/repo/packages/api/client.ts", "/repo/packages/shared/format.ts", "/repo/scripts/unowned.ts",
+	}
+
+	opts := &rules.OwnershipBoundariesDetectionOptions{
+		Enabled:           true,
+		IgnoreTypeImports: true,
+		AllowedDependencies: []rules.TeamDependencyRule{
+			{From: "*", To: []string{"@org/design"}},
+			{From: "@org/web", To: []string{"@org/platform"}},
+		},
+	}
+	violations, matrix := FindOwnershipBoundaryViolations(tree, files, opts, codeowners)
+
+	// Shared ownership (web and platform files importing packages/shared) and unowned files are
+	// not cross-team imports; the type-only import of admin types is ignored.
+	expectedViolations := []OwnershipBoundaryViolation{{
+		FilePath:      "/repo/apps/web/hooks.ts",
+		ImportPath:    "/repo/apps/admin/store.ts",
+		ImportRequest: "../admin/store",
+		FromTeams:     []string{"@org/web"},
+		ToTeams:       []string{"@org/admin"},
+	}}
+	if !reflect.DeepEqual(violations, expectedViolations) {
+		t.Errorf("unexpected violations:\n got: %+v\nwant: %+v", violations, expectedViolations)
+	}
+
+	expectedMatrix := []TeamDependency{
+		{From: "@org/admin", To: "@org/design", Imports: 1},
+		{From: "@org/web", To: "@org/admin", Imports: 1},
+		{From: "@org/web", To: "@org/design", Imports: 1},
+		{From: "@org/web", To: "@org/platform", Imports: 1},
+	}
+	if !reflect.DeepEqual(matrix, expectedMatrix) {
+		t.Errorf("unexpected matrix:\n got: %+v\nwant: %+v", matrix, expectedMatrix)
+	}
+
+	opts.ReportOnly = true
+	violations, reportMatrix := FindOwnershipBoundaryViolations(tree, files, opts, codeowners)
+	if len(violations) != 0 || !reflect.DeepEqual(reportMatrix, expectedMatrix) {
+		t.Errorf("expected only the matrix in report-only mode, got violations %+v and matrix %+v", violations, reportMatrix)
+	}
+}
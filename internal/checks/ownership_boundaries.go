@@ -0,0 +1,126 @@
+package checks
+
+import (
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/rules"
+)
+
+// OwnershipBoundaryViolation represents an import between files of different teams that no
+// allowed dependency permits
+type OwnershipBoundaryViolation struct {
+	FilePath      string
+	ImportPath    string
+	ImportRequest string
+	FromTeams     []string
+	ToTeams       []string
+}
+
+// TeamDependency is one cell of the team dependency matrix: the number of imports from files
+// owned by From to files owned by To.
+type TeamDependency struct {
+	From    string
+	To      string
+	Imports int
+}
+
+// FindOwnershipBoundaryViolations maps the files of the tree to their CODEOWNERS owners and
+// checks the imports between files of different teams against opts.AllowedDependencies. Imports
+// between files sharing an owner, and imports from or to unowned files, are not checked.
+//
+// It also returns the team dependency matrix: an import between files with several owners counts
+// for every pair of their owners. With opts.ReportOnly only the matrix is returned.
+func FindOwnershipBoundaryViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	opts *rules.OwnershipBoundariesDetectionOptions,
+	codeowners *Codeowners,
+) ([]OwnershipBoundaryViolation, []TeamDependency) {
+	violations := []OwnershipBoundaryViolation{}
+	matrix := []TeamDependency{}
+	if opts == nil || !opts.Enabled || codeowners == nil {
+		return violations, matrix
+	}
+
+	owners := map[string][]string{}
+	ownersOf := func(filePath string) []string {
+		if teams, ok := owners[filePath]; ok {
+			return teams
+		}
+		teams := codeowners.OwnersOf(filePath)
+		owners[filePath] = teams
+		return teams
+	}
+	isAllowed := func(fromTeams, toTeams []string) bool {
+		for _, dependency := range opts.AllowedDependencies {
+			if dependency.From != "*" && !slices.Contains(fromTeams, dependency.From) {
+				continue
+			}
+			if slices.Contains(dependency.To, "*") || slices.ContainsFunc(toTeams, func(team string) bool { return slices.Contains(dependency.To, team) }) {
+				return true
+			}
+		}
+		return false
+	}
+
+	counts := map[[2]string]int{}
+	sortedFiles := slices.Clone(files)
+	slices.Sort(sortedFiles)
+
+	for _, filePath := range sortedFiles {
+		fromTeams := ownersOf(filePath)
+		if len(fromTeams) == 0 {
+			continue
+		}
+		seenTargets := map[string]bool{}
+		for _, dep := range minimalTree[filePath] {
+			if dep.IsLocalExport || dep.ID == "" || (dep.ResolvedType != UserModule && dep.ResolvedType != MonorepoModule) {
+				continue
+			}
+			if opts.IgnoreTypeImports && dep.ImportKind == OnlyTypeImport {
+				continue
+			}
+			if dep.ID == filePath || seenTargets[dep.ID] {
+				continue
+			}
+			seenTargets[dep.ID] = true
+
+			toTeams := ownersOf(dep.ID)
+			if len(toTeams) == 0 || slices.ContainsFunc(fromTeams, func(team string) bool { return slices.Contains(toTeams, team) }) {
+				continue
+			}
+
+			for _, from := range fromTeams {
+				for _, to := range toTeams {
+					counts[[2]string{from, to}]++
+				}
+			}
+			if !opts.ReportOnly && !isAllowed(fromTeams, toTeams) {
+				violations = append(violations, OwnershipBoundaryViolation{
+					FilePath:      filePath,
+					ImportPath:    dep.ID,
+					ImportRequest: dep.Request,
+					FromTeams:     fromTeams,
+					ToTeams:       toTeams,
+				})
+			}
+		}
+	}
+
+	for teams, imports := range counts {
+		matrix = append(matrix, TeamDependency{From: teams[0], To: teams[1], Imports: imports})
+	}
+	SortTeamDependencies(matrix)
+	return violations, matrix
+}
+
+// SortTeamDependencies sorts a team dependency matrix by importing team, then imported team.
+func SortTeamDependencies(matrix []TeamDependency) {
+	slices.SortFunc(matrix, func(a, b TeamDependency) int {
+		if a.From != b.From {
+			return strings.Compare(a.From, b.From)
+		}
+		return strings.Compare(a.To, b.To)
+	})
+}
@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+// peerDependenciesFixture writes a pnpm workspace with:
+//
+//	ui      library imported by app, declares react as a dependency and an unused peer (lodash),
+//	        @types/react is a peer used through react, dayjs is an optional peer
+//	hooks   library declared by web, peers react ^18
+//	app     imports ui, provides react ^18 but neither @types/react nor lodash
+//	web     declares hooks with react ^17, which is out of the peer range
+func peerDependenciesFixture(t *testing.T) (*monorepo.MonorepoContext, string, MinimalDependencyTree, []string) {
+	t.Helper()
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	write("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	write("package.json", `{"name":"root","private":true}`)
+	write("packages/ui/package.json", `{
+		"name": "ui",
+		"dependencies": {"react": "^18.0.0", "clsx": "^2.0.0"},
+		"peerDependencies": {"lodash": "^4.0.0", "@types/react": "^18.0.0", "dayjs": "^1.0.0"},
+		"peerDependenciesMeta": {"dayjs": {"optional": true}}
+	}`)
+	write("packages/hooks/package.json", `{"name":"hooks","peerDependencies":{"react":"^18.0.0"}}`)
+	write("packages/app/package.json", `{"name":"app","dependencies":{"react":"^18.2.0"}}`)
+	write("packages/web/package.json", `{"name":"web","dependencies":{"hooks":"workspace:*","react":"^17.0.2"}}`)
+
+	root = pathutil.NormalizePathForInternal(filepath.Clean(root))
+	ctx := monorepo.NewMonorepoContext(root)
+	ctx.FindWorkspacePackages(nil, nil)
+
+	tree := MinimalDependencyTree{
+		root + "/packages/ui/index.ts": {
+			{Request: "react", ResolvedType: NodeModule},
+			{Request: "clsx", ResolvedType: NodeModule},
+		},
+		root + "/packages/hooks/index.ts": {{Request: "react", ResolvedType: NodeModule}},
+		root + "/packages/app/src/main.ts": {
+			{ID: root + "/packages/ui/index.ts", Request: "ui", ResolvedType: MonorepoModule},
+			{Request: "react", ResolvedType: NodeModule},
+		},
+		root + "/packages/web/src/main.ts": {{Request: "react", ResolvedType: NodeModule}},
+	}
+	files := make([]string, 0, len(tree))
+	for file := range tree {
+		files = append(files, file)
+	}
+	return ctx, root, tree, files
+}
+
+func TestFindPeerDependencyViolations(t *testing.T) {
+	ctx, root, tree, files := peerDependenciesFixture(t)
+
+	violations := FindPeerDependencyViolations(tree, files, ctx, &rules.PeerDependenciesDetectionOptions{
+		Enabled:      true,
+		PeerPackages: []string{"react", "react-dom"},
+	}, root)
+
+	expected := []PeerDependencyViolation{
+		{ViolationType: PeerDependencyUnsatisfied, PackageName: "app", PackageJsonPath: root + "/packages/app/package.json", Dependency: "@types/react", Library: "ui", PeerRange: "^18.0.0"},
+		{ViolationType: PeerDependencyUnsatisfied, PackageName: "app", PackageJsonPath: root + "/packages/app/package.json", Dependency: "lodash", Library: "ui", PeerRange: "^4.0.0"},
+		{ViolationType: PeerDependencyShouldBePeer, PackageName: "ui", PackageJsonPath: root + "/packages/ui/package.json", Dependency: "react", DependencyField: "dependencies", FilePath: root + "/packages/ui/index.ts"},
+		{ViolationType: PeerDependencyUnused, PackageName: "ui", PackageJsonPath: root + "/packages/ui/package.json", Dependency: "dayjs", DependencyField: "peerDependencies"},
+		{ViolationType: PeerDependencyUnused, PackageName: "ui", PackageJsonPath: root + "/packages/ui/package.json", Dependency: "lodash", DependencyField: "peerDependencies"},
+		{ViolationType: PeerDependencyUnsatisfied, PackageName: "web", PackageJsonPath: root + "/packages/web/package.json", Dependency: "react", DependencyField: "dependencies", Library: "hooks", PeerRange: "^18.0.0", DeclaredRange: "^17.0.2"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+
+	ignored := FindPeerDependencyViolations(tree, files, ctx, &rules.PeerDependenciesDetectionOptions{
+		Enabled:        true,
+		IgnorePackages: []string{"@types/*", "lodash", "dayjs"},
+	}, root)
+	if len(ignored) != 1 || ignored[0].PackageName != "web" {
+		t.Fatalf("expected only the unsatisfied react peer of web, got %+v", ignored)
+	}
+}
+
+func TestSatisfiesPeerRange(t *testing.T) {
+	cases := []struct {
+		specifier string
+		peerRange string
+		expected  bool
+	}{
+		{"^18.2.0", "^18.0.0", true},
+		{"~17.0.2", "^18.0.0", false},
+		{">=16.8.0", "^16.8.0 || ^17.0.0 || ^18.0.0", true},
+		{"15.0.0", ">=16", false},
+		{"workspace:*", "^1.0.0", true},
+		{"catalog:", "^18.0.0", true},
+		{"latest", "^18.0.0", true},
+		{"^17.0.0 || ^18.0.0", "^18.0.0", true},
+	}
+	for _, c := range cases {
+		if got := satisfiesPeerRange(c.specifier, c.peerRange); got != c.expected {
+			t.Errorf("satisfiesPeerRange(%q, %q) = %v, want %v", c.specifier, c.peerRange, got, c.expected)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package checks
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+const (
+	// PeerDependencyShouldBePeer: a library imports a package configured as peerPackages but declares
+	// it in dependencies or optionalDependencies.
+	PeerDependencyShouldBePeer = "should-be-peer"
+	// PeerDependencyUnused: a package declares a peer dependency none of its files import.
+	PeerDependencyUnused = "unused-peer"
+	// PeerDependencyUnsatisfied: a workspace package depends on a library without declaring one of
+	// the library's peer dependencies, or declares it with a version outside the peer range.
+	PeerDependencyUnsatisfied = "unsatisfied-peer"
+)
+
+// PeerDependencyViolation describes a peer dependency declared in the wrong place, never used, or
+// not provided by a consumer of the library declaring it.
+type PeerDependencyViolation struct {
+	ViolationType string
+	// PackageName and PackageJsonPath are the library for PeerDependencyShouldBePeer and
+	// PeerDependencyUnused, and the consumer for PeerDependencyUnsatisfied.
+	PackageName     string
+	PackageJsonPath string
+	Dependency      string
+	// DependencyField is the field declaring Dependency; it is empty when a consumer does not
+	// declare it at all.
+	DependencyField string
+	// FilePath is the first file of the library importing Dependency (PeerDependencyShouldBePeer).
+	FilePath string
+	// Library, PeerRange and DeclaredRange are set for PeerDependencyUnsatisfied.
+	Library       string
+	PeerRange     string
+	DeclaredRange string
+}
+
+// FindPeerDependencyViolations checks the peer dependencies of the workspace packages under
+// rulePath. A package is a library when another workspace package declares it as a dependency or
+// imports it through a MonorepoModule edge. Libraries must declare the packages matching
+// opts.PeerPackages they import as peerDependencies, every package must import the peers it
+// declares (a `@types/x` peer counts as used when `x` is imported), and every consumer of a library
+// must declare the library's non-optional peers with a version in the peer range. Imports are taken
+// from the files of the rule, so the rule should cover the whole workspace.
+func FindPeerDependencyViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	monorepoContext *monorepo.MonorepoContext,
+	opts *rules.PeerDependenciesDetectionOptions,
+	rulePath string,
+) []PeerDependencyViolation {
+	violations := []PeerDependencyViolation{}
+	if opts == nil || !opts.Enabled || monorepoContext == nil {
+		return violations
+	}
+
+	ruleDir := pathutil.StandardiseDirPathInternal(pathutil.NormalizePathForInternal(filepath.Clean(rulePath)))
+	packageOf := workspacePackageResolver(monorepoContext)
+
+	// imports[pkg][module] is the first file of pkg importing module; consumers[library][consumer]
+	// records the workspace packages importing or declaring a library.
+	imports := map[string]map[string]string{}
+	consumers := map[string]map[string]bool{}
+	hasFiles := map[string]bool{}
+	addConsumer := func(library string, consumer string) {
+		if library == "" || consumer == "" || library == consumer {
+			return
+		}
+		if consumers[library] == nil {
+			consumers[library] = map[string]bool{}
+		}
+		consumers[library][consumer] = true
+	}
+
+	sortedFiles := slices.Clone(files)
+	slices.Sort(sortedFiles)
+	for _, file := range sortedFiles {
+		importer := packageOf(file)
+		if importer == "" {
+			continue
+		}
+		hasFiles[importer] = true
+		for _, dep := range minimalTree[file] {
+			moduleName := ""
+			switch {
+			case dep.ResolvedType == MonorepoModule && dep.ID != "":
+				moduleName = packageOf(dep.ID)
+				addConsumer(moduleName, importer)
+			case dep.ResolvedType == NodeModule || dep.ResolvedType == NotResolvedModule:
+				moduleName = module.GetNodeModuleName(dep.Request)
+			}
+			if moduleName == "" || moduleName == importer {
+				continue
+			}
+			if imports[importer] == nil {
+				imports[importer] = map[string]string{}
+			}
+			if _, seen := imports[importer][moduleName]; !seen {
+				imports[importer][moduleName] = file
+			}
+		}
+	}
+
+	configs := map[string]*monorepo.PackageJsonConfig{}
+	packageNames := []string{}
+	for name, packagePath := range monorepoContext.PackageToPath {
+		config, err := monorepoContext.GetPackageConfig(packagePath)
+		if err != nil {
+			continue
+		}
+		configs[name] = config
+		packageNames = append(packageNames, name)
+		for _, field := range workspaceDependencyFields {
+			if field.isPeer {
+				continue
+			}
+			for dependency := range field.deps(config) {
+				if _, isSibling := monorepoContext.PackageToPath[dependency]; isSibling {
+					addConsumer(dependency, name)
+				}
+			}
+		}
+	}
+	slices.SortFunc(packageNames, func(a, b string) int {
+		return strings.Compare(monorepoContext.PackageToPath[a], monorepoContext.PackageToPath[b])
+	})
+
+	peerMatchers := compileModuleGlobMatchers(opts.PeerPackages)
+	ignoreMatchers := compileModuleGlobMatchers(opts.IgnorePackages)
+	isIgnored := func(dependency string) bool {
+		return matchesAnyModulePattern(ignoreMatchers, dependency, dependency)
+	}
+	packageJsonPathOf := func(name string) string {
+		return pathutil.NormalizePathForInternal(filepath.Join(pathutil.DenormalizePathForOS(monorepoContext.PackageToPath[name]), "package.json"))
+	}
+	isUnderRule := func(name string) bool {
+		return strings.HasPrefix(pathutil.StandardiseDirPathInternal(monorepoContext.PackageToPath[name]), ruleDir)
+	}
+
+	for _, name := range packageNames {
+		if !isUnderRule(name) {
+			continue
+		}
+		config := configs[name]
+
+		// Without files in the rule nothing is known about the imports of the package.
+		if hasFiles[name] {
+			if len(consumers[name]) > 0 {
+				for _, field := range workspaceDependencyFields {
+					if field.name != "dependencies" && field.name != "optionalDependencies" {
+						continue
+					}
+					for _, dependency := range sortedKeys(field.deps(config)) {
+						file, imported := imports[name][dependency]
+						if !imported || isIgnored(dependency) || !matchesAnyModulePattern(peerMatchers, dependency, dependency) {
+							continue
+						}
+						violations = append(violations, PeerDependencyViolation{
+							ViolationType:   PeerDependencyShouldBePeer,
+							PackageName:     name,
+							PackageJsonPath: packageJsonPathOf(name),
+							Dependency:      dependency,
+							DependencyField: field.name,
+							FilePath:        file,
+						})
+					}
+				}
+			}
+
+			for _, peer := range sortedKeys(config.PeerDependencies) {
+				if isIgnored(peer) || isPeerImported(imports[name], peer) {
+					continue
+				}
+				violations = append(violations, PeerDependencyViolation{
+					ViolationType:   PeerDependencyUnused,
+					PackageName:     name,
+					PackageJsonPath: packageJsonPathOf(name),
+					Dependency:      peer,
+					DependencyField: "peerDependencies",
+				})
+			}
+		}
+
+		// The peers of every library this package consumes must be provided by it.
+		for _, library := range packageNames {
+			if !consumers[library][name] {
+				continue
+			}
+			libraryConfig := configs[library]
+			optionalPeers := optionalPeerDependencies(libraryConfig)
+			for _, peer := range sortedKeys(libraryConfig.PeerDependencies) {
+				if peer == name || optionalPeers[peer] || isIgnored(peer) {
+					continue
+				}
+				peerRange := libraryConfig.PeerDependencies[peer]
+				declaredField, declaredRange := declaredDependency(config, peer)
+				if declaredField != "" && satisfiesPeerRange(declaredRange, peerRange) {
+					continue
+				}
+				violations = append(violations, PeerDependencyViolation{
+					ViolationType:   PeerDependencyUnsatisfied,
+					PackageName:     name,
+					PackageJsonPath: packageJsonPathOf(name),
+					Dependency:      peer,
+					DependencyField: declaredField,
+					Library:         library,
+					PeerRange:       peerRange,
+					DeclaredRange:   declaredRange,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+func sortedKeys(deps map[string]string) []string {
+	keys := make([]string, 0, len(deps))
+	for key := range deps {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// isPeerImported reports whether a declared peer is imported, counting `@types/x` (and
+// `@types/scope__x`) as imported when `x` is.
+func isPeerImported(imports map[string]string, peer string) bool {
+	if _, imported := imports[peer]; imported {
+		return true
+	}
+	typed, isTypes := strings.CutPrefix(peer, "@types/")
+	if !isTypes {
+		return false
+	}
+	if scope, name, scoped := strings.Cut(typed, "__"); scoped {
+		typed = "@" + scope + "/" + name
+	}
+	_, imported := imports[typed]
+	return imported
+}
+
+// optionalPeerDependencies returns the peers marked optional in peerDependenciesMeta.
+func optionalPeerDependencies(config *monorepo.PackageJsonConfig) map[string]bool {
+	optional := map[string]bool{}
+	meta, _ := config.Fields["peerDependenciesMeta"].(map[string]interface{})
+	for peer, value := range meta {
+		if fields, ok := value.(map[string]interface{}); ok && fields["optional"] == true {
+			optional[peer] = true
+		}
+	}
+	return optional
+}
+
+// declaredDependency returns the first field of config declaring dependency, with its specifier.
+func declaredDependency(config *monorepo.PackageJsonConfig, dependency string) (string, string) {
+	for _, field := range workspaceDependencyFields {
+		if specifier, ok := field.deps(config)[dependency]; ok {
+			return field.name, specifier
+		}
+	}
+	return "", ""
+}
+
+// satisfiesPeerRange reports whether the lowest version allowed by a consumer's specifier is in the
+// peer range. Specifiers and ranges that are not plain semver ranges (workspace:, catalog:, npm:
+// aliases, unions, tags) cannot be compared and are accepted.
+func satisfiesPeerRange(specifier string, peerRange string) bool {
+	constraint, err := semver.NewConstraint(peerRange)
+	if err != nil {
+		return true
+	}
+	specifier = strings.TrimSpace(specifier)
+	if strings.ContainsAny(specifier, " |<:") {
+		return true
+	}
+	version, err := semver.NewVersion(strings.TrimLeft(specifier, "^~>=v"))
+	if err != nil {
+		return true
+	}
+	return constraint.Check(version)
+}
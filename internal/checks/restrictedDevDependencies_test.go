@@ -1,6 +1,9 @@
 package checks
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 // TestFindDevDependenciesInProduction_PerFileSets verifies that the per-file dev dependency lookup
 // flags violations against each file's own set — mirroring nearest-package (each file's own package
@@ -104,3 +107,56 @@ func TestFindDevDependenciesInProduction_IgnoreTypeImports(t *testing.T) {
 		t.Fatalf("expected eslint violation, got %+v", violationsInclude[0])
 	}
 }
+
+// TestFindUnnecessaryProdDependencies verifies that dependencies imported only outside of the
+// production graph are reported once, with the dev entry point the importing file is reachable
+// from, while dependencies also used by production code or never imported are left alone.
+func TestFindUnnecessaryProdDependencies(t *testing.T) {
+	rulePath := "/repo"
+	ruleTree := MinimalDependencyTree{
+		"/repo/src/main.ts": {
+			{ID: "/repo/src/util.ts", Request: "./util", ResolvedType: UserModule},
+			{Request: "zod", ResolvedType: NodeModule},
+		},
+		"/repo/src/util.ts": {
+			{Request: "lodash/fp", ResolvedType: NodeModule},
+		},
+		"/repo/src/main.test.ts": {
+			{ID: "/repo/src/main.ts", Request: "./main", ResolvedType: UserModule},
+			{ID: "/repo/test/helpers.ts", Request: "../test/helpers", ResolvedType: UserModule},
+			{Request: "zod", ResolvedType: NodeModule},
+		},
+		"/repo/test/helpers.ts": {
+			{Request: "@faker-js/faker", ResolvedType: NodeModule},
+			{Request: "lodash", ResolvedType: NodeModule},
+		},
+		"/repo/scripts/a.ts": {
+			{ID: "/repo/scripts/b.ts", Request: "./b", ResolvedType: UserModule},
+		},
+		"/repo/scripts/b.ts": {
+			{ID: "/repo/scripts/a.ts", Request: "./a", ResolvedType: UserModule},
+			{Request: "chalk", ResolvedType: NodeModule, ImportKind: OnlyTypeImport},
+		},
+	}
+	prodDependencies := map[string]bool{"zod": true, "lodash": true, "@faker-js/faker": true, "chalk": true, "left-pad": true}
+	prodDepsForFile := func(string) (string, map[string]bool) { return "/repo/package.json", prodDependencies }
+
+	violations := FindUnnecessaryProdDependencies(ruleTree, []string{"src/main.ts"}, false, rulePath, prodDepsForFile)
+
+	expected := []UnnecessaryProdDependencyViolation{
+		{ProdDependency: "@faker-js/faker", PackageJsonPath: "/repo/package.json", FilePath: "/repo/test/helpers.ts", EntryPoint: "/repo/src/main.test.ts"},
+		{ProdDependency: "chalk", PackageJsonPath: "/repo/package.json", FilePath: "/repo/scripts/b.ts", EntryPoint: "/repo/scripts/a.ts"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+
+	violations = FindUnnecessaryProdDependencies(ruleTree, []string{"src/main.ts"}, true, rulePath, prodDepsForFile)
+	if len(violations) != 1 || violations[0].ProdDependency != "@faker-js/faker" {
+		t.Fatalf("expected the type-only chalk import to be ignored, got %+v", violations)
+	}
+
+	if violations := FindUnnecessaryProdDependencies(ruleTree, nil, false, rulePath, prodDepsForFile); len(violations) != 0 {
+		t.Fatalf("expected no violations without prod entry points, got %+v", violations)
+	}
+}
@@ -2,6 +2,7 @@ package checks
 
 import (
 	"slices"
+	"strings"
 
 	globutil "rev-dep-go/internal/glob"
 	"rev-dep-go/internal/graph"
@@ -80,12 +81,158 @@ func FindDevDependenciesInProduction(
 	return violations
 }
 
+// UnnecessaryProdDependencyViolation represents a package declared in dependencies that is only
+// imported by files not reachable from production entry points, so it could be a dev dependency
+type UnnecessaryProdDependencyViolation struct {
+	ProdDependency  string `json:"prodDependency"`
+	PackageJsonPath string `json:"packageJsonPath"`
+	FilePath        string `json:"filePath"`
+	EntryPoint      string `json:"entryPoint"`
+}
+
+// FindUnnecessaryProdDependencies detects dependencies that are imported only by files not
+// reachable from production entry points (tests, tooling, dev entry points), so they could be
+// moved to devDependencies. Dependencies that are not imported at all are left to unused node
+// modules detection.
+//
+// prodDepsForFile returns the package.json declaring the dependencies that apply to the given file
+// together with its production dependency names, following nodeModulesResolution like
+// devDepsForFile does. A dependency is reported once per package.json, with the first file
+// importing it and the entry point that file is reachable from as evidence.
+func FindUnnecessaryProdDependencies(
+	ruleTree MinimalDependencyTree,
+	validEntryPoints []string,
+	ignoreTypeImports bool,
+	rulePath string,
+	prodDepsForFile func(filePath string) (string, map[string]bool),
+) []UnnecessaryProdDependencyViolation {
+	if len(validEntryPoints) == 0 || prodDepsForFile == nil {
+		return []UnnecessaryProdDependencyViolation{}
+	}
+
+	entryPointGlobs := globutil.CreateGlobMatchers(validEntryPoints, rulePath)
+	prodEntryPoints := []string{}
+	for filePath := range ruleTree {
+		if globutil.MatchesAnyGlobMatcher(filePath, entryPointGlobs, false) {
+			prodEntryPoints = append(prodEntryPoints, filePath)
+		}
+	}
+	slices.Sort(prodEntryPoints) // ensure deterministic results
+
+	prodGraph := graph.BuildDepsGraphForMultiple(ruleTree, prodEntryPoints, nil, false, ignoreTypeImports)
+
+	// Dependencies imported by production code are needed in production installs.
+	usedInProd := map[string]map[string]bool{}
+	for filePath, vertex := range prodGraph.Vertices {
+		packageJsonPath, prodDeps := prodDepsForFile(filePath)
+		for _, moduleRequest := range vertex.Modules {
+			if moduleName := module.GetNodeModuleName(moduleRequest); prodDeps[moduleName] {
+				if usedInProd[packageJsonPath] == nil {
+					usedInProd[packageJsonPath] = map[string]bool{}
+				}
+				usedInProd[packageJsonPath][moduleName] = true
+			}
+		}
+	}
+
+	devGraph := buildNonProdDepsGraph(ruleTree, prodGraph, ignoreTypeImports)
+	devFiles := make([]string, 0, len(devGraph.Vertices))
+	for filePath := range devGraph.Vertices {
+		if _, isProd := prodGraph.Vertices[filePath]; !isProd {
+			devFiles = append(devFiles, filePath)
+		}
+	}
+	slices.Sort(devFiles)
+
+	violations := []UnnecessaryProdDependencyViolation{}
+	reported := map[string]map[string]bool{}
+	for _, filePath := range devFiles {
+		packageJsonPath, prodDeps := prodDepsForFile(filePath)
+		if len(prodDeps) == 0 {
+			continue
+		}
+		vertex := devGraph.Vertices[filePath]
+		for _, moduleRequest := range vertex.Modules {
+			moduleName := module.GetNodeModuleName(moduleRequest)
+			if !prodDeps[moduleName] || usedInProd[packageJsonPath][moduleName] || reported[packageJsonPath][moduleName] {
+				continue
+			}
+			if reported[packageJsonPath] == nil {
+				reported[packageJsonPath] = map[string]bool{}
+			}
+			reported[packageJsonPath][moduleName] = true
+
+			violations = append(violations, UnnecessaryProdDependencyViolation{
+				ProdDependency:  moduleName,
+				PackageJsonPath: packageJsonPath,
+				FilePath:        filePath,
+				EntryPoint:      FollowPathToGetEntryPoint(vertex, devGraph),
+			})
+		}
+	}
+
+	slices.SortFunc(violations, func(a, b UnnecessaryProdDependencyViolation) int {
+		if a.PackageJsonPath != b.PackageJsonPath {
+			return strings.Compare(a.PackageJsonPath, b.PackageJsonPath)
+		}
+		return strings.Compare(a.ProdDependency, b.ProdDependency)
+	})
+
+	return violations
+}
+
+// buildNonProdDepsGraph builds the graph of files not reachable from production entry points. Its
+// entry points are the files no other such file imports (test files, configs, scripts), so
+// FollowPathToGetEntryPoint leads from any of them to the dev entry point pulling it in. Files
+// only reachable through an import cycle become entry points of their own.
+func buildNonProdDepsGraph(ruleTree MinimalDependencyTree, prodGraph graph.BuildDepsGraphResultMultiple, ignoreTypeImports bool) graph.BuildDepsGraphResultMultiple {
+	nonProdFiles := []string{}
+	importedByNonProd := map[string]bool{}
+	for filePath, deps := range ruleTree {
+		if _, isProd := prodGraph.Vertices[filePath]; isProd {
+			continue
+		}
+		nonProdFiles = append(nonProdFiles, filePath)
+		for _, dep := range deps {
+			if ignoreTypeImports && dep.ImportKind == OnlyTypeImport {
+				continue
+			}
+			if dep.ID != "" && dep.ID != filePath && (dep.ResolvedType == UserModule || dep.ResolvedType == MonorepoModule) {
+				importedByNonProd[dep.ID] = true
+			}
+		}
+	}
+	slices.Sort(nonProdFiles)
+
+	entryPoints := []string{}
+	for _, filePath := range nonProdFiles {
+		if !importedByNonProd[filePath] {
+			entryPoints = append(entryPoints, filePath)
+		}
+	}
+
+	devGraph := graph.BuildDepsGraphForMultiple(ruleTree, entryPoints, nil, false, ignoreTypeImports)
+	unreached := []string{}
+	for _, filePath := range nonProdFiles {
+		if _, reached := devGraph.Vertices[filePath]; !reached {
+			unreached = append(unreached, filePath)
+		}
+	}
+	if len(unreached) == 0 {
+		return devGraph
+	}
+	return graph.BuildDepsGraphForMultiple(ruleTree, append(entryPoints, unreached...), nil, false, ignoreTypeImports)
+}
+
 func FollowPathToGetEntryPoint(vertex *graph.SerializableNode, graph graph.BuildDepsGraphResultMultiple) string {
 	currentVertex := vertex
+	visited := map[string]bool{}
 	for currentVertex != nil {
-		if len(currentVertex.Parents) == 0 {
+		// Entry points imported back from their own subtree have parents too, stop at the cycle
+		if len(currentVertex.Parents) == 0 || visited[currentVertex.Path] {
 			return currentVertex.Path
 		}
+		visited[currentVertex.Path] = true
 		// We assume only one path was resolved, so we take the first parent
 		parentPath := currentVertex.Parents[0]
 		currentVertex = graph.Vertices[parentPath]
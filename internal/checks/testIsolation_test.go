@@ -0,0 +1,109 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/rules"
+)
+
+// testIsolationFixture is a workspace with packages app and ui:
+//   - app/src/main.ts (production entry point) -> store.ts -> __mocks__/api.ts;
+//   - app/scripts/seed.ts is not reachable from main.ts and imports a fixture;
+//   - app/src/store.test.ts imports ui's test utilities, a fixture and (through it) a nested one;
+//   - app/src/__fixtures__/unused.ts is never imported;
+//   - ui/src/button.test.ts imports its own package's test utilities.
+func testIsolationFixture() (MinimalDependencyTree, []string, *monorepo.MonorepoContext) {
+	uiRender := userDep("/repo/packages/ui/test-utils/render.ts", "ui/test-utils/render")
+	uiRender.ResolvedType = MonorepoModule
+
+	tree := MinimalDependencyTree{
+		"/repo/packages/app/src/main.ts":          {userDep("/repo/packages/app/src/store.ts", "./store")},
+		"/repo/packages/app/src/store.ts":         {userDep("/repo/packages/app/src/__mocks__/api.ts", "./__mocks__/api")},
+		"/repo/packages/app/src/__mocks__/api.ts": {},
+		"/repo/packages/app/scripts/seed.ts":      {userDep("/repo/packages/app/src/__fixtures__/user.ts", "../src/__fixtures__/user")},
+		"/repo/packages/app/src/store.test.ts": {
+			userDep("/repo/packages/app/src/store.ts", "./store"),
+			uiRender,
+			userDep("/repo/packages/app/src/__fixtures__/user.ts", "./__fixtures__/user"),
+		},
+		"/repo/packages/app/src/__fixtures__/user.ts":   {userDep("/repo/packages/app/src/__fixtures__/nested.ts", "./nested")},
+		"/repo/packages/app/src/__fixtures__/nested.ts": {},
+		"/repo/packages/app/src/__fixtures__/unused.ts": {},
+		"/repo/packages/ui/test-utils/render.ts":        {userDep("/repo/packages/ui/src/button.ts", "../src/button")},
+		"/repo/packages/ui/src/button.ts":               {},
+		"/repo/packages/ui/src/button.test.ts":          {userDep("/repo/packages/ui/test-utils/render.ts", "../test-utils/render")},
+	}
+	files := make([]string, 0, len(tree))
+	for file := range tree {
+		files = append(files, file)
+	}
+	ctx := &monorepo.MonorepoContext{
+		WorkspaceRoot: "/repo",
+		PackageToPath: map[string]string{"app": "/repo/packages/app", "ui": "/repo/packages/ui"},
+	}
+	return tree, files, ctx
+}
+
+func TestFindTestIsolationViolations(t *testing.T) {
+	tree, files, ctx := testIsolationFixture()
+	opts := &rules.TestIsolationDetectionOptions{
+		Enabled:         true,
+		TestFiles:       []string{"**/*.test.ts", "**/__mocks__/**", "**/test-utils/**"},
+		FixtureFiles:    []string{"**/__fixtures__/**"},
+		ProdEntryPoints: []string{"packages/*/src/main.ts"},
+	}
+
+	violations := FindTestIsolationViolations(tree, files, ctx, opts, "/repo")
+
+	expected := []TestIsolationViolation{
+		{
+			ViolationType: TestIsolationProductionImport,
+			FilePath:      "/repo/packages/app/src/store.ts",
+			ImportPath:    "/repo/packages/app/src/__mocks__/api.ts",
+			ImportRequest: "./__mocks__/api",
+			EntryPoint:    "/repo/packages/app/src/main.ts",
+		},
+		{
+			ViolationType: TestIsolationCrossPackageImport,
+			FilePath:      "/repo/packages/app/src/store.test.ts",
+			ImportPath:    "/repo/packages/ui/test-utils/render.ts",
+			ImportRequest: "ui/test-utils/render",
+			PackageName:   "ui",
+		},
+		{
+			ViolationType: TestIsolationUnusedFixture,
+			FilePath:      "/repo/packages/app/src/__fixtures__/unused.ts",
+		},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+}
+
+func TestFindTestIsolationViolations_WithoutProdEntryPoints(t *testing.T) {
+	tree, files, _ := testIsolationFixture()
+	// Default test globs and no workspace: every file that is not test-only is a production file.
+	// Without fixtureFiles the fixture imported by the seed script is not test-only, so only
+	// store.ts importing a mock is reported.
+	opts := &rules.TestIsolationDetectionOptions{Enabled: true}
+
+	violations := FindTestIsolationViolations(tree, files, nil, opts, "/repo")
+
+	expected := []TestIsolationViolation{{
+		ViolationType: TestIsolationProductionImport,
+		FilePath:      "/repo/packages/app/src/store.ts",
+		ImportPath:    "/repo/packages/app/src/__mocks__/api.ts",
+		ImportRequest: "./__mocks__/api",
+	}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+
+	opts.FixtureFiles = []string{"**/__fixtures__/**"}
+	violations = FindTestIsolationViolations(tree, files, nil, opts, "/repo")
+	if len(violations) != 3 || violations[0].FilePath != "/repo/packages/app/scripts/seed.ts" || violations[2].ViolationType != TestIsolationUnusedFixture {
+		t.Errorf("expected the seed script importing a fixture and the unused fixture to be reported, got %+v", violations)
+	}
+}
@@ -0,0 +1,204 @@
+package checks
+
+import (
+	"slices"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/rules"
+)
+
+const (
+	// TestIsolationProductionImport: a production file imports a test-only file.
+	TestIsolationProductionImport = "production-imports-test"
+	// TestIsolationCrossPackageImport: a test file imports a test-only file of another workspace package.
+	TestIsolationCrossPackageImport = "cross-package-test-import"
+	// TestIsolationUnusedFixture: no test file reaches the fixture.
+	TestIsolationUnusedFixture = "unused-fixture"
+)
+
+// DefaultTestFilePatterns are the test file globs used when testFiles is not configured.
+var DefaultTestFilePatterns = []string{"**/*.test.*", "**/*.spec.*", "**/__tests__/**", "**/__mocks__/**"}
+
+// TestIsolationViolation represents test-only code leaking out of tests, or an unused fixture
+type TestIsolationViolation struct {
+	ViolationType string
+	FilePath      string
+	// ImportPath and ImportRequest are empty for TestIsolationUnusedFixture.
+	ImportPath    string
+	ImportRequest string
+	// EntryPoint is the production entry point reaching FilePath (TestIsolationProductionImport,
+	// when prodEntryPoints are set).
+	EntryPoint string
+	// PackageName is the workspace package owning ImportPath (TestIsolationCrossPackageImport).
+	PackageName string
+}
+
+// FindTestIsolationViolations reports production files importing test-only files (test files and
+// fixtures), test files importing the test-only files of another workspace package, and fixtures
+// no test file reaches through imports.
+//
+// Production files are the files reachable from opts.ProdEntryPoints without going through a
+// test-only file; without prodEntryPoints every file that is not test-only is a production file.
+// Cross-package imports are only checked in workspaces (monorepoContext set).
+func FindTestIsolationViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	monorepoContext *monorepo.MonorepoContext,
+	opts *rules.TestIsolationDetectionOptions,
+	rulePath string,
+) []TestIsolationViolation {
+	violations := []TestIsolationViolation{}
+	if opts == nil || !opts.Enabled {
+		return violations
+	}
+
+	testPatterns := opts.TestFiles
+	if len(testPatterns) == 0 {
+		testPatterns = DefaultTestFilePatterns
+	}
+	testMatchers := globutil.CreateGlobMatchers(testPatterns, rulePath)
+	fixtureMatchers := globutil.CreateGlobMatchers(opts.FixtureFiles, rulePath)
+	isTest := func(filePath string) bool {
+		return globutil.MatchesAnyGlobMatcher(filePath, testMatchers, false)
+	}
+	isFixture := func(filePath string) bool {
+		return globutil.MatchesAnyGlobMatcher(filePath, fixtureMatchers, false)
+	}
+	isTestOnly := func(filePath string) bool {
+		return isTest(filePath) || isFixture(filePath)
+	}
+
+	// fileImports returns the distinct files a file imports, with the request of the first import.
+	fileImports := func(filePath string) ([]string, map[string]string) {
+		var targets []string
+		requests := map[string]string{}
+		for _, dep := range minimalTree[filePath] {
+			if dep.IsLocalExport || dep.ID == "" || (dep.ResolvedType != UserModule && dep.ResolvedType != MonorepoModule) {
+				continue
+			}
+			if opts.IgnoreTypeImports && dep.ImportKind == OnlyTypeImport {
+				continue
+			}
+			if _, seen := requests[dep.ID]; seen || dep.ID == filePath {
+				continue
+			}
+			requests[dep.ID] = dep.Request
+			targets = append(targets, dep.ID)
+		}
+		return targets, requests
+	}
+
+	sortedFiles := slices.Clone(files)
+	slices.Sort(sortedFiles)
+
+	// Production files and the entry point each one is reached from.
+	entryPointOf := map[string]string{}
+	var productionFiles []string
+	if len(opts.ProdEntryPoints) > 0 {
+		entryPointMatchers := globutil.CreateGlobMatchers(opts.ProdEntryPoints, rulePath)
+		var queue []string
+		for _, filePath := range sortedFiles {
+			if !isTestOnly(filePath) && globutil.MatchesAnyGlobMatcher(filePath, entryPointMatchers, false) {
+				entryPointOf[filePath] = filePath
+				queue = append(queue, filePath)
+			}
+		}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			productionFiles = append(productionFiles, current)
+			targets, _ := fileImports(current)
+			for _, target := range targets {
+				if _, visited := entryPointOf[target]; visited || isTestOnly(target) {
+					continue
+				}
+				if _, inTree := minimalTree[target]; !inTree {
+					continue
+				}
+				entryPointOf[target] = entryPointOf[current]
+				queue = append(queue, target)
+			}
+		}
+		slices.Sort(productionFiles)
+	} else {
+		for _, filePath := range sortedFiles {
+			if !isTestOnly(filePath) {
+				productionFiles = append(productionFiles, filePath)
+			}
+		}
+	}
+
+	for _, filePath := range productionFiles {
+		targets, requests := fileImports(filePath)
+		for _, target := range targets {
+			if isTestOnly(target) {
+				violations = append(violations, TestIsolationViolation{
+					ViolationType: TestIsolationProductionImport,
+					FilePath:      filePath,
+					ImportPath:    target,
+					ImportRequest: requests[target],
+					EntryPoint:    entryPointOf[filePath],
+				})
+			}
+		}
+	}
+
+	if monorepoContext != nil {
+		packageOf := workspacePackageResolver(monorepoContext)
+		for _, filePath := range sortedFiles {
+			if !isTest(filePath) {
+				continue
+			}
+			importerPackage := packageOf(filePath)
+			targets, requests := fileImports(filePath)
+			for _, target := range targets {
+				if !isTestOnly(target) {
+					continue
+				}
+				if targetPackage := packageOf(target); targetPackage != "" && targetPackage != importerPackage {
+					violations = append(violations, TestIsolationViolation{
+						ViolationType: TestIsolationCrossPackageImport,
+						FilePath:      filePath,
+						ImportPath:    target,
+						ImportRequest: requests[target],
+						PackageName:   targetPackage,
+					})
+				}
+			}
+		}
+	}
+
+	if len(opts.FixtureFiles) > 0 {
+		// Files reachable from test files; fixtures outside this set are unused.
+		reachedFromTests := map[string]bool{}
+		var queue []string
+		for _, filePath := range sortedFiles {
+			if isTest(filePath) && !isFixture(filePath) {
+				reachedFromTests[filePath] = true
+				queue = append(queue, filePath)
+			}
+		}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			targets, _ := fileImports(current)
+			for _, target := range targets {
+				if !reachedFromTests[target] {
+					reachedFromTests[target] = true
+					queue = append(queue, target)
+				}
+			}
+		}
+		for _, filePath := range sortedFiles {
+			if isFixture(filePath) && !reachedFromTests[filePath] {
+				violations = append(violations, TestIsolationViolation{
+					ViolationType: TestIsolationUnusedFixture,
+					FilePath:      filePath,
+				})
+			}
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,163 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/model"
+	"rev-dep-go/internal/parser"
+	"rev-dep-go/internal/rules"
+	"rev-dep-go/internal/sourceedit"
+)
+
+// typeImportsTree writes sources (relative path -> code) to a temp dir and builds a detailed tree,
+// resolving `./x` requests to `x.ts` in the same directory.
+func typeImportsTree(t *testing.T, sources map[string]string) (string, MinimalDependencyTree, []string) {
+	t.Helper()
+	dir := t.TempDir()
+	var fileImportsArr []model.FileImports
+	var files []string
+	for rel, code := range sources {
+		file := filepath.ToSlash(filepath.Join(dir, rel))
+		if err := os.WriteFile(file, []byte(code), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+		imports := parser.ParseImportsForTestsDetailed(code)
+		for i := range imports {
+			if imports[i].IsLocalExport {
+				continue
+			}
+			imports[i].PathOrName = filepath.ToSlash(filepath.Join(dir, strings.TrimPrefix(imports[i].Request, "./")+".ts"))
+			imports[i].ResolvedType = UserModule
+		}
+		fileImportsArr = append(fileImportsArr, model.FileImports{FilePath: file, Imports: imports})
+		files = append(files, file)
+	}
+	return dir, model.TransformToMinimalDependencyTreeCustomParser(fileImportsArr), files
+}
+
+// applyTypeImportFix applies the fix of the single violation reported for file and returns the content.
+func applyTypeImportFix(t *testing.T, violations []TypeImportViolation, file string) string {
+	t.Helper()
+	if len(violations) != 1 || violations[0].FilePath != file {
+		t.Fatalf("expected 1 violation in %s, got %+v", file, violations)
+	}
+	if violations[0].Fix == nil {
+		t.Fatalf("expected a fix for %+v", violations[0])
+	}
+	if err := sourceedit.ApplyFileChanges(map[string][]sourceedit.Change{file: {*violations[0].Fix}}); err != nil {
+		t.Fatalf("apply fix: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read %s: %v", file, err)
+	}
+	return string(content)
+}
+
+const typeImportsModel = "export interface User { id: string }\nexport type Id = string;\nexport const createUser = (id: Id): User => ({ id });\nexport default interface Props {}\n"
+
+func TestFindTypeImportViolations_WholeStatementDropsInlineModifiers(t *testing.T) {
+	dir, tree, files := typeImportsTree(t, map[string]string{
+		"model.ts": typeImportsModel,
+		"app.ts":   "import { type User, Id } from './model';\n",
+	})
+
+	violations := FindTypeImportViolations(tree, files, &rules.TypeImportsDetectionOptions{Enabled: true, Autofix: true}, dir)
+
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", violations)
+	}
+	if !violations[0].WholeStatement || !slices.Equal(violations[0].TypeOnlyNames, []string{"Id"}) {
+		t.Errorf("unexpected violation: %+v", violations[0])
+	}
+	content := applyTypeImportFix(t, violations, filepath.ToSlash(filepath.Join(dir, "app.ts")))
+	if content != "import type { User, Id } from './model';\n" {
+		t.Errorf("unexpected fixed content: %q", content)
+	}
+}
+
+func TestFindTypeImportViolations_PreferInline(t *testing.T) {
+	dir, tree, files := typeImportsTree(t, map[string]string{
+		"model.ts": typeImportsModel,
+		"app.ts":   "import { User, Id as UserId } from './model';\n",
+	})
+
+	violations := FindTypeImportViolations(tree, files, &rules.TypeImportsDetectionOptions{Enabled: true, Autofix: true, PreferInline: true}, dir)
+
+	content := applyTypeImportFix(t, violations, filepath.ToSlash(filepath.Join(dir, "app.ts")))
+	if content != "import { type User, type Id as UserId } from './model';\n" {
+		t.Errorf("unexpected fixed content: %q", content)
+	}
+}
+
+func TestFindTypeImportViolations_PartialImportOnly(t *testing.T) {
+	dir, tree, files := typeImportsTree(t, map[string]string{
+		"model.ts":  typeImportsModel,
+		"mixed.ts":  "import Props, { User } from './model';\n",
+		"values.ts": "import { createUser } from './model';\n",
+		"ns.ts":     "import * as model from './model';\n",
+		"types.ts":  "import type { User } from './model';\n",
+	})
+
+	violations := FindTypeImportViolations(tree, files, &rules.TypeImportsDetectionOptions{Enabled: true, Autofix: true}, dir)
+
+	if len(violations) != 1 {
+		t.Fatalf("expected only mixed.ts to be reported, got %+v", violations)
+	}
+	if violations[0].FilePath != filepath.ToSlash(filepath.Join(dir, "mixed.ts")) || violations[0].WholeStatement {
+		t.Errorf("unexpected violation: %+v", violations[0])
+	}
+	if violations[0].Fix == nil || violations[0].Fix.Text != "type User" {
+		t.Errorf("expected an inline fix for the named binding, got %+v", violations[0].Fix)
+	}
+}
+
+func TestFindTypeImportViolations_NameExportedAsValueAndType(t *testing.T) {
+	dir, tree, files := typeImportsTree(t, map[string]string{
+		"model.ts":  "export const User = z.object({ id: z.string() });\nexport type User = z.infer<typeof User>;\nexport type Id = string;\nexport const Id = (value: string): Id => value;\n",
+		"index.ts":  "export { User, Id } from './model';\n",
+		"app.ts":    "import { User } from './model';\nUser.parse(input);\n",
+		"barrel.ts": "import { User, Id } from './index';\nUser.parse(Id(input));\n",
+	})
+
+	violations := FindTypeImportViolations(tree, files, &rules.TypeImportsDetectionOptions{Enabled: true, Autofix: true}, dir)
+
+	if len(violations) != 0 {
+		t.Errorf("expected names exported both as a value and as a type not to be reported, got %+v", violations)
+	}
+}
+
+func TestFindTypeImportViolations_BindingsOnlyReExportedAsTypes(t *testing.T) {
+	dir, tree, files := typeImportsTree(t, map[string]string{
+		"model.ts":   "export class User {}\nexport const createUser = () => new User();\n",
+		"whole.ts":   "import { User as Account } from './model';\nexport type { Account };\n",
+		"partial.ts": "import { User, createUser } from './model';\nexport type { User };\nexport const admin = createUser();\n",
+		"used.ts":    "import { User } from './model';\nexport type { User };\nexport const user = new User();\n",
+		"values.ts":  "import { User } from './model';\nexport { User };\n",
+		"from.ts":    "import { User } from './model';\nexport type { User } from './model';\n",
+	})
+
+	violations := FindTypeImportViolations(tree, files, &rules.TypeImportsDetectionOptions{Enabled: true, Autofix: true}, dir)
+
+	if len(violations) != 2 {
+		t.Fatalf("expected partial.ts and whole.ts to be reported, got %+v", violations)
+	}
+	partial, whole := violations[0], violations[1]
+	if partial.FilePath != filepath.ToSlash(filepath.Join(dir, "partial.ts")) || partial.WholeStatement || !slices.Equal(partial.TypeOnlyNames, []string{"User"}) {
+		t.Errorf("unexpected violation: %+v", partial)
+	}
+	if partial.Fix == nil || partial.Fix.Text != "type User" {
+		t.Errorf("expected an inline fix for User, got %+v", partial.Fix)
+	}
+	if !whole.WholeStatement || !slices.Equal(whole.TypeOnlyNames, []string{"User"}) {
+		t.Errorf("unexpected violation: %+v", whole)
+	}
+	content := applyTypeImportFix(t, violations[1:], filepath.ToSlash(filepath.Join(dir, "whole.ts")))
+	if content != "import type { User as Account } from './model';\nexport type { Account };\n" {
+		t.Errorf("unexpected fixed content: %q", content)
+	}
+}
@@ -0,0 +1,408 @@
+package checks
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/rules"
+	"rev-dep-go/internal/sourceedit"
+)
+
+// TypeImportViolation represents a value import whose bindings are exported only as types by the
+// target module, or are only re-exported as types by the importing file
+type TypeImportViolation struct {
+	FilePath       string
+	ImportPath     string
+	ImportRequest  string
+	TypeOnlyNames  []string           // Imported names (as exported by the target) that can be type imports
+	WholeStatement bool               // Every binding of the statement is type-only
+	Fix            *sourceedit.Change // Conversion to `import type` / inline `type`, nil if not auto-fixable
+}
+
+// FindTypeImportViolations reports value imports (no `import type` and no inline `type`) of names
+// that the target module exports only as types: `export type` / `export interface` declarations,
+// `export type { x } from` re-exports, and names traced to those through re-exports. Bindings the
+// importing file uses only in local `export type { x }` statements are reported too, whatever the
+// target exports. Requires a tree built in detailed parse mode.
+func FindTypeImportViolations(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	opts *rules.TypeImportsDetectionOptions,
+	cwd string,
+) []TypeImportViolation {
+	var violations []TypeImportViolation
+
+	ignoreMatchers := globutil.CreateGlobMatchers(opts.IgnoreFiles, cwd)
+	exports := map[string]map[string][]exportEntry{}
+	forEachExportEntry(files, minimalTree, nil, nil, func(file string, entry exportEntry) {
+		if exports[file] == nil {
+			exports[file] = map[string][]exportEntry{}
+		}
+		exports[file][entry.Name] = append(exports[file][entry.Name], entry)
+	})
+	resolver := &typeOnlyExportResolver{tree: minimalTree, exports: exports}
+
+	for _, file := range files {
+		if globutil.MatchesAnyGlobMatcher(file, ignoreMatchers, false) {
+			continue
+		}
+		deps := minimalTree[file]
+		var typeReExported map[string]bool
+		for i := range deps {
+			dep := &deps[i]
+			if dep.IsDynamicImport || dep.IsLocalExport || isReExport(dep) || dep.Keywords == nil || dep.ImportKind == OnlyTypeImport {
+				continue
+			}
+			checkTarget := dep.ID != "" && (dep.ResolvedType == UserModule || dep.ResolvedType == MonorepoModule)
+
+			var flagged []KeywordInfo
+			valueBindings := 0
+			hasDefault := false
+			for _, kw := range dep.Keywords.Keywords {
+				if kw.IsType {
+					continue
+				}
+				if kw.Name == "*" {
+					// Namespace imports cannot be narrowed to the type-only names they use.
+					flagged = nil
+					valueBindings = -1
+					break
+				}
+				valueBindings++
+				if kw.Name == "default" {
+					hasDefault = true
+				}
+				isType := false
+				if checkTarget {
+					isType, _ = resolver.isTypeOnly(dep.ID, kw.Name, map[string]bool{})
+				}
+				if !isType {
+					if typeReExported == nil {
+						typeReExported = typeReExportOnlyBindings(file, deps)
+					}
+					isType = typeReExported[localBindingName(kw)]
+				}
+				if isType {
+					flagged = append(flagged, kw)
+				}
+			}
+			if len(flagged) == 0 {
+				continue
+			}
+
+			violation := TypeImportViolation{
+				FilePath:       file,
+				ImportPath:     dep.ID,
+				ImportRequest:  dep.Request,
+				WholeStatement: len(flagged) == valueBindings,
+			}
+			for _, kw := range flagged {
+				violation.TypeOnlyNames = append(violation.TypeOnlyNames, kw.Name)
+			}
+			if opts.Autofix {
+				violation.Fix = typeImportFix(file, dep, flagged, violation.WholeStatement && !opts.PreferInline, hasDefault)
+			}
+			violations = append(violations, violation)
+		}
+	}
+
+	slices.SortFunc(violations, func(a, b TypeImportViolation) int {
+		if a.FilePath != b.FilePath {
+			return strings.Compare(a.FilePath, b.FilePath)
+		}
+		return strings.Compare(a.ImportRequest, b.ImportRequest)
+	})
+
+	return violations
+}
+
+// typeOnlyExportResolver answers whether a name exported by a file is a type, following re-exports
+// and local `export { x }` of imported bindings.
+type typeOnlyExportResolver struct {
+	tree MinimalDependencyTree
+	// exports lists every export of a name per file: a name can be exported both as a value and as
+	// a type (`export const User = ...` and `export type User = ...`).
+	exports map[string]map[string][]exportEntry
+}
+
+// isTypeOnly reports whether file exports name only as a type, i.e. none of its exports of name is
+// a value; found is false when the name could not be located among the exports of file.
+func (r *typeOnlyExportResolver) isTypeOnly(file, name string, visited map[string]bool) (isType bool, found bool) {
+	key := file + "\x00" + name
+	if visited[key] {
+		return false, false
+	}
+	visited[key] = true
+
+	if entries, ok := r.exports[file][name]; ok {
+		for _, entry := range entries {
+			if !r.isTypeOnlyEntry(file, name, entry, visited) {
+				return false, true
+			}
+		}
+		return true, true
+	}
+
+	// Not exported by name: look behind the star re-exports.
+	for i := range r.tree[file] {
+		dep := &r.tree[file][i]
+		if !isReExport(dep) || dep.ID == "" || !isStarReExport(dep) {
+			continue
+		}
+		if isType, found := r.isTypeOnly(dep.ID, name, visited); found {
+			return isType || dep.ImportKind == OnlyTypeImport, true
+		}
+	}
+	return false, false
+}
+
+var localTypeExportPattern = regexp.MustCompile(`(?:^|[^\w$.])export\s+type\s*\{([^}]*)\}`)
+
+// typeReExportOnlyBindings returns the local names of the import bindings of file that are used
+// only in local `export type { x }` statements (not followed by `from`). Any other occurrence of a
+// name, including one in a comment or a string, counts as a use, so a binding is never reported
+// for a use the scan cannot tell apart.
+func typeReExportOnlyBindings(file string, deps []MinimalDependency) map[string]bool {
+	result := map[string]bool{}
+	source, err := os.ReadFile(file)
+	if err != nil {
+		return result
+	}
+
+	type span struct{ start, end int }
+	var skipped []span
+	typeExported := map[string]bool{}
+	for _, match := range localTypeExportPattern.FindAllSubmatchIndex(source, -1) {
+		after := match[1]
+		for after < len(source) && isWhiteSpaceByte(source[after]) {
+			after++
+		}
+		if bytes.HasPrefix(source[after:], []byte("from")) && (after+len("from") == len(source) || !isIdentifierByte(source[after+len("from")])) {
+			continue
+		}
+		skipped = append(skipped, span{match[0], match[1]})
+		for _, entry := range strings.Split(string(source[match[2]:match[3]]), ",") {
+			fields := strings.Fields(entry)
+			if len(fields) > 1 && fields[0] == "type" {
+				fields = fields[1:]
+			}
+			if len(fields) > 0 {
+				typeExported[fields[0]] = true
+			}
+		}
+	}
+	if len(typeExported) == 0 {
+		return result
+	}
+
+	for i := range deps {
+		dep := &deps[i]
+		if dep.IsLocalExport || isReExport(dep) || dep.Keywords == nil {
+			continue
+		}
+		for _, kw := range dep.Keywords.Keywords {
+			skipped = append(skipped, span{int(kw.Start), int(kw.End)})
+		}
+	}
+
+	for name := range typeExported {
+		used := false
+		for pos := 0; !used; {
+			idx := bytes.Index(source[pos:], []byte(name))
+			if idx < 0 {
+				break
+			}
+			start := pos + idx
+			end := start + len(name)
+			pos = end
+			if (start > 0 && isIdentifierByte(source[start-1])) || (end < len(source) && isIdentifierByte(source[end])) {
+				continue
+			}
+			used = !slices.ContainsFunc(skipped, func(s span) bool { return start >= s.start && end <= s.end })
+		}
+		if !used {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+// localBindingName returns the name an import binding is known by in the importing file.
+func localBindingName(kw KeywordInfo) string {
+	if kw.Alias != "" {
+		return kw.Alias
+	}
+	return kw.Name
+}
+
+// isTypeOnlyEntry reports whether one export of name by file is a type.
+func (r *typeOnlyExportResolver) isTypeOnlyEntry(file, name string, entry exportEntry, visited map[string]bool) bool {
+	if entry.IsType || entry.Dep.ImportKind == OnlyTypeImport {
+		return true
+	}
+	original := exportedKeyword(entry.Dep, name)
+	if original.Name == "" || original.Name == "*" {
+		return false
+	}
+	if !entry.Dep.IsLocalExport {
+		if entry.Dep.ID == "" {
+			return false
+		}
+		isType, _ := r.isTypeOnly(entry.Dep.ID, original.Name, visited)
+		return isType
+	}
+	// `export { x }` of a binding imported from another file.
+	for i := range r.tree[file] {
+		imp := &r.tree[file][i]
+		if imp.IsLocalExport || isReExport(imp) || imp.Keywords == nil || imp.ID == "" {
+			continue
+		}
+		for _, kw := range imp.Keywords.Keywords {
+			local := kw.Name
+			if kw.Alias != "" {
+				local = kw.Alias
+			}
+			if local != original.Name || kw.Name == "*" {
+				continue
+			}
+			if kw.IsType || imp.ImportKind == OnlyTypeImport {
+				return true
+			}
+			isType, _ := r.isTypeOnly(imp.ID, kw.Name, visited)
+			return isType
+		}
+	}
+	return false
+}
+
+// exportedKeyword returns the keyword of dep exported under name.
+func exportedKeyword(dep *MinimalDependency, name string) KeywordInfo {
+	if dep.Keywords == nil {
+		return KeywordInfo{}
+	}
+	for _, kw := range dep.Keywords.Keywords {
+		exported := kw.Name
+		if kw.Alias != "" {
+			exported = kw.Alias
+		}
+		if exported == name {
+			return kw
+		}
+	}
+	return KeywordInfo{}
+}
+
+// isStarReExport reports whether dep is a plain `export * from` (or `export type * from`).
+func isStarReExport(dep *MinimalDependency) bool {
+	if dep.Keywords == nil {
+		return true
+	}
+	for _, kw := range dep.Keywords.Keywords {
+		if kw.Name == "*" && kw.Alias == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// typeImportFix converts the whole statement to `import type` (dropping inline `type` modifiers) or
+// adds inline `type` modifiers to the flagged bindings. A default binding cannot take an inline
+// modifier, and `import type` cannot combine a default and named bindings, so those are not fixed.
+func typeImportFix(file string, dep *MinimalDependency, flagged []KeywordInfo, wholeStatement bool, hasDefault bool) *sourceedit.Change {
+	source, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	keywords := dep.Keywords.Keywords
+	first, last := keywords[0], keywords[len(keywords)-1]
+	if int(last.End) > len(source) || first.Start >= last.End {
+		return nil
+	}
+
+	if wholeStatement {
+		if hasDefault && len(keywords) > 1 {
+			return nil
+		}
+		importEnd := importKeywordEndBefore(source, int(first.Start))
+		if importEnd < 0 {
+			return nil
+		}
+		var text strings.Builder
+		text.WriteString(" type")
+		pos := importEnd
+		for _, kw := range keywords {
+			if !kw.IsType {
+				continue
+			}
+			text.Write(source[pos:kw.Start])
+			pos = int(kw.Start)
+			// Inline keywords span `type name`; drop the modifier.
+			if bytes.HasPrefix(source[pos:], []byte("type")) {
+				pos += len("type")
+				for pos < int(kw.End) && isWhiteSpaceByte(source[pos]) {
+					pos++
+				}
+			}
+		}
+		text.Write(source[pos:last.End])
+		return &sourceedit.Change{
+			Start: int32(importEnd),
+			End:   int32(last.End),
+			Text:  text.String(),
+		}
+	}
+
+	for _, kw := range flagged {
+		if kw.Name == "default" {
+			return nil
+		}
+	}
+	start, end := flagged[0].Start, flagged[len(flagged)-1].End
+	var text strings.Builder
+	pos := start
+	for _, kw := range flagged {
+		text.Write(source[pos:kw.Start])
+		text.WriteString("type ")
+		pos = kw.Start
+	}
+	text.Write(source[pos:end])
+	return &sourceedit.Change{
+		Start: int32(start),
+		End:   int32(end),
+		Text:  text.String(),
+	}
+}
+
+// importKeywordEndBefore returns the offset right after the `import` keyword preceding the first
+// binding at pos (skipping whitespace and an opening brace), or -1 if it is not found.
+func importKeywordEndBefore(source []byte, pos int) int {
+	i := pos
+	for i > 0 && isWhiteSpaceByte(source[i-1]) {
+		i--
+	}
+	if i > 0 && source[i-1] == '{' {
+		i--
+		for i > 0 && isWhiteSpaceByte(source[i-1]) {
+			i--
+		}
+	}
+	if i < len("import") || string(source[i-len("import"):i]) != "import" {
+		return -1
+	}
+	if start := i - len("import"); start > 0 && (isIdentifierByte(source[start-1]) || source[start-1] == '.') {
+		return -1
+	}
+	return i
+}
+
+func isWhiteSpaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
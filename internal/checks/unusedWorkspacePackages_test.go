@@ -0,0 +1,98 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+// unusedWorkspacePackagesFixture writes a pnpm workspace with:
+//
+//	app     entry point (src/main.ts), imports ui, declares ui, utils and cli
+//	ui      imported by app, declares utils and imports it
+//	utils   imported by ui only
+//	legacy  imported by nobody             -> unused
+//	cli     has a bin, imported by nobody  -> used; app's dependency on it is not reported
+//	web     imported by nobody, no entries -> unused unless listed in rootPackages
+//
+// app declares utils without importing it, which is reported.
+func unusedWorkspacePackagesFixture(t *testing.T) (*monorepo.MonorepoContext, string, MinimalDependencyTree, []string) {
+	t.Helper()
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	write("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	write("package.json", `{"name":"root","private":true}`)
+	write("packages/app/package.json", `{"name":"app","dependencies":{"ui":"workspace:*","utils":"workspace:*","cli":"workspace:*"}}`)
+	write("packages/ui/package.json", `{"name":"ui","dependencies":{"utils":"workspace:*"}}`)
+	write("packages/utils/package.json", `{"name":"utils"}`)
+	write("packages/legacy/package.json", `{"name":"legacy"}`)
+	write("packages/cli/package.json", `{"name":"cli","bin":{"cli":"./bin.js"}}`)
+	write("packages/web/package.json", `{"name":"web"}`)
+
+	root = pathutil.NormalizePathForInternal(filepath.Clean(root))
+	ctx := monorepo.NewMonorepoContext(root)
+	ctx.FindWorkspacePackages(nil, nil)
+
+	tree := MinimalDependencyTree{
+		root + "/packages/app/src/main.ts": {
+			{ID: root + "/packages/ui/index.ts", Request: "ui", ResolvedType: MonorepoModule},
+			{ID: "react", Request: "react", ResolvedType: NodeModule},
+		},
+		root + "/packages/ui/index.ts":     {{ID: "utils", Request: "utils/format", ResolvedType: NodeModule}},
+		root + "/packages/utils/format.ts": nil,
+		root + "/packages/legacy/index.ts": nil,
+		root + "/packages/cli/bin.js":      nil,
+		root + "/packages/web/index.ts":    {userDep(root+"/packages/web/app.ts", "./app")},
+	}
+	files := make([]string, 0, len(tree))
+	for file := range tree {
+		files = append(files, file)
+	}
+	return ctx, root, tree, files
+}
+
+func TestFindUnusedWorkspacePackages(t *testing.T) {
+	ctx, root, tree, files := unusedWorkspacePackagesFixture(t)
+
+	violations := FindUnusedWorkspacePackages(tree, files, ctx, &rules.UnusedWorkspacePackagesDetectionOptions{
+		Enabled:     true,
+		EntryPoints: []string{"packages/*/src/main.ts"},
+	}, root)
+
+	expected := []UnusedWorkspacePackageViolation{
+		{ViolationType: UnusedWorkspaceDependency, PackageName: "app", PackageJsonPath: root + "/packages/app/package.json", Dependency: "utils", DependencyField: "dependencies"},
+		{ViolationType: UnusedWorkspacePackage, PackageName: "legacy", PackageJsonPath: root + "/packages/legacy/package.json"},
+		{ViolationType: UnusedWorkspacePackage, PackageName: "web", PackageJsonPath: root + "/packages/web/package.json"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+}
+
+func TestFindUnusedWorkspacePackages_RootAndIgnoredPackages(t *testing.T) {
+	ctx, root, tree, files := unusedWorkspacePackagesFixture(t)
+
+	violations := FindUnusedWorkspacePackages(tree, files, ctx, &rules.UnusedWorkspacePackagesDetectionOptions{
+		Enabled:        true,
+		RootPackages:   []string{"app", "w*"},
+		IgnorePackages: []string{"utils", "legacy"},
+	}, root)
+
+	if len(violations) != 0 {
+		t.Fatalf("expected root and ignored packages to be skipped, got %+v", violations)
+	}
+}
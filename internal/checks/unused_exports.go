@@ -45,66 +45,7 @@ func FindUnusedExports(
 	graphExcludeGlobs := globutil.CreateGlobMatchers(graphExclude, cwd)
 
 	// Step 1: Build export map - file -> exportName -> exportEntry
-	exportMap := make(map[string]map[string]exportEntry)
-
-	for _, file := range ruleFiles {
-		if globutil.MatchesAnyGlobMatcher(file, graphExcludeGlobs, false) {
-			continue
-		}
-		if moduleSuffixVariants != nil && moduleSuffixVariants[file] {
-			continue
-		}
-
-		deps := ruleTree[file]
-		for i := range deps {
-			dep := &deps[i]
-
-			// Local export (export const X, export function Y, export { A as B }, etc.)
-			if dep.IsLocalExport && dep.Keywords != nil {
-				if exportMap[file] == nil {
-					exportMap[file] = make(map[string]exportEntry)
-				}
-				for _, kw := range dep.Keywords.Keywords {
-					name := kw.Name
-					if kw.Alias != "" {
-						name = kw.Alias
-					}
-					exportMap[file][name] = exportEntry{
-						Name:   name,
-						IsType: kw.IsType,
-						Dep:    dep,
-					}
-				}
-				continue
-			}
-
-			// Re-export (export { A } from './file' or export * from './file')
-			if dep.ExportKeyEnd > 0 && !dep.IsLocalExport {
-				if dep.Keywords != nil {
-					for _, kw := range dep.Keywords.Keywords {
-						// Plain star re-exports (export * from './file') are passthrough -
-						// they don't define named exports in the current file.
-						// export * as name from './file' DOES define a named export (the alias).
-						if kw.Name == "*" && kw.Alias == "" {
-							continue
-						}
-						if exportMap[file] == nil {
-							exportMap[file] = make(map[string]exportEntry)
-						}
-						name := kw.Name
-						if kw.Alias != "" {
-							name = kw.Alias
-						}
-						exportMap[file][name] = exportEntry{
-							Name:   name,
-							IsType: kw.IsType,
-							Dep:    dep,
-						}
-					}
-				}
-			}
-		}
-	}
+	exportMap := buildExportMap(ruleFiles, ruleTree, graphExcludeGlobs, moduleSuffixVariants)
 
 	// Step 2: Build usage map - file -> set of used export names
 	usedExports := make(map[string]map[string]bool)
@@ -276,6 +217,89 @@ func FindUnusedExports(
 	return results
 }
 
+// buildExportMap maps each file to the names it exports (local exports and named re-exports).
+// Plain star re-exports define no names of their own and are not included.
+func buildExportMap(
+	ruleFiles []string,
+	ruleTree MinimalDependencyTree,
+	graphExcludeGlobs []globutil.GlobMatcher,
+	moduleSuffixVariants map[string]bool,
+) map[string]map[string]exportEntry {
+	exportMap := make(map[string]map[string]exportEntry)
+
+	forEachExportEntry(ruleFiles, ruleTree, graphExcludeGlobs, moduleSuffixVariants, func(file string, entry exportEntry) {
+		if exportMap[file] == nil {
+			exportMap[file] = make(map[string]exportEntry)
+		}
+		exportMap[file][entry.Name] = entry
+	})
+
+	return exportMap
+}
+
+// forEachExportEntry calls fn for every named export of the rule files, in source order. A name
+// exported more than once (e.g. as a value and as a type) gets one call per export.
+func forEachExportEntry(
+	ruleFiles []string,
+	ruleTree MinimalDependencyTree,
+	graphExcludeGlobs []globutil.GlobMatcher,
+	moduleSuffixVariants map[string]bool,
+	fn func(file string, entry exportEntry),
+) {
+	for _, file := range ruleFiles {
+		if globutil.MatchesAnyGlobMatcher(file, graphExcludeGlobs, false) {
+			continue
+		}
+		if moduleSuffixVariants != nil && moduleSuffixVariants[file] {
+			continue
+		}
+
+		deps := ruleTree[file]
+		for i := range deps {
+			dep := &deps[i]
+
+			// Local export (export const X, export function Y, export { A as B }, etc.)
+			if dep.IsLocalExport && dep.Keywords != nil {
+				for _, kw := range dep.Keywords.Keywords {
+					name := kw.Name
+					if kw.Alias != "" {
+						name = kw.Alias
+					}
+					fn(file, exportEntry{
+						Name:   name,
+						IsType: kw.IsType,
+						Dep:    dep,
+					})
+				}
+				continue
+			}
+
+			// Re-export (export { A } from './file' or export * from './file')
+			if dep.ExportKeyEnd > 0 && !dep.IsLocalExport {
+				if dep.Keywords != nil {
+					for _, kw := range dep.Keywords.Keywords {
+						// Plain star re-exports (export * from './file') are passthrough -
+						// they don't define named exports in the current file.
+						// export * as name from './file' DOES define a named export (the alias).
+						if kw.Name == "*" && kw.Alias == "" {
+							continue
+						}
+						name := kw.Name
+						if kw.Alias != "" {
+							name = kw.Alias
+						}
+						fn(file, exportEntry{
+							Name:   name,
+							IsType: kw.IsType,
+							Dep:    dep,
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
 func FilterUnusedExports(unusedExports []UnusedExport, opts *UnusedExportsFilterOptions, cwd string) []UnusedExport {
 	if opts == nil {
 		return unusedExports
@@ -0,0 +1,172 @@
+package checks
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+const (
+	// UnusedWorkspacePackage: no other workspace package imports the package, and it is not a root
+	// package, has no `bin` and contains no entry point.
+	UnusedWorkspacePackage = "unused-package"
+	// UnusedWorkspaceDependency: a package declares a sibling workspace package as a dependency but
+	// none of its files import it.
+	UnusedWorkspaceDependency = "unused-workspace-dependency"
+)
+
+// UnusedWorkspacePackageViolation describes a dead workspace package or a declared workspace
+// dependency that is never imported.
+type UnusedWorkspacePackageViolation struct {
+	ViolationType   string
+	PackageName     string
+	PackageJsonPath string
+	// Dependency and DependencyField are set for UnusedWorkspaceDependency.
+	Dependency      string
+	DependencyField string
+}
+
+// FindUnusedWorkspacePackages reports the workspace packages under rulePath that no file of another
+// package imports, and the sibling dependencies a package declares (dependencies, devDependencies,
+// optionalDependencies) without importing them. Imports are taken from the files of the rule, so the
+// rule should cover the whole workspace. A package counts as used when it matches
+// opts.RootPackages, has a `bin` field, or contains a file matching opts.EntryPoints. Dependencies on
+// packages with a `bin` are not reported, since those are usually run rather than imported.
+func FindUnusedWorkspacePackages(
+	minimalTree MinimalDependencyTree,
+	files []string,
+	monorepoContext *monorepo.MonorepoContext,
+	opts *rules.UnusedWorkspacePackagesDetectionOptions,
+	rulePath string,
+) []UnusedWorkspacePackageViolation {
+	violations := []UnusedWorkspacePackageViolation{}
+	if opts == nil || !opts.Enabled || monorepoContext == nil {
+		return violations
+	}
+
+	ruleDir := pathutil.StandardiseDirPathInternal(pathutil.NormalizePathForInternal(filepath.Clean(rulePath)))
+	workspaceRoot := pathutil.StandardiseDirPathInternal(monorepoContext.WorkspaceRoot)
+
+	packageOf := workspacePackageResolver(monorepoContext)
+	packageDirs := map[string]string{}
+	packageNames := make([]string, 0, len(monorepoContext.PackageToPath))
+	for name, packagePath := range monorepoContext.PackageToPath {
+		packageDirs[name] = pathutil.StandardiseDirPathInternal(packagePath)
+		packageNames = append(packageNames, name)
+	}
+
+	// importedBy[target][importer] records package-to-package imports; the workspace root (not a
+	// package) is recorded as "".
+	importedBy := map[string]map[string]bool{}
+	hasFiles := map[string]bool{}
+	hasEntryPoint := map[string]bool{}
+	entryPointMatchers := globutil.CreateGlobMatchers(opts.EntryPoints, rulePath)
+
+	for _, file := range files {
+		importer := packageOf(file)
+		hasFiles[importer] = true
+		if globutil.MatchesAnyGlobMatcher(file, entryPointMatchers, false) {
+			hasEntryPoint[importer] = true
+		}
+		for _, dep := range minimalTree[file] {
+			target := ""
+			if dep.ResolvedType == MonorepoModule && dep.ID != "" {
+				target = packageOf(dep.ID)
+			}
+			if target == "" && dep.Request != "" && !strings.HasPrefix(dep.Request, ".") {
+				if _, isPackage := packageDirs[module.GetNodeModuleName(dep.Request)]; isPackage {
+					target = module.GetNodeModuleName(dep.Request)
+				}
+			}
+			if target == "" || target == importer {
+				continue
+			}
+			if importedBy[target] == nil {
+				importedBy[target] = map[string]bool{}
+			}
+			importedBy[target][importer] = true
+		}
+	}
+
+	rootMatchers := compileModuleGlobMatchers(opts.RootPackages)
+	ignoreMatchers := compileModuleGlobMatchers(opts.IgnorePackages)
+
+	packageJsonPathOf := func(packageDir string) string {
+		return pathutil.NormalizePathForInternal(filepath.Join(pathutil.DenormalizePathForOS(packageDir), "package.json"))
+	}
+	hasBin := func(config *monorepo.PackageJsonConfig) bool {
+		_, ok := config.Fields["bin"]
+		return ok
+	}
+
+	// Report in a stable order: packages by directory.
+	slices.SortFunc(packageNames, func(a, b string) int {
+		return strings.Compare(packageDirs[a], packageDirs[b])
+	})
+
+	for _, name := range packageNames {
+		packageDir := packageDirs[name]
+		if !strings.HasPrefix(packageDir, ruleDir) || packageDir == workspaceRoot {
+			continue
+		}
+		if matchesAnyModulePattern(ignoreMatchers, name, name) {
+			continue
+		}
+		config, err := monorepoContext.GetPackageConfig(monorepoContext.PackageToPath[name])
+		if err != nil {
+			continue
+		}
+
+		if len(importedBy[name]) == 0 && !hasEntryPoint[name] && !hasBin(config) && !matchesAnyModulePattern(rootMatchers, name, name) {
+			violations = append(violations, UnusedWorkspacePackageViolation{
+				ViolationType:   UnusedWorkspacePackage,
+				PackageName:     name,
+				PackageJsonPath: packageJsonPathOf(packageDir),
+			})
+		}
+
+		// Without files in the rule nothing is known about the imports of the package.
+		if !hasFiles[name] {
+			continue
+		}
+		for _, field := range workspaceDependencyFields {
+			if field.isPeer {
+				continue
+			}
+			deps := field.deps(config)
+			dependencies := make([]string, 0, len(deps))
+			for dependency := range deps {
+				dependencies = append(dependencies, dependency)
+			}
+			slices.Sort(dependencies)
+
+			for _, dependency := range dependencies {
+				siblingPath, isSibling := monorepoContext.PackageToPath[dependency]
+				if !isSibling || dependency == name || importedBy[dependency][name] {
+					continue
+				}
+				if matchesAnyModulePattern(ignoreMatchers, dependency, dependency) {
+					continue
+				}
+				if siblingConfig, err := monorepoContext.GetPackageConfig(siblingPath); err == nil && hasBin(siblingConfig) {
+					continue
+				}
+				violations = append(violations, UnusedWorkspacePackageViolation{
+					ViolationType:   UnusedWorkspaceDependency,
+					PackageName:     name,
+					PackageJsonPath: packageJsonPathOf(packageDir),
+					Dependency:      dependency,
+					DependencyField: field.name,
+				})
+			}
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,144 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+// versionConsistencyFixture writes a pnpm workspace with:
+//
+//	root    declares typescript ^5.4.0
+//	admin   declares react ^18.2.0, typescript ^5.4.0, zod through the default catalog, ui via workspace:
+//	legacy  declares react ^17.0.2, typescript ~5.1.0 and zod ^3.20.0
+//	ui      declares react ^18.2.0 and a local file: dependency
+func versionConsistencyFixture(t *testing.T) (*monorepo.MonorepoContext, string) {
+	t.Helper()
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	write("pnpm-workspace.yaml", "packages:\n  - apps/*\n  - packages/*\ncatalog:\n  zod: ^3.22.0\n")
+	write("package.json", `{"name":"root","private":true,"devDependencies":{"typescript":"^5.4.0"}}`)
+	write("apps/admin/package.json", `{
+		"name": "admin",
+		"dependencies": {"ui": "workspace:*", "react": "^18.2.0", "zod": "catalog:"},
+		"devDependencies": {"typescript": "^5.4.0"}
+	}`)
+	write("apps/legacy/package.json", `{
+		"name": "legacy",
+		"dependencies": {"react": "^17.0.2", "zod": "^3.20.0"},
+		"devDependencies": {"typescript": "~5.1.0"}
+	}`)
+	write("packages/ui/package.json", `{
+		"name": "ui",
+		"dependencies": {"local-lib": "file:../local-lib"},
+		"devDependencies": {"react": "^18.2.0"},
+		"peerDependencies": {"react": ">=17"}
+	}`)
+
+	root = pathutil.NormalizePathForInternal(filepath.Clean(root))
+	ctx := monorepo.NewMonorepoContext(root)
+	ctx.FindWorkspacePackages(nil, nil)
+	return ctx, root
+}
+
+func TestCollectDependencyVersions(t *testing.T) {
+	ctx, root := versionConsistencyFixture(t)
+
+	declarations := CollectDependencyVersions(ctx)
+
+	if _, ok := declarations["ui"]; ok {
+		t.Errorf("expected sibling workspace packages to be skipped")
+	}
+	if _, ok := declarations["local-lib"]; ok {
+		t.Errorf("expected file: specifiers to be skipped")
+	}
+	expectedZod := []DependencyVersionDeclaration{
+		{PackageName: "admin", PackageJsonPath: root + "/apps/admin/package.json", DependencyField: "dependencies", Range: "^3.22.0", Catalog: "default"},
+		{PackageName: "legacy", PackageJsonPath: root + "/apps/legacy/package.json", DependencyField: "dependencies", Range: "^3.20.0"},
+	}
+	if !reflect.DeepEqual(declarations["zod"], expectedZod) {
+		t.Errorf("unexpected zod declarations:\n got: %+v\nwant: %+v", declarations["zod"], expectedZod)
+	}
+	if len(declarations["react"]) != 3 {
+		t.Errorf("expected peer ranges not to be collected, got %+v", declarations["react"])
+	}
+	if len(declarations["typescript"]) != 3 || declarations["typescript"][0].PackageName != "root" {
+		t.Errorf("expected the workspace root to be collected, got %+v", declarations["typescript"])
+	}
+}
+
+func TestFindVersionConsistencyViolations(t *testing.T) {
+	ctx, root := versionConsistencyFixture(t)
+	legacy := root + "/apps/legacy/package.json"
+
+	violations := FindVersionConsistencyViolations(ctx, &rules.VersionConsistencyDetectionOptions{
+		Enabled:        true,
+		PinnedVersions: map[string]string{"typescript": "^5.4.0"},
+		Autofix:        true,
+	}, root)
+
+	expected := []VersionConsistencyViolation{
+		{ViolationType: VersionConsistencyMismatch, PackageName: "legacy", PackageJsonPath: legacy, Dependency: "react", DependencyField: "dependencies", DeclaredRange: "^17.0.2", ExpectedRange: "^18.2.0", Autofix: true},
+		{ViolationType: VersionConsistencyNotPinned, PackageName: "legacy", PackageJsonPath: legacy, Dependency: "typescript", DependencyField: "devDependencies", DeclaredRange: "~5.1.0", ExpectedRange: "^5.4.0", Autofix: true},
+		{ViolationType: VersionConsistencyMismatch, PackageName: "legacy", PackageJsonPath: legacy, Dependency: "zod", DependencyField: "dependencies", DeclaredRange: "^3.20.0", ExpectedRange: "^3.22.0", Autofix: true},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+
+	pinnedToLegacy := FindVersionConsistencyViolations(ctx, &rules.VersionConsistencyDetectionOptions{
+		Enabled:        true,
+		IgnorePackages: []string{"zod", "type*"},
+		PinnedVersions: map[string]string{"react": "^17.0.2"},
+	}, root)
+	if len(pinnedToLegacy) != 2 || pinnedToLegacy[0].PackageName != "admin" || pinnedToLegacy[1].PackageName != "ui" {
+		t.Fatalf("expected admin and ui not to match the pinned react range, got %+v", pinnedToLegacy)
+	}
+
+	scoped := FindVersionConsistencyViolations(ctx, &rules.VersionConsistencyDetectionOptions{Enabled: true}, root+"/packages")
+	if len(scoped) != 0 {
+		t.Errorf("expected no violations reported under packages/, got %+v", scoped)
+	}
+}
+
+func TestExpectedDependencyRange(t *testing.T) {
+	declarations := func(ranges ...string) []DependencyVersionDeclaration {
+		result := []DependencyVersionDeclaration{}
+		for _, versionRange := range ranges {
+			result = append(result, DependencyVersionDeclaration{Range: versionRange})
+		}
+		return result
+	}
+	cases := []struct {
+		ranges   []string
+		expected string
+	}{
+		// Most packages declaring an older range does not make it the target.
+		{[]string{"^17.0.0", "^17.0.0", "^18.2.0", "^17.0.0"}, "^18.2.0"},
+		{[]string{"^1.0.0", "^2.0.0", "^1.0.0"}, "^2.0.0"},
+		{[]string{"latest", "1.x", "latest"}, "latest"},
+		{[]string{"^1.0.0", "~1.2.0"}, "~1.2.0"},
+		{[]string{"latest", "^0.9.0"}, "^0.9.0"},
+		{[]string{">=1 <2", "^1.5.0", "^1.5.0", ">=1 <2"}, "^1.5.0"},
+		{nil, ""},
+	}
+	for _, c := range cases {
+		if got := ExpectedDependencyRange(declarations(c.ranges...)); got != c.expected {
+			t.Errorf("ExpectedDependencyRange(%v) = %q, want %q", c.ranges, got, c.expected)
+		}
+	}
+}
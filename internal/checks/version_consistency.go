@@ -0,0 +1,228 @@
+package checks
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+const (
+	// VersionConsistencyMismatch: a workspace package declares a range different from the most
+	// recent one other workspaces declare for the same dependency.
+	VersionConsistencyMismatch = "mismatch"
+	// VersionConsistencyNotPinned: a workspace package declares a range different from the pinned
+	// policy range of the dependency.
+	VersionConsistencyNotPinned = "not-pinned"
+)
+
+// DependencyVersionDeclaration is a range a workspace package declares for an external dependency.
+// Catalog is the pnpm catalog the range comes from when the package declares a "catalog:" reference.
+type DependencyVersionDeclaration struct {
+	PackageName     string
+	PackageJsonPath string
+	DependencyField string
+	Range           string
+	Catalog         string
+}
+
+// VersionConsistencyViolation is a declaration of an external dependency whose range differs from
+// the range expected across the workspace.
+type VersionConsistencyViolation struct {
+	ViolationType   string
+	PackageName     string
+	PackageJsonPath string
+	Dependency      string
+	DependencyField string
+	DeclaredRange   string
+	ExpectedRange   string
+	// Autofix is set when the detection asks for the range to be rewritten to ExpectedRange.
+	Autofix bool
+}
+
+// versionConsistencyFields are the package.json fields compared. Peer ranges are left out, as
+// libraries usually declare them wider than the versions they are developed against.
+var versionConsistencyFields = slices.DeleteFunc(slices.Clone(workspaceDependencyFields), func(field dependencyField) bool {
+	return field.isPeer
+})
+
+// CollectDependencyVersions returns the declarations of every external dependency in the
+// package.json files of the workspace packages and the workspace root, keyed by dependency name.
+// "catalog:" references are resolved to the range of the catalog. Sibling workspace packages,
+// unresolved catalog references and specifiers that do not declare a range of the registry package
+// (workspace:, link:, file:, portal:) are skipped. Declarations are ordered by package.json path
+// and field.
+func CollectDependencyVersions(monorepoContext *monorepo.MonorepoContext) map[string][]DependencyVersionDeclaration {
+	declarations := map[string][]DependencyVersionDeclaration{}
+	if monorepoContext == nil {
+		return declarations
+	}
+
+	packagePaths := []string{}
+	for _, packagePath := range monorepoContext.PackageToPath {
+		packagePaths = append(packagePaths, packagePath)
+	}
+	if monorepoContext.WorkspaceRoot != "" && !slices.Contains(packagePaths, monorepoContext.WorkspaceRoot) {
+		packagePaths = append(packagePaths, monorepoContext.WorkspaceRoot)
+	}
+	slices.Sort(packagePaths)
+
+	for _, packagePath := range packagePaths {
+		config, err := monorepoContext.GetPackageConfig(packagePath)
+		if err != nil {
+			continue
+		}
+		packageJsonPath := pathutil.NormalizePathForInternal(filepath.Join(pathutil.DenormalizePathForOS(packagePath), "package.json"))
+		for _, field := range versionConsistencyFields {
+			deps := field.deps(config)
+			for _, dependency := range sortedKeys(deps) {
+				if _, isSibling := monorepoContext.PackageToPath[dependency]; isSibling {
+					continue
+				}
+				specifier := strings.TrimSpace(deps[dependency])
+				catalog := ""
+				if version, catalogName, ok := monorepoContext.ResolveCatalogVersion(dependency, specifier); ok {
+					specifier, catalog = strings.TrimSpace(version), catalogName
+				}
+				if !isComparableSpecifier(specifier) {
+					continue
+				}
+				declarations[dependency] = append(declarations[dependency], DependencyVersionDeclaration{
+					PackageName:     config.Name,
+					PackageJsonPath: packageJsonPath,
+					DependencyField: field.name,
+					Range:           specifier,
+					Catalog:         catalog,
+				})
+			}
+		}
+	}
+	return declarations
+}
+
+func isComparableSpecifier(specifier string) bool {
+	for _, protocol := range []string{monorepo.WorkspaceProtocol, monorepo.CatalogProtocol, "link:", "file:", "portal:"} {
+		if strings.HasPrefix(specifier, protocol) {
+			return false
+		}
+	}
+	return specifier != ""
+}
+
+// ExpectedDependencyRange returns the range workspaces should align on: the range allowing the
+// highest lowest version, so aligning never downgrades a package, even when most packages declare
+// an older range. Ranges whose lowest version cannot be told (e.g. "latest" or ">=1 <2") only win
+// when no other range can; ties go to the range declared most often.
+func ExpectedDependencyRange(declarations []DependencyVersionDeclaration) string {
+	counts := map[string]int{}
+	for _, declaration := range declarations {
+		counts[declaration.Range]++
+	}
+	ranges := make([]string, 0, len(counts))
+	for versionRange := range counts {
+		ranges = append(ranges, versionRange)
+	}
+	slices.SortFunc(ranges, func(a, b string) int {
+		if c := compareLowestVersions(b, a); c != 0 {
+			return c
+		}
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	if len(ranges) == 0 {
+		return ""
+	}
+	return ranges[0]
+}
+
+// compareLowestVersions compares the lowest versions allowed by two ranges; ranges that are not a
+// single plain version or caret/tilde/>= range sort first.
+func compareLowestVersions(a, b string) int {
+	lowest := func(versionRange string) *semver.Version {
+		if strings.ContainsAny(versionRange, " |<:") {
+			return nil
+		}
+		version, err := semver.NewVersion(strings.TrimLeft(versionRange, "^~>=v"))
+		if err != nil {
+			return nil
+		}
+		return version
+	}
+	va, vb := lowest(a), lowest(b)
+	switch {
+	case va == nil && vb == nil:
+		return 0
+	case va == nil:
+		return -1
+	case vb == nil:
+		return 1
+	}
+	return va.Compare(vb)
+}
+
+// FindVersionConsistencyViolations compares the ranges the workspace packages (and the workspace
+// root) declare for each external dependency and reports the declarations of packages located
+// under rulePath that differ from the expected range: the pinned range of opts.PinnedVersions, or
+// ExpectedDependencyRange when the dependency is not pinned and workspaces disagree on it. Every
+// workspace takes part in the comparison, so the rule path only limits what is reported.
+// Declarations through a pnpm catalog count in the comparison but are not reported, as the range
+// has to be changed in the catalog.
+func FindVersionConsistencyViolations(
+	monorepoContext *monorepo.MonorepoContext,
+	opts *rules.VersionConsistencyDetectionOptions,
+	rulePath string,
+) []VersionConsistencyViolation {
+	violations := []VersionConsistencyViolation{}
+	if opts == nil || !opts.Enabled || monorepoContext == nil {
+		return violations
+	}
+
+	ruleDir := pathutil.StandardiseDirPathInternal(pathutil.NormalizePathForInternal(filepath.Clean(rulePath)))
+	ignoreMatchers := compileModuleGlobMatchers(opts.IgnorePackages)
+
+	declarations := CollectDependencyVersions(monorepoContext)
+	dependencies := make([]string, 0, len(declarations))
+	for dependency := range declarations {
+		dependencies = append(dependencies, dependency)
+	}
+	slices.Sort(dependencies)
+
+	for _, dependency := range dependencies {
+		if matchesAnyModulePattern(ignoreMatchers, dependency, dependency) {
+			continue
+		}
+		violationType := VersionConsistencyNotPinned
+		expectedRange, pinned := opts.PinnedVersions[dependency]
+		if !pinned {
+			violationType = VersionConsistencyMismatch
+			expectedRange = ExpectedDependencyRange(declarations[dependency])
+		}
+		for _, declaration := range declarations[dependency] {
+			if declaration.Range == expectedRange || declaration.Catalog != "" || !strings.HasPrefix(declaration.PackageJsonPath, ruleDir) {
+				continue
+			}
+			violations = append(violations, VersionConsistencyViolation{
+				ViolationType:   violationType,
+				PackageName:     declaration.PackageName,
+				PackageJsonPath: declaration.PackageJsonPath,
+				Dependency:      dependency,
+				DependencyField: declaration.DependencyField,
+				DeclaredRange:   declaration.Range,
+				ExpectedRange:   expectedRange,
+				Autofix:         opts.Autofix,
+			})
+		}
+	}
+
+	slices.SortStableFunc(violations, func(a, b VersionConsistencyViolation) int {
+		return strings.Compare(a.PackageJsonPath, b.PackageJsonPath)
+	})
+	return violations
+}
@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+)
+
+func TestWorkspacePackageResolver(t *testing.T) {
+	packageOf := workspacePackageResolver(&monorepo.MonorepoContext{
+		WorkspaceRoot: "/repo",
+		PackageToPath: map[string]string{
+			"ui":          "/repo/packages/ui",
+			"ui-icons":    "/repo/packages/ui/icons",
+			"ui-internal": "/repo/packages/ui-internal",
+		},
+	})
+
+	cases := map[string]string{
+		"/repo/packages/ui/src/button.ts":          "ui",
+		"/repo/packages/ui/icons/src/arrow.ts":     "ui-icons",
+		"/repo/packages/ui-internal/src/tokens.ts": "ui-internal",
+		"/repo/scripts/build.ts":                   "",
+	}
+	for file, expected := range cases {
+		if actual := packageOf(file); actual != expected {
+			t.Errorf("package of %s: expected %q, got %q", file, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+// workspaceProtocolFixture writes a pnpm workspace where packages/app depends on its siblings
+// through every kind of specifier the detector distinguishes:
+//
+//	lib       1.2.0  "workspace:^"       -> ok (shorthand)
+//	utils     2.0.0  "^2.0.0"            -> missing workspace: protocol
+//	legacy    1.0.0  "workspace:^2.0.0"  -> version mismatch
+//	ui        3.1.0  "^3.0.0" (peer)     -> ok, peers do not need the protocol
+//	react            "catalog:"          -> ok, default catalog entry
+//	vue              "catalog:vue2"      -> missing catalog entry
+func workspaceProtocolFixture(t *testing.T) (*monorepo.MonorepoContext, string) {
+	t.Helper()
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	write("pnpm-workspace.yaml", "packages:\n  - packages/*\ncatalog:\n  react: ^18.2.0\n")
+	write("package.json", `{"name":"root","private":true}`)
+	write("packages/lib/package.json", `{"name":"lib","version":"1.2.0"}`)
+	write("packages/utils/package.json", `{"name":"utils","version":"2.0.0"}`)
+	write("packages/legacy/package.json", `{"name":"legacy","version":"1.0.0"}`)
+	write("packages/ui/package.json", `{"name":"ui","version":"3.1.0"}`)
+	write("packages/app/package.json", `{
+		"name": "app",
+		"version": "0.0.1",
+		"dependencies": {
+			"lib": "workspace:^",
+			"utils": "^2.0.0",
+			"legacy": "workspace:^2.0.0",
+			"react": "catalog:"
+		},
+		"devDependencies": {
+			"vue": "catalog:vue2"
+		},
+		"peerDependencies": {
+			"ui": "^3.0.0"
+		}
+	}`)
+
+	ctx := monorepo.NewMonorepoContext(pathutil.NormalizePathForInternal(filepath.Clean(root)))
+	ctx.FindWorkspacePackages(nil, nil)
+	return ctx, pathutil.NormalizePathForInternal(filepath.Clean(root))
+}
+
+func TestFindWorkspaceProtocolViolations(t *testing.T) {
+	ctx, root := workspaceProtocolFixture(t)
+	appPackageJson := root + "/packages/app/package.json"
+
+	violations := FindWorkspaceProtocolViolations(ctx, &rules.WorkspaceProtocolDetectionOptions{Enabled: true}, root)
+
+	expected := []WorkspaceProtocolViolation{
+		{ViolationType: WorkspaceProtocolVersionMismatch, PackageName: "app", PackageJsonPath: appPackageJson, DependencyField: "dependencies", Dependency: "legacy", Specifier: "workspace:^2.0.0", SiblingVersion: "1.0.0"},
+		{ViolationType: WorkspaceProtocolMissingProtocol, PackageName: "app", PackageJsonPath: appPackageJson, DependencyField: "dependencies", Dependency: "utils", Specifier: "^2.0.0", SiblingVersion: "2.0.0"},
+		{ViolationType: WorkspaceProtocolMissingCatalogEntry, PackageName: "app", PackageJsonPath: appPackageJson, DependencyField: "devDependencies", Dependency: "vue", Specifier: "catalog:vue2", Catalog: "vue2"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("unexpected violations:\n got: %+v\nwant: %+v", violations, expected)
+	}
+}
+
+func TestFindWorkspaceProtocolViolations_AllowVersionRanges(t *testing.T) {
+	ctx, root := workspaceProtocolFixture(t)
+
+	violations := FindWorkspaceProtocolViolations(ctx, &rules.WorkspaceProtocolDetectionOptions{Enabled: true, AllowVersionRanges: true}, root)
+
+	types := map[string]string{}
+	for _, v := range violations {
+		types[v.Dependency] = v.ViolationType
+	}
+	expected := map[string]string{
+		"legacy": WorkspaceProtocolVersionMismatch,
+		"vue":    WorkspaceProtocolMissingCatalogEntry,
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Fatalf("expected satisfied plain ranges to pass, got %+v", violations)
+	}
+}
+
+func TestFindWorkspaceProtocolViolations_ScopedToRulePathAndIgnore(t *testing.T) {
+	ctx, root := workspaceProtocolFixture(t)
+
+	if violations := FindWorkspaceProtocolViolations(ctx, &rules.WorkspaceProtocolDetectionOptions{Enabled: true}, root+"/packages/lib"); len(violations) != 0 {
+		t.Errorf("expected packages outside the rule path to be skipped, got %+v", violations)
+	}
+
+	violations := FindWorkspaceProtocolViolations(ctx, &rules.WorkspaceProtocolDetectionOptions{Enabled: true, IgnoreDependencies: []string{"legacy", "v*"}}, root+"/packages/app/")
+	if len(violations) != 1 || violations[0].Dependency != "utils" {
+		t.Errorf("expected only utils after ignoring legacy and v*, got %+v", violations)
+	}
+}
+
+func TestWorkspaceVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		versionRange string
+		version      string
+		want         bool
+	}{
+		{"*", "1.0.0", true},
+		{"^", "1.0.0", true},
+		{"~", "1.0.0", true},
+		{"^1.0.0", "1.4.2", true},
+		{"^1.0.0", "2.0.0", false},
+		{"1.0.0", "1.0.1", false},
+		{">=1.0.0 <2.0.0", "1.9.9", true},
+		// Not checkable: unversioned sibling or non-semver ranges.
+		{"^1.0.0", "", true},
+		{"./packages/lib", "1.0.0", true},
+	}
+	for _, tt := range tests {
+		if got := workspaceVersionSatisfies(tt.versionRange, tt.version); got != tt.want {
+			t.Errorf("workspaceVersionSatisfies(%q, %q) = %v, want %v", tt.versionRange, tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package checks
+
+import (
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+)
+
+// workspacePackageResolver returns a function mapping a file to the name of the innermost
+// workspace package containing it, or "" for files outside every package.
+func workspacePackageResolver(monorepoContext *monorepo.MonorepoContext) func(filePath string) string {
+	packageDirs := map[string]string{}
+	packageNames := make([]string, 0, len(monorepoContext.PackageToPath))
+	for name, packagePath := range monorepoContext.PackageToPath {
+		packageDirs[name] = pathutil.StandardiseDirPathInternal(packagePath)
+		packageNames = append(packageNames, name)
+	}
+	slices.SortFunc(packageNames, func(a, b string) int {
+		if lenA, lenB := len(packageDirs[a]), len(packageDirs[b]); lenA != lenB {
+			return lenB - lenA
+		}
+		return strings.Compare(a, b)
+	})
+	return func(filePath string) string {
+		for _, name := range packageNames {
+			if strings.HasPrefix(filePath, packageDirs[name]) {
+				return name
+			}
+		}
+		return ""
+	}
+}
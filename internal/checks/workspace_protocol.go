@@ -0,0 +1,173 @@
+package checks
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/rules"
+)
+
+const (
+	WorkspaceProtocolMissingProtocol     = "missing-workspace-protocol"
+	WorkspaceProtocolVersionMismatch     = "version-mismatch"
+	WorkspaceProtocolMissingCatalogEntry = "missing-catalog-entry"
+)
+
+// WorkspaceProtocolViolation describes a dependency specifier of a workspace package.json that
+// does not match the workspace: the sibling is referenced without the `workspace:` protocol, its
+// version does not satisfy the declared range, or a `catalog:` entry does not exist.
+type WorkspaceProtocolViolation struct {
+	ViolationType   string `json:"violationType"`
+	PackageName     string `json:"packageName,omitempty"`
+	PackageJsonPath string `json:"packageJsonPath"`
+	DependencyField string `json:"dependencyField"`
+	Dependency      string `json:"dependency"`
+	Specifier       string `json:"specifier"`
+	// SiblingVersion is the version of the sibling workspace package, set for sibling dependencies.
+	SiblingVersion string `json:"siblingVersion,omitempty"`
+	// Catalog is the catalog name referenced by a `catalog:` specifier.
+	Catalog string `json:"catalog,omitempty"`
+}
+
+type dependencyField struct {
+	name   string
+	deps   func(config *monorepo.PackageJsonConfig) map[string]string
+	isPeer bool
+}
+
+// workspaceDependencyFields lists the package.json fields checked, in reporting order.
+var workspaceDependencyFields = []dependencyField{
+	{name: "dependencies", deps: func(c *monorepo.PackageJsonConfig) map[string]string { return c.Dependencies }},
+	{name: "devDependencies", deps: func(c *monorepo.PackageJsonConfig) map[string]string { return c.DevDependencies }},
+	{name: "optionalDependencies", deps: func(c *monorepo.PackageJsonConfig) map[string]string { return c.OptionalDependencies }},
+	{name: "peerDependencies", deps: func(c *monorepo.PackageJsonConfig) map[string]string { return c.PeerDependencies }, isPeer: true},
+}
+
+// FindWorkspaceProtocolViolations validates the dependency specifiers of every workspace package
+// (and the workspace root) located under rulePath. peerDependencies are exempt from the
+// `workspace:` protocol requirement, since a plain range is the usual way to declare a peer, but
+// their ranges and catalog references are still checked.
+func FindWorkspaceProtocolViolations(
+	monorepoContext *monorepo.MonorepoContext,
+	opts *rules.WorkspaceProtocolDetectionOptions,
+	rulePath string,
+) []WorkspaceProtocolViolation {
+	violations := []WorkspaceProtocolViolation{}
+	if opts == nil || !opts.Enabled || monorepoContext == nil {
+		return violations
+	}
+
+	ruleDir := pathutil.StandardiseDirPathInternal(pathutil.NormalizePathForInternal(filepath.Clean(rulePath)))
+	isUnderRule := func(packagePath string) bool {
+		return strings.HasPrefix(pathutil.StandardiseDirPathInternal(packagePath), ruleDir)
+	}
+
+	packagePaths := []string{}
+	for _, packagePath := range monorepoContext.PackageToPath {
+		if isUnderRule(packagePath) {
+			packagePaths = append(packagePaths, packagePath)
+		}
+	}
+	if isUnderRule(monorepoContext.WorkspaceRoot) && !slices.Contains(packagePaths, monorepoContext.WorkspaceRoot) {
+		packagePaths = append(packagePaths, monorepoContext.WorkspaceRoot)
+	}
+	slices.Sort(packagePaths)
+
+	ignoreMatchers := compileModuleGlobMatchers(opts.IgnoreDependencies)
+
+	for _, packagePath := range packagePaths {
+		config, err := monorepoContext.GetPackageConfig(packagePath)
+		if err != nil {
+			continue
+		}
+		packageJsonPath := pathutil.NormalizePathForInternal(filepath.Join(pathutil.DenormalizePathForOS(packagePath), "package.json"))
+
+		for _, field := range workspaceDependencyFields {
+			deps := field.deps(config)
+			dependencies := make([]string, 0, len(deps))
+			for dependency := range deps {
+				dependencies = append(dependencies, dependency)
+			}
+			slices.Sort(dependencies)
+
+			for _, dependency := range dependencies {
+				if dependency == config.Name || matchesAnyModulePattern(ignoreMatchers, dependency, dependency) {
+					continue
+				}
+				specifier := strings.TrimSpace(deps[dependency])
+				violation := WorkspaceProtocolViolation{
+					PackageName:     config.Name,
+					PackageJsonPath: packageJsonPath,
+					DependencyField: field.name,
+					Dependency:      dependency,
+					Specifier:       specifier,
+				}
+
+				catalogVersion, catalogName, catalogFound := monorepoContext.ResolveCatalogVersion(dependency, specifier)
+				if catalogName != "" && !catalogFound {
+					violation.ViolationType = WorkspaceProtocolMissingCatalogEntry
+					violation.Catalog = catalogName
+					violations = append(violations, violation)
+					continue
+				}
+
+				siblingPath, isSibling := monorepoContext.PackageToPath[dependency]
+				if !isSibling {
+					continue
+				}
+				siblingVersion := ""
+				if siblingConfig, err := monorepoContext.GetPackageConfig(siblingPath); err == nil {
+					siblingVersion = siblingConfig.Version
+				}
+				violation.SiblingVersion = siblingVersion
+
+				versionRange := specifier
+				if strings.HasPrefix(specifier, monorepo.WorkspaceProtocol) {
+					versionRange = strings.TrimPrefix(specifier, monorepo.WorkspaceProtocol)
+				} else if !field.isPeer && !opts.AllowVersionRanges {
+					violation.ViolationType = WorkspaceProtocolMissingProtocol
+					violations = append(violations, violation)
+					continue
+				} else if catalogFound {
+					violation.Catalog = catalogName
+					versionRange = catalogVersion
+				}
+
+				if !workspaceVersionSatisfies(versionRange, siblingVersion) {
+					violation.ViolationType = WorkspaceProtocolVersionMismatch
+					violations = append(violations, violation)
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// workspaceVersionSatisfies reports whether version satisfies versionRange. The `workspace:`
+// shorthands (`*`, `^`, `~`) always match the current version, and ranges or versions that are not
+// valid semver (paths, other protocols, unversioned packages) are not checked.
+func workspaceVersionSatisfies(versionRange string, version string) bool {
+	versionRange = strings.TrimSpace(versionRange)
+	switch versionRange {
+	case "", "*", "^", "~":
+		return true
+	}
+	if version == "" {
+		return true
+	}
+	constraint, err := semver.NewConstraint(versionRange)
+	if err != nil {
+		return true
+	}
+	parsedVersion, err := semver.NewVersion(version)
+	if err != nil {
+		return true
+	}
+	return constraint.Check(parsedVersion)
+}
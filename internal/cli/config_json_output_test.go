@@ -27,7 +27,7 @@ func captureJSONOutput(t *testing.T, result *config.ConfigProcessingResult, cwd
 	}
 
 	output := jsonOutput{
-		Version: "1.2",
+		Version: "1.3",
 		Rules:   []jsonRuleResult{},
 	}
 	if result.HasFailures {
@@ -36,6 +36,9 @@ func captureJSONOutput(t *testing.T, result *config.ConfigProcessingResult, cwd
 	output.FixSummary.FixedFilesCount = result.FixedFilesCount
 	output.FixSummary.FixedImportsCount = result.FixedImportsCount
 	output.FixSummary.DeletedFilesCount = result.DeletedFilesCount
+	output.FixSummary.AddedNodeModulesCount = result.AddedNodeModulesCount
+	output.FixSummary.RemovedNodeModulesCount = result.RemovedNodeModulesCount
+	output.FixSummary.UpdatedNodeModulesCount = result.UpdatedNodeModulesCount
 	output.FixSummary.FixableIssuesCount = result.FixableIssuesCount
 	output.FixSummary.UnfixableAliasingCount = result.UnfixableAliasingCount
 
@@ -75,7 +78,7 @@ func TestJSONOutput_AllChecksPassing(t *testing.T) {
 
 	output := captureJSONOutput(t, result, cwd)
 
-	if output.Version != "1.2" {
+	if output.Version != "1.3" {
 		t.Errorf("expected version '1.2', got '%s'", output.Version)
 	}
 	if output.HasFailures {
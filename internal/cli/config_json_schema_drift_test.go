@@ -9,7 +9,7 @@ import (
 	"testing"
 )
 
-// TestJSONOutputSchemaNoDrift guards output-schema/1.2.schema.json against silent drift from the Go
+// TestJSONOutputSchemaNoDrift guards output-schema/1.3.schema.json against silent drift from the Go
 // structs that produce `config run --format json`. Every object in the schema sets
 // additionalProperties:false, so a struct field whose JSON key is missing from the schema would make
 // real output fail validation, and a schema property with no backing struct field is dead weight.
@@ -21,7 +21,7 @@ import (
 // basic mode by default and the locator can return nil even under detailed parsing, so locations are
 // best-effort, not guaranteed. This test pins the key *vocabulary*, not presence.
 func TestJSONOutputSchemaNoDrift(t *testing.T) {
-	schemaPath := filepath.Join("..", "..", "output-schema", "1.2.schema.json")
+	schemaPath := filepath.Join("..", "..", "output-schema", "1.3.schema.json")
 	raw, err := os.ReadFile(schemaPath)
 	if err != nil {
 		t.Fatalf("read schema: %v", err)
@@ -46,6 +46,17 @@ func TestJSONOutputSchemaNoDrift(t *testing.T) {
 		RestrictedImports:              &jsonCheckResult{Issues: []interface{}{}},
 		RestrictedImporters:            &jsonCheckResult{Issues: []interface{}{}},
 		RestrictedDirectImporters:      &jsonCheckResult{Issues: []interface{}{}},
+		WorkspaceProtocol:              &jsonCheckResult{Issues: []interface{}{}},
+		Layers:                         &jsonCheckResult{Issues: []interface{}{}},
+		BarrelFiles:                    &jsonCheckResult{Issues: []interface{}{}},
+		TypeImports:                    &jsonCheckResult{Issues: []interface{}{}},
+		UnusedWorkspacePackages:        &jsonCheckResult{Issues: []interface{}{}},
+		DeepImports:                    &jsonCheckResult{Issues: []interface{}{}},
+		ComplexityBudgets:              &jsonCheckResult{Issues: []interface{}{}},
+		OwnershipBoundaries:            &jsonCheckResult{Issues: []interface{}{}},
+		TestIsolation:                  &jsonCheckResult{Issues: []interface{}{}},
+		PeerDependencies:               &jsonCheckResult{Issues: []interface{}{}},
+		VersionConsistency:             &jsonCheckResult{Issues: []interface{}{}},
 	}
 
 	cases := []struct {
@@ -53,24 +64,41 @@ func TestJSONOutputSchemaNoDrift(t *testing.T) {
 		pointer []string // path of keys into the schema to the object node ({} = root)
 		value   interface{}
 	}{
-		{"output (root)", nil, jsonOutput{Version: "1.2", Rules: []jsonRuleResult{}}},
-		{"ruleResult", []string{"definitions", "ruleResult"}, jsonRuleResult{}},
+		{"output (root)", nil, jsonOutput{Version: "1.3", Rules: []jsonRuleResult{}}},
+		{"ruleResult", []string{"definitions", "ruleResult"}, jsonRuleResult{TeamDependencies: []jsonTeamDependency{{}}}},
 		{"checks", []string{"definitions", "checks"}, allChecks},
 		{"checkResult", []string{"definitions", "checkResult"}, jsonCheckResult{Issues: []interface{}{}}},
 		{"fixSummary", []string{"definitions", "fixSummary"}, jsonFixSummary{}},
-		{"circularDependencyIssue", []string{"definitions", "circularDependencyIssue"}, jsonCircularDependencyIssue{}},
+		{"circularDependencyIssue", []string{"definitions", "circularDependencyIssue"}, jsonCircularDependencyIssue{SuggestedBreak: &jsonCycleBreaking{}}},
+		{"cycleBreakingEdge", []string{"definitions", "cycleBreakingEdge"}, jsonCycleBreaking{}},
 		{"orphanFileIssue", []string{"definitions", "orphanFileIssue"}, jsonOrphanFileIssue{}},
 		{"moduleBoundaryIssue", []string{"definitions", "moduleBoundaryIssue"}, jsonModuleBoundaryIssue{jsonLocationFields: loc}},
 		{"unusedNodeModuleIssue", []string{"definitions", "unusedNodeModuleIssue"}, jsonUnusedNodeModuleIssue{jsonLocationFields: loc}},
 		{"missingNodeModuleIssue", []string{"definitions", "missingNodeModuleIssue"}, jsonMissingNodeModuleIssue{Locations: []jsonLocation{{}}}},
 		{"missingNodeModuleIssue.locations.items", []string{"definitions", "missingNodeModuleIssue", "properties", "locations", "items"}, jsonLocation{}},
+		{"untypedNodeModuleIssue", []string{"definitions", "untypedNodeModuleIssue"}, jsonUntypedNodeModuleIssue{Locations: []jsonLocation{{}}}},
+		{"untypedNodeModuleIssue.locations.items", []string{"definitions", "untypedNodeModuleIssue", "properties", "locations", "items"}, jsonLocation{}},
 		{"importConventionIssue", []string{"definitions", "importConventionIssue"}, jsonImportConventionIssue{jsonLocationFields: loc}},
 		{"unresolvedImportIssue", []string{"definitions", "unresolvedImportIssue"}, jsonUnresolvedImportIssue{jsonLocationFields: loc}},
 		{"unusedExportIssue", []string{"definitions", "unusedExportIssue"}, jsonUnusedExportIssue{jsonLocationFields: loc}},
 		{"restrictedDevDepsIssue", []string{"definitions", "restrictedDevDepsIssue"}, jsonRestrictedDevDepsIssue{jsonLocationFields: loc}},
+		{"unnecessaryProdDependencyIssue", []string{"definitions", "unnecessaryProdDependencyIssue"}, jsonUnnecessaryProdDependencyIssue{jsonLocationFields: loc}},
 		{"restrictedImportIssue", []string{"definitions", "restrictedImportIssue"}, jsonRestrictedImportIssue{DeniedFile: "f", DeniedModule: "m", ImportRequest: "r", jsonLocationFields: loc}},
 		{"restrictedImporterIssue", []string{"definitions", "restrictedImporterIssue"}, jsonRestrictedImporterIssue{File: "f", Module: "m"}},
 		{"restrictedDirectImporterIssue", []string{"definitions", "restrictedDirectImporterIssue"}, jsonRestrictedDirectImporterIssue{File: "f", Module: "m", ImportRequest: "r"}},
+		{"layerIssue", []string{"definitions", "layerIssue"}, jsonLayerIssue{jsonLocationFields: loc}},
+		{"unusedWorkspacePackageIssue", []string{"definitions", "unusedWorkspacePackageIssue"}, jsonUnusedWorkspacePackageIssue{Dependency: "d", DependencyField: "dependencies", jsonLocationFields: loc}},
+		{"deepImportIssue", []string{"definitions", "deepImportIssue"}, jsonDeepImportIssue{SuggestedRequest: "@org/ui/button", jsonLocationFields: loc}},
+		{"complexityBudgetIssue", []string{"definitions", "complexityBudgetIssue"}, jsonComplexityBudgetIssue{Contributors: []jsonComplexityContributor{{}}, Chain: []string{"a"}}},
+		{"complexityContributor", []string{"definitions", "complexityContributor"}, jsonComplexityContributor{}},
+		{"ownershipBoundaryIssue", []string{"definitions", "ownershipBoundaryIssue"}, jsonOwnershipBoundaryIssue{jsonLocationFields: loc}},
+		{"teamDependency", []string{"definitions", "teamDependency"}, jsonTeamDependency{}},
+		{"testIsolationIssue", []string{"definitions", "testIsolationIssue"}, jsonTestIsolationIssue{ImportPath: "i", EntryPoint: "e", PackageName: "p", jsonLocationFields: loc}},
+		{"peerDependencyIssue", []string{"definitions", "peerDependencyIssue"}, jsonPeerDependencyIssue{DependencyField: "dependencies", ImportedFrom: "f", Library: "l", PeerRange: "^18", DeclaredRange: "^17", jsonLocationFields: loc}},
+		{"versionConsistencyIssue", []string{"definitions", "versionConsistencyIssue"}, jsonVersionConsistencyIssue{jsonLocationFields: loc}},
+		{"typeImportIssue", []string{"definitions", "typeImportIssue"}, jsonTypeImportIssue{jsonLocationFields: loc}},
+		{"barrelFileIssue", []string{"definitions", "barrelFileIssue"}, jsonBarrelFileIssue{ImportPath: "i", FanOut: 1, jsonLocationFields: loc}},
+		{"workspaceProtocolIssue", []string{"definitions", "workspaceProtocolIssue"}, jsonWorkspaceProtocolIssue{PackageName: "p", SiblingVersion: "1.0.0", Catalog: "c", jsonLocationFields: loc}},
 	}
 
 	for _, tc := range cases {
@@ -35,7 +35,11 @@ With --fix, dead patterns are removed in place, preserving all comments and form
 Some patterns are reported but never auto-removed because deleting them could change a
 check's behavior or make the config invalid — rule paths, required entry points / files
 / modules, and module-boundary selectors. These are marked "not auto-removed"; resolve
-them by hand.`,
+them by hand.
+
+The opt-in unused-aliases rule (--rules unused-aliases) reports package.json "exports"
+subpaths of workspace packages, "#imports" entries and tsconfig "paths" aliases that no
+import uses. With --fix they are removed from those files.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		startTime := time.Now()
 		cwd := pathutil.ResolveAbsoluteCwd(lintConfigCwd)
@@ -57,7 +61,7 @@ them by hand.`,
 
 		printConfigLintResults(result, cwd)
 
-		if lintConfigFix && (len(result.DeadPatterns) > 0 || result.TrailingCommaCount > 0 || result.CompactableCount > 0) {
+		if lintConfigFix && (len(result.DeadPatterns) > 0 || result.TrailingCommaCount > 0 || result.CompactableCount > 0 || len(result.UnusedAliases) > 0) {
 			fixResult, err := config.ApplyLintFix(result)
 			if err != nil {
 				return fmt.Errorf("Error applying fixes: %v", err)
@@ -94,6 +98,16 @@ func countLintFindings(result *config.LintResult, applyingFix bool) (errors, war
 		errors++
 	}
 	warnings += len(result.Overlaps)
+	for _, alias := range result.UnusedAliases {
+		switch {
+		case applyingFix:
+			// removed by --fix
+		case alias.Severity == config.SeverityWarning:
+			warnings++
+		default:
+			errors++
+		}
+	}
 	if !applyingFix {
 		// Cleared by --fix, so not counted as "remaining" in that mode.
 		warnings += result.TrailingCommaCount
@@ -149,7 +163,7 @@ func printConfigLintResults(result *config.LintResult, cwd string) {
 		}
 	}
 
-	if len(errorDeads) == 0 && len(warningDeads) == 0 && len(result.Overlaps) == 0 && result.TrailingCommaCount == 0 && result.CompactableCount == 0 {
+	if len(errorDeads) == 0 && len(warningDeads) == 0 && len(result.Overlaps) == 0 && result.TrailingCommaCount == 0 && result.CompactableCount == 0 && len(result.UnusedAliases) == 0 {
 		fmt.Printf("\n%s No issues found — every glob matches something, no patterns overlap, config is compact.\n", emoji.Success)
 		return
 	}
@@ -160,6 +174,31 @@ func printConfigLintResults(result *config.LintResult, cwd string) {
 	if len(warningDeads) > 0 || len(result.Overlaps) > 0 || result.TrailingCommaCount > 0 || result.CompactableCount > 0 {
 		printWarningSection(warningDeads, result.Overlaps, result.TrailingCommaCount, result.CompactableCount)
 	}
+	if len(result.UnusedAliases) > 0 {
+		printUnusedAliasesSection(result.UnusedAliases, cwd)
+	}
+}
+
+// printUnusedAliasesSection lists unused package.json exports/imports entries and tsconfig
+// paths aliases, grouped by the file declaring them.
+func printUnusedAliasesSection(aliases []config.UnusedAlias, cwd string) {
+	fmt.Printf("\n── Unused aliases ──\n")
+	lastFile := ""
+	for _, alias := range aliases {
+		if alias.FilePath != lastFile {
+			fileRel := alias.FilePath
+			if rel, err := filepath.Rel(cwd, pathutil.DenormalizePathForOS(alias.FilePath)); err == nil {
+				fileRel = filepath.ToSlash(rel)
+			}
+			fmt.Printf("\n%s %s\n", emoji.File, fileRel)
+			lastFile = alias.FilePath
+		}
+		mark := emoji.Error
+		if alias.Severity == config.SeverityWarning {
+			mark = emoji.Warning
+		}
+		fmt.Printf("    %s  %s %q  (line %d:%d, used by no import)\n", mark, alias.Source, alias.Key, alias.Line, alias.Column)
+	}
 }
 
 // ruleHeader prints a "📁 Rule:" / "📄 Top-level" header when the rule changes.
@@ -327,10 +366,16 @@ func printConfigLintFixSummary(fix *config.FixResult) {
 	if fix.TrailingCommasRemoved > 0 {
 		fmt.Printf("%s  Removed %d redundant trailing comma(s).\n", emoji.Fix, fix.TrailingCommasRemoved)
 	}
+	if fix.AliasesRemoved > 0 {
+		fmt.Printf("%s  Removed %d unused alias entr(ies) from package.json/tsconfig files.\n", emoji.Fix, fix.AliasesRemoved)
+	}
+	if fix.AliasesKept > 0 {
+		fmt.Printf("%s  %d unused exports entr(ies) not auto-removed (removing them would drop the whole exports map) — review manually.\n", emoji.Warning, fix.AliasesKept)
+	}
 	if fix.ReportOnlyKept > 0 {
 		fmt.Printf("%s  %d dead pattern(s) not auto-removed (removing them could change a check's behavior or make the config invalid) — review and remove manually.\n", emoji.Warning, fix.ReportOnlyKept)
 	}
-	if fix.RemovedCount == 0 && fix.ReportOnlyKept == 0 && fix.TrailingCommasRemoved == 0 && fix.CompactedCount == 0 {
+	if fix.RemovedCount == 0 && fix.ReportOnlyKept == 0 && fix.TrailingCommasRemoved == 0 && fix.CompactedCount == 0 && fix.AliasesRemoved == 0 && fix.AliasesKept == 0 {
 		fmt.Printf("\n%s Nothing to remove.\n", emoji.Success)
 	}
 }
@@ -339,7 +384,7 @@ func init() {
 	addSharedFlags(configLintCmd)
 	configLintCmd.Flags().StringVarP(&lintConfigCwd, "cwd", "c", currentDir, "Working directory")
 	configLintCmd.Flags().BoolVar(&lintConfigFix, "fix", false, "Remove dead patterns from the config file (preserves comments and formatting)")
-	configLintCmd.Flags().StringSliceVar(&lintConfigRules, "rules", nil, "Lint rules to run (comma-separated): orphan-file-globs, orphan-module-globs, overlapping-globs, trailing-commas, compact. Default: all. orphan-file-globs/overlapping-globs use file discovery; orphan-module-globs parses the dependency tree; trailing-commas and compact only read the config file.")
+	configLintCmd.Flags().StringSliceVar(&lintConfigRules, "rules", nil, "Lint rules to run (comma-separated): orphan-file-globs, orphan-module-globs, overlapping-globs, trailing-commas, compact, unused-aliases. Default: all except unused-aliases. orphan-file-globs/overlapping-globs use file discovery; orphan-module-globs and unused-aliases parse the dependency tree; trailing-commas and compact only read the config file. unused-aliases reports unused package.json exports/imports entries and tsconfig paths, and its --fix edits those files.")
 
 	configCmd.AddCommand(configLintCmd)
 }
@@ -91,7 +91,11 @@ func buildIssuesListGroups(rules []jsonRuleResult) []issuesListGroup {
 		if rule.Checks.CircularDependencies != nil {
 			for _, issue := range rule.Checks.CircularDependencies.Issues {
 				if v, ok := issue.(jsonCircularDependencyIssue); ok {
-					add("Circular Dependencies Issues", strings.Join(v.Cycle, " -> "), "")
+					description := strings.Join(v.Cycle, " -> ")
+					if v.SuggestedBreak != nil {
+						description += " (break at " + v.SuggestedBreak.From + " -> " + v.SuggestedBreak.To + ")"
+					}
+					add("Circular Dependencies Issues", description, "")
 				}
 			}
 		}
@@ -118,6 +122,17 @@ func buildIssuesListGroups(rules []jsonRuleResult) []issuesListGroup {
 				if v, ok := issue.(jsonUnusedNodeModuleIssue); ok {
 					add("Unused Node Modules Issues", v.ModuleName, formatIssueLocationWithFields(v.PackageJsonPath, v.jsonLocationFields))
 				}
+				if v, ok := issue.(jsonUntypedNodeModuleIssue); ok {
+					if len(v.Locations) > 0 {
+						for _, loc := range v.Locations {
+							add("Untyped Node Modules Issues", v.ModuleName, formatIssueLocation(loc.FilePath, loc.StartLine, loc.StartCol))
+						}
+					} else {
+						for _, filePath := range v.ImportedFrom {
+							add("Untyped Node Modules Issues", v.ModuleName, formatIssueLocation(filePath, 0, 0))
+						}
+					}
+				}
 			}
 		}
 		if rule.Checks.MissingNodeModules != nil {
@@ -163,6 +178,9 @@ func buildIssuesListGroups(rules []jsonRuleResult) []issuesListGroup {
 				if v, ok := issue.(jsonRestrictedDevDepsIssue); ok {
 					add("Dev Deps Usage On Prod Issues", v.DevDependency, formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
 				}
+				if v, ok := issue.(jsonUnnecessaryProdDependencyIssue); ok {
+					add("Unnecessary Prod Dependencies", v.ProdDependency, formatIssueLocationWithFields(v.PackageJsonPath, v.jsonLocationFields))
+				}
 			}
 		}
 		if rule.Checks.RestrictedImports != nil {
@@ -202,6 +220,95 @@ func buildIssuesListGroups(rules []jsonRuleResult) []issuesListGroup {
 				}
 			}
 		}
+		if rule.Checks.Layers != nil {
+			for _, issue := range rule.Checks.Layers.Issues {
+				if v, ok := issue.(jsonLayerIssue); ok {
+					add("Layer Issues", v.SourceLayer+" -> "+v.TargetLayer+": "+v.ImportPath, formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
+				}
+			}
+		}
+		if rule.Checks.BarrelFiles != nil {
+			for _, issue := range rule.Checks.BarrelFiles.Issues {
+				if v, ok := issue.(jsonBarrelFileIssue); ok {
+					if v.ImportPath == "" {
+						add("Barrel File Issues", fmt.Sprintf("barrel re-exporting from %d modules", v.FanOut), v.FilePath)
+					} else {
+						add("Barrel File Issues", v.ViolationType+": "+v.ImportPath, formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
+					}
+				}
+			}
+		}
+		if rule.Checks.TypeImports != nil {
+			for _, issue := range rule.Checks.TypeImports.Issues {
+				if v, ok := issue.(jsonTypeImportIssue); ok {
+					add("Type Import Issues", strings.Join(v.TypeOnlyNames, ", ")+" from "+v.ImportRequest, formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
+				}
+			}
+		}
+		if rule.Checks.UnusedWorkspacePackages != nil {
+			for _, issue := range rule.Checks.UnusedWorkspacePackages.Issues {
+				if v, ok := issue.(jsonUnusedWorkspacePackageIssue); ok {
+					if v.Dependency == "" {
+						add("Unused Workspace Packages Issues", v.PackageName, v.PackageJsonPath)
+					} else {
+						add("Unused Workspace Packages Issues", v.PackageName+" -> "+v.Dependency, formatIssueLocationWithFields(v.PackageJsonPath, v.jsonLocationFields))
+					}
+				}
+			}
+		}
+		if rule.Checks.DeepImports != nil {
+			for _, issue := range rule.Checks.DeepImports.Issues {
+				if v, ok := issue.(jsonDeepImportIssue); ok {
+					add("Deep Import Issues", v.ImportRequest+" ("+v.PackageName+")", formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
+				}
+			}
+		}
+		if rule.Checks.ComplexityBudgets != nil {
+			for _, issue := range rule.Checks.ComplexityBudgets.Issues {
+				if v, ok := issue.(jsonComplexityBudgetIssue); ok {
+					add("Complexity Budget Issues", fmt.Sprintf("%s %d (budget %d)", v.Metric, v.Actual, v.Budget), v.FilePath)
+				}
+			}
+		}
+		if rule.Checks.OwnershipBoundaries != nil {
+			for _, issue := range rule.Checks.OwnershipBoundaries.Issues {
+				if v, ok := issue.(jsonOwnershipBoundaryIssue); ok {
+					add("Ownership Boundary Issues", strings.Join(v.FromTeams, " ")+" -> "+strings.Join(v.ToTeams, " ")+": "+v.ImportPath, formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
+				}
+			}
+		}
+		if rule.Checks.TestIsolation != nil {
+			for _, issue := range rule.Checks.TestIsolation.Issues {
+				if v, ok := issue.(jsonTestIsolationIssue); ok {
+					if v.ImportPath == "" {
+						add("Test Isolation Issues", v.ViolationType, v.FilePath)
+					} else {
+						add("Test Isolation Issues", v.ViolationType+": "+v.ImportPath, formatIssueLocationWithFields(v.FilePath, v.jsonLocationFields))
+					}
+				}
+			}
+		}
+		if rule.Checks.PeerDependencies != nil {
+			for _, issue := range rule.Checks.PeerDependencies.Issues {
+				if v, ok := issue.(jsonPeerDependencyIssue); ok {
+					add("Peer Dependencies Issues", v.ViolationType+": "+v.PackageName+" "+v.Dependency, formatIssueLocationWithFields(v.PackageJsonPath, v.jsonLocationFields))
+				}
+			}
+		}
+		if rule.Checks.VersionConsistency != nil {
+			for _, issue := range rule.Checks.VersionConsistency.Issues {
+				if v, ok := issue.(jsonVersionConsistencyIssue); ok {
+					add("Version Consistency Issues", v.ViolationType+": "+v.PackageName+" "+v.Dependency+" "+v.DeclaredRange, formatIssueLocationWithFields(v.PackageJsonPath, v.jsonLocationFields))
+				}
+			}
+		}
+		if rule.Checks.WorkspaceProtocol != nil {
+			for _, issue := range rule.Checks.WorkspaceProtocol.Issues {
+				if v, ok := issue.(jsonWorkspaceProtocolIssue); ok {
+					add("Workspace Protocol Issues", v.Dependency+"@"+v.Specifier, formatIssueLocationWithFields(v.PackageJsonPath, v.jsonLocationFields))
+				}
+			}
+		}
 	}
 
 	order := []string{
@@ -209,14 +316,27 @@ func buildIssuesListGroups(rules []jsonRuleResult) []issuesListGroup {
 		"Orphan Files Issues",
 		"Module Boundary Issues",
 		"Unused Node Modules Issues",
+		"Untyped Node Modules Issues",
 		"Missing Node Modules Issues",
 		"Import Convention Issues",
 		"Unresolved Imports",
 		"Unused Exports Issues",
 		"Dev Deps Usage On Prod Issues",
+		"Unnecessary Prod Dependencies",
 		"Restricted Imports Issues",
 		"Restricted Importers Issues",
 		"Restricted Direct Importers Issues",
+		"Workspace Protocol Issues",
+		"Layer Issues",
+		"Barrel File Issues",
+		"Type Import Issues",
+		"Unused Workspace Packages Issues",
+		"Deep Import Issues",
+		"Complexity Budget Issues",
+		"Ownership Boundary Issues",
+		"Test Isolation Issues",
+		"Peer Dependencies Issues",
+		"Version Consistency Issues",
 	}
 
 	groups := make([]issuesListGroup, 0, len(order))
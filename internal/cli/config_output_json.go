@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
+	"rev-dep-go/internal/checks"
 	"rev-dep-go/internal/config"
 )
 
@@ -19,9 +21,12 @@ type jsonOutput struct {
 }
 
 type jsonRuleResult struct {
-	Path      string     `json:"path"`
-	FileCount int        `json:"fileCount"`
-	Checks    jsonChecks `json:"checks"`
+	Path           string     `json:"path"`
+	FileCount      int        `json:"fileCount"`
+	ConditionNames []string   `json:"conditionNames"`
+	Checks         jsonChecks `json:"checks"`
+	// Imports between the teams of CODEOWNERS, present when ownership boundaries are checked.
+	TeamDependencies []jsonTeamDependency `json:"teamDependencies,omitempty"`
 }
 
 type jsonChecks struct {
@@ -37,6 +42,17 @@ type jsonChecks struct {
 	RestrictedImports              *jsonCheckResult `json:"restrictedImports,omitempty"`
 	RestrictedImporters            *jsonCheckResult `json:"restrictedImporters,omitempty"`
 	RestrictedDirectImporters      *jsonCheckResult `json:"restrictedDirectImporters,omitempty"`
+	WorkspaceProtocol              *jsonCheckResult `json:"workspaceProtocol,omitempty"`
+	Layers                         *jsonCheckResult `json:"layers,omitempty"`
+	BarrelFiles                    *jsonCheckResult `json:"barrelFiles,omitempty"`
+	TypeImports                    *jsonCheckResult `json:"typeImports,omitempty"`
+	UnusedWorkspacePackages        *jsonCheckResult `json:"unusedWorkspacePackages,omitempty"`
+	DeepImports                    *jsonCheckResult `json:"deepImports,omitempty"`
+	ComplexityBudgets              *jsonCheckResult `json:"complexityBudgets,omitempty"`
+	OwnershipBoundaries            *jsonCheckResult `json:"ownershipBoundaries,omitempty"`
+	TestIsolation                  *jsonCheckResult `json:"testIsolation,omitempty"`
+	PeerDependencies               *jsonCheckResult `json:"peerDependencies,omitempty"`
+	VersionConsistency             *jsonCheckResult `json:"versionConsistency,omitempty"`
 }
 
 type jsonCheckResult struct {
@@ -60,15 +76,26 @@ type jsonLocation struct {
 }
 
 type jsonFixSummary struct {
-	FixedFilesCount        int `json:"fixedFilesCount"`
-	FixedImportsCount      int `json:"fixedImportsCount"`
-	DeletedFilesCount      int `json:"deletedFilesCount"`
-	FixableIssuesCount     int `json:"fixableIssuesCount"`
-	UnfixableAliasingCount int `json:"unfixableAliasingCount"`
+	FixedFilesCount         int `json:"fixedFilesCount"`
+	FixedImportsCount       int `json:"fixedImportsCount"`
+	DeletedFilesCount       int `json:"deletedFilesCount"`
+	AddedNodeModulesCount   int `json:"addedNodeModulesCount"`
+	RemovedNodeModulesCount int `json:"removedNodeModulesCount"`
+	UpdatedNodeModulesCount int `json:"updatedNodeModulesCount"`
+	FixableIssuesCount      int `json:"fixableIssuesCount"`
+	UnfixableAliasingCount  int `json:"unfixableAliasingCount"`
 }
 
 type jsonCircularDependencyIssue struct {
-	Cycle []string `json:"cycle"`
+	Cycle          []string           `json:"cycle"`
+	SuggestedBreak *jsonCycleBreaking `json:"suggestedBreak,omitempty"`
+}
+
+type jsonCycleBreaking struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Request string `json:"request"`
+	Cycles  int    `json:"cycles"`
 }
 
 type jsonOrphanFileIssue struct {
@@ -95,6 +122,13 @@ type jsonMissingNodeModuleIssue struct {
 	Locations    []jsonLocation `json:"locations,omitempty"`
 }
 
+type jsonUntypedNodeModuleIssue struct {
+	ModuleName   string         `json:"moduleName"`
+	TypesPackage string         `json:"typesPackage"`
+	ImportedFrom []string       `json:"importedFrom"`
+	Locations    []jsonLocation `json:"locations,omitempty"`
+}
+
 type jsonImportConventionIssue struct {
 	FilePath      string `json:"filePath"`
 	ImportRequest string `json:"importRequest"`
@@ -122,6 +156,14 @@ type jsonRestrictedDevDepsIssue struct {
 	jsonLocationFields
 }
 
+type jsonUnnecessaryProdDependencyIssue struct {
+	ProdDependency  string `json:"prodDependency"`
+	PackageJsonPath string `json:"packageJsonPath"`
+	FilePath        string `json:"filePath"`
+	EntryPoint      string `json:"entryPoint"`
+	jsonLocationFields
+}
+
 type jsonRestrictedImportIssue struct {
 	ViolationType string `json:"violationType"`
 	ImporterFile  string `json:"importerFile"`
@@ -146,11 +188,146 @@ type jsonRestrictedDirectImporterIssue struct {
 	ImportRequest string `json:"importRequest,omitempty"`
 }
 
+type jsonWorkspaceProtocolIssue struct {
+	ViolationType   string `json:"violationType"`
+	PackageName     string `json:"packageName,omitempty"`
+	PackageJsonPath string `json:"filePath"`
+	DependencyField string `json:"dependencyField"`
+	Dependency      string `json:"dependency"`
+	Specifier       string `json:"specifier"`
+	SiblingVersion  string `json:"siblingVersion,omitempty"`
+	Catalog         string `json:"catalog,omitempty"`
+	jsonLocationFields
+}
+
+type jsonLayerIssue struct {
+	FilePath      string `json:"filePath"`
+	ImportPath    string `json:"importPath"`
+	SourceLayer   string `json:"sourceLayer"`
+	TargetLayer   string `json:"targetLayer"`
+	ViolationType string `json:"violationType"`
+	jsonLocationFields
+}
+
+type jsonBarrelFileIssue struct {
+	ViolationType string `json:"violationType"`
+	FilePath      string `json:"filePath"`
+	ImportPath    string `json:"importPath,omitempty"`
+	BarrelPath    string `json:"barrelPath"`
+	FanOut        int    `json:"fanOut,omitempty"`
+	jsonLocationFields
+}
+
+type jsonTypeImportIssue struct {
+	FilePath       string   `json:"filePath"`
+	ImportPath     string   `json:"importPath"`
+	ImportRequest  string   `json:"importRequest"`
+	TypeOnlyNames  []string `json:"typeOnlyNames"`
+	WholeStatement bool     `json:"wholeStatement"`
+	jsonLocationFields
+}
+
+type jsonUnusedWorkspacePackageIssue struct {
+	ViolationType   string `json:"violationType"`
+	PackageName     string `json:"packageName"`
+	PackageJsonPath string `json:"filePath"`
+	Dependency      string `json:"dependency,omitempty"`
+	DependencyField string `json:"dependencyField,omitempty"`
+	jsonLocationFields
+}
+
+type jsonDeepImportIssue struct {
+	FilePath         string `json:"filePath"`
+	ImportPath       string `json:"importPath"`
+	ImportRequest    string `json:"importRequest"`
+	PackageName      string `json:"packageName"`
+	SuggestedRequest string `json:"suggestedRequest,omitempty"`
+	jsonLocationFields
+}
+
+type jsonComplexityContributor struct {
+	Path  string `json:"path"`
+	Count int    `json:"count"`
+}
+
+type jsonComplexityBudgetIssue struct {
+	FilePath     string                      `json:"filePath"`
+	Metric       string                      `json:"metric"`
+	Actual       int                         `json:"actual"`
+	Budget       int                         `json:"budget"`
+	Contributors []jsonComplexityContributor `json:"contributors,omitempty"`
+	Chain        []string                    `json:"chain,omitempty"`
+}
+
+type jsonOwnershipBoundaryIssue struct {
+	FilePath   string   `json:"filePath"`
+	ImportPath string   `json:"importPath"`
+	FromTeams  []string `json:"fromTeams"`
+	ToTeams    []string `json:"toTeams"`
+	jsonLocationFields
+}
+
+type jsonTestIsolationIssue struct {
+	ViolationType string `json:"violationType"`
+	FilePath      string `json:"filePath"`
+	ImportPath    string `json:"importPath,omitempty"`
+	EntryPoint    string `json:"entryPoint,omitempty"`
+	PackageName   string `json:"packageName,omitempty"`
+	jsonLocationFields
+}
+
+type jsonPeerDependencyIssue struct {
+	ViolationType   string `json:"violationType"`
+	PackageName     string `json:"packageName"`
+	PackageJsonPath string `json:"filePath"`
+	Dependency      string `json:"dependency"`
+	DependencyField string `json:"dependencyField,omitempty"`
+	ImportedFrom    string `json:"importedFrom,omitempty"`
+	Library         string `json:"library,omitempty"`
+	PeerRange       string `json:"peerRange,omitempty"`
+	DeclaredRange   string `json:"declaredRange,omitempty"`
+	jsonLocationFields
+}
+
+type jsonVersionConsistencyIssue struct {
+	ViolationType   string `json:"violationType"`
+	PackageName     string `json:"packageName"`
+	PackageJsonPath string `json:"filePath"`
+	Dependency      string `json:"dependency"`
+	DependencyField string `json:"dependencyField"`
+	DeclaredRange   string `json:"declaredRange"`
+	ExpectedRange   string `json:"expectedRange"`
+	jsonLocationFields
+}
+
+type jsonTeamDependency struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Imports int    `json:"imports"`
+}
+
+// suggestedCycleBreak returns the highest-ranked cycle-breaking import that lies on cycle.
+func suggestedCycleBreak(cycle []string, suggestions []checks.CycleBreakingSuggestion) *checks.CycleBreakingEdge {
+	for _, suggestion := range suggestions {
+		if !slices.Contains(suggestion.Files, cycle[0]) {
+			continue
+		}
+		for i, edge := range suggestion.Edges {
+			for j := 0; j+1 < len(cycle); j++ {
+				if cycle[j] == edge.From && cycle[j+1] == edge.To {
+					return &suggestion.Edges[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // ---------------- JSON output logic ----------------
 
 func runConfigWithJSONOutput(cfg config.RevDepConfig, cwd string, packageJsonPath string, tsconfigJsonPath string, runConfigFix bool, runConfigRecheck bool) error {
 	output := jsonOutput{
-		Version: "1.2",
+		Version: "1.3",
 		Rules:   []jsonRuleResult{},
 	}
 
@@ -170,6 +347,9 @@ func runConfigWithJSONOutput(cfg config.RevDepConfig, cwd string, packageJsonPat
 	output.FixSummary.FixedFilesCount += result.FixedFilesCount
 	output.FixSummary.FixedImportsCount += result.FixedImportsCount
 	output.FixSummary.DeletedFilesCount += result.DeletedFilesCount
+	output.FixSummary.AddedNodeModulesCount += result.AddedNodeModulesCount
+	output.FixSummary.RemovedNodeModulesCount += result.RemovedNodeModulesCount
+	output.FixSummary.UpdatedNodeModulesCount += result.UpdatedNodeModulesCount
 	output.FixSummary.FixableIssuesCount += result.FixableIssuesCount
 	output.FixSummary.UnfixableAliasingCount += result.UnfixableAliasingCount
 
@@ -204,6 +384,8 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 	jr := jsonRuleResult{
 		Path:      ruleResult.RulePath,
 		FileCount: ruleResult.FileCount,
+		// Always emitted; [] means the resolver's default conditions are active.
+		ConditionNames: append([]string{}, ruleResult.ConditionNames...),
 	}
 
 	for _, check := range ruleResult.EnabledChecks {
@@ -217,7 +399,16 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 					for i, p := range cycle {
 						relCycle[i] = relPath(p)
 					}
-					cr.Issues = append(cr.Issues, jsonCircularDependencyIssue{Cycle: relCycle})
+					issue := jsonCircularDependencyIssue{Cycle: relCycle}
+					if edge := suggestedCycleBreak(cycle, ruleResult.CycleBreakingSuggestions); edge != nil {
+						issue.SuggestedBreak = &jsonCycleBreaking{
+							From:    relPath(edge.From),
+							To:      relPath(edge.To),
+							Request: edge.Request,
+							Cycles:  edge.Cycles,
+						}
+					}
+					cr.Issues = append(cr.Issues, issue)
 				}
 			} else {
 				cr.Status = "pass"
@@ -259,7 +450,7 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 
 		case "unused-node-modules":
 			cr := &jsonCheckResult{Issues: []interface{}{}}
-			if len(ruleResult.UnusedNodeModules) > 0 {
+			if len(ruleResult.UnusedNodeModules) > 0 || len(ruleResult.UntypedNodeModuleImports) > 0 {
 				cr.Status = "fail"
 				for _, module := range ruleResult.UnusedNodeModules {
 					loc := jsonLocationFields{}
@@ -272,6 +463,32 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 						jsonLocationFields: loc,
 					})
 				}
+				for _, m := range ruleResult.UntypedNodeModuleImports {
+					importedFrom := make([]string, len(m.ImportedFrom))
+					for i, p := range m.ImportedFrom {
+						importedFrom[i] = relPath(p)
+					}
+					issue := jsonUntypedNodeModuleIssue{
+						ModuleName:   m.ModuleName,
+						TypesPackage: m.TypesPackage,
+						ImportedFrom: importedFrom,
+					}
+					if locator != nil {
+						for _, filePath := range m.ImportedFrom {
+							fields := locator.locationForModuleName(filePath, m.ModuleName)
+							if fields.StartLine != nil && fields.StartCol != nil && fields.EndLine != nil && fields.EndCol != nil {
+								issue.Locations = append(issue.Locations, jsonLocation{
+									FilePath:  relPath(filePath),
+									StartLine: *fields.StartLine,
+									StartCol:  *fields.StartCol,
+									EndLine:   *fields.EndLine,
+									EndCol:    *fields.EndCol,
+								})
+							}
+						}
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
 			} else {
 				cr.Status = "pass"
 			}
@@ -372,7 +589,7 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 
 		case "dev-deps-usage-on-prod":
 			cr := &jsonCheckResult{Issues: []interface{}{}}
-			if len(ruleResult.RestrictedDevDependenciesUsageViolations) > 0 {
+			if len(ruleResult.RestrictedDevDependenciesUsageViolations) > 0 || len(ruleResult.UnnecessaryProdDependencyViolations) > 0 {
 				cr.Status = "fail"
 				for _, v := range ruleResult.RestrictedDevDependenciesUsageViolations {
 					issue := jsonRestrictedDevDepsIssue{
@@ -385,6 +602,18 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 					}
 					cr.Issues = append(cr.Issues, issue)
 				}
+				for _, v := range ruleResult.UnnecessaryProdDependencyViolations {
+					issue := jsonUnnecessaryProdDependencyIssue{
+						ProdDependency:  v.ProdDependency,
+						PackageJsonPath: relPath(v.PackageJsonPath),
+						FilePath:        relPath(v.FilePath),
+						EntryPoint:      relPath(v.EntryPoint),
+					}
+					if locator != nil {
+						issue.jsonLocationFields = locator.locationForPackageJsonDependency(v.PackageJsonPath, v.ProdDependency)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
 			} else {
 				cr.Status = "pass"
 			}
@@ -446,6 +675,270 @@ func buildJSONRuleResult(ruleResult config.RuleResult, cwd string, locator *file
 				cr.Status = "pass"
 			}
 			jr.Checks.RestrictedDirectImporters = cr
+
+		case "workspace-protocol":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.WorkspaceProtocolViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.WorkspaceProtocolViolations {
+					loc := jsonLocationFields{}
+					if locator != nil {
+						loc = locator.locationForPackageJsonDependency(v.PackageJsonPath, v.Dependency)
+					}
+					cr.Issues = append(cr.Issues, jsonWorkspaceProtocolIssue{
+						ViolationType:      v.ViolationType,
+						PackageName:        v.PackageName,
+						PackageJsonPath:    relPath(v.PackageJsonPath),
+						DependencyField:    v.DependencyField,
+						Dependency:         v.Dependency,
+						Specifier:          v.Specifier,
+						SiblingVersion:     v.SiblingVersion,
+						Catalog:            v.Catalog,
+						jsonLocationFields: loc,
+					})
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.WorkspaceProtocol = cr
+
+		case "layers":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.LayerViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.LayerViolations {
+					issue := jsonLayerIssue{
+						FilePath:      relPath(v.FilePath),
+						ImportPath:    relPath(v.ImportPath),
+						SourceLayer:   v.SourceLayer,
+						TargetLayer:   v.TargetLayer,
+						ViolationType: v.ViolationType,
+					}
+					if locator != nil && v.ImportRequest != "" {
+						issue.jsonLocationFields = locator.locationForRequest(v.FilePath, v.ImportRequest)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.Layers = cr
+
+		case "barrel-files":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.BarrelFileViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.BarrelFileViolations {
+					issue := jsonBarrelFileIssue{
+						ViolationType: v.ViolationType,
+						FilePath:      relPath(v.FilePath),
+						BarrelPath:    relPath(v.BarrelPath),
+						FanOut:        v.FanOut,
+					}
+					if v.ImportPath != "" {
+						issue.ImportPath = relPath(v.ImportPath)
+					}
+					if locator != nil && v.ImportRequest != "" {
+						issue.jsonLocationFields = locator.locationForRequest(v.FilePath, v.ImportRequest)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.BarrelFiles = cr
+
+		case "type-imports":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.TypeImportViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.TypeImportViolations {
+					issue := jsonTypeImportIssue{
+						FilePath:       relPath(v.FilePath),
+						ImportPath:     relPath(v.ImportPath),
+						ImportRequest:  v.ImportRequest,
+						TypeOnlyNames:  v.TypeOnlyNames,
+						WholeStatement: v.WholeStatement,
+					}
+					if locator != nil {
+						issue.jsonLocationFields = locator.locationForRequest(v.FilePath, v.ImportRequest)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.TypeImports = cr
+
+		case "unused-workspace-packages":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.UnusedWorkspacePackageViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.UnusedWorkspacePackageViolations {
+					loc := jsonLocationFields{}
+					if locator != nil && v.Dependency != "" {
+						loc = locator.locationForPackageJsonDependency(v.PackageJsonPath, v.Dependency)
+					}
+					cr.Issues = append(cr.Issues, jsonUnusedWorkspacePackageIssue{
+						ViolationType:      v.ViolationType,
+						PackageName:        v.PackageName,
+						PackageJsonPath:    relPath(v.PackageJsonPath),
+						Dependency:         v.Dependency,
+						DependencyField:    v.DependencyField,
+						jsonLocationFields: loc,
+					})
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.UnusedWorkspacePackages = cr
+
+		case "deep-imports":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.DeepImportViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.DeepImportViolations {
+					issue := jsonDeepImportIssue{
+						FilePath:         relPath(v.FilePath),
+						ImportPath:       relPath(v.ImportPath),
+						ImportRequest:    v.ImportRequest,
+						PackageName:      v.PackageName,
+						SuggestedRequest: v.SuggestedRequest,
+					}
+					if locator != nil {
+						issue.jsonLocationFields = locator.locationForRequest(v.FilePath, v.ImportRequest)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.DeepImports = cr
+
+		case "complexity-budgets":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.ComplexityBudgetViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.ComplexityBudgetViolations {
+					issue := jsonComplexityBudgetIssue{
+						FilePath: relPath(v.FilePath),
+						Metric:   v.Metric,
+						Actual:   v.Actual,
+						Budget:   v.Budget,
+					}
+					for _, contributor := range v.Contributors {
+						issue.Contributors = append(issue.Contributors, jsonComplexityContributor{Path: relPath(contributor.Path), Count: contributor.Count})
+					}
+					for _, filePath := range v.Chain {
+						issue.Chain = append(issue.Chain, relPath(filePath))
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.ComplexityBudgets = cr
+
+		case "ownership-boundaries":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.OwnershipBoundaryViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.OwnershipBoundaryViolations {
+					issue := jsonOwnershipBoundaryIssue{
+						FilePath:   relPath(v.FilePath),
+						ImportPath: relPath(v.ImportPath),
+						FromTeams:  v.FromTeams,
+						ToTeams:    v.ToTeams,
+					}
+					if locator != nil && v.ImportRequest != "" {
+						issue.jsonLocationFields = locator.locationForRequest(v.FilePath, v.ImportRequest)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.OwnershipBoundaries = cr
+			for _, dependency := range ruleResult.TeamDependencies {
+				jr.TeamDependencies = append(jr.TeamDependencies, jsonTeamDependency{From: dependency.From, To: dependency.To, Imports: dependency.Imports})
+			}
+
+		case "test-isolation":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.TestIsolationViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.TestIsolationViolations {
+					issue := jsonTestIsolationIssue{
+						ViolationType: v.ViolationType,
+						FilePath:      relPath(v.FilePath),
+						ImportPath:    relPath(v.ImportPath),
+						EntryPoint:    relPath(v.EntryPoint),
+						PackageName:   v.PackageName,
+					}
+					if locator != nil && v.ImportRequest != "" {
+						issue.jsonLocationFields = locator.locationForRequest(v.FilePath, v.ImportRequest)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.TestIsolation = cr
+
+		case "peer-dependencies":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.PeerDependencyViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.PeerDependencyViolations {
+					issue := jsonPeerDependencyIssue{
+						ViolationType:   v.ViolationType,
+						PackageName:     v.PackageName,
+						PackageJsonPath: relPath(v.PackageJsonPath),
+						Dependency:      v.Dependency,
+						DependencyField: v.DependencyField,
+						ImportedFrom:    relPath(v.FilePath),
+						Library:         v.Library,
+						PeerRange:       v.PeerRange,
+						DeclaredRange:   v.DeclaredRange,
+					}
+					if locator != nil {
+						// A consumer not declaring the peer is pointed at its dependency on the library.
+						declaration := v.Dependency
+						if v.DependencyField == "" {
+							declaration = v.Library
+						}
+						issue.jsonLocationFields = locator.locationForPackageJsonDependency(v.PackageJsonPath, declaration)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.PeerDependencies = cr
+
+		case "version-consistency":
+			cr := &jsonCheckResult{Issues: []interface{}{}}
+			if len(ruleResult.VersionConsistencyViolations) > 0 {
+				cr.Status = "fail"
+				for _, v := range ruleResult.VersionConsistencyViolations {
+					issue := jsonVersionConsistencyIssue{
+						ViolationType:   v.ViolationType,
+						PackageName:     v.PackageName,
+						PackageJsonPath: relPath(v.PackageJsonPath),
+						Dependency:      v.Dependency,
+						DependencyField: v.DependencyField,
+						DeclaredRange:   v.DeclaredRange,
+						ExpectedRange:   v.ExpectedRange,
+					}
+					if locator != nil {
+						issue.jsonLocationFields = locator.locationForPackageJsonDependency(v.PackageJsonPath, v.Dependency)
+					}
+					cr.Issues = append(cr.Issues, issue)
+				}
+			} else {
+				cr.Status = "pass"
+			}
+			jr.Checks.VersionConsistency = cr
 		}
 	}
 
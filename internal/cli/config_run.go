@@ -175,14 +175,27 @@ func hasUnfixableConfigRunIssues(result *config.ConfigProcessingResult) bool {
 		totalIssues += len(ruleResult.OrphanFiles)
 		totalIssues += len(ruleResult.ModuleBoundaryViolations)
 		totalIssues += len(ruleResult.UnusedNodeModules)
+		totalIssues += len(ruleResult.UntypedNodeModuleImports)
 		totalIssues += len(ruleResult.MissingNodeModules)
 		totalIssues += len(ruleResult.ImportConventionViolations)
 		totalIssues += len(ruleResult.UnusedExports)
 		totalIssues += len(ruleResult.UnresolvedImports)
 		totalIssues += len(ruleResult.RestrictedDevDependenciesUsageViolations)
+		totalIssues += len(ruleResult.UnnecessaryProdDependencyViolations)
 		totalIssues += len(ruleResult.RestrictedImportsViolations)
 		totalIssues += len(ruleResult.RestrictedImportersViolations)
 		totalIssues += len(ruleResult.RestrictedDirectImportersViolations)
+		totalIssues += len(ruleResult.WorkspaceProtocolViolations)
+		totalIssues += len(ruleResult.LayerViolations)
+		totalIssues += len(ruleResult.BarrelFileViolations)
+		totalIssues += len(ruleResult.TypeImportViolations)
+		totalIssues += len(ruleResult.UnusedWorkspacePackageViolations)
+		totalIssues += len(ruleResult.DeepImportViolations)
+		totalIssues += len(ruleResult.ComplexityBudgetViolations)
+		totalIssues += len(ruleResult.OwnershipBoundaryViolations)
+		totalIssues += len(ruleResult.TestIsolationViolations)
+		totalIssues += len(ruleResult.PeerDependencyViolations)
+		totalIssues += len(ruleResult.VersionConsistencyViolations)
 
 		fixableIssues += len(ruleResult.OrphanFilesAutofixable)
 
@@ -197,6 +210,30 @@ func hasUnfixableConfigRunIssues(result *config.ConfigProcessingResult) bool {
 				fixableIssues++
 			}
 		}
+
+		for _, violation := range ruleResult.BarrelFileViolations {
+			if violation.Fix != nil {
+				fixableIssues++
+			}
+		}
+
+		for _, violation := range ruleResult.TypeImportViolations {
+			if violation.Fix != nil {
+				fixableIssues++
+			}
+		}
+
+		for _, missing := range ruleResult.MissingNodeModules {
+			if len(missing.Fixes) > 0 {
+				fixableIssues++
+			}
+		}
+
+		for _, unused := range ruleResult.UnusedNodeModules {
+			if unused.Fix != nil {
+				fixableIssues++
+			}
+		}
 	}
 
 	return totalIssues > fixableIssues
@@ -232,6 +269,9 @@ func processConfigRun(
 	recheckedResult.FixedFilesCount = result.FixedFilesCount
 	recheckedResult.FixedImportsCount = result.FixedImportsCount
 	recheckedResult.DeletedFilesCount = result.DeletedFilesCount
+	recheckedResult.AddedNodeModulesCount = result.AddedNodeModulesCount
+	recheckedResult.RemovedNodeModulesCount = result.RemovedNodeModulesCount
+	recheckedResult.UpdatedNodeModulesCount = result.UpdatedNodeModulesCount
 	recheckedResult.UnfixableAliasingCount = result.UnfixableAliasingCount
 
 	return recheckedResult, nil
@@ -276,11 +316,41 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 						circularDepsToDisplay = circularDepsToDisplay[:maxIssuesToList]
 					}
 
+					for _, budget := range ruleResult.CycleBudgets {
+						if budget.Exceeded() {
+							fmt.Printf("  Cycle budget exceeded for %s: %d cycles (max %d)\n\n", formatCycleBudgetFiles(budget.Files), budget.Cycles, budget.MaxCycles)
+						}
+					}
+
 					formattedOutput := checks.FormatCircularDependenciesWithoutHeader(circularDepsToDisplay, cwd, ruleResult.DependencyTree, 2)
 					fmt.Printf("%s", formattedOutput)
 
 					if remaining > 0 {
-						fmt.Printf("    ... and %d more circular dependency issues\n", remaining)
+						fmt.Printf("    ... and %d more circular dependency issues\n\n", remaining)
+					}
+
+					for _, suggestion := range ruleResult.CycleBreakingSuggestions {
+						cyclesCount := fmt.Sprintf("%d", suggestion.Cycles)
+						if suggestion.Truncated {
+							cyclesCount = "at least " + cyclesCount
+						}
+						fmt.Printf("  Imports to remove to break all %s cycles between %d files:\n", cyclesCount, len(suggestion.Files))
+						for _, edge := range suggestion.Edges {
+							fmt.Printf("    - %s -> %s ('%s', in %d cycles)\n", getRelativePath(edge.From), getRelativePath(edge.To), edge.Request, edge.Cycles)
+						}
+						fmt.Println()
+					}
+				} else if len(ruleResult.CycleBudgets) > 0 {
+					withinBudgets := make([]string, 0, len(ruleResult.CycleBudgets))
+					for _, budget := range ruleResult.CycleBudgets {
+						if budget.Cycles > 0 {
+							withinBudgets = append(withinBudgets, fmt.Sprintf("%s %d/%d", formatCycleBudgetFiles(budget.Files), budget.Cycles, budget.MaxCycles))
+						}
+					}
+					if len(withinBudgets) > 0 {
+						fmt.Printf("  %s Circular Dependencies (within budget: %s)\n", emoji.Success, strings.Join(withinBudgets, ", "))
+					} else {
+						fmt.Printf("  %s Circular Dependencies\n", emoji.Success)
 					}
 				} else {
 					fmt.Printf("  %s Circular Dependencies\n", emoji.Success)
@@ -358,7 +428,29 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 					if remaining > 0 {
 						fmt.Printf("    ... and %d more unused node module issues\n", remaining)
 					}
-				} else {
+				}
+				if len(ruleResult.UntypedNodeModuleImports) > 0 {
+					fmt.Printf("  %s Untyped Node Modules Issues (%d):\n", emoji.Error, len(ruleResult.UntypedNodeModuleImports))
+
+					untypedToDisplay := ruleResult.UntypedNodeModuleImports
+					remaining := 0
+					if !listAll && len(untypedToDisplay) > maxIssuesToList {
+						remaining = len(untypedToDisplay) - maxIssuesToList
+						untypedToDisplay = untypedToDisplay[:maxIssuesToList]
+					}
+
+					for _, untyped := range untypedToDisplay {
+						fmt.Printf("    %s ships no types and %s is not installed\n", untyped.ModuleName, untyped.TypesPackage)
+						for _, file := range untyped.ImportedFrom {
+							fmt.Printf("     - %s\n", getRelativePath(file))
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more untyped node module issues\n", remaining)
+					}
+				}
+				if len(ruleResult.UnusedNodeModules) == 0 && len(ruleResult.UntypedNodeModuleImports) == 0 {
 					fmt.Printf("  %s Unused Node Modules\n", emoji.Success)
 				}
 			case "missing-node-modules":
@@ -587,7 +679,27 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 					if remaining > 0 {
 						fmt.Printf("    ... and %d more Dev Deps Usage On Prod Issues\n", remaining)
 					}
-				} else {
+				}
+				if len(ruleResult.UnnecessaryProdDependencyViolations) > 0 {
+					fmt.Printf("  %s Unnecessary Prod Dependencies (%d), could be moved to devDependencies:\n", emoji.Error, len(ruleResult.UnnecessaryProdDependencyViolations))
+
+					violationsToDisplay := ruleResult.UnnecessaryProdDependencyViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						fmt.Printf("    %s (%s)\n", violation.ProdDependency, getRelativePath(violation.PackageJsonPath))
+						fmt.Printf("     - %s (from entry point: %s)\n", getRelativePath(violation.FilePath), getRelativePath(violation.EntryPoint))
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more Unnecessary Prod Dependencies\n", remaining)
+					}
+				}
+				if len(ruleResult.RestrictedDevDependenciesUsageViolations) == 0 && len(ruleResult.UnnecessaryProdDependencyViolations) == 0 {
 					fmt.Printf("  %s Dev Deps Usage On Prod\n", emoji.Success)
 				}
 			case "restricted-imports":
@@ -780,6 +892,340 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 				} else {
 					fmt.Printf("  %s Restricted Direct Importers\n", emoji.Success)
 				}
+			case "workspace-protocol":
+				if len(ruleResult.WorkspaceProtocolViolations) > 0 {
+					fmt.Printf("  %s Workspace Protocol Issues (%d):\n", emoji.Error, len(ruleResult.WorkspaceProtocolViolations))
+
+					violationsToDisplay := ruleResult.WorkspaceProtocolViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					// Violations are sorted by package.json, so group consecutive entries under it.
+					lastPackageJson := ""
+					for _, violation := range violationsToDisplay {
+						packageJsonPath := getRelativePath(violation.PackageJsonPath)
+						if packageJsonPath != lastPackageJson {
+							fmt.Printf("    %s\n", packageJsonPath)
+							lastPackageJson = packageJsonPath
+						}
+						fmt.Printf("     - %s (%s): %s\n", violation.Dependency, violation.DependencyField, describeWorkspaceProtocolViolation(violation))
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more workspace protocol issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Workspace Protocol\n", emoji.Success)
+				}
+			case "layers":
+				if len(ruleResult.LayerViolations) > 0 {
+					fmt.Printf("  %s Layer Issues (%d):\n", emoji.Error, len(ruleResult.LayerViolations))
+
+					violationsToDisplay := ruleResult.LayerViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						fmt.Printf("    - [%s -> %s] %s -> %s (%s)\n",
+							violation.SourceLayer,
+							violation.TargetLayer,
+							getRelativePath(violation.FilePath),
+							getRelativePath(violation.ImportPath),
+							strings.ToUpper(strings.ReplaceAll(violation.ViolationType, "-", " ")))
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more layer issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Layers\n", emoji.Success)
+				}
+			case "barrel-files":
+				if len(ruleResult.BarrelFileViolations) > 0 {
+					fmt.Printf("  %s Barrel File Issues (%d):\n", emoji.Error, len(ruleResult.BarrelFileViolations))
+
+					violationsToDisplay := ruleResult.BarrelFileViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						switch violation.ViolationType {
+						case checks.BarrelFileViolationBarrel:
+							fmt.Printf("    - %s (BARREL FILE, re-exports from %d modules)\n",
+								getRelativePath(violation.FilePath),
+								violation.FanOut)
+						case checks.BarrelFileViolationBypass:
+							fmt.Printf("    - %s -> %s (BYPASSES %s)\n",
+								getRelativePath(violation.FilePath),
+								getRelativePath(violation.ImportPath),
+								getRelativePath(violation.BarrelPath))
+						default:
+							fmt.Printf("    - %s -> %s (IMPORT THROUGH OWN BARREL)\n",
+								getRelativePath(violation.FilePath),
+								getRelativePath(violation.ImportPath))
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more barrel file issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Barrel Files\n", emoji.Success)
+				}
+			case "type-imports":
+				if len(ruleResult.TypeImportViolations) > 0 {
+					fmt.Printf("  %s Type Import Issues (%d):\n", emoji.Error, len(ruleResult.TypeImportViolations))
+
+					violationsToDisplay := ruleResult.TypeImportViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						fmt.Printf("    - %s -> %s (TYPE ONLY: %s)\n",
+							getRelativePath(violation.FilePath),
+							violation.ImportRequest,
+							strings.Join(violation.TypeOnlyNames, ", "))
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more type import issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Type Imports\n", emoji.Success)
+				}
+			case "unused-workspace-packages":
+				if len(ruleResult.UnusedWorkspacePackageViolations) > 0 {
+					fmt.Printf("  %s Unused Workspace Packages Issues (%d):\n", emoji.Error, len(ruleResult.UnusedWorkspacePackageViolations))
+
+					violationsToDisplay := ruleResult.UnusedWorkspacePackageViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						if violation.ViolationType == checks.UnusedWorkspacePackage {
+							fmt.Printf("    - %s (%s): not imported by any other workspace package\n", violation.PackageName, getRelativePath(violation.PackageJsonPath))
+						} else {
+							fmt.Printf("    - %s (%s): %s %s is never imported\n", violation.PackageName, getRelativePath(violation.PackageJsonPath), violation.DependencyField, violation.Dependency)
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more unused workspace packages issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Unused Workspace Packages\n", emoji.Success)
+				}
+			case "deep-imports":
+				if len(ruleResult.DeepImportViolations) > 0 {
+					fmt.Printf("  %s Deep Import Issues (%d):\n", emoji.Error, len(ruleResult.DeepImportViolations))
+
+					violationsToDisplay := ruleResult.DeepImportViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						if violation.SuggestedRequest != "" {
+							fmt.Printf("    - %s -> %s (not exported by %s, use %s)\n", getRelativePath(violation.FilePath), violation.ImportRequest, violation.PackageName, violation.SuggestedRequest)
+						} else {
+							fmt.Printf("    - %s -> %s (not exported by %s)\n", getRelativePath(violation.FilePath), violation.ImportRequest, violation.PackageName)
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more deep import issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Deep Imports\n", emoji.Success)
+				}
+
+			case "complexity-budgets":
+				if len(ruleResult.ComplexityBudgetViolations) > 0 {
+					fmt.Printf("  %s Complexity Budget Issues (%d):\n", emoji.Error, len(ruleResult.ComplexityBudgetViolations))
+
+					violationsToDisplay := ruleResult.ComplexityBudgetViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						switch violation.Metric {
+						case checks.ComplexityMetricTransitiveDependencies:
+							fmt.Printf("    - %s: %d transitive dependencies (budget %d)\n", getRelativePath(violation.FilePath), violation.Actual, violation.Budget)
+						case checks.ComplexityMetricImportChainDepth:
+							fmt.Printf("    - %s: import chain depth %d (budget %d)\n", getRelativePath(violation.FilePath), violation.Actual, violation.Budget)
+						case checks.ComplexityMetricDirectImports:
+							fmt.Printf("    - %s: %d direct imports (budget %d)\n", getRelativePath(violation.FilePath), violation.Actual, violation.Budget)
+						case checks.ComplexityMetricImporters:
+							fmt.Printf("    - %s: %d importers (budget %d)\n", getRelativePath(violation.FilePath), violation.Actual, violation.Budget)
+						}
+						if len(violation.Chain) > 0 {
+							chain := make([]string, 0, len(violation.Chain))
+							for _, filePath := range violation.Chain {
+								chain = append(chain, getRelativePath(filePath))
+							}
+							fmt.Printf("        %s\n", strings.Join(chain, " -> "))
+						}
+						if len(violation.Contributors) > 0 {
+							contributors := make([]string, 0, len(violation.Contributors))
+							for _, contributor := range violation.Contributors {
+								contributors = append(contributors, fmt.Sprintf("%s (%d)", getRelativePath(contributor.Path), contributor.Count))
+							}
+							fmt.Printf("        top: %s\n", strings.Join(contributors, ", "))
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more complexity budget issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Complexity Budgets\n", emoji.Success)
+				}
+
+			case "ownership-boundaries":
+				if len(ruleResult.OwnershipBoundaryViolations) > 0 {
+					fmt.Printf("  %s Ownership Boundary Issues (%d):\n", emoji.Error, len(ruleResult.OwnershipBoundaryViolations))
+
+					violationsToDisplay := ruleResult.OwnershipBoundaryViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						fmt.Printf("    - [%s -> %s] %s -> %s\n",
+							strings.Join(violation.FromTeams, " "),
+							strings.Join(violation.ToTeams, " "),
+							getRelativePath(violation.FilePath),
+							getRelativePath(violation.ImportPath))
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more ownership boundary issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Ownership Boundaries\n", emoji.Success)
+				}
+				if ruleResult.ReportTeamDependencies {
+					if len(ruleResult.TeamDependencies) == 0 {
+						fmt.Printf("    No imports between teams\n")
+					} else {
+						fmt.Printf("    Team dependencies (imports):\n")
+					}
+					for _, dependency := range ruleResult.TeamDependencies {
+						fmt.Printf("      %s -> %s: %d\n", dependency.From, dependency.To, dependency.Imports)
+					}
+				}
+
+			case "test-isolation":
+				if len(ruleResult.TestIsolationViolations) > 0 {
+					fmt.Printf("  %s Test Isolation Issues (%d):\n", emoji.Error, len(ruleResult.TestIsolationViolations))
+
+					violationsToDisplay := ruleResult.TestIsolationViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						switch violation.ViolationType {
+						case checks.TestIsolationProductionImport:
+							if violation.EntryPoint != "" && violation.EntryPoint != violation.FilePath {
+								fmt.Printf("    - %s -> %s (production file reached from %s imports test-only file)\n", getRelativePath(violation.FilePath), getRelativePath(violation.ImportPath), getRelativePath(violation.EntryPoint))
+							} else {
+								fmt.Printf("    - %s -> %s (production file imports test-only file)\n", getRelativePath(violation.FilePath), getRelativePath(violation.ImportPath))
+							}
+						case checks.TestIsolationCrossPackageImport:
+							fmt.Printf("    - %s -> %s (test utility of %s)\n", getRelativePath(violation.FilePath), getRelativePath(violation.ImportPath), violation.PackageName)
+						case checks.TestIsolationUnusedFixture:
+							fmt.Printf("    - %s (fixture not used by any test)\n", getRelativePath(violation.FilePath))
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more test isolation issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Test Isolation\n", emoji.Success)
+				}
+			case "peer-dependencies":
+				if len(ruleResult.PeerDependencyViolations) > 0 {
+					fmt.Printf("  %s Peer Dependencies Issues (%d):\n", emoji.Error, len(ruleResult.PeerDependencyViolations))
+
+					violationsToDisplay := ruleResult.PeerDependencyViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						switch violation.ViolationType {
+						case checks.PeerDependencyShouldBePeer:
+							fmt.Printf("    - %s (%s): %s is imported by %s but declared in %s, should be a peer dependency\n", violation.PackageName, getRelativePath(violation.PackageJsonPath), violation.Dependency, getRelativePath(violation.FilePath), violation.DependencyField)
+						case checks.PeerDependencyUnused:
+							fmt.Printf("    - %s (%s): peer dependency %s is never imported\n", violation.PackageName, getRelativePath(violation.PackageJsonPath), violation.Dependency)
+						case checks.PeerDependencyUnsatisfied:
+							if violation.DependencyField == "" {
+								fmt.Printf("    - %s (%s): does not provide %s %s required as a peer by %s\n", violation.PackageName, getRelativePath(violation.PackageJsonPath), violation.Dependency, violation.PeerRange, violation.Library)
+							} else {
+								fmt.Printf("    - %s (%s): %s %s does not satisfy %s required as a peer by %s\n", violation.PackageName, getRelativePath(violation.PackageJsonPath), violation.Dependency, violation.DeclaredRange, violation.PeerRange, violation.Library)
+							}
+						}
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more peer dependencies issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Peer Dependencies\n", emoji.Success)
+				}
+			case "version-consistency":
+				if len(ruleResult.VersionConsistencyViolations) > 0 {
+					fmt.Printf("  %s Version Consistency Issues (%d):\n", emoji.Error, len(ruleResult.VersionConsistencyViolations))
+
+					violationsToDisplay := ruleResult.VersionConsistencyViolations
+					remaining := 0
+					if !listAll && len(violationsToDisplay) > maxIssuesToList {
+						remaining = len(violationsToDisplay) - maxIssuesToList
+						violationsToDisplay = violationsToDisplay[:maxIssuesToList]
+					}
+
+					for _, violation := range violationsToDisplay {
+						expectation := "other workspaces declare"
+						if violation.ViolationType == checks.VersionConsistencyNotPinned {
+							expectation = "pinned to"
+						}
+						fmt.Printf("    - %s (%s): %s %s in %s, %s %s\n", violation.PackageName, getRelativePath(violation.PackageJsonPath), violation.Dependency, violation.DeclaredRange, violation.DependencyField, expectation, violation.ExpectedRange)
+					}
+
+					if remaining > 0 {
+						fmt.Printf("    ... and %d more version consistency issues\n", remaining)
+					}
+				} else {
+					fmt.Printf("  %s Version Consistency\n", emoji.Success)
+				}
 			}
 		}
 
@@ -803,7 +1249,7 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 	}
 
 	// Print autofix summary if any fixes were applied or unfixable issues found
-	if result.FixedFilesCount > 0 || result.FixedImportsCount > 0 || result.DeletedFilesCount > 0 {
+	if result.FixedFilesCount > 0 || result.FixedImportsCount > 0 || result.DeletedFilesCount > 0 || result.AddedNodeModulesCount > 0 || result.RemovedNodeModulesCount > 0 || result.UpdatedNodeModulesCount > 0 {
 		var summary []string
 		if result.FixedImportsCount > 0 || result.FixedFilesCount > 0 {
 			summary = append(summary, fmt.Sprintf("fixed %d imports in %d files", result.FixedImportsCount, result.FixedFilesCount))
@@ -811,6 +1257,15 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 		if result.DeletedFilesCount > 0 {
 			summary = append(summary, fmt.Sprintf("removed %d orphan files", result.DeletedFilesCount))
 		}
+		if result.AddedNodeModulesCount > 0 {
+			summary = append(summary, fmt.Sprintf("added %d missing node modules to package.json", result.AddedNodeModulesCount))
+		}
+		if result.RemovedNodeModulesCount > 0 {
+			summary = append(summary, fmt.Sprintf("removed %d unused node modules from package.json", result.RemovedNodeModulesCount))
+		}
+		if result.UpdatedNodeModulesCount > 0 {
+			summary = append(summary, fmt.Sprintf("aligned %d dependency ranges in package.json", result.UpdatedNodeModulesCount))
+		}
 
 		if len(summary) > 0 {
 			// Capitalize first letter of first summary part
@@ -831,6 +1286,30 @@ func formatAndPrintConfigResults(result *config.ConfigProcessingResult, cwd stri
 	}
 }
 
+// describeWorkspaceProtocolViolation explains a workspace protocol violation in one line.
+func describeWorkspaceProtocolViolation(violation checks.WorkspaceProtocolViolation) string {
+	switch violation.ViolationType {
+	case checks.WorkspaceProtocolMissingProtocol:
+		if violation.SiblingVersion != "" {
+			return fmt.Sprintf("%q should use the workspace: protocol (workspace version %s)", violation.Specifier, violation.SiblingVersion)
+		}
+		return fmt.Sprintf("%q should use the workspace: protocol", violation.Specifier)
+	case checks.WorkspaceProtocolVersionMismatch:
+		return fmt.Sprintf("%q does not match workspace version %s", violation.Specifier, violation.SiblingVersion)
+	case checks.WorkspaceProtocolMissingCatalogEntry:
+		return fmt.Sprintf("%q has no entry in catalog %q", violation.Specifier, violation.Catalog)
+	}
+	return violation.Specifier
+}
+
+// formatCycleBudgetFiles describes the area of a cycle budget, "rule" for the rule-wide maxCycles.
+func formatCycleBudgetFiles(files []string) string {
+	if len(files) == 0 {
+		return "rule"
+	}
+	return strings.Join(files, ", ")
+}
+
 func init() {
 	// config command
 	configCmd.Flags().StringVarP(&configCwd, "cwd", "c", currentDir, "Working directory")
@@ -861,6 +861,8 @@ func TestNodeModulesInstalled(t *testing.T) {
 				nodeModulesPath,
 				[]string{},
 				[]string{},
+				nil,
+				nil,
 			)
 			fmt.Print(result)
 			return nil
@@ -878,6 +880,8 @@ func TestNodeModulesInstalled(t *testing.T) {
 				nodeModulesPath,
 				[]string{"dep1", "dep2"},
 				[]string{"dep1"},
+				nil,
+				nil,
 			)
 			fmt.Print(result)
 			return nil
@@ -891,13 +895,7 @@ func TestNodeModulesInstalled(t *testing.T) {
 		nodeModulesPath := fixturePath(t, "nodeModulesCmdSmoke")
 
 		output, err := captureOutput(func() error {
-			result := node.GetDuplicatedModulesCmd(
-				nodeModulesPath,
-				false,
-				false,
-				false,
-				false,
-			)
+			result := node.GetDuplicatedModulesCmd(nodeModulesPath, node.DuplicatedModulesOptions{})
 			fmt.Print(result)
 			return nil
 		})
@@ -919,13 +917,12 @@ func TestNodeModulesInstalled(t *testing.T) {
 		assert.NilError(t, err)
 
 		output, err := captureOutput(func() error {
-			result := node.GetDuplicatedModulesCmd(
-				tmpFixturePath,
-				true,
-				true,
-				true,
-				true,
-			)
+			result := node.GetDuplicatedModulesCmd(tmpFixturePath, node.DuplicatedModulesOptions{
+				Optimize:  true,
+				Verbose:   true,
+				SizeStats: true,
+				Isolate:   true,
+			})
 			fmt.Print(result)
 			return nil
 		})
@@ -937,6 +934,37 @@ func TestNodeModulesInstalled(t *testing.T) {
 
 		golden.Assert(t, output, goldenPath(t, "node-modules-installed-duplicates-optimized.golden"))
 	})
+
+	t.Run("node-modules installed-duplicates --dry-run", func(t *testing.T) {
+		nodeModulesPath := fixturePath(t, "nodeModulesCmdSmoke")
+
+		tmpDir, err := os.MkdirTemp("", "rev-dep-smoke-test-*")
+		assert.NilError(t, err)
+		defer os.RemoveAll(tmpDir)
+
+		tmpFixturePath := filepath.Join(tmpDir, "nodeModulesCmdSmoke")
+		err = copyDir(nodeModulesPath, tmpFixturePath)
+		assert.NilError(t, err)
+
+		output, err := captureOutput(func() error {
+			result := node.GetDuplicatedModulesCmd(tmpFixturePath, node.DuplicatedModulesOptions{
+				Optimize:  true,
+				SizeStats: true,
+				Isolate:   true,
+				DryRun:    true,
+			})
+			fmt.Print(result)
+			return nil
+		})
+
+		assert.NilError(t, err)
+
+		info, err := os.Lstat(filepath.Join(tmpFixturePath, "node_modules", "@types", "dep-types-2"))
+		assert.NilError(t, err)
+		assert.Assert(t, info.Mode()&os.ModeSymlink == 0, "expected --dry-run to leave the duplicate directory in place")
+
+		golden.Assert(t, output, goldenPath(t, "node-modules-installed-duplicates-dry-run.golden"))
+	})
 }
 
 func TestNodeModulesAnalyze(t *testing.T) {
@@ -944,7 +972,7 @@ func TestNodeModulesAnalyze(t *testing.T) {
 		nodeModulesPath := fixturePath(t, "nodeModulesCmdSmoke")
 
 		output, err := captureOutput(func() error {
-			modules, _ := node.GetInstalledModules(nodeModulesPath, []string{}, []string{})
+			modules, _ := node.GetInstalledModules(nodeModulesPath, []string{}, []string{}, nil, nil)
 			results, err := node.AnalyzeNodeModules(nodeModulesPath, modules)
 			if err != nil {
 				return err
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"rev-dep-go/internal/node"
+	"rev-dep-go/internal/pathutil"
+)
+
+// ---------------- node-modules import-size ----------------
+var (
+	nodeModulesImportSizeTop int
+)
+
+var nodeModulesImportSizeCmd = &cobra.Command{
+	Use:   "import-size",
+	Short: "Estimate the package size each entry point imports",
+	Long: `Sums, for each entry point, the size analyze-size reports for the node modules imported by the
+files reachable from it (the package with the dependencies no other package uses), and lists the
+heaviest modules with the files importing them. It is an offline approximation of the node modules
+a bundle of the entry point contains: dependencies shared by several packages are not counted, and
+code a bundler would tree-shake is.`,
+	Example: `rev-dep node-modules import-size
+rev-dep node-modules import-size -p src/lambda/handler.ts --top 5`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		followValue, err := getFollowMonorepoPackagesValue(cmd)
+		if err != nil {
+			return err
+		}
+		nearestPackage, err := getNodeModulesResolutionNearest()
+		if err != nil {
+			return err
+		}
+		cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+		pnpManifest, lock, err := getInstalledPackagesLookup(cwd)
+		if err != nil {
+			return err
+		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if lock != nil {
+			// Lockfiles do not record package sizes.
+			return fmt.Errorf("import-size measures installed packages, but packages would be read from %s; install dependencies first", lock.Path)
+		}
+
+		if jsonFormat {
+			sizes, err := node.GetEntryPointImportSizes(cwd, nodeModulesIgnoreType, nodeModulesEntryPoints, nodeModulesIncludeModules, nodeModulesExcludeModules, packageJsonPath, tsconfigJsonPath, conditionNames, followValue, nearestPackage, pnpManifest)
+			if err != nil {
+				return err
+			}
+			return writeNodeModulesJSON(os.Stdout, buildJSONImportSize(sizes, cwd))
+		}
+		result, err := node.NodeModulesImportSizeCmd(
+			cwd,
+			nodeModulesIgnoreType,
+			nodeModulesEntryPoints,
+			nodeModulesIncludeModules,
+			nodeModulesExcludeModules,
+			packageJsonPath,
+			tsconfigJsonPath,
+			conditionNames,
+			followValue,
+			nearestPackage,
+			pnpManifest,
+			nodeModulesImportSizeTop,
+		)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(result)
+
+		return nil
+	},
+}
+
+func init() {
+	addSharedFlags(nodeModulesImportSizeCmd)
+	nodeModulesImportSizeCmd.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir,
+		"Working directory for the command")
+	nodeModulesImportSizeCmd.Flags().StringSliceVarP(&nodeModulesEntryPoints, "entry-points", "p", []string{},
+		"Entry point file(s) to measure (default: auto-detected)")
+	nodeModulesImportSizeCmd.Flags().BoolVarP(&nodeModulesIgnoreType, "ignore-type-imports", "t", false,
+		"Exclude type imports from the analysis")
+	nodeModulesImportSizeCmd.Flags().IntVar(&nodeModulesImportSizeTop, "top", 10,
+		"Number of heaviest modules listed per entry point (0 lists all of them). JSON output lists all of them")
+	addNodeModulesIncludeExcludeFlags(nodeModulesImportSizeCmd)
+	addNodeModulesResolutionFlag(nodeModulesImportSizeCmd)
+	addNodeModulesLookupFlag(nodeModulesImportSizeCmd)
+	addNodeModulesFormatFlag(nodeModulesImportSizeCmd)
+}
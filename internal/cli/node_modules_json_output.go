@@ -0,0 +1,526 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"rev-dep-go/internal/checks"
+	"rev-dep-go/internal/node"
+	"rev-dep-go/internal/pathutil"
+)
+
+// nodeModulesJSONOutputVersion is the version of node-modules-output-schema the JSON output of
+// the node-modules commands follows.
+const nodeModulesJSONOutputVersion = "1.0"
+
+var nodeModulesFormat string
+
+// addNodeModulesFormatFlag registers --format on the node-modules commands that can print JSON.
+func addNodeModulesFormatFlag(command *cobra.Command) {
+	command.Flags().StringVar(&nodeModulesFormat, "format", "",
+		"Output format (json). JSON output ignores the grouping and count flags")
+}
+
+// isNodeModulesJSONFormat reports whether --format selects JSON output.
+func isNodeModulesJSONFormat() (bool, error) {
+	switch strings.TrimSpace(nodeModulesFormat) {
+	case "":
+		return false, nil
+	case "json":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid --format value %q: must be 'json'", nodeModulesFormat)
+}
+
+func writeNodeModulesJSON(w io.Writer, output interface{}) error {
+	if err := json.NewEncoder(w).Encode(output); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %v", err)
+	}
+	return nil
+}
+
+// ---------------- JSON output types ----------------
+
+type jsonNodeModulesHeader struct {
+	Version string `json:"version"`
+	Command string `json:"command"`
+}
+
+func newJSONNodeModulesHeader(command string) jsonNodeModulesHeader {
+	return jsonNodeModulesHeader{Version: nodeModulesJSONOutputVersion, Command: command}
+}
+
+type jsonNodeModuleFiles struct {
+	Name  string   `json:"name"`
+	Files []string `json:"files"`
+}
+
+type jsonEntryPointNodeModules struct {
+	EntryPoint string   `json:"entryPoint"`
+	Modules    []string `json:"modules"`
+}
+
+type jsonUsedNodeModulesOutput struct {
+	jsonNodeModulesHeader
+	Modules []jsonNodeModuleFiles `json:"modules"`
+	// Set when the modules are grouped by entry point.
+	EntryPoints []jsonEntryPointNodeModules `json:"entryPoints,omitempty"`
+}
+
+type jsonUnusedNodeModule struct {
+	Name string `json:"name"`
+}
+
+type jsonUnusedNodeModulesOutput struct {
+	jsonNodeModulesHeader
+	Modules []jsonUnusedNodeModule `json:"modules"`
+}
+
+type jsonMissingNodeModulesOutput struct {
+	jsonNodeModulesHeader
+	Modules []jsonNodeModuleFiles `json:"modules"`
+}
+
+type jsonInstalledPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+}
+
+type jsonInstalledNodeModulesOutput struct {
+	jsonNodeModulesHeader
+	Packages []jsonInstalledPackage `json:"packages"`
+}
+
+type jsonDuplicatedVersion struct {
+	Version string   `json:"version"`
+	Paths   []string `json:"paths"`
+}
+
+type jsonDuplicatedPackage struct {
+	Name     string                  `json:"name"`
+	Versions []jsonDuplicatedVersion `json:"versions"`
+}
+
+type jsonDuplicatesOptimization struct {
+	DryRun          bool                  `json:"dryRun"`
+	SymlinksCreated int                   `json:"symlinksCreated"`
+	SymlinksErrored int                   `json:"symlinksErrored"`
+	Symlinks        []jsonNodeModulesLink `json:"symlinks"`
+	Skipped         []string              `json:"skipped"`
+	// Set with --size-stats.
+	DirSizes []jsonNodeModulesDirSizeChange `json:"dirSizes,omitempty"`
+}
+
+type jsonNodeModulesLink struct {
+	Path   string `json:"path"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+}
+
+type jsonNodeModulesDirSizeChange struct {
+	Path       string `json:"path"`
+	SizeBefore int64  `json:"sizeBefore"`
+	SizeAfter  int64  `json:"sizeAfter"`
+}
+
+type jsonInstalledDuplicatesOutput struct {
+	jsonNodeModulesHeader
+	Duplicates []jsonDuplicatedPackage `json:"duplicates"`
+	// Set with --optimize.
+	Optimization          *jsonDuplicatesOptimization `json:"optimization,omitempty"`
+	OptimizeSkippedReason string                      `json:"optimizeSkippedReason,omitempty"`
+}
+
+type jsonModuleSize struct {
+	Name              string `json:"name"`
+	Version           string `json:"version"`
+	Path              string `json:"path"`
+	OwnSize           int64  `json:"ownSize"`
+	ExclusiveDepsSize int64  `json:"exclusiveDepsSize"`
+	SharedDepsSize    int64  `json:"sharedDepsSize"`
+	OwnPlusExclusive  int64  `json:"ownPlusExclusiveSize"`
+	TotalSize         int64  `json:"totalSize"`
+}
+
+type jsonAnalyzeSizeOutput struct {
+	jsonNodeModulesHeader
+	Modules []jsonModuleSize `json:"modules"`
+}
+
+type jsonNodeModulesDirSize struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type jsonDirsSizeOutput struct {
+	jsonNodeModulesHeader
+	Dirs      []jsonNodeModulesDirSize `json:"dirs"`
+	TotalSize int64                    `json:"totalSize"`
+}
+
+type jsonWhyPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type jsonWhyChain struct {
+	Root     string           `json:"root"`
+	Packages []jsonWhyPackage `json:"packages"`
+	UsedBy   []string         `json:"usedBy"`
+}
+
+type jsonWhyInstallation struct {
+	Name       string         `json:"name"`
+	Version    string         `json:"version"`
+	Path       string         `json:"path"`
+	Chains     []jsonWhyChain `json:"chains"`
+	MoreChains bool           `json:"moreChains"`
+}
+
+type jsonWhyOutput struct {
+	jsonNodeModulesHeader
+	Package       string                `json:"package"`
+	Installations []jsonWhyInstallation `json:"installations"`
+}
+
+type jsonVersionDeclaration struct {
+	PackageName     string `json:"packageName,omitempty"`
+	PackageJsonPath string `json:"packageJsonPath"`
+	DependencyField string `json:"dependencyField"`
+	Catalog         string `json:"catalog,omitempty"`
+}
+
+type jsonDependencyRange struct {
+	Range        string                   `json:"range"`
+	Declarations []jsonVersionDeclaration `json:"declarations"`
+}
+
+type jsonDependencyVersions struct {
+	Name          string                `json:"name"`
+	ExpectedRange string                `json:"expectedRange"`
+	Ranges        []jsonDependencyRange `json:"ranges"`
+}
+
+type jsonVersionsOutput struct {
+	jsonNodeModulesHeader
+	Dependencies []jsonDependencyVersions `json:"dependencies"`
+}
+
+type jsonModuleImportSize struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Path         string   `json:"path"`
+	Size         int64    `json:"size"`
+	ImportedFrom []string `json:"importedFrom"`
+}
+
+type jsonEntryPointImportSize struct {
+	EntryPoint string                 `json:"entryPoint"`
+	Size       int64                  `json:"size"`
+	Modules    []jsonModuleImportSize `json:"modules"`
+	Unmeasured []string               `json:"unmeasured"`
+}
+
+type jsonImportSizeOutput struct {
+	jsonNodeModulesHeader
+	EntryPoints []jsonEntryPointImportSize `json:"entryPoints"`
+}
+
+// ---------------- JSON output builders ----------------
+
+// nodeModulesRelPath returns an absolute analyzed path relative to cwd, with forward slashes.
+func nodeModulesRelPath(cwd string, path string) string {
+	rel, err := filepath.Rel(cwd, pathutil.DenormalizePathForOS(path))
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// installedPackagePath returns the path of an installed package as listed by
+// node.GetInstalledModules, which strips cwd from it, with forward slashes and without the leading
+// separator. Lockfile entries are returned as is.
+func installedPackagePath(path string) string {
+	return strings.TrimPrefix(filepath.ToSlash(path), "/")
+}
+
+func sortedRelFiles(cwd string, files map[string]bool) []string {
+	result := make([]string, 0, len(files))
+	for file := range files {
+		result = append(result, nodeModulesRelPath(cwd, file))
+	}
+	slices.Sort(result)
+	return result
+}
+
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func buildJSONUsedNodeModules(usage node.NodeModulesUsage, cwd string) jsonUsedNodeModulesOutput {
+	output := jsonUsedNodeModulesOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("used"),
+		Modules:               []jsonNodeModuleFiles{},
+	}
+	for _, name := range sortedMapKeys(usage.Used) {
+		output.Modules = append(output.Modules, jsonNodeModuleFiles{Name: name, Files: sortedRelFiles(cwd, usage.Used[name])})
+	}
+	if usage.UsedByEntryPoint != nil {
+		output.EntryPoints = []jsonEntryPointNodeModules{}
+		for _, entryPoint := range sortedMapKeys(usage.UsedByEntryPoint) {
+			output.EntryPoints = append(output.EntryPoints, jsonEntryPointNodeModules{
+				EntryPoint: nodeModulesRelPath(cwd, entryPoint),
+				Modules:    sortedMapKeys(usage.UsedByEntryPoint[entryPoint]),
+			})
+		}
+	}
+	return output
+}
+
+func buildJSONUnusedNodeModules(usage node.NodeModulesUsage) jsonUnusedNodeModulesOutput {
+	output := jsonUnusedNodeModulesOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("unused"),
+		Modules:               []jsonUnusedNodeModule{},
+	}
+	for _, name := range usage.Unused {
+		output.Modules = append(output.Modules, jsonUnusedNodeModule{Name: name})
+	}
+	return output
+}
+
+func buildJSONMissingNodeModules(usage node.NodeModulesUsage, cwd string) jsonMissingNodeModulesOutput {
+	output := jsonMissingNodeModulesOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("missing"),
+		Modules:               []jsonNodeModuleFiles{},
+	}
+	for _, missing := range usage.Missing {
+		files := make([]string, 0, len(missing.ImportedFrom))
+		for _, file := range missing.ImportedFrom {
+			files = append(files, nodeModulesRelPath(cwd, file))
+		}
+		slices.Sort(files)
+		output.Modules = append(output.Modules, jsonNodeModuleFiles{Name: missing.ModuleName, Files: files})
+	}
+	slices.SortFunc(output.Modules, func(a, b jsonNodeModuleFiles) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return output
+}
+
+func buildJSONInstalledNodeModules(modules map[string][]node.PackageInfo) jsonInstalledNodeModulesOutput {
+	output := jsonInstalledNodeModulesOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("installed"),
+		Packages:              []jsonInstalledPackage{},
+	}
+	for _, name := range sortedMapKeys(modules) {
+		for _, info := range modules[name] {
+			output.Packages = append(output.Packages, jsonInstalledPackage{
+				Name:    info.Name,
+				Version: info.Version,
+				Path:    installedPackagePath(info.FilePath),
+			})
+		}
+	}
+	slices.SortStableFunc(output.Packages, func(a, b jsonInstalledPackage) int {
+		if a.Name != b.Name {
+			return strings.Compare(a.Name, b.Name)
+		}
+		if a.Version != b.Version {
+			return strings.Compare(a.Version, b.Version)
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	return output
+}
+
+func buildJSONInstalledDuplicates(duplicated node.DuplicatedModules, cwd string) jsonInstalledDuplicatesOutput {
+	output := jsonInstalledDuplicatesOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("installed-duplicates"),
+		Duplicates:            []jsonDuplicatedPackage{},
+		OptimizeSkippedReason: duplicated.OptimizeSkippedReason,
+	}
+	for _, name := range sortedMapKeys(duplicated.ByVersion) {
+		pkg := jsonDuplicatedPackage{Name: name, Versions: []jsonDuplicatedVersion{}}
+		for _, version := range sortedMapKeys(duplicated.ByVersion[name]) {
+			paths := []string{}
+			for _, path := range duplicated.ByVersion[name][version] {
+				paths = append(paths, installedPackagePath(path))
+			}
+			slices.Sort(paths)
+			pkg.Versions = append(pkg.Versions, jsonDuplicatedVersion{Version: version, Paths: paths})
+		}
+		output.Duplicates = append(output.Duplicates, pkg)
+	}
+
+	if duplicated.Optimized {
+		optimization := &jsonDuplicatesOptimization{
+			DryRun:          duplicated.DryRun,
+			SymlinksCreated: duplicated.SymlinksCreated,
+			SymlinksErrored: duplicated.SymlinksErrored,
+			Symlinks:        []jsonNodeModulesLink{},
+			Skipped:         []string{},
+		}
+		for _, symlink := range duplicated.Symlinks {
+			optimization.Symlinks = append(optimization.Symlinks, jsonNodeModulesLink{
+				Path:   nodeModulesRelPath(cwd, symlink.Path),
+				Target: nodeModulesRelPath(cwd, symlink.Target),
+				Size:   symlink.Size,
+			})
+		}
+		for _, skipped := range duplicated.Skipped {
+			optimization.Skipped = append(optimization.Skipped, nodeModulesRelPath(cwd, skipped))
+		}
+		for _, dirSize := range duplicated.DirSizes {
+			optimization.DirSizes = append(optimization.DirSizes, jsonNodeModulesDirSizeChange{
+				Path:       nodeModulesRelPath(cwd, dirSize.Path),
+				SizeBefore: dirSize.SizeBefore,
+				SizeAfter:  dirSize.SizeAfter,
+			})
+		}
+		output.Optimization = optimization
+	}
+	return output
+}
+
+func buildJSONAnalyzeSize(reports []node.ModuleReport, cwd string) jsonAnalyzeSizeOutput {
+	output := jsonAnalyzeSizeOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("analyze-size"),
+		Modules:               []jsonModuleSize{},
+	}
+	for _, report := range reports {
+		output.Modules = append(output.Modules, jsonModuleSize{
+			Name:              report.Name,
+			Version:           report.Version,
+			Path:              nodeModulesRelPath(cwd, report.Path),
+			OwnSize:           report.OwnSize,
+			ExclusiveDepsSize: report.ExclusiveDepsSize,
+			SharedDepsSize:    report.SharedDepsSize,
+			OwnPlusExclusive:  report.OwnPlusExclusive,
+			TotalSize:         report.TotalSize,
+		})
+	}
+	return output
+}
+
+func buildJSONDirsSize(sizes []node.NodeModulesDirSize, cwd string) jsonDirsSizeOutput {
+	output := jsonDirsSizeOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("dirs-size"),
+		Dirs:                  []jsonNodeModulesDirSize{},
+	}
+	for _, dirSize := range sizes {
+		output.Dirs = append(output.Dirs, jsonNodeModulesDirSize{Path: nodeModulesRelPath(cwd, dirSize.Path), Size: dirSize.Size})
+		output.TotalSize += dirSize.Size
+	}
+	return output
+}
+
+func buildJSONWhy(packageSpec string, installations []node.WhyInstallation) jsonWhyOutput {
+	output := jsonWhyOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("why"),
+		Package:               packageSpec,
+		Installations:         []jsonWhyInstallation{},
+	}
+	for _, installation := range installations {
+		jsonInstallation := jsonWhyInstallation{
+			Name:       installation.Name,
+			Version:    installation.Version,
+			Path:       installation.Path,
+			Chains:     []jsonWhyChain{},
+			MoreChains: installation.MoreChains,
+		}
+		for _, chain := range installation.Chains {
+			jsonChain := jsonWhyChain{Root: chain.Root, Packages: []jsonWhyPackage{}, UsedBy: append([]string{}, chain.UsedBy...)}
+			for _, pkg := range chain.Packages {
+				jsonChain.Packages = append(jsonChain.Packages, jsonWhyPackage{Name: pkg.Name, Version: pkg.Version})
+			}
+			jsonInstallation.Chains = append(jsonInstallation.Chains, jsonChain)
+		}
+		output.Installations = append(output.Installations, jsonInstallation)
+	}
+	return output
+}
+
+// buildJSONVersions lists the dependencies declared with more than one range, or all of them
+// with all, the expected range first like printDependencyVersions.
+func buildJSONVersions(declarations map[string][]checks.DependencyVersionDeclaration, cwd string, all bool) jsonVersionsOutput {
+	output := jsonVersionsOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("versions"),
+		Dependencies:          []jsonDependencyVersions{},
+	}
+	for _, dependency := range sortedMapKeys(declarations) {
+		expected := checks.ExpectedDependencyRange(declarations[dependency])
+		ranges := []jsonDependencyRange{}
+		for _, declaration := range declarations[dependency] {
+			index := slices.IndexFunc(ranges, func(r jsonDependencyRange) bool { return r.Range == declaration.Range })
+			if index == -1 {
+				ranges = append(ranges, jsonDependencyRange{Range: declaration.Range})
+				index = len(ranges) - 1
+			}
+			ranges[index].Declarations = append(ranges[index].Declarations, jsonVersionDeclaration{
+				PackageName:     declaration.PackageName,
+				PackageJsonPath: nodeModulesRelPath(cwd, declaration.PackageJsonPath),
+				DependencyField: declaration.DependencyField,
+				Catalog:         declaration.Catalog,
+			})
+		}
+		if len(ranges) < 2 && !all {
+			continue
+		}
+		slices.SortStableFunc(ranges, func(a, b jsonDependencyRange) int {
+			if a.Range == expected {
+				return -1
+			}
+			if b.Range == expected {
+				return 1
+			}
+			if len(a.Declarations) != len(b.Declarations) {
+				return len(b.Declarations) - len(a.Declarations)
+			}
+			return strings.Compare(a.Range, b.Range)
+		})
+		output.Dependencies = append(output.Dependencies, jsonDependencyVersions{Name: dependency, ExpectedRange: expected, Ranges: ranges})
+	}
+	return output
+}
+
+func buildJSONImportSize(sizes []node.EntryPointImportSize, cwd string) jsonImportSizeOutput {
+	output := jsonImportSizeOutput{
+		jsonNodeModulesHeader: newJSONNodeModulesHeader("import-size"),
+		EntryPoints:           []jsonEntryPointImportSize{},
+	}
+	for _, entryPointSize := range sizes {
+		jsonEntryPoint := jsonEntryPointImportSize{
+			EntryPoint: nodeModulesRelPath(cwd, entryPointSize.EntryPoint),
+			Size:       entryPointSize.Size,
+			Modules:    []jsonModuleImportSize{},
+			Unmeasured: append([]string{}, entryPointSize.Unmeasured...),
+		}
+		for _, importSize := range entryPointSize.Modules {
+			importedFrom := make([]string, 0, len(importSize.ImportedFrom))
+			for _, filePath := range importSize.ImportedFrom {
+				importedFrom = append(importedFrom, nodeModulesRelPath(cwd, filePath))
+			}
+			jsonEntryPoint.Modules = append(jsonEntryPoint.Modules, jsonModuleImportSize{
+				Name:         importSize.Name,
+				Version:      importSize.Version,
+				Path:         nodeModulesRelPath(cwd, importSize.Path),
+				Size:         importSize.Size,
+				ImportedFrom: importedFrom,
+			})
+		}
+		output.EntryPoints = append(output.EntryPoints, jsonEntryPoint)
+	}
+	return output
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/checks"
+	"rev-dep-go/internal/node"
+	"rev-dep-go/internal/pathutil"
+)
+
+func TestBuildJSONUsedAndMissingNodeModules(t *testing.T) {
+	cwd := t.TempDir()
+	abs := func(rel string) string {
+		return pathutil.NormalizePathForInternal(filepath.Join(cwd, rel))
+	}
+	usage := node.NodeModulesUsage{
+		Used: map[string]map[string]bool{
+			"react":  {abs("src/b.tsx"): true, abs("src/a.tsx"): true},
+			"lodash": {abs("src/a.tsx"): true},
+		},
+		UsedByEntryPoint: map[string]map[string]bool{
+			abs("src/a.tsx"): {"react": true, "lodash": true},
+		},
+		Missing: []node.MissingNodeModuleResult{
+			{ModuleName: "zod", ImportedFrom: []string{abs("src/b.tsx")}},
+			{ModuleName: "axios", ImportedFrom: []string{abs("src/b.tsx"), abs("src/a.tsx")}},
+		},
+	}
+
+	used := buildJSONUsedNodeModules(usage, cwd)
+	expectedUsed := jsonUsedNodeModulesOutput{
+		jsonNodeModulesHeader: jsonNodeModulesHeader{Version: nodeModulesJSONOutputVersion, Command: "used"},
+		Modules: []jsonNodeModuleFiles{
+			{Name: "lodash", Files: []string{"src/a.tsx"}},
+			{Name: "react", Files: []string{"src/a.tsx", "src/b.tsx"}},
+		},
+		EntryPoints: []jsonEntryPointNodeModules{{EntryPoint: "src/a.tsx", Modules: []string{"lodash", "react"}}},
+	}
+	if !reflect.DeepEqual(used, expectedUsed) {
+		t.Errorf("unexpected used output %+v, want %+v", used, expectedUsed)
+	}
+
+	missing := buildJSONMissingNodeModules(usage, cwd)
+	expectedMissing := []jsonNodeModuleFiles{
+		{Name: "axios", Files: []string{"src/a.tsx", "src/b.tsx"}},
+		{Name: "zod", Files: []string{"src/b.tsx"}},
+	}
+	if !reflect.DeepEqual(missing.Modules, expectedMissing) {
+		t.Errorf("unexpected missing modules %+v, want %+v", missing.Modules, expectedMissing)
+	}
+
+	// Empty results are written as empty arrays, and entryPoints only when grouping was requested.
+	var buf bytes.Buffer
+	if err := writeNodeModulesJSON(&buf, buildJSONUsedNodeModules(node.NodeModulesUsage{}, cwd)); err != nil {
+		t.Fatalf("write JSON: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, buf.String())
+	}
+	if modules, ok := parsed["modules"].([]interface{}); !ok || len(modules) != 0 {
+		t.Errorf("expected empty modules array, got %v", parsed["modules"])
+	}
+	if _, ok := parsed["entryPoints"]; ok {
+		t.Errorf("expected entryPoints to be omitted without grouping, got %v", parsed["entryPoints"])
+	}
+}
+
+func TestBuildJSONInstalledDuplicates(t *testing.T) {
+	cwd := t.TempDir()
+	duplicated := node.DuplicatedModules{
+		ByVersion: map[string]map[string][]string{
+			"lodash": {"4.17.21": {"/node_modules/b/node_modules/lodash/package.json", "/node_modules/a/node_modules/lodash/package.json"}},
+		},
+		Optimized:       true,
+		SymlinksCreated: 1,
+		Skipped:         []string{pathutil.NormalizePathForInternal(filepath.Join(cwd, "node_modules/c/node_modules/lodash"))},
+	}
+
+	output := buildJSONInstalledDuplicates(duplicated, cwd)
+	expected := []jsonDuplicatedPackage{{
+		Name: "lodash",
+		Versions: []jsonDuplicatedVersion{{
+			Version: "4.17.21",
+			Paths:   []string{"node_modules/a/node_modules/lodash/package.json", "node_modules/b/node_modules/lodash/package.json"},
+		}},
+	}}
+	if !reflect.DeepEqual(output.Duplicates, expected) {
+		t.Errorf("unexpected duplicates %+v, want %+v", output.Duplicates, expected)
+	}
+	if output.Optimization == nil || output.Optimization.SymlinksCreated != 1 ||
+		!reflect.DeepEqual(output.Optimization.Skipped, []string{"node_modules/c/node_modules/lodash"}) {
+		t.Errorf("unexpected optimization %+v", output.Optimization)
+	}
+
+	if notOptimized := buildJSONInstalledDuplicates(node.DuplicatedModules{}, cwd); notOptimized.Optimization != nil {
+		t.Errorf("expected no optimization without --optimize, got %+v", notOptimized.Optimization)
+	}
+}
+
+func TestBuildJSONVersions(t *testing.T) {
+	cwd := t.TempDir()
+	packageJson := func(rel string) string {
+		return pathutil.NormalizePathForInternal(filepath.Join(cwd, rel, "package.json"))
+	}
+	declarations := map[string][]checks.DependencyVersionDeclaration{
+		"react": {
+			{PackageName: "admin", PackageJsonPath: packageJson("apps/admin"), DependencyField: "dependencies", Range: "^17.0.0"},
+			{PackageName: "web", PackageJsonPath: packageJson("apps/web"), DependencyField: "dependencies", Range: "^18.2.0"},
+			{PackageName: "ui", PackageJsonPath: packageJson("packages/ui"), DependencyField: "devDependencies", Range: "^18.2.0", Catalog: "default"},
+		},
+		"zod": {
+			{PackageName: "web", PackageJsonPath: packageJson("apps/web"), DependencyField: "dependencies", Range: "^3.22.0"},
+		},
+	}
+
+	output := buildJSONVersions(declarations, cwd, false)
+	expected := []jsonDependencyVersions{{
+		Name:          "react",
+		ExpectedRange: "^18.2.0",
+		Ranges: []jsonDependencyRange{
+			{Range: "^18.2.0", Declarations: []jsonVersionDeclaration{
+				{PackageName: "web", PackageJsonPath: "apps/web/package.json", DependencyField: "dependencies"},
+				{PackageName: "ui", PackageJsonPath: "packages/ui/package.json", DependencyField: "devDependencies", Catalog: "default"},
+			}},
+			{Range: "^17.0.0", Declarations: []jsonVersionDeclaration{
+				{PackageName: "admin", PackageJsonPath: "apps/admin/package.json", DependencyField: "dependencies"},
+			}},
+		},
+	}}
+	if !reflect.DeepEqual(output.Dependencies, expected) {
+		t.Errorf("unexpected dependencies %+v, want %+v", output.Dependencies, expected)
+	}
+
+	if all := buildJSONVersions(declarations, cwd, true); len(all.Dependencies) != 2 || all.Dependencies[1].Name != "zod" {
+		t.Errorf("expected all dependencies to be listed with all, got %+v", all.Dependencies)
+	}
+}
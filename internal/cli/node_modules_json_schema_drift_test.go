@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNodeModulesJSONOutputSchemaNoDrift guards node-modules-output-schema/1.0.schema.json against
+// drift from the structs producing `node-modules <command> --format json`, the same way
+// TestJSONOutputSchemaNoDrift guards the config run output: every fully populated struct must emit
+// exactly the properties of its schema definition.
+func TestNodeModulesJSONOutputSchemaNoDrift(t *testing.T) {
+	schemaPath := filepath.Join("..", "..", "node-modules-output-schema", "1.0.schema.json")
+	raw, err := os.ReadFile(schemaPath)
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatalf("parse schema: %v", err)
+	}
+
+	versionConst := navigateSchema(t, schema, []string{"definitions", "version"})["const"]
+	if versionConst != nodeModulesJSONOutputVersion {
+		t.Errorf("schema version %v does not match nodeModulesJSONOutputVersion %q", versionConst, nodeModulesJSONOutputVersion)
+	}
+
+	header := newJSONNodeModulesHeader("used")
+	definition := func(name string) []string { return []string{"definitions", name} }
+	items := func(name string, property string) []string {
+		return []string{"definitions", name, "properties", property, "items"}
+	}
+
+	cases := []struct {
+		name    string
+		pointer []string
+		value   interface{}
+	}{
+		{"usedOutput", definition("usedOutput"), jsonUsedNodeModulesOutput{jsonNodeModulesHeader: header, EntryPoints: []jsonEntryPointNodeModules{{}}}},
+		{"moduleFiles", definition("moduleFiles"), jsonNodeModuleFiles{}},
+		{"entryPointModules", definition("entryPointModules"), jsonEntryPointNodeModules{}},
+		{"unusedOutput", definition("unusedOutput"), jsonUnusedNodeModulesOutput{jsonNodeModulesHeader: header}},
+		{"unusedOutput.modules.items", items("unusedOutput", "modules"), jsonUnusedNodeModule{}},
+		{"missingOutput", definition("missingOutput"), jsonMissingNodeModulesOutput{jsonNodeModulesHeader: header}},
+		{"installedOutput", definition("installedOutput"), jsonInstalledNodeModulesOutput{jsonNodeModulesHeader: header}},
+		{"installedPackage", definition("installedPackage"), jsonInstalledPackage{}},
+		{"installedDuplicatesOutput", definition("installedDuplicatesOutput"), jsonInstalledDuplicatesOutput{jsonNodeModulesHeader: header, Optimization: &jsonDuplicatesOptimization{}, OptimizeSkippedReason: "r"}},
+		{"duplicatedPackage", definition("duplicatedPackage"), jsonDuplicatedPackage{}},
+		{"duplicatedPackage.versions.items", items("duplicatedPackage", "versions"), jsonDuplicatedVersion{}},
+		{"duplicatesOptimization", definition("duplicatesOptimization"), jsonDuplicatesOptimization{DirSizes: []jsonNodeModulesDirSizeChange{{}}}},
+		{"duplicatesOptimization.dirSizes.items", items("duplicatesOptimization", "dirSizes"), jsonNodeModulesDirSizeChange{}},
+		{"duplicatesOptimization.symlinks.items", items("duplicatesOptimization", "symlinks"), jsonNodeModulesLink{}},
+		{"analyzeSizeOutput", definition("analyzeSizeOutput"), jsonAnalyzeSizeOutput{jsonNodeModulesHeader: header}},
+		{"moduleSize", definition("moduleSize"), jsonModuleSize{}},
+		{"importSizeOutput", definition("importSizeOutput"), jsonImportSizeOutput{jsonNodeModulesHeader: header}},
+		{"entryPointImportSize", definition("entryPointImportSize"), jsonEntryPointImportSize{}},
+		{"entryPointImportSize.modules.items", items("entryPointImportSize", "modules"), jsonModuleImportSize{}},
+		{"dirsSizeOutput", definition("dirsSizeOutput"), jsonDirsSizeOutput{jsonNodeModulesHeader: header}},
+		{"dirsSizeOutput.dirs.items", items("dirsSizeOutput", "dirs"), jsonNodeModulesDirSize{}},
+		{"whyOutput", definition("whyOutput"), jsonWhyOutput{jsonNodeModulesHeader: header}},
+		{"whyInstallation", definition("whyInstallation"), jsonWhyInstallation{}},
+		{"whyChain", definition("whyChain"), jsonWhyChain{}},
+		{"whyChain.packages.items", items("whyChain", "packages"), jsonWhyPackage{}},
+		{"versionsOutput", definition("versionsOutput"), jsonVersionsOutput{jsonNodeModulesHeader: header}},
+		{"dependencyVersions", definition("dependencyVersions"), jsonDependencyVersions{}},
+		{"dependencyVersions.ranges.items", items("dependencyVersions", "ranges"), jsonDependencyRange{}},
+		{"dependencyVersions.ranges.items.declarations.items", []string{"definitions", "dependencyVersions", "properties", "ranges", "items", "properties", "declarations", "items"}, jsonVersionDeclaration{PackageName: "p", Catalog: "c"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := navigateSchema(t, schema, tc.pointer)
+
+			if ap, ok := node["additionalProperties"]; !ok || ap != false {
+				t.Errorf("%s: object must set additionalProperties:false so drift is caught", tc.name)
+			}
+
+			schemaKeys := propertyKeys(t, node)
+			structKeys := marshalKeys(t, tc.value)
+
+			if onlyStruct := difference(structKeys, schemaKeys); len(onlyStruct) > 0 {
+				t.Errorf("%s: struct emits keys absent from schema (output would FAIL validation): %v", tc.name, onlyStruct)
+			}
+			if onlySchema := difference(schemaKeys, structKeys); len(onlySchema) > 0 {
+				t.Errorf("%s: schema declares properties no struct field emits (dead schema): %v", tc.name, onlySchema)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"rev-dep-go/internal/node"
+)
+
+// ---------------- node-modules restore ----------------
+var nodeModulesRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Undo the node_modules changes recorded in a journal",
+	Long: `Undoes the changes 'installed-duplicates --optimize' and 'prune-docs' recorded with --journal,
+the last one first: symlinks are replaced with the original package directories and pruned files are
+put back from the trash directory next to the journal. Paths changed since (for example by a new
+install) are left alone and kept in the journal.`,
+	Example: "rev-dep node-modules restore --journal node_modules.journal.json",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := node.RestoreNodeModulesJournal(nodeModulesJournalPath)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Operations restored: %d\n", result.Restored)
+		if len(result.Failed) > 0 {
+			fmt.Printf("Operations not restored: %d\n", len(result.Failed))
+			for _, failed := range result.Failed {
+				fmt.Printf("   %s\n", failed)
+			}
+			return fmt.Errorf("%d operations could not be restored; they are kept in %s", len(result.Failed), nodeModulesJournalPath)
+		}
+		return nil
+	},
+}
+
+// addNodeModulesJournalFlags registers --dry-run and --journal on the commands changing
+// node_modules.
+func addNodeModulesJournalFlags(command *cobra.Command, dryRunUsage string) {
+	command.Flags().BoolVar(&nodeModulesDryRun, "dry-run", false, dryRunUsage)
+	command.Flags().StringVar(&nodeModulesJournalPath, "journal", "",
+		"Record every change in this file, keeping replaced and removed files in a trash directory next to it, so 'node-modules restore' can undo them")
+}
+
+// openNodeModulesJournal opens the journal passed with --journal, or returns nil without it.
+func openNodeModulesJournal(journalPath string, dryRun bool) (*node.NodeModulesJournal, error) {
+	if journalPath == "" {
+		return nil, nil
+	}
+	if dryRun {
+		return nil, fmt.Errorf("--journal cannot be used with --dry-run, which changes nothing")
+	}
+	return node.OpenNodeModulesJournal(journalPath)
+}
+
+func saveNodeModulesJournal(journal *node.NodeModulesJournal) error {
+	if journal == nil {
+		return nil
+	}
+	if err := journal.Save(); err != nil {
+		return fmt.Errorf("failed to write journal %s: %v", journal.Path(), err)
+	}
+	return nil
+}
+
+func printNodeModulesJournalNote(journal *node.NodeModulesJournal) {
+	if journal == nil {
+		return
+	}
+	fmt.Printf("\nJournal: %s (%d operations)\nUndo them with: rev-dep node-modules restore --journal %s\n", journal.Path(), len(journal.Operations), journal.Path())
+}
+
+func init() {
+	nodeModulesRestoreCmd.Flags().StringVar(&nodeModulesJournalPath, "journal", "",
+		"Journal file written by --journal")
+	nodeModulesRestoreCmd.MarkFlagRequired("journal")
+}
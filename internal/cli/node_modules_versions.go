@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"rev-dep-go/internal/checks"
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+)
+
+// ---------------- node-modules versions ----------------
+var (
+	nodeModulesVersionsAll bool
+)
+
+var nodeModulesVersionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Compare dependency ranges declared across workspace packages",
+	Long: `Lists the external dependencies workspace packages declare with different version ranges,
+with the package.json files declaring each range. Ranges of dependencies, devDependencies and
+optionalDependencies of every workspace package and of the workspace root are compared; "catalog:"
+references are shown with the range of the catalog.`,
+	Example: `rev-dep node-modules versions
+rev-dep node-modules versions --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		return nodeModulesVersionsCmdFn(os.Stdout, pathutil.ResolveAbsoluteCwd(nodeModulesCwd), nodeModulesVersionsAll, jsonFormat)
+	},
+}
+
+func nodeModulesVersionsCmdFn(w io.Writer, cwd string, all bool, jsonFormat bool) error {
+	monorepoContext := monorepo.DetectMonorepo(cwd)
+	if monorepoContext == nil {
+		monorepoContext = monorepo.NewMonorepoContext(pathutil.NormalizePathForInternal(filepath.Clean(cwd)))
+	}
+	monorepoContext.FindWorkspacePackages(nil, nil)
+
+	declarations := checks.CollectDependencyVersions(monorepoContext)
+	if jsonFormat {
+		return writeNodeModulesJSON(w, buildJSONVersions(declarations, cwd, all))
+	}
+	printDependencyVersions(w, declarations, cwd, all)
+	return nil
+}
+
+// printDependencyVersions prints the declared ranges of each dependency, the range most workspaces
+// declare first. Without all, only dependencies declared with more than one range are listed.
+func printDependencyVersions(w io.Writer, declarations map[string][]checks.DependencyVersionDeclaration, cwd string, all bool) {
+	rel := func(path string) string {
+		relPath, err := filepath.Rel(cwd, pathutil.DenormalizePathForOS(path))
+		if err != nil {
+			return path
+		}
+		return filepath.ToSlash(relPath)
+	}
+
+	dependencies := make([]string, 0, len(declarations))
+	for dependency := range declarations {
+		dependencies = append(dependencies, dependency)
+	}
+	slices.Sort(dependencies)
+
+	mismatching := 0
+	for _, dependency := range dependencies {
+		byRange := map[string][]checks.DependencyVersionDeclaration{}
+		for _, declaration := range declarations[dependency] {
+			byRange[declaration.Range] = append(byRange[declaration.Range], declaration)
+		}
+		if len(byRange) > 1 {
+			mismatching++
+		} else if !all {
+			continue
+		}
+
+		ranges := make([]string, 0, len(byRange))
+		for versionRange := range byRange {
+			ranges = append(ranges, versionRange)
+		}
+		expected := checks.ExpectedDependencyRange(declarations[dependency])
+		slices.SortFunc(ranges, func(a, b string) int {
+			if a == expected {
+				return -1
+			}
+			if b == expected {
+				return 1
+			}
+			if len(byRange[a]) != len(byRange[b]) {
+				return len(byRange[b]) - len(byRange[a])
+			}
+			return strings.Compare(a, b)
+		})
+
+		if len(ranges) > 1 {
+			fmt.Fprintf(w, "%s (%d ranges)\n", dependency, len(ranges))
+		} else {
+			fmt.Fprintln(w, dependency)
+		}
+		for _, versionRange := range ranges {
+			fmt.Fprintf(w, "  %s\n", versionRange)
+			for _, declaration := range byRange[versionRange] {
+				line := fmt.Sprintf("    - %s %s", rel(declaration.PackageJsonPath), declaration.DependencyField)
+				if declaration.PackageName != "" {
+					line = fmt.Sprintf("    - %s (%s) %s", declaration.PackageName, rel(declaration.PackageJsonPath), declaration.DependencyField)
+				}
+				if declaration.Catalog != "" {
+					line += " via catalog:" + declaration.Catalog
+				}
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+
+	if mismatching == 0 {
+		fmt.Fprintf(w, "All %d external dependencies are declared with consistent ranges\n", len(dependencies))
+		return
+	}
+	fmt.Fprintf(w, "\n%d of %d external dependencies are declared with different ranges\n", mismatching, len(dependencies))
+}
+
+func init() {
+	nodeModulesVersionsCmd.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir,
+		"Working directory for the command")
+	nodeModulesVersionsCmd.Flags().BoolVar(&nodeModulesVersionsAll, "all", false,
+		"List every external dependency, including the ones declared with a single range")
+	addNodeModulesFormatFlag(nodeModulesVersionsCmd)
+}
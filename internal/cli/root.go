@@ -23,9 +23,11 @@ import (
 	"rev-dep-go/internal/fs"
 	globutil "rev-dep-go/internal/glob"
 	"rev-dep-go/internal/graph"
+	"rev-dep-go/internal/lockfile"
 	"rev-dep-go/internal/model"
 	"rev-dep-go/internal/node"
 	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/pnp"
 	"rev-dep-go/internal/resolve"
 	"rev-dep-go/internal/source"
 	"rev-dep-go/internal/version"
@@ -294,6 +296,49 @@ func nodeModulesResolutionStrategy() (model.NodeModulesMatchingStrategy, error)
 	return model.NodeModulesMatchingStrategyCwdResolver, nil
 }
 
+// ---------------- node_modules lookup flag ----------------
+
+var nodeModulesLookupFlag string
+
+// addNodeModulesLookupFlag registers --node-modules-lookup on commands that read installed packages
+// from disk, so Yarn Plug'n'Play projects (no node_modules directory) can be analyzed.
+func addNodeModulesLookupFlag(command *cobra.Command) {
+	command.Flags().StringVar(&nodeModulesLookupFlag, "node-modules-lookup", "auto",
+		"Where installed packages are looked up: 'auto' (Yarn PnP manifest when present, the lockfile when no node_modules directory is found up to it, node_modules otherwise), 'node-modules', 'pnp' (.pnp.cjs / .pnp.data.json) or 'lockfile' (pnpm-lock.yaml, yarn.lock, package-lock.json)")
+}
+
+// getPnPManifest resolves --node-modules-lookup for cwd and returns the Yarn PnP manifest to use, or
+// nil when installed packages should be read from node_modules directories.
+func getPnPManifest(cwd string) (*pnp.Manifest, error) {
+	strategy, err := node.ParseNodeModulesLookupStrategy(strings.TrimSpace(nodeModulesLookupFlag))
+	if err != nil {
+		return nil, err
+	}
+	return node.LoadPnPManifest(cwd, strategy)
+}
+
+// getLockfile resolves --node-modules-lookup for cwd and returns the lockfile to read packages
+// from, or nil when installed packages should be read from disk. Call it only when getPnPManifest
+// returned no manifest.
+func getLockfile(cwd string) (*lockfile.Lockfile, error) {
+	strategy, err := node.ParseNodeModulesLookupStrategy(strings.TrimSpace(nodeModulesLookupFlag))
+	if err != nil {
+		return nil, err
+	}
+	return node.LoadLockfile(cwd, strategy)
+}
+
+// getInstalledPackagesLookup returns the PnP manifest or the lockfile installed packages are read
+// from; both are nil when they are read from node_modules directories.
+func getInstalledPackagesLookup(cwd string) (*pnp.Manifest, *lockfile.Lockfile, error) {
+	pnpManifest, err := getPnPManifest(cwd)
+	if err != nil || pnpManifest != nil {
+		return pnpManifest, nil, err
+	}
+	lock, err := getLockfile(cwd)
+	return nil, lock, err
+}
+
 // ---------------- resolve ----------------
 var (
 	resolveCwd            string
@@ -709,6 +754,9 @@ var (
 	nodeModulesOptimizeIsolate           bool
 	nodeModulesPrunePatterns             []string
 	nodeModulesPruneDefaults             bool
+	nodeModulesWhyMaxChains              int
+	nodeModulesDryRun                    bool
+	nodeModulesJournalPath               string
 )
 
 var nodeModulesCmd = &cobra.Command{
@@ -736,6 +784,16 @@ Helps keep track of your project's runtime dependencies.`,
 		if err != nil {
 			return err
 		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if jsonFormat {
+			cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+			byEntryPoint := nodeModulesGroupByEntryPoint || nodeModulesGroupByEntryPointModCount || nodeModulesGroupByModuleShowEntries || nodeModulesGroupByModuleEntriesCount
+			usage := getNodeModulesUsage(cwd, false, false, byEntryPoint, followValue, nearestPackage)
+			return writeNodeModulesJSON(os.Stdout, buildJSONUsedNodeModules(usage, cwd))
+		}
 		result, _ := node.NodeModulesCmd(
 			pathutil.ResolveAbsoluteCwd(nodeModulesCwd),
 			nodeModulesIgnoreType,
@@ -784,6 +842,21 @@ to identify potentially unused packages.`,
 		if err != nil {
 			return err
 		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if jsonFormat {
+			cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+			usage := getNodeModulesUsage(cwd, true, false, false, followValue, nearestPackage)
+			if err := writeNodeModulesJSON(os.Stdout, buildJSONUnusedNodeModules(usage)); err != nil {
+				return err
+			}
+			if !nodeModulesZeroExitCode {
+				os.Exit(len(usage.Unused))
+			}
+			return nil
+		}
 		result, count := node.NodeModulesCmd(
 			pathutil.ResolveAbsoluteCwd(nodeModulesCwd),
 			nodeModulesIgnoreType,
@@ -836,6 +909,21 @@ in your package.json dependencies.`,
 		if err != nil {
 			return err
 		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if jsonFormat {
+			cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+			usage := getNodeModulesUsage(cwd, false, true, false, followValue, nearestPackage)
+			if err := writeNodeModulesJSON(os.Stdout, buildJSONMissingNodeModules(usage, cwd)); err != nil {
+				return err
+			}
+			if !nodeModulesZeroExitCode {
+				os.Exit(len(usage.Missing))
+			}
+			return nil
+		}
 		result, count := node.NodeModulesCmd(
 			pathutil.ResolveAbsoluteCwd(nodeModulesCwd),
 			nodeModulesIgnoreType,
@@ -880,10 +968,25 @@ var nodeModulesInstalledCmd = &cobra.Command{
 Helpful for auditing dependencies across monorepos.`,
 	Example: "rev-dep node-modules installed --include-modules=@myorg/*",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+		pnpManifest, lock, err := getInstalledPackagesLookup(cwd)
+		if err != nil {
+			return err
+		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if jsonFormat {
+			modules, _ := node.GetInstalledModules(cwd, nodeModulesIncludeModules, nodeModulesExcludeModules, pnpManifest, lock)
+			return writeNodeModulesJSON(os.Stdout, buildJSONInstalledNodeModules(modules))
+		}
 		result := node.GetInstalledModulesCmd(
-			pathutil.ResolveAbsoluteCwd(nodeModulesCwd),
+			cwd,
 			nodeModulesIncludeModules,
 			nodeModulesExcludeModules,
+			pnpManifest,
+			lock,
 		)
 
 		fmt.Print(result)
@@ -897,15 +1000,104 @@ var nodeModulesInstalledDuplicatesCmd = &cobra.Command{
 	Short: "Find and optimize duplicate package installations",
 	Long: `Identifies packages that are installed multiple times in node_modules.
 Can optimize storage by creating symlinks between duplicate packages.`,
-	Example: "rev-dep node-modules installed-duplicates --optimize --size-stats",
+	Example: `rev-dep node-modules installed-duplicates --optimize --size-stats
+rev-dep node-modules installed-duplicates --dry-run
+rev-dep node-modules installed-duplicates --optimize --journal node_modules.journal.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		result := node.GetDuplicatedModulesCmd(
-			pathutil.ResolveAbsoluteCwd(nodeModulesCwd),
-			nodeModulesShouldOptimize,
-			nodeModulesVerbose,
-			nodeModulesSizeStats,
-			nodeModulesOptimizeIsolate,
+		cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+		pnpManifest, lock, err := getInstalledPackagesLookup(cwd)
+		if err != nil {
+			return err
+		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if nodeModulesJournalPath != "" && !nodeModulesShouldOptimize {
+			return fmt.Errorf("--journal records the changes of --optimize; pass --optimize as well")
+		}
+		journal, err := openNodeModulesJournal(nodeModulesJournalPath, nodeModulesDryRun)
+		if err != nil {
+			return err
+		}
+		opts := node.DuplicatedModulesOptions{
+			// --dry-run plans the symlinks --optimize would create.
+			Optimize:    nodeModulesShouldOptimize || nodeModulesDryRun,
+			Verbose:     nodeModulesVerbose,
+			SizeStats:   nodeModulesSizeStats,
+			Isolate:     nodeModulesOptimizeIsolate,
+			DryRun:      nodeModulesDryRun,
+			Journal:     journal,
+			PnPManifest: pnpManifest,
+			Lockfile:    lock,
+		}
+		if jsonFormat {
+			// --verbose prints while optimizing and would break the JSON document.
+			opts.Verbose = false
+			duplicated := node.FindDuplicatedModules(cwd, opts)
+			if err := saveNodeModulesJournal(journal); err != nil {
+				return err
+			}
+			return writeNodeModulesJSON(os.Stdout, buildJSONInstalledDuplicates(duplicated, cwd))
+		}
+		result := node.GetDuplicatedModulesCmd(cwd, opts)
+
+		fmt.Print(result)
+
+		if err := saveNodeModulesJournal(journal); err != nil {
+			return err
+		}
+		printNodeModulesJournalNote(journal)
+
+		return nil
+	},
+}
+
+var nodeModulesWhyCmd = &cobra.Command{
+	Use:   "why <package>",
+	Short: "Explain why a package is installed",
+	Long: `Prints every dependency chain from the project root and workspace package.json files
+to each installed copy of a package, along with the source files using the direct dependency
+each chain starts with. Pass "name@range" to only explain copies matching a semver range.`,
+	Example: `rev-dep node-modules why lodash
+rev-dep node-modules why lodash@^3 --node-modules-lookup lockfile`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		followValue, err := getFollowMonorepoPackagesValue(cmd)
+		if err != nil {
+			return err
+		}
+		cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+		pnpManifest, lock, err := getInstalledPackagesLookup(cwd)
+		if err != nil {
+			return err
+		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if jsonFormat {
+			installations, err := node.FindWhyInstallations(cwd, args[0], nodeModulesIgnoreType, nodeModulesWhyMaxChains, pnpManifest, lock, packageJsonPath, tsconfigJsonPath, conditionNames, followValue)
+			if err != nil {
+				return err
+			}
+			return writeNodeModulesJSON(os.Stdout, buildJSONWhy(args[0], installations))
+		}
+		result, err := node.NodeModulesWhyCmd(
+			cwd,
+			args[0],
+			nodeModulesIgnoreType,
+			nodeModulesWhyMaxChains,
+			pnpManifest,
+			lock,
+			packageJsonPath,
+			tsconfigJsonPath,
+			conditionNames,
+			followValue,
 		)
+		if err != nil {
+			return err
+		}
 
 		fmt.Print(result)
 
@@ -921,12 +1113,32 @@ Helps identify space-hogging dependencies.`,
 	Example: "rev-dep node-modules analyze-size",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
-		modules, _ := node.GetInstalledModules(cwd, []string{}, []string{})
-		results, err := node.AnalyzeNodeModules(cwd, modules)
+		pnpManifest, lock, err := getInstalledPackagesLookup(cwd)
+		if err != nil {
+			return err
+		}
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if lock != nil {
+			// Lockfiles do not record package sizes.
+			return fmt.Errorf("analyze-size measures installed packages, but packages would be read from %s; install dependencies first", lock.Path)
+		}
+		var results []node.ModuleReport
+		if pnpManifest != nil {
+			results, err = node.AnalyzePnPModules(pnpManifest)
+		} else {
+			modules, _ := node.GetInstalledModules(cwd, []string{}, []string{}, nil, nil)
+			results, err = node.AnalyzeNodeModules(cwd, modules)
+		}
 		if err != nil {
 			log.Fatalf("analysis failed: %v", err)
 		}
 
+		if jsonFormat {
+			return writeNodeModulesJSON(os.Stdout, buildJSONAnalyzeSize(results, cwd))
+		}
 		node.PrintAnalysis(results)
 		return nil
 	},
@@ -940,6 +1152,13 @@ in the current directory and subdirectories. Sizes will be smaller than actual f
 	Example: "rev-dep node-modules dirs-size",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cwd := pathutil.ResolveAbsoluteCwd(nodeModulesCwd)
+		jsonFormat, err := isNodeModulesJSONFormat()
+		if err != nil {
+			return err
+		}
+		if jsonFormat {
+			return writeNodeModulesJSON(os.Stdout, buildJSONDirsSize(node.GetNodeModulesDirSizes(cwd), cwd))
+		}
 		result := node.ModulesDiskSizeCmd(cwd)
 
 		fmt.Println(result)
@@ -955,18 +1174,31 @@ var nodeModulesPruneDocsCmd = &cobra.Command{
 Useful for pruning README/LICENSE/docs files to reduce dependency size.`,
 	Example: `rev-dep node-modules prune-docs --defaults
 rev-dep node-modules prune-docs --patterns "*.md,README.md,docs/**"
-rev-dep node-modules prune-docs --defaults --patterns "*.txt"`,
+rev-dep node-modules prune-docs --defaults --patterns "*.txt"
+rev-dep node-modules prune-docs --defaults --dry-run
+rev-dep node-modules prune-docs --defaults --journal node_modules.journal.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		journal, err := openNodeModulesJournal(nodeModulesJournalPath, nodeModulesDryRun)
+		if err != nil {
+			return err
+		}
 		result, err := node.NodeModulesPruneDocsCmd(
 			pathutil.ResolveAbsoluteCwd(nodeModulesCwd),
 			nodeModulesPrunePatterns,
 			nodeModulesPruneDefaults,
+			nodeModulesDryRun,
+			journal,
 		)
 		if err != nil {
 			return err
 		}
 
 		fmt.Print(result)
+
+		if err := saveNodeModulesJournal(journal); err != nil {
+			return err
+		}
+		printNodeModulesJournalNote(journal)
 		return nil
 	},
 }
@@ -1432,6 +1664,29 @@ func addNodeModulesIncludeExcludeFlags(command *cobra.Command) {
 	command.Flags().StringSliceVarP(&nodeModulesExcludeModules, "exclude-modules", "e", []string{}, "list of modules to exclude from the output")
 }
 
+// getNodeModulesUsage runs the used, unused or missing analysis with the node-modules flags.
+func getNodeModulesUsage(cwd string, listUnused bool, listMissing bool, byEntryPoint bool, followValue model.FollowMonorepoPackagesValue, nearestPackage bool) node.NodeModulesUsage {
+	return node.GetNodeModulesUsage(
+		cwd,
+		nodeModulesIgnoreType,
+		nodeModulesEntryPoints,
+		listUnused,
+		listMissing,
+		byEntryPoint,
+		nodeModulesPkgJsonFieldsWithBinaries,
+		nodeModulesFilesWithBinaries,
+		nodeModulesFilesWithModules,
+		nodeModulesIncludeModules,
+		nodeModulesExcludeModules,
+		packageJsonPath,
+		tsconfigJsonPath,
+		conditionNames,
+		followValue,
+		nearestPackage,
+		getIncludeDevDepsFromRoot(),
+	)
+}
+
 func addNodeModulesFlags(command *cobra.Command, skipGroupingFlags bool) {
 	addSharedFlags(command)
 	command.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir,
@@ -1458,6 +1713,7 @@ func addNodeModulesFlags(command *cobra.Command, skipGroupingFlags bool) {
 		"Additional files to search for module imports. Use paths relative to cwd")
 	addNodeModulesIncludeExcludeFlags(command)
 	addNodeModulesResolutionFlag(command)
+	addNodeModulesFormatFlag(command)
 }
 
 func init() {
@@ -1537,8 +1793,22 @@ func init() {
 		"Show detailed information about each optimization")
 	nodeModulesInstalledDuplicatesCmd.Flags().BoolVar(&nodeModulesSizeStats, "size-stats", false, "Print node modules dirs size before and after optimization. Might take longer than optimization itself")
 	nodeModulesInstalledDuplicatesCmd.Flags().BoolVar(&nodeModulesOptimizeIsolate, "isolate", false, "Create symlinks only within the same top-level node_module directories. By default optimize creates symlinks between top-level node_module directories (eg. when workspaces are used). Needs --optimize flag to take effect")
+	addNodeModulesJournalFlags(nodeModulesInstalledDuplicatesCmd, "List the symlinks --optimize would create, with the size of the directories they replace, without changing node_modules")
 
 	nodeModulesAnalyzeSize.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir, "Working directory for the command")
+	addNodeModulesLookupFlag(nodeModulesInstalledCmd)
+	addNodeModulesLookupFlag(nodeModulesInstalledDuplicatesCmd)
+	addNodeModulesLookupFlag(nodeModulesAnalyzeSize)
+	addSharedFlags(nodeModulesWhyCmd)
+	nodeModulesWhyCmd.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir, "Working directory for the command")
+	nodeModulesWhyCmd.Flags().BoolVarP(&nodeModulesIgnoreType, "ignore-type-imports", "t", false,
+		"Exclude type imports when looking up the files using a dependency")
+	nodeModulesWhyCmd.Flags().IntVar(&nodeModulesWhyMaxChains, "max-chains", 50,
+		"Maximum number of dependency chains listed per installed copy (0 lists all of them)")
+	addNodeModulesLookupFlag(nodeModulesWhyCmd)
+	for _, command := range []*cobra.Command{nodeModulesInstalledCmd, nodeModulesInstalledDuplicatesCmd, nodeModulesWhyCmd, nodeModulesAnalyzeSize, nodeModuleDirsSize} {
+		addNodeModulesFormatFlag(command)
+	}
 	nodeModuleDirsSize.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir, "Working directory for the command")
 	nodeModulesPruneDocsCmd.Flags().StringVarP(&nodeModulesCwd, "cwd", "c", currentDir, "Working directory for the command")
 	nodeModulesPruneDocsCmd.Flags().StringSliceVarP(&nodeModulesPrunePatterns, "patterns", "p", []string{},
@@ -1547,9 +1817,10 @@ func init() {
 		"Alias for --patterns")
 	nodeModulesPruneDocsCmd.Flags().BoolVar(&nodeModulesPruneDefaults, "defaults", false,
 		"Use default prune patterns: LICENSE, README.md, docs/**")
+	addNodeModulesJournalFlags(nodeModulesPruneDocsCmd, "List the files that would be removed, with their sizes, without removing them")
 
 	// node modules commands
-	nodeModulesCmd.AddCommand(nodeModulesUsedCmd, nodeModulesUnusedCmd, nodeModulesMissingCmd, nodeModulesInstalledCmd, nodeModulesInstalledDuplicatesCmd, nodeModulesAnalyzeSize, nodeModuleDirsSize, nodeModulesPruneDocsCmd)
+	nodeModulesCmd.AddCommand(nodeModulesUsedCmd, nodeModulesUnusedCmd, nodeModulesMissingCmd, nodeModulesInstalledCmd, nodeModulesInstalledDuplicatesCmd, nodeModulesWhyCmd, nodeModulesVersionsCmd, nodeModulesAnalyzeSize, nodeModulesImportSizeCmd, nodeModuleDirsSize, nodeModulesPruneDocsCmd, nodeModulesRestoreCmd)
 
 	// list-files flags
 	listCwdFilesCmd.Flags().StringVar(&listFilesCwd, "cwd", currentDir,
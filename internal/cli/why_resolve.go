@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"rev-dep-go/internal/fs"
+	"rev-dep-go/internal/model"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/resolve"
+)
+
+// ---------------- why-resolve ----------------
+var (
+	whyResolveCwd        string
+	whyResolveFile       string
+	whyResolveRequest    string
+	whyResolveMainFields []string
+)
+
+var whyResolveCmd = &cobra.Command{
+	Use:   "why-resolve",
+	Short: "Explain how a single import request of a file is resolved",
+	Long: `Resolve one import request as if it was imported from the given file and print every step of the resolution:
+the resolver (package) chosen for the file, each strategy attempted in order with the candidate paths tried,
+and the final resolved type or resolution error.`,
+	Example: "rev-dep why-resolve --file src/index.ts --request @/utils/date",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		followValue, err := getFollowMonorepoPackagesValue(cmd)
+		if err != nil {
+			return err
+		}
+		nodeModulesStrategy, err := nodeModulesResolutionStrategy()
+		if err != nil {
+			return err
+		}
+		return whyResolveCmdFn(os.Stdout, pathutil.ResolveAbsoluteCwd(whyResolveCwd), whyResolveFile, whyResolveRequest, packageJsonPath, tsconfigJsonPath, conditionNames, whyResolveMainFields, followValue, nodeModulesStrategy)
+	},
+}
+
+func whyResolveCmdFn(w io.Writer, cwd, filePath, request, packageJson, tsconfigJson string, conditionNames, mainFields []string, followMonorepoPackages model.FollowMonorepoPackagesValue, nodeModulesStrategy model.NodeModulesMatchingStrategy) error {
+	request = strings.TrimSpace(request)
+	if request == "" {
+		return fmt.Errorf("--request cannot be empty")
+	}
+	absoluteFilePath := pathutil.JoinWithCwd(cwd, filePath)
+	if info, err := os.Stat(absoluteFilePath); err != nil || info.IsDir() {
+		return fmt.Errorf("file '%s' does not exist", filePath)
+	}
+
+	gitIgnoreExcludePatterns := fs.FindAndProcessGitIgnoreFilesUpToRepoRoot(cwd)
+	files := fs.GetFiles(cwd, []string{}, gitIgnoreExcludePatterns, nil)
+
+	resolverManager := resolve.NewResolverManagerForCwd(cwd, packageJson, tsconfigJson, conditionNames, mainFields, followMonorepoPackages, files, gitIgnoreExcludePatterns, nil)
+	trace := resolverManager.TraceResolution(absoluteFilePath, request, nodeModulesStrategy)
+
+	printResolutionTrace(w, trace, cwd)
+	return nil
+}
+
+func printResolutionTrace(w io.Writer, trace *resolve.ResolutionTrace, cwd string) {
+	rel := func(path string) string {
+		if !filepath.IsAbs(pathutil.DenormalizePathForOS(path)) {
+			return path
+		}
+		relPath, err := filepath.Rel(cwd, pathutil.DenormalizePathForOS(path))
+		if err != nil {
+			return path
+		}
+		return filepath.ToSlash(relPath)
+	}
+
+	cwdPrefix := pathutil.StandardiseDirPathInternal(pathutil.NormalizePathForInternal(cwd))
+
+	fmt.Fprintf(w, "File:     %s\n", rel(trace.FilePath))
+	fmt.Fprintf(w, "Request:  %s\n", trace.Request)
+	resolverLine := rel(trace.ResolverRoot)
+	if resolverLine == "" {
+		resolverLine = "."
+	}
+	if trace.PackageJsonPath != "" {
+		resolverLine += " (package.json: " + rel(trace.PackageJsonPath) + ")"
+	}
+	fmt.Fprintf(w, "Resolver: %s\n", resolverLine)
+	fmt.Fprintln(w)
+
+	for i, step := range trace.Steps {
+		line := fmt.Sprintf("%d. %s: %s", i+1, step.Strategy, step.Outcome)
+		if step.Detail != "" {
+			// Details embed absolute paths; show them relative to cwd like the rest of the output.
+			line += " - " + strings.ReplaceAll(step.Detail, cwdPrefix, "")
+		}
+		fmt.Fprintln(w, line)
+		for _, candidate := range step.Candidates {
+			fmt.Fprintf(w, "     %s\n", rel(candidate))
+		}
+	}
+	fmt.Fprintln(w)
+
+	result := model.ResolvedImportTypeToString(trace.ResolvedType)
+	if trace.Error != nil {
+		result += " (" + resolve.ResolutionErrorToString(*trace.Error) + ")"
+	}
+	if trace.ResolvedPath != "" {
+		result += " " + rel(trace.ResolvedPath)
+	}
+	fmt.Fprintf(w, "Result:   %s\n", result)
+}
+
+func init() {
+	addSharedFlags(whyResolveCmd)
+	whyResolveCmd.Flags().StringVarP(&whyResolveCwd, "cwd", "c", currentDir,
+		"Working directory for the command")
+	whyResolveCmd.Flags().StringVarP(&whyResolveFile, "file", "f", "",
+		"File the request is imported from (required)")
+	whyResolveCmd.Flags().StringVarP(&whyResolveRequest, "request", "r", "",
+		"Import request to resolve, as written in the import statement (required)")
+	whyResolveCmd.Flags().StringSliceVar(&whyResolveMainFields, "main-fields", []string{},
+		"Ordered package.json fields used to pick package entry points, mirroring config mainFields (e.g. browser,module,main)")
+	addNodeModulesResolutionFlag(whyResolveCmd)
+	whyResolveCmd.MarkFlagRequired("file")
+	whyResolveCmd.MarkFlagRequired("request")
+
+	rootCmd.AddCommand(whyResolveCmd)
+}
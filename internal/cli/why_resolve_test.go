@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/model"
+	"rev-dep-go/internal/testutil"
+)
+
+func TestWhyResolveCmd_TsAliasTrace(t *testing.T) {
+	testCwd, err := testutil.FixturePath("mockProject")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = whyResolveCmdFn(&out, testCwd, "src/importFileA.ts", "@/module/fileA", "", "", nil, nil, model.FollowMonorepoPackagesValue{}, model.NodeModulesMatchingStrategyCwdResolver)
+	if err != nil {
+		t.Fatalf("whyResolveCmdFn failed: %v", err)
+	}
+
+	expected := []string{
+		"File:     src/importFileA.ts",
+		"Request:  @/module/fileA",
+		"1. built-in module: no match",
+		"2. package.json imports: skipped - request does not start with #",
+		"3. workspace package: skipped - monorepo packages are not followed",
+		"4. tsconfig alias: matched - paths entry @/module/*",
+		"     moduleSrc/fileA",
+		"5. extension probing: resolved - moduleSrc/fileA.ts",
+		"Result:   UserModule moduleSrc/fileA.ts",
+	}
+	for _, line := range expected {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestWhyResolveCmd_NodeModuleAndBuiltIn(t *testing.T) {
+	testCwd, err := testutil.FixturePath("mockProject")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := whyResolveCmdFn(&out, testCwd, "src/nodeModules.ts", "node:fs", "", "", nil, nil, model.FollowMonorepoPackagesValue{}, model.NodeModulesMatchingStrategyCwdResolver); err != nil {
+		t.Fatalf("whyResolveCmdFn failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Result:   BuiltInModule node:fs") {
+		t.Errorf("expected built-in result, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := whyResolveCmdFn(&out, testCwd, "src/nodeModules.ts", "notExistingModule", "", "", nil, nil, model.FollowMonorepoPackagesValue{}, model.NodeModulesMatchingStrategyCwdResolver); err != nil {
+		t.Fatalf("whyResolveCmdFn failed: %v", err)
+	}
+	// baseUrl makes every bare request a "*" alias candidate before it falls through to node modules.
+	for _, line := range []string{
+		"4. tsconfig alias: matched - paths entry *",
+		"node module: no match - notExistingModule is not a dependency in package.json",
+		"Result:   UserModule (FileNotFound) notExistingModule",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestWhyResolveCmd_MissingFile(t *testing.T) {
+	testCwd, err := testutil.FixturePath("mockProject")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = whyResolveCmdFn(&out, testCwd, "src/doesNotExist.ts", "./x", "", "", nil, nil, model.FollowMonorepoPackagesValue{}, model.NodeModulesMatchingStrategyCwdResolver)
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected missing file error, got %v", err)
+	}
+}
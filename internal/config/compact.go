@@ -15,6 +15,17 @@ var detectorFieldNames = map[string]bool{
 	"restrictedImportsDetection":         true,
 	"restrictedImportersDetection":       true,
 	"restrictedDirectImportersDetection": true,
+	"workspaceProtocolDetection":         true,
+	"layersDetection":                    true,
+	"barrelFilesDetection":               true,
+	"typeImportsDetection":               true,
+	"unusedWorkspacePackagesDetection":   true,
+	"deepImportsDetection":               true,
+	"complexityBudgetsDetection":         true,
+	"ownershipBoundariesDetection":       true,
+	"testIsolationDetection":             true,
+	"peerDependenciesDetection":          true,
+	"versionConsistencyDetection":        true,
 }
 
 // CompactConfigText rewrites a rev-dep config document so detector declarations use their most
@@ -19,10 +19,18 @@ import (
 	"rev-dep-go/internal/resolve"
 )
 
+// CircularImportsOptions configures circular dependency detection. Cycles fail the check once
+// there are more than MaxCycles of them (0 by default); CycleBudgets set separate limits for the
+// cycles within an area (see rules.CycleBudget), so legacy code can be frozen at its current count.
+// SuggestCycleBreaks adds the imports to remove to break the reported cycles, which enumerates the
+// cycles of their components and is opt-in for that reason.
 type CircularImportsOptions struct {
-	Enabled           bool   `json:"enabled"`
-	IgnoreTypeImports bool   `json:"ignoreTypeImports,omitempty"`
-	Algorithm         string `json:"algorithm,omitempty"`
+	Enabled            bool          `json:"enabled"`
+	IgnoreTypeImports  bool          `json:"ignoreTypeImports,omitempty"`
+	Algorithm          string        `json:"algorithm,omitempty"`
+	MaxCycles          int           `json:"maxCycles,omitempty"`
+	CycleBudgets       []CycleBudget `json:"cycleBudgets,omitempty"`
+	SuggestCycleBreaks bool          `json:"suggestCycleBreaks,omitempty"`
 }
 
 func (o *CircularImportsOptions) IsEnabled() bool { return o != nil && o.Enabled }
@@ -45,6 +53,10 @@ type UnusedNodeModulesOptions struct {
 	FilesWithBinaries         []string `json:"filesWithBinaries,omitempty"`
 	FilesWithModules          []string `json:"filesWithModules,omitempty"`
 	OutputType                string   `json:"outputType,omitempty"` // "list", "groupByModule", "groupByFile"
+	Autofix                   bool     `json:"autofix,omitempty"`    // remove unused modules from package.json
+	// ReportUntypedImports also reports packages imported from TypeScript files that ship no types
+	// and have no `@types` package installed.
+	ReportUntypedImports bool `json:"reportUntypedImports,omitempty"`
 }
 
 func (o *UnusedNodeModulesOptions) IsEnabled() bool { return o != nil && o.Enabled }
@@ -54,6 +66,7 @@ type MissingNodeModulesOptions struct {
 	IncludeModules []string `json:"includeModules,omitempty"`
 	ExcludeModules []string `json:"excludeModules,omitempty"`
 	OutputType     string   `json:"outputType,omitempty"` // "list", "groupByModule", "groupByFile", "groupByModuleFilesCount"
+	Autofix        bool     `json:"autofix,omitempty"`    // add missing modules to package.json
 }
 
 func (o *MissingNodeModulesOptions) IsEnabled() bool { return o != nil && o.Enabled }
@@ -81,9 +94,10 @@ type UnresolvedImportsOptions struct {
 func (o *UnresolvedImportsOptions) IsEnabled() bool { return o != nil && o.Enabled }
 
 type RestrictedDevDependenciesUsageOptions struct {
-	Enabled           bool     `json:"enabled"`
-	ProdEntryPoints   []string `json:"prodEntryPoints,omitempty"`
-	IgnoreTypeImports bool     `json:"ignoreTypeImports,omitempty"`
+	Enabled                           bool     `json:"enabled"`
+	ProdEntryPoints                   []string `json:"prodEntryPoints,omitempty"`
+	IgnoreTypeImports                 bool     `json:"ignoreTypeImports,omitempty"`
+	ReportUnnecessaryProdDependencies bool     `json:"reportUnnecessaryProdDependencies,omitempty"`
 }
 
 func (o *RestrictedDevDependenciesUsageOptions) IsEnabled() bool { return o != nil && o.Enabled }
@@ -105,7 +119,23 @@ type Rule struct {
 	RestrictedImportsDetections         []*RestrictedImportsDetectionOptions         `json:"-"`
 	RestrictedImportersDetections       []*RestrictedImportersDetectionOptions       `json:"-"`
 	RestrictedDirectImportersDetections []*RestrictedDirectImportersDetectionOptions `json:"-"`
+	WorkspaceProtocolDetections         []*WorkspaceProtocolDetectionOptions         `json:"-"`
+	LayersDetections                    []*LayersDetectionOptions                    `json:"-"`
+	BarrelFilesDetections               []*BarrelFilesDetectionOptions               `json:"-"`
+	TypeImportsDetections               []*TypeImportsDetectionOptions               `json:"-"`
+	UnusedWorkspacePackagesDetections   []*UnusedWorkspacePackagesDetectionOptions   `json:"-"`
+	DeepImportsDetections               []*DeepImportsDetectionOptions               `json:"-"`
+	ComplexityBudgetsDetections         []*ComplexityBudgetsDetectionOptions         `json:"-"`
+	OwnershipBoundariesDetections       []*OwnershipBoundariesDetectionOptions       `json:"-"`
+	TestIsolationDetections             []*TestIsolationDetectionOptions             `json:"-"`
+	PeerDependenciesDetections          []*PeerDependenciesDetectionOptions          `json:"-"`
+	VersionConsistencyDetections        []*VersionConsistencyDetectionOptions        `json:"-"`
 	ImportConventions                   []ImportConventionRule                       `json:"-"`
+	// ConditionNames overrides the config-level conditionNames for this rule. The rule's files
+	// are resolved against a dependency tree built with these conditions, so rules targeting
+	// different environments (e.g. "node" vs "browser") see different conditional
+	// imports/exports. Empty inherits the config-level conditionNames.
+	ConditionNames []string `json:"conditionNames,omitempty"`
 }
 
 func (r *Rule) getCircularImportsDetections() []*CircularImportsOptions {
@@ -148,6 +178,50 @@ func (r *Rule) getRestrictedDirectImportersDetections() []*RestrictedDirectImpor
 	return r.RestrictedDirectImportersDetections
 }
 
+func (r *Rule) getWorkspaceProtocolDetections() []*WorkspaceProtocolDetectionOptions {
+	return r.WorkspaceProtocolDetections
+}
+
+func (r *Rule) getLayersDetections() []*LayersDetectionOptions {
+	return r.LayersDetections
+}
+
+func (r *Rule) getBarrelFilesDetections() []*BarrelFilesDetectionOptions {
+	return r.BarrelFilesDetections
+}
+
+func (r *Rule) getTypeImportsDetections() []*TypeImportsDetectionOptions {
+	return r.TypeImportsDetections
+}
+
+func (r *Rule) getUnusedWorkspacePackagesDetections() []*UnusedWorkspacePackagesDetectionOptions {
+	return r.UnusedWorkspacePackagesDetections
+}
+
+func (r *Rule) getDeepImportsDetections() []*DeepImportsDetectionOptions {
+	return r.DeepImportsDetections
+}
+
+func (r *Rule) getComplexityBudgetsDetections() []*ComplexityBudgetsDetectionOptions {
+	return r.ComplexityBudgetsDetections
+}
+
+func (r *Rule) getOwnershipBoundariesDetections() []*OwnershipBoundariesDetectionOptions {
+	return r.OwnershipBoundariesDetections
+}
+
+func (r *Rule) getTestIsolationDetections() []*TestIsolationDetectionOptions {
+	return r.TestIsolationDetections
+}
+
+func (r *Rule) getPeerDependenciesDetections() []*PeerDependenciesDetectionOptions {
+	return r.PeerDependenciesDetections
+}
+
+func (r *Rule) getVersionConsistencyDetections() []*VersionConsistencyDetectionOptions {
+	return r.VersionConsistencyDetections
+}
+
 // setDetectorEnabled sets the bool `Enabled` field of a detection options struct via reflection.
 // Every detection options type embeds an `Enabled bool` field; this lets the generic detection
 // parsing/marshaling helpers toggle it without a per-type interface.
@@ -247,6 +321,7 @@ func (r Rule) MarshalJSON() ([]byte, error) {
 		ProdEntryPoints                    []string               `json:"prodEntryPoints,omitempty"`
 		DevEntryPoints                     []string               `json:"devEntryPoints,omitempty"`
 		IgnoreEntryPoints                  []string               `json:"ignoreEntryPoints,omitempty"`
+		ConditionNames                     []string               `json:"conditionNames,omitempty"`
 		ModuleBoundaries                   []BoundaryRule         `json:"moduleBoundaries,omitempty"`
 		CircularImportsDetection           interface{}            `json:"circularImportsDetection,omitempty"`
 		OrphanFilesDetection               interface{}            `json:"orphanFilesDetection,omitempty"`
@@ -258,6 +333,17 @@ func (r Rule) MarshalJSON() ([]byte, error) {
 		RestrictedImportsDetection         interface{}            `json:"restrictedImportsDetection,omitempty"`
 		RestrictedImportersDetection       interface{}            `json:"restrictedImportersDetection,omitempty"`
 		RestrictedDirectImportersDetection interface{}            `json:"restrictedDirectImportersDetection,omitempty"`
+		WorkspaceProtocolDetection         interface{}            `json:"workspaceProtocolDetection,omitempty"`
+		LayersDetection                    interface{}            `json:"layersDetection,omitempty"`
+		BarrelFilesDetection               interface{}            `json:"barrelFilesDetection,omitempty"`
+		TypeImportsDetection               interface{}            `json:"typeImportsDetection,omitempty"`
+		UnusedWorkspacePackagesDetection   interface{}            `json:"unusedWorkspacePackagesDetection,omitempty"`
+		DeepImportsDetection               interface{}            `json:"deepImportsDetection,omitempty"`
+		ComplexityBudgetsDetection         interface{}            `json:"complexityBudgetsDetection,omitempty"`
+		OwnershipBoundariesDetection       interface{}            `json:"ownershipBoundariesDetection,omitempty"`
+		TestIsolationDetection             interface{}            `json:"testIsolationDetection,omitempty"`
+		PeerDependenciesDetection          interface{}            `json:"peerDependenciesDetection,omitempty"`
+		VersionConsistencyDetection        interface{}            `json:"versionConsistencyDetection,omitempty"`
 		ImportConventions                  []ImportConventionRule `json:"importConventions,omitempty"`
 	}
 
@@ -266,6 +352,7 @@ func (r Rule) MarshalJSON() ([]byte, error) {
 		ProdEntryPoints:                    r.ProdEntryPoints,
 		DevEntryPoints:                     r.DevEntryPoints,
 		IgnoreEntryPoints:                  r.IgnoreEntryPoints,
+		ConditionNames:                     r.ConditionNames,
 		ModuleBoundaries:                   r.ModuleBoundaries,
 		CircularImportsDetection:           marshalOneOrManyObjects(r.getCircularImportsDetections()),
 		OrphanFilesDetection:               marshalOneOrManyObjects(r.getOrphanFilesDetections()),
@@ -277,6 +364,17 @@ func (r Rule) MarshalJSON() ([]byte, error) {
 		RestrictedImportsDetection:         marshalOneOrManyObjects(r.getRestrictedImportsDetections()),
 		RestrictedImportersDetection:       marshalOneOrManyObjects(r.getRestrictedImportersDetections()),
 		RestrictedDirectImportersDetection: marshalOneOrManyObjects(r.getRestrictedDirectImportersDetections()),
+		WorkspaceProtocolDetection:         marshalOneOrManyObjects(r.getWorkspaceProtocolDetections()),
+		LayersDetection:                    marshalOneOrManyObjects(r.getLayersDetections()),
+		BarrelFilesDetection:               marshalOneOrManyObjects(r.getBarrelFilesDetections()),
+		TypeImportsDetection:               marshalOneOrManyObjects(r.getTypeImportsDetections()),
+		UnusedWorkspacePackagesDetection:   marshalOneOrManyObjects(r.getUnusedWorkspacePackagesDetections()),
+		DeepImportsDetection:               marshalOneOrManyObjects(r.getDeepImportsDetections()),
+		ComplexityBudgetsDetection:         marshalOneOrManyObjects(r.getComplexityBudgetsDetections()),
+		OwnershipBoundariesDetection:       marshalOneOrManyObjects(r.getOwnershipBoundariesDetections()),
+		TestIsolationDetection:             marshalOneOrManyObjects(r.getTestIsolationDetections()),
+		PeerDependenciesDetection:          marshalOneOrManyObjects(r.getPeerDependenciesDetections()),
+		VersionConsistencyDetection:        marshalOneOrManyObjects(r.getVersionConsistencyDetections()),
 		ImportConventions:                  r.ImportConventions,
 	}
 
@@ -289,6 +387,7 @@ func (r *Rule) UnmarshalJSON(data []byte) error {
 		ProdEntryPoints                    []string        `json:"prodEntryPoints,omitempty"`
 		DevEntryPoints                     []string        `json:"devEntryPoints,omitempty"`
 		IgnoreEntryPoints                  []string        `json:"ignoreEntryPoints,omitempty"`
+		ConditionNames                     []string        `json:"conditionNames,omitempty"`
 		ModuleBoundaries                   []BoundaryRule  `json:"moduleBoundaries,omitempty"`
 		CircularImportsDetection           json.RawMessage `json:"circularImportsDetection,omitempty"`
 		OrphanFilesDetection               json.RawMessage `json:"orphanFilesDetection,omitempty"`
@@ -300,6 +399,17 @@ func (r *Rule) UnmarshalJSON(data []byte) error {
 		RestrictedImportsDetection         json.RawMessage `json:"restrictedImportsDetection,omitempty"`
 		RestrictedImportersDetection       json.RawMessage `json:"restrictedImportersDetection,omitempty"`
 		RestrictedDirectImportersDetection json.RawMessage `json:"restrictedDirectImportersDetection,omitempty"`
+		WorkspaceProtocolDetection         json.RawMessage `json:"workspaceProtocolDetection,omitempty"`
+		LayersDetection                    json.RawMessage `json:"layersDetection,omitempty"`
+		BarrelFilesDetection               json.RawMessage `json:"barrelFilesDetection,omitempty"`
+		TypeImportsDetection               json.RawMessage `json:"typeImportsDetection,omitempty"`
+		UnusedWorkspacePackagesDetection   json.RawMessage `json:"unusedWorkspacePackagesDetection,omitempty"`
+		DeepImportsDetection               json.RawMessage `json:"deepImportsDetection,omitempty"`
+		ComplexityBudgetsDetection         json.RawMessage `json:"complexityBudgetsDetection,omitempty"`
+		OwnershipBoundariesDetection       json.RawMessage `json:"ownershipBoundariesDetection,omitempty"`
+		TestIsolationDetection             json.RawMessage `json:"testIsolationDetection,omitempty"`
+		PeerDependenciesDetection          json.RawMessage `json:"peerDependenciesDetection,omitempty"`
+		VersionConsistencyDetection        json.RawMessage `json:"versionConsistencyDetection,omitempty"`
 	}
 
 	var wire ruleWire
@@ -347,11 +457,56 @@ func (r *Rule) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
+	workspaceProtocol, err := parseOneOrManyObjects[WorkspaceProtocolDetectionOptions](wire.WorkspaceProtocolDetection)
+	if err != nil {
+		return err
+	}
+	layers, err := parseOneOrManyObjects[LayersDetectionOptions](wire.LayersDetection)
+	if err != nil {
+		return err
+	}
+	barrelFilesDetections, err := parseOneOrManyObjects[BarrelFilesDetectionOptions](wire.BarrelFilesDetection)
+	if err != nil {
+		return err
+	}
+	typeImportsDetections, err := parseOneOrManyObjects[TypeImportsDetectionOptions](wire.TypeImportsDetection)
+	if err != nil {
+		return err
+	}
+	unusedWorkspacePackagesDetections, err := parseOneOrManyObjects[UnusedWorkspacePackagesDetectionOptions](wire.UnusedWorkspacePackagesDetection)
+	if err != nil {
+		return err
+	}
+	deepImportsDetections, err := parseOneOrManyObjects[DeepImportsDetectionOptions](wire.DeepImportsDetection)
+	if err != nil {
+		return err
+	}
+	complexityBudgetsDetections, err := parseOneOrManyObjects[ComplexityBudgetsDetectionOptions](wire.ComplexityBudgetsDetection)
+	if err != nil {
+		return err
+	}
+	ownershipBoundariesDetections, err := parseOneOrManyObjects[OwnershipBoundariesDetectionOptions](wire.OwnershipBoundariesDetection)
+	if err != nil {
+		return err
+	}
+	testIsolationDetections, err := parseOneOrManyObjects[TestIsolationDetectionOptions](wire.TestIsolationDetection)
+	if err != nil {
+		return err
+	}
+	peerDependenciesDetections, err := parseOneOrManyObjects[PeerDependenciesDetectionOptions](wire.PeerDependenciesDetection)
+	if err != nil {
+		return err
+	}
+	versionConsistencyDetections, err := parseOneOrManyObjects[VersionConsistencyDetectionOptions](wire.VersionConsistencyDetection)
+	if err != nil {
+		return err
+	}
 
 	r.Path = wire.Path
 	r.ProdEntryPoints = wire.ProdEntryPoints
 	r.DevEntryPoints = wire.DevEntryPoints
 	r.IgnoreEntryPoints = wire.IgnoreEntryPoints
+	r.ConditionNames = wire.ConditionNames
 	r.ModuleBoundaries = wire.ModuleBoundaries
 
 	r.CircularImportsDetections = circular
@@ -364,14 +519,30 @@ func (r *Rule) UnmarshalJSON(data []byte) error {
 	r.RestrictedImportsDetections = restrictedImports
 	r.RestrictedImportersDetections = restrictedImporters
 	r.RestrictedDirectImportersDetections = restrictedDirectImporters
+	r.WorkspaceProtocolDetections = workspaceProtocol
+	r.LayersDetections = layers
+	r.BarrelFilesDetections = barrelFilesDetections
+	r.TypeImportsDetections = typeImportsDetections
+	r.UnusedWorkspacePackagesDetections = unusedWorkspacePackagesDetections
+	r.DeepImportsDetections = deepImportsDetections
+	r.ComplexityBudgetsDetections = complexityBudgetsDetections
+	r.OwnershipBoundariesDetections = ownershipBoundariesDetections
+	r.TestIsolationDetections = testIsolationDetections
+	r.PeerDependenciesDetections = peerDependenciesDetections
+	r.VersionConsistencyDetections = versionConsistencyDetections
 
 	return nil
 }
 
 type RevDepConfig struct {
-	ConfigVersion         string   `json:"configVersion"` // Required
-	Schema                string   `json:"$schema,omitempty"`
-	ConditionNames        []string `json:"conditionNames,omitempty"`
+	ConfigVersion  string   `json:"configVersion"` // Required
+	Schema         string   `json:"$schema,omitempty"`
+	ConditionNames []string `json:"conditionNames,omitempty"`
+	// MainFields opts into bundler-style package entry selection (e.g. ["browser", "module", "main"]).
+	// The fields are tried in order when a package has no matching exports, and the object form of
+	// "browser" / "react-native" (when listed) replaces or empties modules. Omitted keeps the default
+	// module -> main fallback.
+	MainFields            []string `json:"mainFields,omitempty"`
 	CustomAssetExtensions []string `json:"customAssetExtensions,omitempty"`
 	IgnoreFiles           []string `json:"ignoreFiles,omitempty"`
 	ProcessIgnoredFiles   []string `json:"processIgnoredFiles,omitempty"`
@@ -456,6 +627,15 @@ func (c *RevDepConfig) IncludeDevDepsFromRoot() bool {
 	return c.NodeModulesResolution != nil && c.NodeModulesResolution.IncludeDevDepsFromRoot
 }
 
+// ConditionNamesForRule returns the condition names the rule's files are resolved with: the
+// rule's own conditionNames when set, otherwise the config-level ones.
+func (c *RevDepConfig) ConditionNamesForRule(rule Rule) []string {
+	if len(rule.ConditionNames) > 0 {
+		return rule.ConditionNames
+	}
+	return c.ConditionNames
+}
+
 var configFileName = "rev-dep.config.json"
 var hiddenConfigFileName = ".rev-dep.config.json"
 var configFileNameJsonc = "rev-dep.config.jsonc"
@@ -471,11 +651,11 @@ func ConfigFileNameJSONC() string {
 
 // CurrentConfigVersion is the config schema version this CLI release treats as current — the one
 // `config init` writes into generated configs. Keep it as the last entry of supportedConfigVersions.
-const CurrentConfigVersion = "1.12"
+const CurrentConfigVersion = "1.13"
 
 // supportedConfigVersions lists config versions supported by this CLI release.
 // Update this slice when adding or removing support for config versions.
-var supportedConfigVersions = []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "1.10", "1.11", CurrentConfigVersion}
+var supportedConfigVersions = []string{"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "1.10", "1.11", "1.12", CurrentConfigVersion}
 
 // validateConfigVersion returns an error when the provided config version
 // is not in the supportedConfigVersions list.
@@ -661,11 +841,23 @@ func ParseConfig(content []byte) (RevDepConfig, error) {
 				}
 			}
 
+			for _, unusedWorkspacePackagesCfg := range config.Rules[i].getUnusedWorkspacePackagesDetections() {
+				if unusedWorkspacePackagesCfg.EntryPoints == nil {
+					unusedWorkspacePackagesCfg.EntryPoints = mergeAndDedupeEntryPoints(config.Rules[i].ProdEntryPoints, config.Rules[i].DevEntryPoints)
+				}
+			}
+
 			for _, devDepsCfg := range config.Rules[i].getDevDepsUsageOnProdDetections() {
 				if devDepsCfg.ProdEntryPoints == nil {
 					devDepsCfg.ProdEntryPoints = cloneStringSlice(config.Rules[i].ProdEntryPoints)
 				}
 			}
+
+			for _, testIsolationCfg := range config.Rules[i].getTestIsolationDetections() {
+				if testIsolationCfg.ProdEntryPoints == nil {
+					testIsolationCfg.ProdEntryPoints = cloneStringSlice(config.Rules[i].ProdEntryPoints)
+				}
+			}
 		}
 
 		// Process and normalize import conventions
@@ -764,6 +956,7 @@ func validateRawConfig(raw map[string]interface{}) error {
 		"$schema":               true,
 		"configVersion":         true,
 		"conditionNames":        true,
+		"mainFields":            true,
 		"customAssetExtensions": true,
 		"ignoreFiles":           true,
 		"processIgnoredFiles":   true,
@@ -797,6 +990,22 @@ func validateRawConfig(raw map[string]interface{}) error {
 		}
 	}
 
+	if mainFields, exists := raw["mainFields"]; exists && mainFields != nil {
+		mainFieldsArray, ok := mainFields.([]interface{})
+		if !ok {
+			return fmt.Errorf("mainFields must be an array, got %T", mainFields)
+		}
+		for i, field := range mainFieldsArray {
+			fieldName, ok := field.(string)
+			if !ok {
+				return fmt.Errorf("mainFields[%d] must be a string, got %T", i, field)
+			}
+			if strings.TrimSpace(fieldName) == "" {
+				return fmt.Errorf("mainFields[%d] cannot be empty", i)
+			}
+		}
+	}
+
 	if processIgnoredFiles, exists := raw["processIgnoredFiles"]; exists && processIgnoredFiles != nil {
 		processIgnoredFilesArray, ok := processIgnoredFiles.([]interface{})
 		if !ok {
@@ -883,6 +1092,7 @@ func validateRawRule(rule map[string]interface{}, index int) error {
 		"prodEntryPoints":                    true,
 		"devEntryPoints":                     true,
 		"ignoreEntryPoints":                  true,
+		"conditionNames":                     true,
 		"followMonorepoPackages":             true,
 		"moduleBoundaries":                   true,
 		"circularImportsDetection":           true,
@@ -895,6 +1105,17 @@ func validateRawRule(rule map[string]interface{}, index int) error {
 		"restrictedImportsDetection":         true,
 		"restrictedImportersDetection":       true,
 		"restrictedDirectImportersDetection": true,
+		"workspaceProtocolDetection":         true,
+		"layersDetection":                    true,
+		"barrelFilesDetection":               true,
+		"typeImportsDetection":               true,
+		"unusedWorkspacePackagesDetection":   true,
+		"deepImportsDetection":               true,
+		"complexityBudgetsDetection":         true,
+		"ownershipBoundariesDetection":       true,
+		"testIsolationDetection":             true,
+		"peerDependenciesDetection":          true,
+		"versionConsistencyDetection":        true,
 		"importConventions":                  true,
 		// "cloud" is accepted but intentionally not parsed, schema'd, or documented yet.
 		// It is allowed through validation so existing/forward configs do not error.
@@ -936,6 +1157,22 @@ func validateRawRule(rule map[string]interface{}, index int) error {
 		}
 	}
 
+	if conditionNames, exists := rule["conditionNames"]; exists && conditionNames != nil {
+		conditionNamesArray, ok := conditionNames.([]interface{})
+		if !ok {
+			return fmt.Errorf("rules[%d].conditionNames must be an array, got %T", index, conditionNames)
+		}
+		for i, condition := range conditionNamesArray {
+			conditionName, ok := condition.(string)
+			if !ok {
+				return fmt.Errorf("rules[%d].conditionNames[%d] must be a string, got %T", index, i, condition)
+			}
+			if strings.TrimSpace(conditionName) == "" {
+				return fmt.Errorf("rules[%d].conditionNames[%d] cannot be empty", index, i)
+			}
+		}
+	}
+
 	// Validate module boundaries if present
 	if boundaries, exists := rule["moduleBoundaries"]; exists {
 		if err := validateRawModuleBoundaries(boundaries, index); err != nil {
@@ -998,6 +1235,72 @@ func validateRawRule(rule map[string]interface{}, index int) error {
 		}
 	}
 
+	if workspaceProtocol, exists := rule["workspaceProtocolDetection"]; exists {
+		if err := validateRawWorkspaceProtocolDetection(workspaceProtocol, index); err != nil {
+			return err
+		}
+	}
+
+	if layers, exists := rule["layersDetection"]; exists {
+		if err := validateRawLayersDetection(layers, index); err != nil {
+			return err
+		}
+	}
+
+	if barrelFiles, exists := rule["barrelFilesDetection"]; exists {
+		if err := validateRawBarrelFilesDetection(barrelFiles, index); err != nil {
+			return err
+		}
+	}
+
+	if typeImports, exists := rule["typeImportsDetection"]; exists {
+		if err := validateRawTypeImportsDetection(typeImports, index); err != nil {
+			return err
+		}
+	}
+
+	if unusedWorkspacePackages, exists := rule["unusedWorkspacePackagesDetection"]; exists {
+		if err := validateRawUnusedWorkspacePackagesDetection(unusedWorkspacePackages, index); err != nil {
+			return err
+		}
+	}
+
+	if deepImports, exists := rule["deepImportsDetection"]; exists {
+		if err := validateRawDeepImportsDetection(deepImports, index); err != nil {
+			return err
+		}
+	}
+
+	if complexityBudgets, exists := rule["complexityBudgetsDetection"]; exists {
+		if err := validateRawComplexityBudgetsDetection(complexityBudgets, index); err != nil {
+			return err
+		}
+	}
+
+	if ownershipBoundaries, exists := rule["ownershipBoundariesDetection"]; exists {
+		if err := validateRawOwnershipBoundariesDetection(ownershipBoundaries, index); err != nil {
+			return err
+		}
+	}
+
+	if testIsolation, exists := rule["testIsolationDetection"]; exists {
+		if err := validateRawTestIsolationDetection(testIsolation, index); err != nil {
+			return err
+		}
+	}
+
+	if peerDependencies, exists := rule["peerDependenciesDetection"]; exists {
+		if err := validateRawPeerDependenciesDetection(peerDependencies, index); err != nil {
+			return err
+		}
+	}
+
+	if versionConsistency, exists := rule["versionConsistencyDetection"]; exists {
+		if err := validateRawVersionConsistencyDetection(versionConsistency, index); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1170,9 +1473,12 @@ func validateRawCircularImportsDetection(circular interface{}, ruleIndex int) er
 
 func validateRawCircularImportsDetectionInstance(circularMap map[string]interface{}, prefix string) error {
 	allowedFields := map[string]bool{
-		"enabled":           true,
-		"ignoreTypeImports": true,
-		"algorithm":         true,
+		"enabled":            true,
+		"ignoreTypeImports":  true,
+		"algorithm":          true,
+		"maxCycles":          true,
+		"cycleBudgets":       true,
+		"suggestCycleBreaks": true,
 	}
 
 	for field := range circularMap {
@@ -1191,12 +1497,54 @@ func validateRawCircularImportsDetectionInstance(circularMap map[string]interfac
 		}
 	}
 
+	if suggest, exists := circularMap["suggestCycleBreaks"]; exists && suggest != nil {
+		if _, ok := suggest.(bool); !ok {
+			return fmt.Errorf("%s.suggestCycleBreaks must be a boolean, got %T", prefix, suggest)
+		}
+	}
+
 	if algo, exists := circularMap["algorithm"]; exists && algo != nil {
 		if _, ok := algo.(string); !ok {
 			return fmt.Errorf("%s.algorithm must be a string, got %T", prefix, algo)
 		}
 	}
 
+	if err := validateRawNonNegativeInteger(circularMap, "maxCycles", prefix); err != nil {
+		return err
+	}
+
+	budgets, exists := circularMap["cycleBudgets"]
+	if !exists || budgets == nil {
+		return nil
+	}
+	budgetsArray, ok := budgets.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s.cycleBudgets must be an array, got %T", prefix, budgets)
+	}
+	for i, budget := range budgetsArray {
+		budgetPrefix := fmt.Sprintf("%s.cycleBudgets[%d]", prefix, i)
+		budgetMap, ok := budget.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object, got %T", budgetPrefix, budget)
+		}
+		for field := range budgetMap {
+			if field != "files" && field != "maxCycles" {
+				return fmt.Errorf("%s: unknown field '%s'", budgetPrefix, field)
+			}
+		}
+		if value, exists := budgetMap["files"]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.files must be an array, got %T", budgetPrefix, value)
+			}
+		}
+		if _, exists := budgetMap["maxCycles"]; !exists {
+			return fmt.Errorf("%s.maxCycles is required", budgetPrefix)
+		}
+		if err := validateRawNonNegativeInteger(budgetMap, "maxCycles", budgetPrefix); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1266,6 +1614,8 @@ func validateRawUnusedNodeModulesDetectionInstance(unusedMap map[string]interfac
 		"filesWithBinaries":         true,
 		"filesWithModules":          true,
 		"outputType":                true,
+		"autofix":                   true,
+		"reportUntypedImports":      true,
 	}
 
 	for field := range unusedMap {
@@ -1294,6 +1644,18 @@ func validateRawUnusedNodeModulesDetectionInstance(unusedMap map[string]interfac
 		}
 	}
 
+	if autofix, exists := unusedMap["autofix"]; exists && autofix != nil {
+		if _, ok := autofix.(bool); !ok {
+			return fmt.Errorf("%s.autofix must be a boolean, got %T", prefix, autofix)
+		}
+	}
+
+	if reportUntyped, exists := unusedMap["reportUntypedImports"]; exists && reportUntyped != nil {
+		if _, ok := reportUntyped.(bool); !ok {
+			return fmt.Errorf("%s.reportUntypedImports must be a boolean, got %T", prefix, reportUntyped)
+		}
+	}
+
 	return nil
 }
 
@@ -1308,6 +1670,7 @@ func validateRawMissingNodeModulesDetectionInstance(missingMap map[string]interf
 		"includeModules": true,
 		"excludeModules": true,
 		"outputType":     true,
+		"autofix":        true,
 	}
 
 	for field := range missingMap {
@@ -1336,6 +1699,12 @@ func validateRawMissingNodeModulesDetectionInstance(missingMap map[string]interf
 		}
 	}
 
+	if autofix, exists := missingMap["autofix"]; exists && autofix != nil {
+		if _, ok := autofix.(bool); !ok {
+			return fmt.Errorf("%s.autofix must be a boolean, got %T", prefix, autofix)
+		}
+	}
+
 	return nil
 }
 
@@ -1581,6 +1950,116 @@ func ValidateConfig(config *RevDepConfig) error {
 			}
 		}
 
+		for idx, detection := range rule.getWorkspaceProtocolDetections() {
+			prefix := fmt.Sprintf("rules[%d].workspaceProtocolDetection", j)
+			if len(rule.getWorkspaceProtocolDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateWorkspaceProtocolDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getLayersDetections() {
+			prefix := fmt.Sprintf("rules[%d].layersDetection", j)
+			if len(rule.getLayersDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateLayersDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getBarrelFilesDetections() {
+			prefix := fmt.Sprintf("rules[%d].barrelFilesDetection", j)
+			if len(rule.getBarrelFilesDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateBarrelFilesDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getTypeImportsDetections() {
+			prefix := fmt.Sprintf("rules[%d].typeImportsDetection", j)
+			if len(rule.getTypeImportsDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateTypeImportsDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getUnusedWorkspacePackagesDetections() {
+			prefix := fmt.Sprintf("rules[%d].unusedWorkspacePackagesDetection", j)
+			if len(rule.getUnusedWorkspacePackagesDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateUnusedWorkspacePackagesDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getDeepImportsDetections() {
+			prefix := fmt.Sprintf("rules[%d].deepImportsDetection", j)
+			if len(rule.getDeepImportsDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateDeepImportsDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getComplexityBudgetsDetections() {
+			prefix := fmt.Sprintf("rules[%d].complexityBudgetsDetection", j)
+			if len(rule.getComplexityBudgetsDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateComplexityBudgetsDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getOwnershipBoundariesDetections() {
+			prefix := fmt.Sprintf("rules[%d].ownershipBoundariesDetection", j)
+			if len(rule.getOwnershipBoundariesDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateOwnershipBoundariesDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getTestIsolationDetections() {
+			prefix := fmt.Sprintf("rules[%d].testIsolationDetection", j)
+			if len(rule.getTestIsolationDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateTestIsolationDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getPeerDependenciesDetections() {
+			prefix := fmt.Sprintf("rules[%d].peerDependenciesDetection", j)
+			if len(rule.getPeerDependenciesDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validatePeerDependenciesDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
+		for idx, detection := range rule.getVersionConsistencyDetections() {
+			prefix := fmt.Sprintf("rules[%d].versionConsistencyDetection", j)
+			if len(rule.getVersionConsistencyDetections()) > 1 {
+				prefix = fmt.Sprintf("%s[%d]", prefix, idx)
+			}
+			if err := validateVersionConsistencyDetectionOptions(detection, prefix); err != nil {
+				return err
+			}
+		}
+
 		// Validate import conventions
 		if len(rule.ImportConventions) > 0 {
 			// Additional validation can be added here if needed
@@ -1651,6 +2130,23 @@ func validateCircularImportsOptions(opts *CircularImportsOptions, prefix string)
 			return fmt.Errorf("%s.algorithm: must be one of 'DFS', 'SCC', got '%s'", prefix, opts.Algorithm)
 		}
 	}
+	if opts.MaxCycles < 0 {
+		return fmt.Errorf("%s.maxCycles: must be a non-negative integer, got %d", prefix, opts.MaxCycles)
+	}
+	for i, budget := range opts.CycleBudgets {
+		budgetPrefix := fmt.Sprintf("%s.cycleBudgets[%d]", prefix, i)
+		if len(budget.Files) == 0 {
+			return fmt.Errorf("%s.files: at least one pattern is required", budgetPrefix)
+		}
+		for j, pattern := range budget.Files {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.files[%d]: cannot be empty", budgetPrefix, j)
+			}
+		}
+		if budget.MaxCycles < 0 {
+			return fmt.Errorf("%s.maxCycles: must be a non-negative integer, got %d", budgetPrefix, budget.MaxCycles)
+		}
+	}
 	return nil
 }
 
@@ -1837,9 +2333,10 @@ func validateRawRestrictedDevDependenciesUsageDetection(restrictedDevDeps interf
 
 func validateRawRestrictedDevDependenciesUsageDetectionInstance(restrictedDevDepsMap map[string]interface{}, prefix string) error {
 	allowedFields := map[string]bool{
-		"enabled":           true,
-		"prodEntryPoints":   true,
-		"ignoreTypeImports": true,
+		"enabled":                           true,
+		"prodEntryPoints":                   true,
+		"ignoreTypeImports":                 true,
+		"reportUnnecessaryProdDependencies": true,
 	}
 
 	for field := range restrictedDevDepsMap {
@@ -1864,6 +2361,12 @@ func validateRawRestrictedDevDependenciesUsageDetectionInstance(restrictedDevDep
 		}
 	}
 
+	if reportUnnecessary, exists := restrictedDevDepsMap["reportUnnecessaryProdDependencies"]; exists && reportUnnecessary != nil {
+		if _, ok := reportUnnecessary.(bool); !ok {
+			return fmt.Errorf("%s.reportUnnecessaryProdDependencies must be a boolean, got %T", prefix, reportUnnecessary)
+		}
+	}
+
 	return nil
 }
 
@@ -2164,6 +2667,827 @@ func validateRestrictedDirectImportersDetectionOptions(opts *RestrictedDirectImp
 	return nil
 }
 
+func validateRawWorkspaceProtocolDetection(workspaceProtocol interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(workspaceProtocol, ruleIndex, "workspaceProtocolDetection", validateRawWorkspaceProtocolDetectionInstance)
+}
+
+func validateRawWorkspaceProtocolDetectionInstance(workspaceProtocolMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":            true,
+		"allowVersionRanges": true,
+		"ignoreDependencies": true,
+	}
+
+	for field := range workspaceProtocolMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(workspaceProtocolMap, prefix); err != nil {
+		return err
+	}
+
+	if allowVersionRanges, exists := workspaceProtocolMap["allowVersionRanges"]; exists && allowVersionRanges != nil {
+		if _, ok := allowVersionRanges.(bool); !ok {
+			return fmt.Errorf("%s.allowVersionRanges must be a boolean, got %T", prefix, allowVersionRanges)
+		}
+	}
+
+	if ignoreDependencies, exists := workspaceProtocolMap["ignoreDependencies"]; exists && ignoreDependencies != nil {
+		if _, ok := ignoreDependencies.([]interface{}); !ok {
+			return fmt.Errorf("%s.ignoreDependencies must be an array, got %T", prefix, ignoreDependencies)
+		}
+	}
+
+	return nil
+}
+
+func validateWorkspaceProtocolDetectionOptions(opts *WorkspaceProtocolDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	// ignoreDependencies are matched as dependency-name globs (like restrictedImports' denyModules).
+	for i, pattern := range opts.IgnoreDependencies {
+		trimmed := strings.TrimSpace(pattern)
+		if trimmed == "" {
+			return fmt.Errorf("%s.ignoreDependencies[%d]: cannot be empty", prefix, i)
+		}
+		if _, err := glob.Compile(trimmed); err != nil {
+			return fmt.Errorf("%s.ignoreDependencies[%d]: invalid glob pattern '%s': %v", prefix, i, trimmed, err)
+		}
+	}
+
+	return nil
+}
+
+func validateRawLayersDetection(layers interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(layers, ruleIndex, "layersDetection", validateRawLayersDetectionInstance)
+}
+
+func validateRawLayersDetectionInstance(layersMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":        true,
+		"layers":         true,
+		"allowSameLayer": true,
+		"strict":         true,
+	}
+
+	for field := range layersMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(layersMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"allowSameLayer", "strict"} {
+		if value, exists := layersMap[field]; exists && value != nil {
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("%s.%s must be a boolean, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	layers, exists := layersMap["layers"]
+	if !exists || layers == nil {
+		return nil
+	}
+	layersArray, ok := layers.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s.layers must be an array, got %T", prefix, layers)
+	}
+
+	allowedLayerFields := map[string]bool{
+		"name":         true,
+		"patterns":     true,
+		"allowImports": true,
+	}
+	for i, layer := range layersArray {
+		layerPrefix := fmt.Sprintf("%s.layers[%d]", prefix, i)
+		layerMap, ok := layer.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object, got %T", layerPrefix, layer)
+		}
+		for field := range layerMap {
+			if !allowedLayerFields[field] {
+				return fmt.Errorf("%s: unknown field '%s'", layerPrefix, field)
+			}
+		}
+		if name, exists := layerMap["name"]; exists {
+			if _, ok := name.(string); !ok {
+				return fmt.Errorf("%s.name must be a string, got %T", layerPrefix, name)
+			}
+		}
+		for _, field := range []string{"patterns", "allowImports"} {
+			if value, exists := layerMap[field]; exists && value != nil {
+				if _, ok := value.([]interface{}); !ok {
+					return fmt.Errorf("%s.%s must be an array, got %T", layerPrefix, field, value)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateLayersDetectionOptions(opts *LayersDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	if len(opts.Layers) == 0 {
+		return fmt.Errorf("%s.layers: at least one layer is required", prefix)
+	}
+
+	seenNames := make(map[string]bool, len(opts.Layers))
+	for i, layer := range opts.Layers {
+		layerPrefix := fmt.Sprintf("%s.layers[%d]", prefix, i)
+		name := strings.TrimSpace(layer.Name)
+		if name == "" {
+			return fmt.Errorf("%s.name: cannot be empty", layerPrefix)
+		}
+		if seenNames[name] {
+			return fmt.Errorf("%s.name: duplicate layer name '%s'", layerPrefix, name)
+		}
+		seenNames[name] = true
+
+		if len(layer.Patterns) == 0 {
+			return fmt.Errorf("%s.patterns: at least one pattern is required", layerPrefix)
+		}
+		for k, pattern := range layer.Patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.patterns[%d]: cannot be empty", layerPrefix, k)
+			}
+		}
+		for k, pattern := range layer.AllowImports {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.allowImports[%d]: cannot be empty", layerPrefix, k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawBarrelFilesDetection(barrelFiles interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(barrelFiles, ruleIndex, "barrelFilesDetection", validateRawBarrelFilesDetectionInstance)
+}
+
+func validateRawBarrelFilesDetectionInstance(barrelFilesMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":                      true,
+		"reportBarrelFiles":            true,
+		"maxFanOut":                    true,
+		"publicBarrels":                true,
+		"noBarrelImportsWithinFeature": true,
+		"ignoreFiles":                  true,
+		"autofix":                      true,
+	}
+
+	for field := range barrelFilesMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(barrelFilesMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"reportBarrelFiles", "noBarrelImportsWithinFeature", "autofix"} {
+		if value, exists := barrelFilesMap[field]; exists && value != nil {
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("%s.%s must be a boolean, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	if maxFanOut, exists := barrelFilesMap["maxFanOut"]; exists && maxFanOut != nil {
+		number, ok := maxFanOut.(float64)
+		if !ok || number != float64(int(number)) || number < 0 {
+			return fmt.Errorf("%s.maxFanOut must be a non-negative integer, got %v", prefix, maxFanOut)
+		}
+	}
+
+	for _, field := range []string{"publicBarrels", "ignoreFiles"} {
+		if value, exists := barrelFilesMap[field]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.%s must be an array, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateBarrelFilesDetectionOptions(opts *BarrelFilesDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	if !opts.ReportBarrelFiles && len(opts.PublicBarrels) == 0 && !opts.NoBarrelImportsWithinFeature {
+		return fmt.Errorf("%s: at least one of reportBarrelFiles, publicBarrels or noBarrelImportsWithinFeature is required", prefix)
+	}
+
+	for i, pattern := range opts.PublicBarrels {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("%s.publicBarrels[%d]: cannot be empty", prefix, i)
+		}
+	}
+	for i, pattern := range opts.IgnoreFiles {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("%s.ignoreFiles[%d]: cannot be empty", prefix, i)
+		}
+	}
+
+	return nil
+}
+
+func validateRawTypeImportsDetection(typeImports interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(typeImports, ruleIndex, "typeImportsDetection", validateRawTypeImportsDetectionInstance)
+}
+
+func validateRawTypeImportsDetectionInstance(typeImportsMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":      true,
+		"ignoreFiles":  true,
+		"autofix":      true,
+		"preferInline": true,
+	}
+
+	for field := range typeImportsMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(typeImportsMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"autofix", "preferInline"} {
+		if value, exists := typeImportsMap[field]; exists && value != nil {
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("%s.%s must be a boolean, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	if ignoreFiles, exists := typeImportsMap["ignoreFiles"]; exists && ignoreFiles != nil {
+		if _, ok := ignoreFiles.([]interface{}); !ok {
+			return fmt.Errorf("%s.ignoreFiles must be an array, got %T", prefix, ignoreFiles)
+		}
+	}
+
+	return nil
+}
+
+func validateTypeImportsDetectionOptions(opts *TypeImportsDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	for i, pattern := range opts.IgnoreFiles {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("%s.ignoreFiles[%d]: cannot be empty", prefix, i)
+		}
+	}
+
+	return nil
+}
+
+func validateRawUnusedWorkspacePackagesDetection(unusedWorkspacePackages interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(unusedWorkspacePackages, ruleIndex, "unusedWorkspacePackagesDetection", validateRawUnusedWorkspacePackagesDetectionInstance)
+}
+
+func validateRawUnusedWorkspacePackagesDetectionInstance(unusedWorkspacePackagesMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":        true,
+		"rootPackages":   true,
+		"entryPoints":    true,
+		"ignorePackages": true,
+	}
+
+	for field := range unusedWorkspacePackagesMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(unusedWorkspacePackagesMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"rootPackages", "entryPoints", "ignorePackages"} {
+		if value, exists := unusedWorkspacePackagesMap[field]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.%s must be an array, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateUnusedWorkspacePackagesDetectionOptions(opts *UnusedWorkspacePackagesDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	for field, patterns := range map[string][]string{
+		"rootPackages":   opts.RootPackages,
+		"entryPoints":    opts.EntryPoints,
+		"ignorePackages": opts.IgnorePackages,
+	} {
+		for i, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.%s[%d]: cannot be empty", prefix, field, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawDeepImportsDetection(deepImports interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(deepImports, ruleIndex, "deepImportsDetection", validateRawDeepImportsDetectionInstance)
+}
+
+func validateRawDeepImportsDetectionInstance(deepImportsMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":        true,
+		"ignorePackages": true,
+		"ignoreFiles":    true,
+	}
+
+	for field := range deepImportsMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(deepImportsMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"ignorePackages", "ignoreFiles"} {
+		if value, exists := deepImportsMap[field]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.%s must be an array, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateDeepImportsDetectionOptions(opts *DeepImportsDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	for field, patterns := range map[string][]string{
+		"ignorePackages": opts.IgnorePackages,
+		"ignoreFiles":    opts.IgnoreFiles,
+	} {
+		for i, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.%s[%d]: cannot be empty", prefix, field, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawComplexityBudgetsDetection(complexityBudgets interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(complexityBudgets, ruleIndex, "complexityBudgetsDetection", validateRawComplexityBudgetsDetectionInstance)
+}
+
+func validateRawComplexityBudgetsDetectionInstance(complexityBudgetsMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":           true,
+		"budgets":           true,
+		"entryPoints":       true,
+		"ignoreFiles":       true,
+		"ignoreTypeImports": true,
+		"topContributors":   true,
+	}
+
+	for field := range complexityBudgetsMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(complexityBudgetsMap, prefix); err != nil {
+		return err
+	}
+
+	if value, exists := complexityBudgetsMap["ignoreTypeImports"]; exists && value != nil {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s.ignoreTypeImports must be a boolean, got %T", prefix, value)
+		}
+	}
+
+	for _, field := range []string{"entryPoints", "ignoreFiles"} {
+		if value, exists := complexityBudgetsMap[field]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.%s must be an array, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	if err := validateRawNonNegativeInteger(complexityBudgetsMap, "topContributors", prefix); err != nil {
+		return err
+	}
+
+	budgets, exists := complexityBudgetsMap["budgets"]
+	if !exists || budgets == nil {
+		return nil
+	}
+	budgetsArray, ok := budgets.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s.budgets must be an array, got %T", prefix, budgets)
+	}
+
+	thresholdFields := []string{"maxTransitiveDependencies", "maxImportChainDepth", "maxDirectImports", "maxImporters"}
+	for i, budget := range budgetsArray {
+		budgetPrefix := fmt.Sprintf("%s.budgets[%d]", prefix, i)
+		budgetMap, ok := budget.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object, got %T", budgetPrefix, budget)
+		}
+		for field := range budgetMap {
+			if field != "files" && !slices.Contains(thresholdFields, field) {
+				return fmt.Errorf("%s: unknown field '%s'", budgetPrefix, field)
+			}
+		}
+		if value, exists := budgetMap["files"]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.files must be an array, got %T", budgetPrefix, value)
+			}
+		}
+		for _, field := range thresholdFields {
+			if err := validateRawNonNegativeInteger(budgetMap, field, budgetPrefix); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRawNonNegativeInteger checks that an optional numeric field holds a non-negative integer.
+func validateRawNonNegativeInteger(fields map[string]interface{}, field, prefix string) error {
+	value, exists := fields[field]
+	if !exists || value == nil {
+		return nil
+	}
+	number, ok := value.(float64)
+	if !ok || number != float64(int(number)) || number < 0 {
+		return fmt.Errorf("%s.%s must be a non-negative integer, got %v", prefix, field, value)
+	}
+	return nil
+}
+
+func validateComplexityBudgetsDetectionOptions(opts *ComplexityBudgetsDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	if len(opts.Budgets) == 0 {
+		return fmt.Errorf("%s.budgets: at least one budget is required", prefix)
+	}
+
+	for i, budget := range opts.Budgets {
+		budgetPrefix := fmt.Sprintf("%s.budgets[%d]", prefix, i)
+		if len(budget.Files) == 0 {
+			return fmt.Errorf("%s.files: at least one pattern is required", budgetPrefix)
+		}
+		for j, pattern := range budget.Files {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.files[%d]: cannot be empty", budgetPrefix, j)
+			}
+		}
+		if budget.MaxTransitiveDependencies == 0 && budget.MaxImportChainDepth == 0 && budget.MaxDirectImports == 0 && budget.MaxImporters == 0 {
+			return fmt.Errorf("%s: at least one of maxTransitiveDependencies, maxImportChainDepth, maxDirectImports or maxImporters is required", budgetPrefix)
+		}
+	}
+
+	for field, patterns := range map[string][]string{
+		"entryPoints": opts.EntryPoints,
+		"ignoreFiles": opts.IgnoreFiles,
+	} {
+		for i, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.%s[%d]: cannot be empty", prefix, field, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawOwnershipBoundariesDetection(ownershipBoundaries interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(ownershipBoundaries, ruleIndex, "ownershipBoundariesDetection", validateRawOwnershipBoundariesDetectionInstance)
+}
+
+func validateRawOwnershipBoundariesDetectionInstance(ownershipBoundariesMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":             true,
+		"codeownersPath":      true,
+		"allowedDependencies": true,
+		"reportOnly":          true,
+		"ignoreTypeImports":   true,
+	}
+
+	for field := range ownershipBoundariesMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(ownershipBoundariesMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"reportOnly", "ignoreTypeImports"} {
+		if value, exists := ownershipBoundariesMap[field]; exists && value != nil {
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("%s.%s must be a boolean, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	if value, exists := ownershipBoundariesMap["codeownersPath"]; exists && value != nil {
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s.codeownersPath must be a string, got %T", prefix, value)
+		}
+	}
+
+	allowed, exists := ownershipBoundariesMap["allowedDependencies"]
+	if !exists || allowed == nil {
+		return nil
+	}
+	allowedArray, ok := allowed.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s.allowedDependencies must be an array, got %T", prefix, allowed)
+	}
+
+	for i, dependency := range allowedArray {
+		dependencyPrefix := fmt.Sprintf("%s.allowedDependencies[%d]", prefix, i)
+		dependencyMap, ok := dependency.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object, got %T", dependencyPrefix, dependency)
+		}
+		for field := range dependencyMap {
+			if field != "from" && field != "to" {
+				return fmt.Errorf("%s: unknown field '%s'", dependencyPrefix, field)
+			}
+		}
+		from, exists := dependencyMap["from"]
+		if !exists || from == nil {
+			return fmt.Errorf("%s.from is required", dependencyPrefix)
+		}
+		if _, ok := from.(string); !ok {
+			return fmt.Errorf("%s.from must be a string, got %T", dependencyPrefix, from)
+		}
+		to, exists := dependencyMap["to"]
+		if !exists || to == nil {
+			return fmt.Errorf("%s.to is required", dependencyPrefix)
+		}
+		if _, ok := to.([]interface{}); !ok {
+			return fmt.Errorf("%s.to must be an array, got %T", dependencyPrefix, to)
+		}
+	}
+
+	return nil
+}
+
+func validateOwnershipBoundariesDetectionOptions(opts *OwnershipBoundariesDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	if opts.CodeownersPath != "" && strings.TrimSpace(opts.CodeownersPath) == "" {
+		return fmt.Errorf("%s.codeownersPath: cannot be empty", prefix)
+	}
+
+	for i, dependency := range opts.AllowedDependencies {
+		dependencyPrefix := fmt.Sprintf("%s.allowedDependencies[%d]", prefix, i)
+		if strings.TrimSpace(dependency.From) == "" {
+			return fmt.Errorf("%s.from: cannot be empty", dependencyPrefix)
+		}
+		if len(dependency.To) == 0 {
+			return fmt.Errorf("%s.to: at least one team is required", dependencyPrefix)
+		}
+		for j, team := range dependency.To {
+			if strings.TrimSpace(team) == "" {
+				return fmt.Errorf("%s.to[%d]: cannot be empty", dependencyPrefix, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawTestIsolationDetection(testIsolation interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(testIsolation, ruleIndex, "testIsolationDetection", validateRawTestIsolationDetectionInstance)
+}
+
+func validateRawTestIsolationDetectionInstance(testIsolationMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":           true,
+		"testFiles":         true,
+		"fixtureFiles":      true,
+		"prodEntryPoints":   true,
+		"ignoreTypeImports": true,
+	}
+
+	for field := range testIsolationMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(testIsolationMap, prefix); err != nil {
+		return err
+	}
+
+	if value, exists := testIsolationMap["ignoreTypeImports"]; exists && value != nil {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s.ignoreTypeImports must be a boolean, got %T", prefix, value)
+		}
+	}
+
+	for _, field := range []string{"testFiles", "fixtureFiles", "prodEntryPoints"} {
+		if value, exists := testIsolationMap[field]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.%s must be an array, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateTestIsolationDetectionOptions(opts *TestIsolationDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	for _, field := range []struct {
+		name     string
+		patterns []string
+	}{
+		{"testFiles", opts.TestFiles},
+		{"fixtureFiles", opts.FixtureFiles},
+		{"prodEntryPoints", opts.ProdEntryPoints},
+	} {
+		for i, pattern := range field.patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.%s[%d]: cannot be empty", prefix, field.name, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawPeerDependenciesDetection(peerDependencies interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(peerDependencies, ruleIndex, "peerDependenciesDetection", validateRawPeerDependenciesDetectionInstance)
+}
+
+func validateRawPeerDependenciesDetectionInstance(peerDependenciesMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":        true,
+		"peerPackages":   true,
+		"ignorePackages": true,
+	}
+
+	for field := range peerDependenciesMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(peerDependenciesMap, prefix); err != nil {
+		return err
+	}
+
+	for _, field := range []string{"peerPackages", "ignorePackages"} {
+		if value, exists := peerDependenciesMap[field]; exists && value != nil {
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("%s.%s must be an array, got %T", prefix, field, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validatePeerDependenciesDetectionOptions(opts *PeerDependenciesDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	for field, patterns := range map[string][]string{
+		"peerPackages":   opts.PeerPackages,
+		"ignorePackages": opts.IgnorePackages,
+	} {
+		for i, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%s.%s[%d]: cannot be empty", prefix, field, i)
+			}
+			if _, err := glob.Compile(strings.TrimSpace(pattern)); err != nil {
+				return fmt.Errorf("%s.%s[%d] has invalid glob pattern '%s': %v", prefix, field, i, pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRawVersionConsistencyDetection(versionConsistency interface{}, ruleIndex int) error {
+	return validateRawDetectionObjectOrArray(versionConsistency, ruleIndex, "versionConsistencyDetection", validateRawVersionConsistencyDetectionInstance)
+}
+
+func validateRawVersionConsistencyDetectionInstance(versionConsistencyMap map[string]interface{}, prefix string) error {
+	allowedFields := map[string]bool{
+		"enabled":        true,
+		"ignorePackages": true,
+		"pinnedVersions": true,
+		"autofix":        true,
+	}
+
+	for field := range versionConsistencyMap {
+		if !allowedFields[field] {
+			return fmt.Errorf("%s: unknown field '%s'", prefix, field)
+		}
+	}
+
+	if err := validateRawEnabledField(versionConsistencyMap, prefix); err != nil {
+		return err
+	}
+
+	if value, exists := versionConsistencyMap["ignorePackages"]; exists && value != nil {
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("%s.ignorePackages must be an array, got %T", prefix, value)
+		}
+	}
+
+	if value, exists := versionConsistencyMap["pinnedVersions"]; exists && value != nil {
+		pinnedVersions, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s.pinnedVersions must be an object, got %T", prefix, value)
+		}
+		for dependency, versionRange := range pinnedVersions {
+			if _, ok := versionRange.(string); !ok {
+				return fmt.Errorf("%s.pinnedVersions.%s must be a string, got %T", prefix, dependency, versionRange)
+			}
+		}
+	}
+
+	if autofix, exists := versionConsistencyMap["autofix"]; exists && autofix != nil {
+		if _, ok := autofix.(bool); !ok {
+			return fmt.Errorf("%s.autofix must be a boolean, got %T", prefix, autofix)
+		}
+	}
+
+	return nil
+}
+
+func validateVersionConsistencyDetectionOptions(opts *VersionConsistencyDetectionOptions, prefix string) error {
+	if !opts.Enabled {
+		return nil
+	}
+
+	for i, pattern := range opts.IgnorePackages {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("%s.ignorePackages[%d]: cannot be empty", prefix, i)
+		}
+		if _, err := glob.Compile(strings.TrimSpace(pattern)); err != nil {
+			return fmt.Errorf("%s.ignorePackages[%d] has invalid glob pattern '%s': %v", prefix, i, pattern, err)
+		}
+	}
+
+	for dependency, versionRange := range opts.PinnedVersions {
+		if strings.TrimSpace(dependency) == "" {
+			return fmt.Errorf("%s.pinnedVersions: dependency name cannot be empty", prefix)
+		}
+		if strings.TrimSpace(versionRange) == "" {
+			return fmt.Errorf("%s.pinnedVersions.%s: version range cannot be empty", prefix, dependency)
+		}
+	}
+
+	return nil
+}
+
 // validateRawImportConventions validates import conventions structure
 func validateRawImportConventions(conventions interface{}, ruleIndex int) error {
 	conventionsArray, ok := conventions.([]interface{})
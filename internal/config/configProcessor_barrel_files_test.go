@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// End-to-end: a file importing through its own feature barrel is reported and, with --fix, rewritten
+// to the file declaring the imported name; a deep import from outside the feature is reported as a
+// bypass of the public barrel.
+func TestConfigProcessor_BarrelFiles(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"barrel-fixture"}`)
+	mustWrite("src/features/auth/index.ts", "export { login } from './login';\nexport * from './internal/session';\n")
+	mustWrite("src/features/auth/login.ts", "export const login = () => 'login';\n")
+	mustWrite("src/features/auth/internal/session.ts", "export const session = () => 'session';\n")
+	mustWrite("src/features/auth/logout.ts", "import { session } from './index';\nexport const logout = session;\n")
+	mustWrite("src/app.ts", "import { login } from './features/auth';\nimport { session } from './features/auth/internal/session';\nexport const app = [login, session];\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"barrelFilesDetection": {
+				"publicBarrels": ["src/features/*/index.ts"],
+				"noBarrelImportsWithinFeature": true,
+				"autofix": true
+			}
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, true)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "barrel-files") {
+		t.Errorf("expected 'barrel-files' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+	if len(ruleResult.BarrelFileViolations) != 2 {
+		t.Fatalf("expected 2 barrel file violations, got %+v", ruleResult.BarrelFileViolations)
+	}
+	bypass := ruleResult.BarrelFileViolations[0]
+	if bypass.ViolationType != "barrel-bypass" || !containsPathWithSuffix([]string{bypass.FilePath}, "src/app.ts") || !containsPathWithSuffix([]string{bypass.BarrelPath}, "src/features/auth/index.ts") {
+		t.Errorf("expected src/app.ts to bypass the auth barrel, got %+v", bypass)
+	}
+	withinFeature := ruleResult.BarrelFileViolations[1]
+	if withinFeature.ViolationType != "barrel-within-feature" || !containsPathWithSuffix([]string{withinFeature.FilePath}, "src/features/auth/logout.ts") {
+		t.Errorf("expected src/features/auth/logout.ts to import through its own barrel, got %+v", withinFeature)
+	}
+	if result.FixedImportsCount != 1 {
+		t.Errorf("expected 1 fixed import, got %d", result.FixedImportsCount)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "src/features/auth/logout.ts"))
+	if err != nil {
+		t.Fatalf("read fixed file: %v", err)
+	}
+	if string(content) != "import { session } from './internal/session';\nexport const logout = session;\n" {
+		t.Errorf("unexpected fixed content:\n%s", content)
+	}
+}
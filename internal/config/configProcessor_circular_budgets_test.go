@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+)
+
+// End-to-end: the legacy cycle stays within its budget and passes, while the new cycle exceeds
+// the rule-wide limit, fails the run and comes with the import that breaks it.
+func TestConfigProcessor_CycleBudgets(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"cycle-budgets-fixture"}`)
+	mustWrite("src/legacy/a.ts", "import { b } from './b';\nexport const a = () => b;\n")
+	mustWrite("src/legacy/b.ts", "import { a } from './a';\nexport const b = () => a;\n")
+	mustWrite("src/app/x.ts", "import { y } from './y';\nexport const x = () => y;\n")
+	mustWrite("src/app/y.ts", "import { x } from './x';\nimport { z } from './z';\nexport const y = () => x + z;\n")
+	mustWrite("src/app/z.ts", "import { x } from './x';\nexport const z = () => x;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"circularImportsDetection": {
+				"algorithm": "SCC",
+				"suggestCycleBreaks": true,
+				"cycleBudgets": [{ "files": ["src/legacy/**"], "maxCycles": 1 }]
+			}
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if len(ruleResult.CircularDependencies) != 1 || !containsPathWithSuffix(ruleResult.CircularDependencies[0], "src/app/x.ts") {
+		t.Fatalf("expected only the src/app cycle to be reported, got %v", ruleResult.CircularDependencies)
+	}
+	if len(ruleResult.CycleBudgets) != 2 || !ruleResult.CycleBudgets[0].Exceeded() || ruleResult.CycleBudgets[1].Exceeded() || ruleResult.CycleBudgets[1].Cycles != 1 {
+		t.Errorf("expected the rule-wide budget to be exceeded and the legacy one to hold, got %+v", ruleResult.CycleBudgets)
+	}
+
+	if len(ruleResult.CycleBreakingSuggestions) != 1 {
+		t.Fatalf("expected a suggestion for the src/app component only, got %+v", ruleResult.CycleBreakingSuggestions)
+	}
+	suggestion := ruleResult.CycleBreakingSuggestions[0]
+	if suggestion.Cycles != 2 || len(suggestion.Edges) != 1 {
+		t.Fatalf("expected one import breaking both src/app cycles, got %+v", suggestion)
+	}
+	edge := suggestion.Edges[0]
+	if !containsPathWithSuffix([]string{edge.From}, "src/app/x.ts") || edge.Request != "./y" || edge.Cycles != 2 {
+		t.Errorf("expected src/app/x.ts -> './y' in 2 cycles, got %+v", edge)
+	}
+	if !result.HasFailures {
+		t.Errorf("expected the exceeded budget to fail the run")
+	}
+}
+
+// Cycle-breaking suggestions enumerate the cycles of every reported component, so they are only
+// computed when suggestCycleBreaks is set.
+func TestConfigProcessor_CycleBreakSuggestionsAreOptIn(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"cycle-breaks-fixture"}`)
+	mustWrite("src/a.ts", "import { b } from './b';\nexport const a = () => b;\n")
+	mustWrite("src/b.ts", "import { a } from './a';\nexport const b = () => a;\n")
+
+	cfg := parseTestConfig(t, `{
+		"configVersion": "1.13",
+		"rules": [{ "path": ".", "circularImportsDetection": { "enabled": true } }]
+	}`)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if len(ruleResult.CircularDependencies) != 1 {
+		t.Fatalf("expected the cycle to be reported, got %v", ruleResult.CircularDependencies)
+	}
+	if len(ruleResult.CycleBreakingSuggestions) != 0 {
+		t.Errorf("expected no suggestions without suggestCycleBreaks, got %+v", ruleResult.CycleBreakingSuggestions)
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// End-to-end: the entry point exceeding its transitive dependency budget is reported with the
+// actual count and its heaviest direct import, and fails the run.
+func TestConfigProcessor_ComplexityBudgets(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"complexity-fixture"}`)
+	mustWrite("src/index.ts", "import { a } from './a';\nimport { c } from './c';\nexport const index = a + c;\n")
+	mustWrite("src/a.ts", "import { b } from './b';\nexport const a = b;\n")
+	mustWrite("src/b.ts", "export const b = 1;\n")
+	mustWrite("src/c.ts", "export const c = 2;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"complexityBudgetsDetection": {
+				"budgets": [{ "files": ["src/**"], "maxTransitiveDependencies": 3, "maxImportChainDepth": 2 }]
+			}
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "complexity-budgets") {
+		t.Errorf("expected 'complexity-budgets' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+	if len(ruleResult.ComplexityBudgetViolations) != 1 {
+		t.Fatalf("expected 1 complexity budget violation, got %+v", ruleResult.ComplexityBudgetViolations)
+	}
+	violation := ruleResult.ComplexityBudgetViolations[0]
+	if !containsPathWithSuffix([]string{violation.FilePath}, "src/index.ts") || violation.Metric != "transitive-dependencies" || violation.Actual != 4 || violation.Budget != 3 {
+		t.Errorf("expected src/index.ts with 4 transitive dependencies (budget 3), got %+v", violation)
+	}
+	if len(violation.Contributors) != 2 || !containsPathWithSuffix([]string{violation.Contributors[0].Path}, "src/a.ts") || violation.Contributors[0].Count != 2 {
+		t.Errorf("expected src/a.ts (2) to be the top contributor, got %+v", violation.Contributors)
+	}
+	if !result.HasFailures {
+		t.Errorf("expected complexity budget violations to fail the run")
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// An isomorphic package whose `#platform` import maps to a different file per environment.
+// A server rule (top-level conditions) and a browser rule (rule-level conditions) over the same
+// path must each reach their own platform file, and leave the other one orphaned.
+func TestConfigProcessor_PerRuleConditionNames(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{
+		"name": "isomorphic-fixture",
+		"imports": {
+			"#platform": {
+				"browser": "./src/platform.browser.ts",
+				"node": "./src/platform.node.ts"
+			}
+		}
+	}`)
+	mustWrite("src/index.ts", "import { platform } from '#platform';\nconsole.log(platform);\n")
+	mustWrite("src/platform.browser.ts", "export const platform = 'browser';\n")
+	mustWrite("src/platform.node.ts", "export const platform = 'node';\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"conditionNames": ["node"],
+		"rules": [
+			{ "path": ".", "prodEntryPoints": ["src/index.ts"], "orphanFilesDetection": true },
+			{ "path": ".", "conditionNames": ["browser"], "prodEntryPoints": ["src/index.ts"], "orphanFilesDetection": true }
+		]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	server, browser := result.RuleResults[0], result.RuleResults[1]
+
+	if !slices.Equal(server.ConditionNames, []string{"node"}) {
+		t.Errorf("expected server rule to inherit the top-level conditions, got %v", server.ConditionNames)
+	}
+	if !slices.Equal(browser.ConditionNames, []string{"browser"}) {
+		t.Errorf("expected browser rule to use its own conditions, got %v", browser.ConditionNames)
+	}
+
+	if len(server.OrphanFiles) != 1 || !containsPathWithSuffix(server.OrphanFiles, "src/platform.browser.ts") {
+		t.Errorf("expected only platform.browser.ts to be orphaned for the server rule, got %v", server.OrphanFiles)
+	}
+	if len(browser.OrphanFiles) != 1 || !containsPathWithSuffix(browser.OrphanFiles, "src/platform.node.ts") {
+		t.Errorf("expected only platform.node.ts to be orphaned for the browser rule, got %v", browser.OrphanFiles)
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// End-to-end: imports reaching into a workspace package past its exports are reported with the
+// public request of the same file, while exported subpaths and packages without exports pass.
+func TestConfigProcessor_DeepImports(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	mustWrite("package.json", `{"name":"deep-imports-fixture","private":true}`)
+	mustWrite("packages/ui/package.json", `{
+		"name": "@acme/ui",
+		"exports": {
+			".": "./src/index.ts",
+			"./button": "./src/components/button.ts",
+			"./icons/*": "./src/icons/*.ts"
+		}
+	}`)
+	mustWrite("packages/ui/src/index.ts", "export const ui = 1;\n")
+	mustWrite("packages/ui/src/components/button.ts", "export const button = 1;\n")
+	mustWrite("packages/ui/src/icons/star.ts", "export const star = 1;\n")
+	mustWrite("packages/ui/src/internal/theme.ts", "export const theme = 1;\n")
+	mustWrite("packages/utils/package.json", `{"name":"@acme/utils","main":"index.ts"}`)
+	mustWrite("packages/utils/index.ts", "export const utils = 1;\n")
+	mustWrite("packages/utils/format.ts", "export const format = 1;\n")
+	mustWrite("packages/app/package.json", `{
+		"name": "@acme/app",
+		"dependencies": { "@acme/ui": "workspace:*", "@acme/utils": "workspace:*" }
+	}`)
+	mustWrite("packages/app/index.ts", `import { ui } from '@acme/ui';
+import { button } from '@acme/ui/button';
+import { star } from '@acme/ui/icons/star';
+import { format } from '@acme/utils/format';
+import { button as deepButton } from '../ui/src/components/button';
+import { star as deepStar } from '../ui/src/icons/star';
+import { theme } from '../ui/src/internal/theme';
+export const app = [ui, button, star, format, deepButton, deepStar, theme];
+`)
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"followMonorepoPackages": true,
+			"deepImportsDetection": true
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "deep-imports") {
+		t.Errorf("expected 'deep-imports' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+
+	got := map[string]string{}
+	for _, v := range ruleResult.DeepImportViolations {
+		got[v.ImportRequest] = v.SuggestedRequest
+	}
+	expected := map[string]string{
+		"../ui/src/components/button": "@acme/ui/button",
+		"../ui/src/icons/star":        "@acme/ui/icons/star",
+		"../ui/src/internal/theme":    "",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %+v", expected, ruleResult.DeepImportViolations)
+	}
+	for request, suggestion := range expected {
+		if actual, ok := got[request]; !ok || actual != suggestion {
+			t.Errorf("expected %q to be reported with suggestion %q, got %+v", request, suggestion, ruleResult.DeepImportViolations)
+		}
+	}
+	if !result.HasFailures {
+		t.Errorf("expected deep imports to fail the run")
+	}
+}
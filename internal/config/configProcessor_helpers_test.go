@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestProject creates an empty project directory and returns it with a function writing a file,
+// given by its path relative to the directory, into it.
+func newTestProject(t *testing.T) (string, func(rel, content string)) {
+	t.Helper()
+	dir := t.TempDir()
+	return dir, func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+}
+
+// parseTestConfig parses configJSON, failing the test when it is invalid.
+func parseTestConfig(t *testing.T, configJSON string) RevDepConfig {
+	t.Helper()
+	cfg, err := ParseConfig([]byte(configJSON))
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	return cfg
+}
+
+// processTestConfig processes cfg for the project in cwd and its package.json, failing the test on
+// errors.
+func processTestConfig(t *testing.T, cfg *RevDepConfig, cwd string, fix bool) *ConfigProcessingResult {
+	t.Helper()
+	result, err := ProcessConfig(cfg, cwd, "package.json", "", fix, false)
+	if err != nil {
+		t.Fatalf("process config: %v", err)
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// End-to-end: a domain file reaching up into the ui layer is reported with both layer names and
+// fails the run, while the downward ui -> domain import passes.
+func TestConfigProcessor_Layers(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"layers-fixture"}`)
+	mustWrite("src/ui/page.ts", "import { user } from '../domain/user';\nexport const page = user;\n")
+	mustWrite("src/ui/format.ts", "export const format = (s: string) => s;\n")
+	mustWrite("src/domain/user.ts", "import { format } from '../ui/format';\nexport const user = format('user');\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"layersDetection": {
+				"layers": [
+					{ "name": "ui", "patterns": ["src/ui/**"] },
+					{ "name": "domain", "patterns": ["src/domain/**"] }
+				]
+			}
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "layers") {
+		t.Errorf("expected 'layers' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+	if len(ruleResult.LayerViolations) != 1 {
+		t.Fatalf("expected 1 layer violation, got %+v", ruleResult.LayerViolations)
+	}
+	violation := ruleResult.LayerViolations[0]
+	if !containsPathWithSuffix([]string{violation.FilePath}, "src/domain/user.ts") || !containsPathWithSuffix([]string{violation.ImportPath}, "src/ui/format.ts") {
+		t.Errorf("expected src/domain/user.ts -> src/ui/format.ts, got %+v", violation)
+	}
+	if violation.SourceLayer != "domain" || violation.TargetLayer != "ui" || violation.ViolationType != "upward-import" {
+		t.Errorf("expected an upward import from domain to ui, got %+v", violation)
+	}
+	if !result.HasFailures {
+		t.Errorf("expected layer violations to fail the run")
+	}
+}
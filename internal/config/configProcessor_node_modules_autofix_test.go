@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// End-to-end: a missing module imported by production code is added to dependencies, one only
+// imported by tests to devDependencies, and an unused one is removed, keeping the formatting.
+func TestConfigProcessor_NodeModules_Autofix(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{
+    "name": "autofix-fixture",
+    "dependencies": {
+        "left-pad": "^1.3.0", // unused
+        "zod": "^3.22.0"
+    },
+    "devDependencies": {
+        "vitest": "^1.0.0"
+    }
+}
+`)
+	mustWrite("node_modules/lodash/package.json", `{"name": "lodash", "version": "4.17.21"}`)
+	mustWrite("node_modules/zod/package.json", `{"name": "zod", "version": "3.22.4"}`)
+	mustWrite("node_modules/vitest/package.json", `{"name": "vitest", "version": "1.2.0"}`)
+	mustWrite("node_modules/left-pad/package.json", `{"name": "left-pad", "version": "1.3.0"}`)
+	// msw is not installed, its version comes from the lockfile.
+	mustWrite("package-lock.json", `{
+  "name": "autofix-fixture",
+  "lockfileVersion": 3,
+  "packages": {
+    "": { "name": "autofix-fixture" },
+    "node_modules/msw": { "version": "2.1.0" }
+  }
+}`)
+	mustWrite("src/main.ts", "import { z } from 'zod';\nimport _ from 'lodash';\nexport const main = [z, _];\n")
+	mustWrite("src/main.test.ts", "import { test } from 'vitest';\nimport { http } from 'msw';\nexport const t = [test, http];\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"prodEntryPoints": ["src/main.ts"],
+			"missingNodeModulesDetection": { "enabled": true, "autofix": true },
+			"unusedNodeModulesDetection": { "enabled": true, "autofix": true }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+
+	result := processTestConfig(t, &cfg, tempDir, false)
+	if result.FixableIssuesCount != 3 {
+		t.Errorf("expected 3 fixable issues (lodash, msw, left-pad), got %d", result.FixableIssuesCount)
+	}
+
+	result = processTestConfig(t, &cfg, tempDir, true)
+	if result.AddedNodeModulesCount != 2 || result.RemovedNodeModulesCount != 1 {
+		t.Errorf("expected 2 added and 1 removed modules, got %d and %d", result.AddedNodeModulesCount, result.RemovedNodeModulesCount)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tempDir, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+    "name": "autofix-fixture",
+    "dependencies": {
+        "lodash": "^4.17.21",
+        "zod": "^3.22.0"
+    },
+    "devDependencies": {
+        "msw": "^2.1.0",
+        "vitest": "^1.0.0"
+    }
+}
+`
+	if string(content) != expected {
+		t.Errorf("unexpected package.json:\n%s\nwant:\n%s", content, expected)
+	}
+
+	result = processTestConfig(t, &cfg, tempDir, false)
+	if result.HasFailures {
+		t.Errorf("expected no issues after the fix, got %+v %+v", result.RuleResults[0].MissingNodeModules, result.RuleResults[0].UnusedNodeModules)
+	}
+}
+
+// Without an installed copy or a lockfile entry there is no version to declare, so the issue
+// is reported but not fixable.
+func TestConfigProcessor_MissingNodeModules_AutofixWithoutVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "package.json"), []byte(`{"name": "no-version"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "src/index.ts"), []byte("import 'unknown-lib';\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := RevDepConfig{
+		ConfigVersion: "1.13",
+		Rules: []Rule{{
+			Path:                         ".",
+			MissingNodeModulesDetections: []*MissingNodeModulesOptions{{Enabled: true, Autofix: true}},
+		}},
+	}
+	result := processTestConfig(t, &cfg, tempDir, false)
+	missing := result.RuleResults[0].MissingNodeModules
+	if len(missing) != 1 || missing[0].ModuleName != "unknown-lib" || missing[0].Fixes != nil {
+		t.Errorf("expected unknown-lib to be reported without a fix, got %+v", missing)
+	}
+	if result.FixableIssuesCount != 0 {
+		t.Errorf("expected no fixable issues, got %d", result.FixableIssuesCount)
+	}
+}
+
+func TestParseConfig_NodeModulesAutofixMustBeBoolean(t *testing.T) {
+	for _, detection := range []string{"missingNodeModulesDetection", "unusedNodeModulesDetection"} {
+		configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "` + detection + `": {"enabled": true, "autofix": "yes"}}]}`
+		if _, err := ParseConfig([]byte(configJSON)); err == nil || !strings.Contains(err.Error(), detection+".autofix must be a boolean") {
+			t.Errorf("expected %s.autofix to be validated, got %v", detection, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// End-to-end: @types packages are used through their runtime package, a tsconfig types entry and
+// a triple-slash reference, and reportUntypedImports reports an imported package without types.
+func TestConfigProcessor_UnusedNodeModules_TypesPackages(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{
+		"name": "types-fixture",
+		"dependencies": {"@babel/core": "^7.0.0", "untyped-lib": "^1.0.0", "typed-lib": "^1.0.0"},
+		"devDependencies": {
+			"@types/babel__core": "^7.0.0",
+			"@types/node": "^20.0.0",
+			"@types/jest": "^29.0.0",
+			"@types/lodash": "^4.0.0",
+			"vitest": "^1.0.0"
+		}
+	}`)
+	mustWrite("tsconfig.json", `{"compilerOptions": {"types": ["vitest/globals"]}}`)
+	mustWrite("node_modules/@babel/core/package.json", `{"name": "@babel/core", "main": "lib/index.js"}`)
+	mustWrite("node_modules/untyped-lib/package.json", `{"name": "untyped-lib", "main": "index.js"}`)
+	mustWrite("node_modules/typed-lib/package.json", `{"name": "typed-lib", "types": "index.d.ts"}`)
+	mustWrite("src/main.ts", "import { transform } from '@babel/core';\nimport { readFileSync } from 'fs';\nimport pad from 'untyped-lib';\nimport typed from 'typed-lib';\nexport const main = [transform, readFileSync, pad, typed];\n")
+	mustWrite("src/jest-env.d.ts", "/// <reference types=\"jest\" />\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"unusedNodeModulesDetection": { "enabled": true, "reportUntypedImports": true }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+
+	result := processTestConfig(t, &cfg, tempDir, false)
+	ruleResult := result.RuleResults[0]
+
+	if len(ruleResult.UnusedNodeModules) != 1 || ruleResult.UnusedNodeModules[0].ModuleName != "@types/lodash" {
+		t.Errorf("expected only @types/lodash to be unused, got %+v", ruleResult.UnusedNodeModules)
+	}
+	untyped := ruleResult.UntypedNodeModuleImports
+	if len(untyped) != 1 || untyped[0].ModuleName != "untyped-lib" || untyped[0].TypesPackage != "@types/untyped-lib" {
+		t.Fatalf("expected untyped-lib to be reported, got %+v", untyped)
+	}
+	if len(untyped[0].ImportedFrom) != 1 || !strings.HasSuffix(untyped[0].ImportedFrom[0], "src/main.ts") {
+		t.Errorf("expected untyped-lib to be imported from src/main.ts, got %v", untyped[0].ImportedFrom)
+	}
+	if !result.HasFailures {
+		t.Errorf("expected the rule to fail")
+	}
+
+	cfg.Rules[0].UnusedNodeModulesDetections[0].ReportUntypedImports = false
+	result = processTestConfig(t, &cfg, tempDir, false)
+	if len(result.RuleResults[0].UntypedNodeModuleImports) != 0 {
+		t.Errorf("expected untyped imports not to be reported without reportUntypedImports, got %+v", result.RuleResults[0].UntypedNodeModuleImports)
+	}
+}
+
+func TestParseConfig_ReportUntypedImportsMustBeBoolean(t *testing.T) {
+	configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "unusedNodeModulesDetection": {"enabled": true, "reportUntypedImports": "yes"}}]}`
+	if _, err := ParseConfig([]byte(configJSON)); err == nil || !strings.Contains(err.Error(), "unusedNodeModulesDetection.reportUntypedImports must be a boolean") {
+		t.Errorf("expected reportUntypedImports to be validated, got %v", err)
+	}
+}
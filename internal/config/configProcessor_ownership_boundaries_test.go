@@ -0,0 +1,82 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/checks"
+)
+
+// End-to-end: owners come from .github/CODEOWNERS; the import of the admin app from the web app
+// is not allowed and fails the run, while the allowed import of the ui package only shows up in
+// the team dependency matrix.
+func TestConfigProcessor_OwnershipBoundaries(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"ownership-fixture"}`)
+	mustWrite(".github/CODEOWNERS", "/src/web/ @org/web\n/src/admin/ @org/admin\n/src/ui/ @org/design\n")
+	mustWrite("src/web/page.ts", "import { button } from '../ui/button';\nimport { store } from '../admin/store';\nexport const page = button + store;\n")
+	mustWrite("src/admin/store.ts", "import { button } from '../ui/button';\nexport const store = button;\n")
+	mustWrite("src/ui/button.ts", "export const button = 1;\n")
+
+	process := func(detection string) *ConfigProcessingResult {
+		t.Helper()
+		cfg := parseTestConfig(t, `{"configVersion": "1.13", "rules": [{"path": ".", "ownershipBoundariesDetection": `+detection+`}]}`)
+		return processTestConfig(t, &cfg, tempDir, false)
+	}
+
+	expectedMatrix := []checks.TeamDependency{
+		{From: "@org/admin", To: "@org/design", Imports: 1},
+		{From: "@org/web", To: "@org/admin", Imports: 1},
+		{From: "@org/web", To: "@org/design", Imports: 1},
+	}
+
+	result := process(`{"allowedDependencies": [{ "from": "*", "to": ["@org/design"] }]}`)
+	ruleResult := result.RuleResults[0]
+	violations := ruleResult.OwnershipBoundaryViolations
+	if len(violations) != 1 || !strings.HasSuffix(violations[0].FilePath, "src/web/page.ts") || !strings.HasSuffix(violations[0].ImportPath, "src/admin/store.ts") {
+		t.Fatalf("expected the web -> admin import to be reported, got %+v", violations)
+	}
+	if !reflect.DeepEqual(ruleResult.TeamDependencies, expectedMatrix) || ruleResult.ReportTeamDependencies {
+		t.Errorf("unexpected team dependencies: %+v", ruleResult.TeamDependencies)
+	}
+	if !result.HasFailures {
+		t.Errorf("expected the disallowed import to fail the run")
+	}
+
+	result = process(`{"reportOnly": true}`)
+	ruleResult = result.RuleResults[0]
+	if len(ruleResult.OwnershipBoundaryViolations) != 0 || result.HasFailures {
+		t.Errorf("expected report-only mode not to fail, got %+v", ruleResult.OwnershipBoundaryViolations)
+	}
+	if !reflect.DeepEqual(ruleResult.TeamDependencies, expectedMatrix) || !ruleResult.ReportTeamDependencies {
+		t.Errorf("unexpected team dependencies in report-only mode: %+v", ruleResult.TeamDependencies)
+	}
+
+	cfg := parseTestConfig(t, `{"configVersion": "1.13", "rules": [{"path": ".", "ownershipBoundariesDetection": {"codeownersPath": "missing/CODEOWNERS"}}]}`)
+	if _, err := ProcessConfig(&cfg, tempDir, "package.json", "", false, false); err == nil || !strings.Contains(err.Error(), "missing/CODEOWNERS") {
+		t.Errorf("expected an error for the missing CODEOWNERS file, got %v", err)
+	}
+}
+
+// CODEOWNERS lives at the repository root and its patterns are relative to it, also when rev-dep
+// runs in a workspace package.
+func TestConfigProcessor_OwnershipBoundariesFromRepoRoot(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite(".git/HEAD", "ref: refs/heads/main\n")
+	mustWrite(".github/CODEOWNERS", "/ @org/platform\n/packages/app/src/web/ @org/web\n")
+	mustWrite("packages/app/package.json", `{"name":"app"}`)
+	mustWrite("packages/app/src/web/page.ts", "import { util } from '../util';\nexport const page = util;\n")
+	mustWrite("packages/app/src/util.ts", "export const util = 1;\n")
+
+	cfg := parseTestConfig(t, `{"configVersion": "1.13", "rules": [{"path": ".", "ownershipBoundariesDetection": {}}]}`)
+	result := processTestConfig(t, &cfg, filepath.Join(tempDir, "packages", "app"), false)
+
+	violations := result.RuleResults[0].OwnershipBoundaryViolations
+	if len(violations) != 1 || !reflect.DeepEqual(violations[0].FromTeams, []string{"@org/web"}) || !reflect.DeepEqual(violations[0].ToTeams, []string{"@org/platform"}) {
+		t.Errorf("expected the web -> platform import to be reported, got %+v", violations)
+	}
+}
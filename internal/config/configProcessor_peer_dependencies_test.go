@@ -0,0 +1,63 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// End-to-end: in a pnpm workspace, a library declaring react as a dependency and the app depending
+// on it without react are reported, while the peer the library declares is used.
+func TestConfigProcessor_PeerDependencies(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	mustWrite("package.json", `{"name":"peer-dependencies-fixture","private":true}`)
+	mustWrite("packages/ui/package.json", `{
+		"name": "@acme/ui",
+		"main": "index.ts",
+		"dependencies": { "react": "^18.0.0" },
+		"peerDependencies": { "react-dom": "^18.0.0" }
+	}`)
+	mustWrite("packages/ui/index.ts", "import React from 'react';\nimport { createPortal } from 'react-dom';\nexport const ui = [React, createPortal];\n")
+	mustWrite("packages/app/package.json", `{
+		"name": "@acme/app",
+		"dependencies": { "@acme/ui": "workspace:*", "react": "^18.2.0" }
+	}`)
+	mustWrite("packages/app/index.ts", "import { ui } from '@acme/ui';\nexport const app = ui;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"followMonorepoPackages": true,
+			"peerDependenciesDetection": { "peerPackages": ["react", "react-dom"] }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "peer-dependencies") {
+		t.Errorf("expected 'peer-dependencies' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+
+	got := map[string]string{}
+	for _, v := range ruleResult.PeerDependencyViolations {
+		got[v.PackageName+" "+v.Dependency] = v.ViolationType
+	}
+	expected := map[string]string{
+		"@acme/ui react":      "should-be-peer",
+		"@acme/app react-dom": "unsatisfied-peer",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %+v", expected, ruleResult.PeerDependencyViolations)
+	}
+	for key, violationType := range expected {
+		if got[key] != violationType {
+			t.Errorf("expected %q to be reported as %s, got %+v", key, violationType, ruleResult.PeerDependencyViolations)
+		}
+	}
+	if !result.HasFailures {
+		t.Errorf("expected peer dependency issues to fail the run")
+	}
+}
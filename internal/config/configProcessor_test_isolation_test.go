@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/checks"
+)
+
+// End-to-end: the production file importing a mock and the unused fixture are reported, while
+// the test importing the mock and the used fixture are not.
+func TestConfigProcessor_TestIsolation(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"test-isolation-fixture"}`)
+	mustWrite("src/main.ts", "import { api } from './api';\nexport const main = api;\n")
+	mustWrite("src/api.ts", "import { mockApi } from './__mocks__/api';\nexport const api = mockApi;\n")
+	mustWrite("src/__mocks__/api.ts", "export const mockApi = {};\n")
+	mustWrite("src/api.test.ts", "import { mockApi } from './__mocks__/api';\nimport { user } from './__fixtures__/user';\nexport const t = [mockApi, user];\n")
+	mustWrite("src/__fixtures__/user.ts", "export const user = {};\n")
+	mustWrite("src/__fixtures__/orders.ts", "export const orders = [];\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"prodEntryPoints": ["src/main.ts"],
+			"testIsolationDetection": { "fixtureFiles": ["**/__fixtures__/**"] }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	violations := result.RuleResults[0].TestIsolationViolations
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %+v", violations)
+	}
+	if violations[0].ViolationType != checks.TestIsolationProductionImport || !strings.HasSuffix(violations[0].FilePath, "src/api.ts") ||
+		!strings.HasSuffix(violations[0].ImportPath, "src/__mocks__/api.ts") || !strings.HasSuffix(violations[0].EntryPoint, "src/main.ts") {
+		t.Errorf("expected src/api.ts importing the mock to be reported, got %+v", violations[0])
+	}
+	if violations[1].ViolationType != checks.TestIsolationUnusedFixture || !strings.HasSuffix(violations[1].FilePath, "src/__fixtures__/orders.ts") {
+		t.Errorf("expected the orders fixture to be reported as unused, got %+v", violations[1])
+	}
+	if !result.HasFailures {
+		t.Errorf("expected the violations to fail the run")
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// End-to-end: value imports of names exported only as types (directly, through `export type {} from`
+// and through a star re-export) and value imports only re-exported with `export type { x }` are
+// reported and, with --fix, converted to `import type` or inline
+// `type` specifiers; imports of values are left alone.
+func TestConfigProcessor_TypeImports(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{"name":"type-imports-fixture"}`)
+	mustWrite("src/model.ts", "export interface User { id: string }\nexport type Id = string;\nexport const createUser = (id: Id): User => ({ id });\n")
+	mustWrite("src/types.ts", "export type { User } from './model';\nexport * from './model';\n")
+	mustWrite("src/service.ts", "import { User, Id } from './types';\nexport const load = (id: Id): User | undefined => undefined;\n")
+	mustWrite("src/app.ts", "import { createUser, User } from './model';\nexport const app: User = createUser('1');\n")
+	mustWrite("src/session.ts", "export class Session {}\n")
+	mustWrite("src/index.ts", "import { Session } from './session';\nexport type { Session };\n")
+	mustWrite("src/values.ts", "import { createUser } from './model';\nexport const admin = createUser('admin');\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"typeImportsDetection": { "autofix": true }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, true)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "type-imports") {
+		t.Errorf("expected 'type-imports' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+	if len(ruleResult.TypeImportViolations) != 3 {
+		t.Fatalf("expected 3 type import violations, got %+v", ruleResult.TypeImportViolations)
+	}
+	app := ruleResult.TypeImportViolations[0]
+	if !containsPathWithSuffix([]string{app.FilePath}, "src/app.ts") || app.WholeStatement || !slices.Equal(app.TypeOnlyNames, []string{"User"}) {
+		t.Errorf("expected User in src/app.ts to be reported as a partial type import, got %+v", app)
+	}
+	index := ruleResult.TypeImportViolations[1]
+	if !containsPathWithSuffix([]string{index.FilePath}, "src/index.ts") || !index.WholeStatement || !slices.Equal(index.TypeOnlyNames, []string{"Session"}) {
+		t.Errorf("expected Session re-exported as a type in src/index.ts to be reported, got %+v", index)
+	}
+	service := ruleResult.TypeImportViolations[2]
+	if !containsPathWithSuffix([]string{service.FilePath}, "src/service.ts") || !service.WholeStatement || !slices.Equal(service.TypeOnlyNames, []string{"User", "Id"}) {
+		t.Errorf("expected src/service.ts to be reported as a whole-statement type import, got %+v", service)
+	}
+	if result.FixedImportsCount != 3 {
+		t.Errorf("expected 3 fixed imports, got %d", result.FixedImportsCount)
+	}
+
+	expectedContent := map[string]string{
+		"src/service.ts": "import type { User, Id } from './types';\nexport const load = (id: Id): User | undefined => undefined;\n",
+		"src/app.ts":     "import { createUser, type User } from './model';\nexport const app: User = createUser('1');\n",
+		"src/index.ts":   "import type { Session } from './session';\nexport type { Session };\n",
+	}
+	for rel, expected := range expectedContent {
+		content, err := os.ReadFile(filepath.Join(tempDir, rel))
+		if err != nil {
+			t.Fatalf("read %s: %v", rel, err)
+		}
+		if string(content) != expected {
+			t.Errorf("unexpected content of %s:\n%s", rel, content)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// End-to-end: in a pnpm workspace, a package nobody imports and a declared sibling dependency that
+// is never imported are reported, while the app (a root package) and the imported library pass.
+func TestConfigProcessor_UnusedWorkspacePackages(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	mustWrite("package.json", `{"name":"unused-workspace-packages-fixture","private":true}`)
+	mustWrite("packages/shared/package.json", `{"name":"@acme/shared","main":"index.ts"}`)
+	mustWrite("packages/shared/index.ts", "export const shared = 1;\n")
+	mustWrite("packages/legacy/package.json", `{"name":"@acme/legacy","main":"index.ts"}`)
+	mustWrite("packages/legacy/index.ts", "export const legacy = 1;\n")
+	mustWrite("packages/app/package.json", `{
+		"name": "@acme/app",
+		"dependencies": { "@acme/shared": "workspace:*", "@acme/legacy": "workspace:*" }
+	}`)
+	mustWrite("packages/app/index.ts", "import { shared } from '@acme/shared';\nexport const app = shared;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"followMonorepoPackages": true,
+			"unusedWorkspacePackagesDetection": { "rootPackages": ["@acme/app"] }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	ruleResult := result.RuleResults[0]
+	if !slices.Contains(ruleResult.EnabledChecks, "unused-workspace-packages") {
+		t.Errorf("expected 'unused-workspace-packages' in enabled checks, got %v", ruleResult.EnabledChecks)
+	}
+
+	got := map[string]string{}
+	for _, v := range ruleResult.UnusedWorkspacePackageViolations {
+		got[v.PackageName+" "+v.Dependency] = v.ViolationType
+	}
+	expected := map[string]string{
+		"@acme/app @acme/legacy": "unused-workspace-dependency",
+		"@acme/legacy ":          "unused-package",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %+v", expected, ruleResult.UnusedWorkspacePackageViolations)
+	}
+	for key, violationType := range expected {
+		if got[key] != violationType {
+			t.Errorf("expected %q to be reported as %s, got %+v", key, violationType, ruleResult.UnusedWorkspacePackageViolations)
+		}
+	}
+	if !result.HasFailures {
+		t.Errorf("expected unused workspace packages to fail the run")
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// End-to-end: a workspace package declaring an older react range than the others is reported, and
+// autofix rewrites the range in its package.json.
+func TestConfigProcessor_VersionConsistency(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	mustWrite("package.json", `{"name":"version-consistency-fixture","private":true}`)
+	mustWrite("packages/web/package.json", "{\n  \"name\": \"web\",\n  \"dependencies\": {\n    \"react\": \"^18.2.0\"\n  }\n}\n")
+	mustWrite("packages/docs/package.json", "{\n  \"name\": \"docs\",\n  \"dependencies\": {\n    \"react\": \"^18.2.0\"\n  }\n}\n")
+	mustWrite("packages/legacy/package.json", "{\n  \"name\": \"legacy\",\n  \"dependencies\": {\n    \"react\": \"^17.0.2\"\n  }\n}\n")
+	mustWrite("packages/web/index.ts", "export const web = 1;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"versionConsistencyDetection": { "autofix": true }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+
+	result := processTestConfig(t, &cfg, tempDir, false)
+	violations := result.RuleResults[0].VersionConsistencyViolations
+	if len(violations) != 1 || violations[0].PackageName != "legacy" || violations[0].ExpectedRange != "^18.2.0" {
+		t.Fatalf("expected the react range of legacy to be reported, got %+v", violations)
+	}
+	if !result.HasFailures || result.FixableIssuesCount != 1 {
+		t.Errorf("expected a failing rule with one fixable issue, got hasFailures=%v fixable=%d", result.HasFailures, result.FixableIssuesCount)
+	}
+
+	result = processTestConfig(t, &cfg, tempDir, true)
+	if result.UpdatedNodeModulesCount != 1 {
+		t.Errorf("expected one updated range, got %d", result.UpdatedNodeModulesCount)
+	}
+	content, err := os.ReadFile(filepath.Join(tempDir, "packages/legacy/package.json"))
+	if err != nil {
+		t.Fatalf("read package.json: %v", err)
+	}
+	if !strings.Contains(string(content), `"react": "^18.2.0"`) {
+		t.Errorf("expected react to be aligned to ^18.2.0, got:\n%s", content)
+	}
+}
+
+// Most packages still declaring react@^17 does not make autofix downgrade the one that moved to
+// react@^18: the older declarations are aligned on the newer range instead.
+func TestConfigProcessor_VersionConsistencyNeverDowngrades(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("pnpm-workspace.yaml", "packages:\n  - packages/*\n")
+	mustWrite("package.json", `{"name":"version-consistency-fixture","private":true}`)
+	for _, name := range []string{"admin", "docs", "legacy"} {
+		mustWrite("packages/"+name+"/package.json", "{\n  \"name\": \""+name+"\",\n  \"dependencies\": {\n    \"react\": \"^17.0.2\"\n  }\n}\n")
+	}
+	mustWrite("packages/web/package.json", "{\n  \"name\": \"web\",\n  \"dependencies\": {\n    \"react\": \"^18.2.0\"\n  }\n}\n")
+
+	cfg := parseTestConfig(t, `{
+		"configVersion": "1.13",
+		"rules": [{ "path": ".", "versionConsistencyDetection": { "autofix": true } }]
+	}`)
+
+	result := processTestConfig(t, &cfg, tempDir, true)
+	if result.UpdatedNodeModulesCount != 3 {
+		t.Errorf("expected the three ^17.0.2 ranges to be updated, got %d", result.UpdatedNodeModulesCount)
+	}
+	for _, name := range []string{"admin", "docs", "legacy", "web"} {
+		content, err := os.ReadFile(filepath.Join(tempDir, "packages", name, "package.json"))
+		if err != nil {
+			t.Fatalf("read package.json: %v", err)
+		}
+		if !strings.Contains(string(content), `"react": "^18.2.0"`) {
+			t.Errorf("expected react of %s to be ^18.2.0, got:\n%s", name, content)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// End-to-end: a pnpm workspace whose app package references siblings and catalogs incorrectly.
+// The processor discovers the workspace (including its catalogs), runs the detector and fails.
+func TestConfigProcessor_WorkspaceProtocol(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("pnpm-workspace.yaml", "packages:\n  - packages/*\ncatalogs:\n  react18:\n    react: ^18.2.0\n")
+	mustWrite("package.json", `{"name":"workspace-protocol-fixture","private":true}`)
+	mustWrite("packages/shared/package.json", `{"name":"@acme/shared","version":"1.0.0"}`)
+	mustWrite("packages/shared/index.ts", "export const shared = 1;\n")
+	mustWrite("packages/app/package.json", `{
+		"name": "@acme/app",
+		"dependencies": {
+			"@acme/shared": "^1.0.0",
+			"react": "catalog:react18",
+			"react-dom": "catalog:react18"
+		}
+	}`)
+	mustWrite("packages/app/index.ts", "import { shared } from '@acme/shared';\nexport const app = shared;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [
+			{ "path": "packages/app", "workspaceProtocolDetection": true },
+			{ "path": "packages/shared", "workspaceProtocolDetection": true }
+		]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+
+	app := result.RuleResults[0]
+	if !slices.Contains(app.EnabledChecks, "workspace-protocol") {
+		t.Errorf("expected 'workspace-protocol' in enabled checks, got %v", app.EnabledChecks)
+	}
+
+	got := map[string]string{}
+	for _, v := range app.WorkspaceProtocolViolations {
+		got[v.Dependency] = v.ViolationType
+	}
+	expected := map[string]string{
+		"@acme/shared": "missing-workspace-protocol",
+		"react-dom":    "missing-catalog-entry",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected violations %v, got %+v", expected, app.WorkspaceProtocolViolations)
+	}
+	for dependency, violationType := range expected {
+		if got[dependency] != violationType {
+			t.Errorf("expected %s to be reported as %s, got %q", dependency, violationType, got[dependency])
+		}
+	}
+
+	if shared := result.RuleResults[1]; len(shared.WorkspaceProtocolViolations) != 0 {
+		t.Errorf("expected no violations for packages/shared, got %+v", shared.WorkspaceProtocolViolations)
+	}
+	if !result.HasFailures {
+		t.Errorf("expected workspace protocol violations to fail the run")
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_BarrelFilesDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"barrelFilesDetection": {
+					"reportBarrelFiles": true,
+					"maxFanOut": 5,
+					"publicBarrels": ["src/features/*/index.ts"],
+					"noBarrelImportsWithinFeature": true,
+					"ignoreFiles": ["src/index.ts"],
+					"autofix": true
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].BarrelFilesDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected barrelFilesDetection to be enabled")
+		}
+		opts := detections[0]
+		if !opts.ReportBarrelFiles || opts.MaxFanOut != 5 || !opts.NoBarrelImportsWithinFeature || !opts.Autofix {
+			t.Errorf("unexpected options: %+v", opts)
+		}
+		if len(opts.PublicBarrels) != 1 || opts.PublicBarrels[0] != "src/features/*/index.ts" {
+			t.Errorf("unexpected publicBarrels: %+v", opts.PublicBarrels)
+		}
+		if len(opts.IgnoreFiles) != 1 || opts.IgnoreFiles[0] != "src/index.ts" {
+			t.Errorf("unexpected ignoreFiles: %+v", opts.IgnoreFiles)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"reportBarrelFiles": true, "maxDepth": 2}`, "unknown field 'maxDepth'"},
+		{"non-boolean reportBarrelFiles", `{"reportBarrelFiles": "yes"}`, "reportBarrelFiles must be a boolean"},
+		{"negative maxFanOut", `{"reportBarrelFiles": true, "maxFanOut": -1}`, "maxFanOut must be a non-negative integer"},
+		{"fractional maxFanOut", `{"reportBarrelFiles": true, "maxFanOut": 1.5}`, "maxFanOut must be a non-negative integer"},
+		{"non-array publicBarrels", `{"publicBarrels": "src/index.ts"}`, "publicBarrels must be an array"},
+		{"no policy", `true`, "at least one of reportBarrelFiles, publicBarrels or noBarrelImportsWithinFeature is required"},
+		{"empty publicBarrels entry", `{"publicBarrels": [""]}`, "publicBarrels[0]: cannot be empty"},
+		{"empty ignoreFiles entry", `{"reportBarrelFiles": true, "ignoreFiles": [" "]}`, "ignoreFiles[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "barrelFilesDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_CircularImportsCycleBudgets(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"circularImportsDetection": {
+					"maxCycles": 2,
+					"suggestCycleBreaks": true,
+					"cycleBudgets": [
+						{ "files": ["src/legacy/**"], "maxCycles": 14 },
+						{ "files": ["src/old-admin/**", "src/old-shared/**"], "maxCycles": 0 }
+					]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detection := cfg.Rules[0].CircularImportsDetections[0]
+		if detection.MaxCycles != 2 {
+			t.Errorf("expected maxCycles 2, got %d", detection.MaxCycles)
+		}
+		if len(detection.CycleBudgets) != 2 || detection.CycleBudgets[0].MaxCycles != 14 || len(detection.CycleBudgets[1].Files) != 2 {
+			t.Errorf("unexpected cycleBudgets: %+v", detection.CycleBudgets)
+		}
+		if !detection.SuggestCycleBreaks {
+			t.Errorf("expected suggestCycleBreaks to be set")
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"negative maxCycles", `{"maxCycles": -1}`, "maxCycles must be a non-negative integer"},
+		{"non-array cycleBudgets", `{"cycleBudgets": {"files": ["a"], "maxCycles": 1}}`, "cycleBudgets must be an array"},
+		{"unknown budget field", `{"cycleBudgets": [{"files": ["a"], "maxCycles": 1, "max": 2}]}`, "cycleBudgets[0]: unknown field 'max'"},
+		{"missing budget maxCycles", `{"cycleBudgets": [{"files": ["a"]}]}`, "cycleBudgets[0].maxCycles is required"},
+		{"no files", `{"cycleBudgets": [{"files": [], "maxCycles": 1}]}`, "cycleBudgets[0].files: at least one pattern is required"},
+		{"non-boolean suggestCycleBreaks", `{"suggestCycleBreaks": "yes"}`, "suggestCycleBreaks must be a boolean"},
+		{"empty file pattern", `{"cycleBudgets": [{"files": [""], "maxCycles": 1}]}`, "cycleBudgets[0].files[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "circularImportsDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_ComplexityBudgetsDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"complexityBudgetsDetection": {
+					"entryPoints": ["src/pages/*.tsx"],
+					"ignoreFiles": ["**/*.test.ts"],
+					"ignoreTypeImports": true,
+					"topContributors": 3,
+					"budgets": [
+						{ "files": ["src/pages/**"], "maxTransitiveDependencies": 300, "maxImportChainDepth": 12 },
+						{ "files": ["src/**"], "maxDirectImports": 20, "maxImporters": 40 }
+					]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].ComplexityBudgetsDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected complexityBudgetsDetection to be enabled")
+		}
+		detection := detections[0]
+		if !detection.IgnoreTypeImports || detection.TopContributors != 3 || len(detection.EntryPoints) != 1 || len(detection.IgnoreFiles) != 1 {
+			t.Errorf("unexpected options: %+v", detection)
+		}
+		budgets := detection.Budgets
+		if len(budgets) != 2 || budgets[0].MaxTransitiveDependencies != 300 || budgets[0].MaxImportChainDepth != 12 {
+			t.Fatalf("unexpected budgets: %+v", budgets)
+		}
+		if budgets[1].MaxDirectImports != 20 || budgets[1].MaxImporters != 40 || budgets[1].Files[0] != "src/**" {
+			t.Errorf("unexpected second budget: %+v", budgets[1])
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"budgets": [{"files": ["a"], "maxImporters": 1}], "maxDepth": 3}`, "unknown field 'maxDepth'"},
+		{"unknown budget field", `{"budgets": [{"files": ["a"], "maxFanOut": 1}]}`, "budgets[0]: unknown field 'maxFanOut'"},
+		{"non-array budgets", `{"budgets": {"files": ["a"]}}`, "budgets must be an array"},
+		{"negative threshold", `{"budgets": [{"files": ["a"], "maxImporters": -1}]}`, "budgets[0].maxImporters must be a non-negative integer"},
+		{"fractional threshold", `{"budgets": [{"files": ["a"], "maxDirectImports": 1.5}]}`, "budgets[0].maxDirectImports must be a non-negative integer"},
+		{"invalid topContributors", `{"topContributors": "5", "budgets": [{"files": ["a"], "maxImporters": 1}]}`, "topContributors must be a non-negative integer"},
+		{"no budgets", `true`, "budgets: at least one budget is required"},
+		{"no files", `{"budgets": [{"files": [], "maxImporters": 1}]}`, "budgets[0].files: at least one pattern is required"},
+		{"empty file pattern", `{"budgets": [{"files": [" "], "maxImporters": 1}]}`, "budgets[0].files[0]: cannot be empty"},
+		{"no threshold", `{"budgets": [{"files": ["a"]}]}`, "budgets[0]: at least one of maxTransitiveDependencies"},
+		{"empty entryPoints entry", `{"entryPoints": [""], "budgets": [{"files": ["a"], "maxImporters": 1}]}`, "entryPoints[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "complexityBudgetsDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_RuleConditionNames(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"conditionNames": ["node"],
+			"rules": [
+				{ "path": "." },
+				{ "path": ".", "conditionNames": ["browser", "import"] }
+			]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if got := cfg.ConditionNamesForRule(cfg.Rules[0]); !slices.Equal(got, []string{"node"}) {
+			t.Errorf("expected rule without conditionNames to inherit the config-level ones, got %v", got)
+		}
+		if got := cfg.ConditionNamesForRule(cfg.Rules[1]); !slices.Equal(got, []string{"browser", "import"}) {
+			t.Errorf("expected rule-level conditionNames to take precedence, got %v", got)
+		}
+
+		marshaled, err := json.Marshal(cfg.Rules[1])
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if !strings.Contains(string(marshaled), `"conditionNames":["browser","import"]`) {
+			t.Errorf("expected conditionNames to round-trip, got %s", marshaled)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		value  string
+		errMsg string
+	}{
+		{"non-array", `"browser"`, "rules[0].conditionNames must be an array"},
+		{"non-string entry", `[1]`, "rules[0].conditionNames[0] must be a string"},
+		{"empty entry", `["browser", " "]`, "rules[0].conditionNames[1] cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "conditionNames": ` + tc.value + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_DeepImportsDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"deepImportsDetection": {
+					"ignorePackages": ["@acme/legacy-*"],
+					"ignoreFiles": ["**/*.test.ts"]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].DeepImportsDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected deepImportsDetection to be enabled")
+		}
+		if len(detections[0].IgnorePackages) != 1 || detections[0].IgnorePackages[0] != "@acme/legacy-*" {
+			t.Errorf("unexpected ignorePackages: %+v", detections[0].IgnorePackages)
+		}
+		if len(detections[0].IgnoreFiles) != 1 || detections[0].IgnoreFiles[0] != "**/*.test.ts" {
+			t.Errorf("unexpected ignoreFiles: %+v", detections[0].IgnoreFiles)
+		}
+	})
+
+	t.Run("boolean shorthand", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"configVersion": "1.13", "rules": [{"path": ".", "deepImportsDetection": true}]}`))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !cfg.Rules[0].DeepImportsDetections[0].Enabled {
+			t.Errorf("expected deepImportsDetection to be enabled")
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"packages": []}`, "unknown field 'packages'"},
+		{"non-boolean enabled", `{"enabled": "yes"}`, "enabled must be a boolean"},
+		{"non-array ignorePackages", `{"ignorePackages": "@acme/ui"}`, "ignorePackages must be an array"},
+		{"empty ignoreFiles entry", `{"ignoreFiles": [""]}`, "ignoreFiles[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "deepImportsDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
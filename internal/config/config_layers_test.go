@@ -0,0 +1,74 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_LayersDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"layersDetection": {
+					"allowSameLayer": true,
+					"strict": true,
+					"layers": [
+						{ "name": "ui", "patterns": ["src/ui/**"], "allowImports": ["src/infra/logger.ts"] },
+						{ "name": "domain", "patterns": ["src/domain/**"] },
+						{ "name": "infra", "patterns": ["src/infra/**"] }
+					]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].LayersDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected layersDetection to be enabled")
+		}
+		if !detections[0].AllowSameLayer || !detections[0].Strict {
+			t.Errorf("expected allowSameLayer and strict to be parsed, got %+v", detections[0])
+		}
+		layers := detections[0].Layers
+		if len(layers) != 3 || layers[0].Name != "ui" || layers[2].Name != "infra" {
+			t.Fatalf("expected layers to keep their order, got %+v", layers)
+		}
+		if len(layers[0].AllowImports) != 1 || layers[0].AllowImports[0] != "src/infra/logger.ts" {
+			t.Errorf("unexpected allowImports: %+v", layers[0].AllowImports)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"layers": [{"name": "ui", "patterns": ["a"]}], "order": []}`, "unknown field 'order'"},
+		{"unknown layer field", `{"layers": [{"name": "ui", "patterns": ["a"], "deny": []}]}`, "layers[0]: unknown field 'deny'"},
+		{"non-boolean strict", `{"strict": "yes", "layers": [{"name": "ui", "patterns": ["a"]}]}`, "strict must be a boolean"},
+		{"non-array layers", `{"layers": {"ui": ["a"]}}`, "layers must be an array"},
+		{"no layers", `true`, "layers: at least one layer is required"},
+		{"empty name", `{"layers": [{"name": " ", "patterns": ["a"]}]}`, "layers[0].name: cannot be empty"},
+		{"duplicate name", `{"layers": [{"name": "ui", "patterns": ["a"]}, {"name": "ui", "patterns": ["b"]}]}`, "layers[1].name: duplicate layer name 'ui'"},
+		{"no patterns", `{"layers": [{"name": "ui", "patterns": []}]}`, "layers[0].patterns: at least one pattern is required"},
+		{"empty allowImports entry", `{"layers": [{"name": "ui", "patterns": ["a"], "allowImports": [""]}]}`, "layers[0].allowImports[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "layersDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_OwnershipBoundariesDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"ownershipBoundariesDetection": {
+					"codeownersPath": "config/CODEOWNERS",
+					"ignoreTypeImports": true,
+					"allowedDependencies": [
+						{ "from": "@org/web", "to": ["@org/platform", "@org/design"] },
+						{ "from": "*", "to": ["@org/platform"] }
+					]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].OwnershipBoundariesDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected ownershipBoundariesDetection to be enabled")
+		}
+		detection := detections[0]
+		if detection.CodeownersPath != "config/CODEOWNERS" || !detection.IgnoreTypeImports || detection.ReportOnly {
+			t.Errorf("unexpected options: %+v", detection)
+		}
+		allowed := detection.AllowedDependencies
+		if len(allowed) != 2 || allowed[0].From != "@org/web" || len(allowed[0].To) != 2 || allowed[1].From != "*" {
+			t.Errorf("unexpected allowedDependencies: %+v", allowed)
+		}
+	})
+
+	t.Run("report only shorthand", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"configVersion": "1.13", "rules": [{"path": ".", "ownershipBoundariesDetection": {"reportOnly": true}}]}`))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if detection := cfg.Rules[0].OwnershipBoundariesDetections[0]; !detection.Enabled || !detection.ReportOnly {
+			t.Errorf("expected an enabled report-only detection, got %+v", detection)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"teams": []}`, "unknown field 'teams'"},
+		{"non-string codeownersPath", `{"codeownersPath": 1}`, "codeownersPath must be a string"},
+		{"non-boolean reportOnly", `{"reportOnly": "yes"}`, "reportOnly must be a boolean"},
+		{"non-array allowedDependencies", `{"allowedDependencies": {"from": "@a", "to": ["@b"]}}`, "allowedDependencies must be an array"},
+		{"unknown dependency field", `{"allowedDependencies": [{"from": "@a", "to": ["@b"], "via": "@c"}]}`, "allowedDependencies[0]: unknown field 'via'"},
+		{"missing from", `{"allowedDependencies": [{"to": ["@b"]}]}`, "allowedDependencies[0].from is required"},
+		{"non-array to", `{"allowedDependencies": [{"from": "@a", "to": "@b"}]}`, "allowedDependencies[0].to must be an array"},
+		{"empty from", `{"allowedDependencies": [{"from": " ", "to": ["@b"]}]}`, "allowedDependencies[0].from: cannot be empty"},
+		{"no teams in to", `{"allowedDependencies": [{"from": "@a", "to": []}]}`, "allowedDependencies[0].to: at least one team is required"},
+		{"empty team in to", `{"allowedDependencies": [{"from": "@a", "to": [""]}]}`, "allowedDependencies[0].to[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "ownershipBoundariesDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
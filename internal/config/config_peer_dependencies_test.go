@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_PeerDependenciesDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"peerDependenciesDetection": {
+					"peerPackages": ["react", "react-dom"],
+					"ignorePackages": ["@types/*"]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].PeerDependenciesDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected peerDependenciesDetection to be enabled")
+		}
+		if len(detections[0].PeerPackages) != 2 || detections[0].PeerPackages[1] != "react-dom" {
+			t.Errorf("unexpected peerPackages: %+v", detections[0].PeerPackages)
+		}
+		if len(detections[0].IgnorePackages) != 1 || detections[0].IgnorePackages[0] != "@types/*" {
+			t.Errorf("unexpected ignorePackages: %+v", detections[0].IgnorePackages)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"peerPackages": [], "libraries": []}`, "unknown field 'libraries'"},
+		{"non-array peerPackages", `{"peerPackages": "react"}`, "peerPackages must be an array"},
+		{"empty ignorePackages entry", `{"ignorePackages": [" "]}`, "ignorePackages[0]: cannot be empty"},
+		{"invalid peerPackages glob", `{"peerPackages": ["react-["]}`, "peerPackages[0] has invalid glob pattern"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "peerDependenciesDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+)
 
 func TestParseConfig_DevDepsUsageOnProdDetection_IgnoreTypeImports(t *testing.T) {
 	t.Run("valid config", func(t *testing.T) {
@@ -52,3 +55,64 @@ func TestParseConfig_DevDepsUsageOnProdDetection_IgnoreTypeImports(t *testing.T)
 		}
 	})
 }
+
+func TestParseConfig_DevDepsUsageOnProdDetection_ReportUnnecessaryProdDependencies(t *testing.T) {
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"devDepsUsageOnProdDetection": {
+				"enabled": true,
+				"prodEntryPoints": ["src/server.ts"],
+				"reportUnnecessaryProdDependencies": "yes"
+			}
+		}]
+	}`
+
+	_, err := ParseConfig([]byte(configJSON))
+	if err == nil || !contains(err.Error(), "reportUnnecessaryProdDependencies must be a boolean") {
+		t.Fatalf("expected reportUnnecessaryProdDependencies boolean error, got: %v", err)
+	}
+}
+
+func TestConfigProcessor_DevDepsUsageOnProd_ReportUnnecessaryProdDependencies(t *testing.T) {
+	tempDir, mustWrite := newTestProject(t)
+
+	mustWrite("package.json", `{
+		"name": "unnecessary-prod-deps",
+		"dependencies": { "zod": "^3.22.0", "msw": "^2.1.0" },
+		"devDependencies": { "vitest": "^1.0.0" }
+	}`)
+	mustWrite("src/server.ts", "import { z } from 'zod';\nexport const schema = z.string();\n")
+	mustWrite("src/server.test.ts", "import { test } from 'vitest';\nimport { setup } from '../test/setup';\nimport { schema } from './server';\nexport const t = [test, setup, schema];\n")
+	mustWrite("test/setup.ts", "import { http } from 'msw';\nexport const setup = http;\n")
+
+	configJSON := `{
+		"configVersion": "1.13",
+		"rules": [{
+			"path": ".",
+			"prodEntryPoints": ["src/server.ts"],
+			"devDepsUsageOnProdDetection": { "enabled": true, "reportUnnecessaryProdDependencies": true }
+		}]
+	}`
+	cfg := parseTestConfig(t, configJSON)
+	result := processTestConfig(t, &cfg, tempDir, false)
+	if !result.HasFailures {
+		t.Errorf("expected the unnecessary prod dependency to fail the run")
+	}
+
+	violations := result.RuleResults[0].UnnecessaryProdDependencyViolations
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 unnecessary prod dependency, got %+v", violations)
+	}
+	violation := violations[0]
+	if violation.ProdDependency != "msw" ||
+		violation.PackageJsonPath != filepath.Join(tempDir, "package.json") ||
+		violation.FilePath != filepath.Join(tempDir, "test/setup.ts") ||
+		violation.EntryPoint != filepath.Join(tempDir, "src/server.test.ts") {
+		t.Errorf("unexpected violation %+v", violation)
+	}
+	if len(result.RuleResults[0].RestrictedDevDependenciesUsageViolations) != 0 {
+		t.Errorf("expected no dev dependencies in production, got %+v", result.RuleResults[0].RestrictedDevDependenciesUsageViolations)
+	}
+}
@@ -375,6 +375,56 @@ func TestParseConfig_CustomAssetExtensionsValidation(t *testing.T) {
 	})
 }
 
+func TestParseConfig_MainFieldsValidation(t *testing.T) {
+	t.Run("mainFields are parsed in order", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"mainFields": ["browser", "module", "main"],
+			"rules": [{"path": "."}]
+		}`
+
+		config, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(config.MainFields) != 3 || config.MainFields[0] != "browser" || config.MainFields[2] != "main" {
+			t.Fatalf("Expected mainFields [browser module main], got: %v", config.MainFields)
+		}
+	})
+
+	t.Run("wrong type for mainFields", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"mainFields": "browser",
+			"rules": [{"path": "."}]
+		}`
+
+		_, err := ParseConfig([]byte(configJSON))
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if !contains(err.Error(), "mainFields must be an array") {
+			t.Errorf("Expected mainFields array type error, got: %s", err.Error())
+		}
+	})
+
+	t.Run("empty mainFields entry is rejected", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"mainFields": ["browser", " "],
+			"rules": [{"path": "."}]
+		}`
+
+		_, err := ParseConfig([]byte(configJSON))
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if !contains(err.Error(), "mainFields[1] cannot be empty") {
+			t.Errorf("Expected empty mainFields entry error, got: %s", err.Error())
+		}
+	})
+}
+
 func TestParseConfig_InvalidTypes(t *testing.T) {
 	tests := []struct {
 		name        string
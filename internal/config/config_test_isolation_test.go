@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_TestIsolationDetection(t *testing.T) {
+	t.Run("valid config inherits prodEntryPoints", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"prodEntryPoints": ["src/main.ts"],
+				"testIsolationDetection": {
+					"testFiles": ["**/*.test.ts", "**/__mocks__/**"],
+					"fixtureFiles": ["**/__fixtures__/**"],
+					"ignoreTypeImports": true
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].TestIsolationDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected testIsolationDetection to be enabled")
+		}
+		detection := detections[0]
+		if len(detection.TestFiles) != 2 || len(detection.FixtureFiles) != 1 || !detection.IgnoreTypeImports {
+			t.Errorf("unexpected options: %+v", detection)
+		}
+		if len(detection.ProdEntryPoints) != 1 || detection.ProdEntryPoints[0] != "src/main.ts" {
+			t.Errorf("expected prodEntryPoints to be inherited from the rule, got %v", detection.ProdEntryPoints)
+		}
+	})
+
+	t.Run("explicit prodEntryPoints override the rule", func(t *testing.T) {
+		configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "prodEntryPoints": ["src/main.ts"], "testIsolationDetection": {"prodEntryPoints": ["src/server.ts"]}}]}`
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if entryPoints := cfg.Rules[0].TestIsolationDetections[0].ProdEntryPoints; len(entryPoints) != 1 || entryPoints[0] != "src/server.ts" {
+			t.Errorf("expected the detector's prodEntryPoints, got %v", entryPoints)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"mockFiles": []}`, "unknown field 'mockFiles'"},
+		{"non-array testFiles", `{"testFiles": "**/*.test.ts"}`, "testFiles must be an array"},
+		{"non-array fixtureFiles", `{"fixtureFiles": true}`, "fixtureFiles must be an array"},
+		{"non-boolean ignoreTypeImports", `{"ignoreTypeImports": 1}`, "ignoreTypeImports must be a boolean"},
+		{"empty test pattern", `{"testFiles": [" "]}`, "testFiles[0]: cannot be empty"},
+		{"empty fixture pattern", `{"fixtureFiles": ["**/__fixtures__/**", ""]}`, "fixtureFiles[1]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "testIsolationDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_TypeImportsDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"typeImportsDetection": {
+					"ignoreFiles": ["src/legacy/**"],
+					"autofix": true,
+					"preferInline": true
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].TypeImportsDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected typeImportsDetection to be enabled")
+		}
+		if !detections[0].Autofix || !detections[0].PreferInline {
+			t.Errorf("expected autofix and preferInline to be parsed, got %+v", detections[0])
+		}
+		if len(detections[0].IgnoreFiles) != 1 || detections[0].IgnoreFiles[0] != "src/legacy/**" {
+			t.Errorf("unexpected ignoreFiles: %+v", detections[0].IgnoreFiles)
+		}
+	})
+
+	t.Run("boolean shorthand", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"configVersion": "1.13", "rules": [{"path": ".", "typeImportsDetection": true}]}`))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if detections := cfg.Rules[0].TypeImportsDetections; len(detections) != 1 || !detections[0].Enabled {
+			t.Fatalf("expected typeImportsDetection to be enabled, got %+v", detections)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"autofix": true, "style": "inline"}`, "unknown field 'style'"},
+		{"non-boolean autofix", `{"autofix": "yes"}`, "autofix must be a boolean"},
+		{"non-boolean preferInline", `{"preferInline": 1}`, "preferInline must be a boolean"},
+		{"non-array ignoreFiles", `{"ignoreFiles": "src/**"}`, "ignoreFiles must be an array"},
+		{"empty ignoreFiles entry", `{"ignoreFiles": [""]}`, "ignoreFiles[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "typeImportsDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_UnusedWorkspacePackagesDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"unusedWorkspacePackagesDetection": {
+					"rootPackages": ["@acme/app-*"],
+					"ignorePackages": ["@acme/tsconfig"]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].UnusedWorkspacePackagesDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected unusedWorkspacePackagesDetection to be enabled")
+		}
+		if len(detections[0].RootPackages) != 1 || detections[0].RootPackages[0] != "@acme/app-*" {
+			t.Errorf("unexpected rootPackages: %+v", detections[0].RootPackages)
+		}
+		if len(detections[0].IgnorePackages) != 1 || detections[0].IgnorePackages[0] != "@acme/tsconfig" {
+			t.Errorf("unexpected ignorePackages: %+v", detections[0].IgnorePackages)
+		}
+	})
+
+	t.Run("entry points default to the rule entry points", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"prodEntryPoints": ["apps/*/src/main.ts"],
+				"devEntryPoints": ["scripts/*.ts"],
+				"unusedWorkspacePackagesDetection": true
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		entryPoints := cfg.Rules[0].UnusedWorkspacePackagesDetections[0].EntryPoints
+		if len(entryPoints) != 2 || entryPoints[0] != "apps/*/src/main.ts" || entryPoints[1] != "scripts/*.ts" {
+			t.Errorf("expected entry points from prodEntryPoints and devEntryPoints, got %+v", entryPoints)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"rootPackages": [], "apps": []}`, "unknown field 'apps'"},
+		{"non-array rootPackages", `{"rootPackages": "@acme/app"}`, "rootPackages must be an array"},
+		{"non-array entryPoints", `{"entryPoints": true}`, "entryPoints must be an array"},
+		{"empty ignorePackages entry", `{"ignorePackages": [" "]}`, "ignorePackages[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "unusedWorkspacePackagesDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
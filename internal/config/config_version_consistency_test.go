@@ -0,0 +1,67 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_VersionConsistencyDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"versionConsistencyDetection": {
+					"ignorePackages": ["@types/*"],
+					"pinnedVersions": {"typescript": "^5.4.0"},
+					"autofix": true
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].VersionConsistencyDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected versionConsistencyDetection to be enabled")
+		}
+		if len(detections[0].IgnorePackages) != 1 || detections[0].IgnorePackages[0] != "@types/*" {
+			t.Errorf("unexpected ignorePackages: %+v", detections[0].IgnorePackages)
+		}
+		if detections[0].PinnedVersions["typescript"] != "^5.4.0" {
+			t.Errorf("unexpected pinnedVersions: %+v", detections[0].PinnedVersions)
+		}
+		if !detections[0].Autofix {
+			t.Errorf("expected autofix to be enabled")
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"pinned": {}}`, "unknown field 'pinned'"},
+		{"non-array ignorePackages", `{"ignorePackages": "react"}`, "ignorePackages must be an array"},
+		{"non-object pinnedVersions", `{"pinnedVersions": ["react@18"]}`, "pinnedVersions must be an object"},
+		{"non-string pinned range", `{"pinnedVersions": {"react": 18}}`, "pinnedVersions.react must be a string"},
+		{"empty pinned range", `{"pinnedVersions": {"react": " "}}`, "pinnedVersions.react: version range cannot be empty"},
+		{"non-boolean autofix", `{"autofix": "yes"}`, "autofix must be a boolean"},
+		{"invalid ignorePackages glob", `{"ignorePackages": ["react-["]}`, "ignorePackages[0] has invalid glob pattern"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "versionConsistencyDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
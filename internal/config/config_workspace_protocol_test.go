@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig_WorkspaceProtocolDetection(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		configJSON := `{
+			"configVersion": "1.13",
+			"rules": [{
+				"path": ".",
+				"workspaceProtocolDetection": {
+					"allowVersionRanges": true,
+					"ignoreDependencies": ["@legacy/*"]
+				}
+			}]
+		}`
+
+		cfg, err := ParseConfig([]byte(configJSON))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		detections := cfg.Rules[0].WorkspaceProtocolDetections
+		if len(detections) != 1 || detections[0] == nil || !detections[0].Enabled {
+			t.Fatalf("expected workspaceProtocolDetection to be enabled")
+		}
+		if !detections[0].AllowVersionRanges {
+			t.Errorf("expected allowVersionRanges to be parsed")
+		}
+		if len(detections[0].IgnoreDependencies) != 1 || detections[0].IgnoreDependencies[0] != "@legacy/*" {
+			t.Errorf("unexpected ignoreDependencies: %+v", detections[0].IgnoreDependencies)
+		}
+	})
+
+	t.Run("boolean shorthand marshals as enabled object", func(t *testing.T) {
+		cfg, err := ParseConfig([]byte(`{"configVersion": "1.13", "rules": [{"path": ".", "workspaceProtocolDetection": true}]}`))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		marshaled, err := json.Marshal(cfg.Rules[0])
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if !strings.Contains(string(marshaled), `"workspaceProtocolDetection":{"enabled":true}`) {
+			t.Errorf("expected workspaceProtocolDetection to marshal as an enabled object, got %s", marshaled)
+		}
+	})
+
+	errorCases := []struct {
+		name   string
+		option string
+		errMsg string
+	}{
+		{"unknown field", `{"enabled": true, "requireProtocol": true}`, "unknown field 'requireProtocol'"},
+		{"non-boolean allowVersionRanges", `{"allowVersionRanges": "yes"}`, "allowVersionRanges must be a boolean"},
+		{"non-array ignoreDependencies", `{"ignoreDependencies": "react"}`, "ignoreDependencies must be an array"},
+		{"empty ignoreDependencies entry", `{"ignoreDependencies": [" "]}`, "ignoreDependencies[0]: cannot be empty"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configJSON := `{"configVersion": "1.13", "rules": [{"path": ".", "workspaceProtocolDetection": ` + tc.option + `}]}`
+			_, err := ParseConfig([]byte(configJSON))
+			if err == nil {
+				t.Fatalf("expected error containing %q", tc.errMsg)
+			}
+			if !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tc.errMsg, err)
+			}
+		})
+	}
+}
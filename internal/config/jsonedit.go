@@ -11,15 +11,17 @@ import (
 
 // jsonedit is a self-contained, position-aware JSON/JSONC editor. It parses a JSONC
 // document into a navigable node tree that carries byte offsets into the ORIGINAL
-// file, then lets callers surgically remove array elements / object members or replace
-// individual values — all while leaving every other byte (comments, key order,
-// whitespace) untouched.
+// file, then lets callers surgically remove array elements / object members, replace
+// individual values or insert new members — all while leaving every other byte
+// (comments, key order, whitespace) untouched.
 //
-// It underpins two consumers in this repo:
+// It underpins three consumers in this repo:
 //   - `config lint --fix`, which removes dead glob/path patterns (RemoveArrayElements,
 //     RemoveObjectMembers, RemoveMember);
 //   - `CompactConfigText`, which collapses detector declarations to their shorthand
-//     form (ReplaceNode, RemoveMember).
+//     form (ReplaceNode, RemoveMember);
+//   - the missing/unused node modules autofix, which adds and removes package.json
+//     dependencies (InsertMember, RemoveMember).
 //
 // The whole approach relies on tidwall/jsonc.ToJSON blanking comments and trailing
 // commas to spaces IN PLACE, keeping byte offsets identical between the stripped and
@@ -150,6 +152,21 @@ func (d *JSONDocument) StringValue(n *JSONNode) (string, bool) {
 	return s, true
 }
 
+// Position converts a byte offset into a 1-based line and column (in bytes).
+func (d *JSONDocument) Position(offset int) (line, column int) {
+	if offset > len(d.Original) {
+		offset = len(d.Original)
+	}
+	line, lineStart := 1, 0
+	for i := 0; i < offset; i++ {
+		if d.Original[i] == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+	return line, offset - lineStart + 1
+}
+
 // ---- scanner ----
 
 type jsonParser struct {
@@ -654,3 +671,91 @@ func RemoveMember(content []byte, obj *JSONNode, key string) ([]Edit, bool) {
 	}
 	return edits, true
 }
+
+// ---- member insertion ----
+
+// precedingWhitespace returns the whitespace (including newlines) directly before pos.
+func precedingWhitespace(content []byte, pos int) string {
+	start := pos
+	for start > 0 {
+		c := content[start-1]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+			start--
+			continue
+		}
+		break
+	}
+	return string(content[start:pos])
+}
+
+// lineIndent returns the indentation of the line holding pos.
+func lineIndent(content []byte, pos int) string {
+	lineStart := pos
+	for lineStart > 0 && content[lineStart-1] != '\n' {
+		lineStart--
+	}
+	end := lineStart
+	for end < len(content) && (content[end] == ' ' || content[end] == '\t') {
+		end++
+	}
+	return string(content[lineStart:end])
+}
+
+// MemberIndent returns the indentation of obj's members, or "" when obj is empty or its
+// members share a line with the braces.
+func MemberIndent(content []byte, obj *JSONNode) string {
+	if obj == nil || obj.Kind != JSONObject || len(obj.Members) == 0 {
+		return ""
+	}
+	first := obj.Members[0].KeyStart
+	if !isLineStart(content, lineIndentStart(content, first)) {
+		return ""
+	}
+	return lineIndent(content, first)
+}
+
+// InsertMember returns the edits that insert a `"key": valueText` member into obj before the
+// member at index (len(obj.Members) appends it). The new member copies the layout of its
+// neighbours, on its own line with their indentation or inline next to them. An empty object
+// gets the member on its own line, indented by indent relative to the line holding the object.
+// valueText is inserted verbatim and must be valid JSON.
+func InsertMember(content []byte, obj *JSONNode, index int, key string, valueText string, indent string) []Edit {
+	if obj == nil || obj.Kind != JSONObject || index < 0 || index > len(obj.Members) {
+		return nil
+	}
+	quotedKey, _ := json.Marshal(key)
+	member := string(quotedKey) + ": " + valueText
+
+	if len(obj.Members) == 0 {
+		outerIndent := lineIndent(content, obj.Start)
+		text := "\n" + outerIndent + indent + member + "\n" + outerIndent
+		if len(bytes.TrimSpace(content[obj.Start+1:obj.End-1])) == 0 {
+			return []Edit{{Start: obj.Start + 1, End: obj.End - 1, Text: text}}
+		}
+		// Keep whatever comment sits inside the braces after the new member.
+		return []Edit{{Start: obj.Start + 1, End: obj.Start + 1, Text: text}}
+	}
+
+	if index < len(obj.Members) {
+		next := obj.Members[index]
+		separator := precedingWhitespace(content, next.KeyStart)
+		return []Edit{{Start: next.KeyStart, End: next.KeyStart, Text: member + "," + separator}}
+	}
+
+	last := obj.Members[len(obj.Members)-1]
+	separator := precedingWhitespace(content, last.KeyStart)
+	if comma := commaAfter(content, last.ValueEnd); comma != -1 {
+		// A trailing comma (JSONC) is kept after the new last member.
+		pos := includeInlineComment(content, comma+1)
+		return []Edit{{Start: pos, End: pos, Text: separator + member + ","}}
+	}
+	// An inline comment stays with the member it documents.
+	pos := includeInlineComment(content, last.ValueEnd)
+	if pos == last.ValueEnd {
+		return []Edit{{Start: pos, End: pos, Text: "," + separator + member}}
+	}
+	return []Edit{
+		{Start: last.ValueEnd, End: last.ValueEnd, Text: ","},
+		{Start: pos, End: pos, Text: separator + member},
+	}
+}
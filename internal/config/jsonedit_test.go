@@ -273,3 +273,64 @@ func TestRemoveMember_LastPreservesSurvivorComment(t *testing.T) {
 		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
 	}
 }
+
+// ---- member insertion ----
+
+func insertMember(t *testing.T, src string, index int, key, value string) string {
+	t.Helper()
+	doc, err := ParseJSONC([]byte(src))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return string(ApplyEdits(doc.Original, InsertMember(doc.Original, doc.Root, index, key, value, "  ")))
+}
+
+func TestInsertMember_Middle(t *testing.T) {
+	src := "{\n  \"a\": 1,\n  \"c\": 3\n}"
+	want := "{\n  \"a\": 1,\n  \"b\": 2,\n  \"c\": 3\n}"
+	if got := insertMember(t, src, 1, "b", "2"); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestInsertMember_First(t *testing.T) {
+	src := "{\n  \"b\": 2\n}"
+	want := "{\n  \"a\": 1,\n  \"b\": 2\n}"
+	if got := insertMember(t, src, 0, "a", "1"); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestInsertMember_LastKeepsInlineComment(t *testing.T) {
+	src := "{\n  \"a\": 1 // KEEP\n}"
+	want := "{\n  \"a\": 1, // KEEP\n  \"b\": 2\n}"
+	if got := insertMember(t, src, 1, "b", "2"); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestInsertMember_LastAfterTrailingComma(t *testing.T) {
+	src := "{\n  \"a\": 1,\n}"
+	want := "{\n  \"a\": 1,\n  \"b\": 2,\n}"
+	if got := insertMember(t, src, 1, "b", "2"); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestInsertMember_Inline(t *testing.T) {
+	src := `{ "a": 1, "c": 3 }`
+	want := `{ "a": 1, "b": 2, "c": 3 }`
+	if got := insertMember(t, src, 1, "b", "2"); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestInsertMember_Empty(t *testing.T) {
+	src := "{\n  \"deps\": {}\n}"
+	want := "{\n  \"deps\": {\n    \"a\": 1\n  }\n}"
+	doc, _ := ParseJSONC([]byte(src))
+	edits := InsertMember(doc.Original, doc.Root.Get("deps"), 0, "a", "1", MemberIndent(doc.Original, doc.Root))
+	if got := string(ApplyEdits(doc.Original, edits)); got != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
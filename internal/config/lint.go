@@ -119,19 +119,28 @@ const (
 	// It is a lossless formatter (like gofmt): the fix is deterministic and semantically
 	// identical, so findings are warnings, not errors. Raw file only; no discovery or parse.
 	RuleCompact LintRuleName = "compact"
+	// RuleUnusedAliases flags package.json `exports` subpaths and `#imports` entries, and
+	// tsconfig `paths` aliases, that no request in the dependency tree uses. It parses the
+	// dependency tree, and its --fix edits package.json/tsconfig files rather than the
+	// config, so it only runs when selected explicitly.
+	RuleUnusedAliases LintRuleName = "unused-aliases"
 )
 
 // AllLintRules is the default set run when no selection is given, in output order.
 var AllLintRules = []LintRuleName{RuleOrphanFileGlobs, RuleOrphanModuleGlobs, RuleOverlappingGlobs, RuleTrailingCommas, RuleCompact}
 
+// OptInLintRules are valid rule names that are not part of the default set.
+var OptInLintRules = []LintRuleName{RuleUnusedAliases}
+
 // ParseLintRules validates a list of rule names (as typed on the CLI) and returns them
 // as LintRuleName values. An empty input selects all rules. Unknown names are an error.
 func ParseLintRules(names []string) ([]LintRuleName, error) {
 	if len(names) == 0 {
 		return append([]LintRuleName(nil), AllLintRules...), nil
 	}
-	valid := make(map[LintRuleName]bool, len(AllLintRules))
-	for _, r := range AllLintRules {
+	knownRules := append(append([]LintRuleName(nil), AllLintRules...), OptInLintRules...)
+	valid := make(map[LintRuleName]bool, len(knownRules))
+	for _, r := range knownRules {
 		valid[r] = true
 	}
 	seen := make(map[LintRuleName]bool)
@@ -142,7 +151,7 @@ func ParseLintRules(names []string) ([]LintRuleName, error) {
 			continue
 		}
 		if !valid[name] {
-			return nil, fmt.Errorf("unknown lint rule %q (valid: %s)", raw, joinLintRules(AllLintRules))
+			return nil, fmt.Errorf("unknown lint rule %q (valid: %s)", raw, joinLintRules(knownRules))
 		}
 		if !seen[name] {
 			seen[name] = true
@@ -171,6 +180,7 @@ type LintResult struct {
 	Overlaps           []OverlapFinding
 	TrailingCommaCount int // redundant trailing commas in the config file (a warning)
 	CompactableCount   int // detector declarations that can be written more compactly (a warning)
+	UnusedAliases      []UnusedAlias
 }
 
 // lintCtx holds the discovered universes for one lint run.
@@ -236,7 +246,7 @@ func LintConfigWithGraph(cfg *RevDepConfig, cwd, packageJson, tsconfigJson strin
 	if len(rules) == 0 {
 		rules = AllLintRules
 	}
-	runFile, runModule, runOverlap, runTrailingCommas, runCompact := false, false, false, false, false
+	runFile, runModule, runOverlap, runTrailingCommas, runCompact, runUnusedAliases := false, false, false, false, false, false
 	for _, r := range rules {
 		switch r {
 		case RuleOrphanFileGlobs:
@@ -249,6 +259,8 @@ func LintConfigWithGraph(cfg *RevDepConfig, cwd, packageJson, tsconfigJson strin
 			runTrailingCommas = true
 		case RuleCompact:
 			runCompact = true
+		case RuleUnusedAliases:
+			runUnusedAliases = true
 		}
 	}
 
@@ -268,9 +280,11 @@ func LintConfigWithGraph(cfg *RevDepConfig, cwd, packageJson, tsconfigJson strin
 
 	ctx := &lintCtx{cwd: cwd, doc: doc, runFile: runFile, runOverlap: runOverlap, sem: make(chan struct{}, runtime.GOMAXPROCS(0))}
 
-	// Only the file/module/overlap rules need file discovery. When only trailing-commas
+	var unusedAliases []UnusedAlias
+
+	// Only the file/module/overlap/alias rules need file discovery. When only trailing-commas
 	// or compact is selected, skip discovery entirely — those are pure document scans.
-	if runFile || runModule || runOverlap {
+	if runFile || runModule || runOverlap || runUnusedAliases {
 		// Discovered files (respecting gitignore + the config's ignoreFiles), needed by the
 		// file/overlap rules and the module rule. The top-level ignoreFiles/processIgnoredFiles
 		// dead-check additionally needs the walk's exclusion byproducts — ignoreScopeFiles (what
@@ -294,6 +308,9 @@ func LintConfigWithGraph(cfg *RevDepConfig, cwd, packageJson, tsconfigJson strin
 			if runModule {
 				ctx.moduleUniverse = buildModuleUniverse(graph.FullTree, graph.ResolverManager, cfg, cwd)
 			}
+			if runUnusedAliases {
+				unusedAliases = findUnusedAliases(graph.FullTree, graph.ResolverManager, cfg, cwd, tsconfigJson)
+			}
 		} else {
 			discovered, excludePatterns, includePatterns, exclusions, err := discoverAllFilesForConfig(cwd, cfg.IgnoreFiles, cfg.ProcessIgnoredFiles)
 			if err != nil {
@@ -302,14 +319,19 @@ func LintConfigWithGraph(cfg *RevDepConfig, cwd, packageJson, tsconfigJson strin
 			allFiles = discovered
 			ignoreScopeFiles = ignoreScope(discovered, exclusions)
 			ignorePrunedDirs = configRelevantPrunedDirs(exclusions.PrunedDirs, cfg.IgnoreFiles, cwd)
-			// The module universe requires the parsed dependency tree — build it only when the
-			// module rule runs, so file-only rule selections skip parsing entirely.
-			if runModule {
-				universe, err := buildModuleUniverseForConfig(cfg, cwd, packageJson, tsconfigJson, allFiles, excludePatterns, includePatterns)
+			// The module universe and alias usage require the parsed dependency tree — build it
+			// only when those rules run, so file-only rule selections skip parsing entirely.
+			if runModule || runUnusedAliases {
+				fullTree, resolverManager, err := buildDependencyTreeForLint(cfg, cwd, packageJson, tsconfigJson, allFiles, excludePatterns, includePatterns)
 				if err != nil {
 					return nil, err
 				}
-				ctx.moduleUniverse = universe
+				if runModule {
+					ctx.moduleUniverse = buildModuleUniverse(fullTree, resolverManager, cfg, cwd)
+				}
+				if runUnusedAliases {
+					unusedAliases = findUnusedAliases(fullTree, resolverManager, cfg, cwd, tsconfigJson)
+				}
 			}
 		}
 
@@ -358,6 +380,7 @@ func LintConfigWithGraph(cfg *RevDepConfig, cwd, packageJson, tsconfigJson strin
 		Overlaps:           ctx.overlaps,
 		TrailingCommaCount: trailingCommaCount,
 		CompactableCount:   compactableCount,
+		UnusedAliases:      unusedAliases,
 	}, nil
 }
 
@@ -892,10 +915,9 @@ func sortOverlaps(overlaps []OverlapFinding) {
 	})
 }
 
-// buildModuleUniverseForConfig builds the dependency tree (parsing every file, the
-// expensive step) and derives the module universe from it. Called only when the module
-// rule runs.
-func buildModuleUniverseForConfig(cfg *RevDepConfig, cwd, packageJson, tsconfigJson string, allFiles []string, excludePatterns, includePatterns []globutil.GlobMatcher) ([]string, error) {
+// buildDependencyTreeForLint builds the dependency tree (parsing every file, the expensive
+// step). Called only when the module or unused-aliases rule runs.
+func buildDependencyTreeForLint(cfg *RevDepConfig, cwd, packageJson, tsconfigJson string, allFiles []string, excludePatterns, includePatterns []globutil.GlobMatcher) (model.MinimalDependencyTree, *resolve.ResolverManager, error) {
 	rulePackageDirs := make([]string, 0, len(cfg.Rules))
 	for _, rule := range cfg.Rules {
 		if rule.Path == "" {
@@ -909,6 +931,7 @@ func buildModuleUniverseForConfig(cfg *RevDepConfig, cwd, packageJson, tsconfigJ
 		excludePatterns,
 		includePatterns,
 		cfg.ConditionNames,
+		cfg.MainFields,
 		cwd,
 		packageJson,
 		tsconfigJson,
@@ -917,9 +940,9 @@ func buildModuleUniverseForConfig(cfg *RevDepConfig, cwd, packageJson, tsconfigJ
 		rulePackageDirs,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return buildModuleUniverse(fullTree, resolverManager, cfg, cwd), nil
+	return fullTree, resolverManager, nil
 }
 
 // buildModuleUniverse gathers every module name a pattern could legitimately match:
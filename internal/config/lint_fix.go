@@ -11,6 +11,8 @@ type FixResult struct {
 	ReportOnlyKept        int // dead patterns left in place (not auto-removed / could not navigate)
 	TrailingCommasRemoved int // redundant trailing commas removed
 	CompactedCount        int // detector declarations rewritten to compact form
+	AliasesRemoved        int // unused exports/imports/paths entries removed from package.json/tsconfig files
+	AliasesKept           int // unused exports entries left in place (removing them would drop the whole exports map)
 }
 
 func ruleWasRun(result *LintResult, rule LintRuleName) bool {
@@ -52,6 +54,15 @@ func ApplyLintFix(result *LintResult) (*FixResult, error) {
 		return fix, fmt.Errorf("no config file to fix")
 	}
 
+	// Unused aliases live in package.json/tsconfig files, independent of the config edits.
+	if len(result.UnusedAliases) > 0 {
+		removed, kept, err := applyUnusedAliasesFix(result.UnusedAliases)
+		fix.AliasesRemoved, fix.AliasesKept = removed, kept
+		if err != nil {
+			return fix, err
+		}
+	}
+
 	content, err := os.ReadFile(result.ConfigFilePath)
 	if err != nil {
 		return fix, err
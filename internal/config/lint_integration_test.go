@@ -607,6 +607,16 @@ func TestParseLintRules(t *testing.T) {
 	if _, err := ParseLintRules([]string{"bogus"}); err == nil {
 		t.Fatal("expected error for unknown rule name")
 	}
+	// Opt-in rules are valid names but not part of the default set.
+	optIn, err := ParseLintRules([]string{"unused-aliases"})
+	if err != nil || len(optIn) != 1 || optIn[0] != RuleUnusedAliases {
+		t.Fatalf("expected [unused-aliases], got %v err=%v", optIn, err)
+	}
+	for _, r := range all {
+		if r == RuleUnusedAliases {
+			t.Fatal("unused-aliases should not run by default")
+		}
+	}
 	// Duplicates collapse.
 	dup, _ := ParseLintRules([]string{"orphan-file-globs", "orphan-file-globs"})
 	if len(dup) != 1 {
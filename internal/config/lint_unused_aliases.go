@@ -0,0 +1,347 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"rev-dep-go/internal/model"
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/resolve"
+)
+
+// AliasSource is the section of a package.json or tsconfig file an alias entry lives in.
+type AliasSource string
+
+const (
+	AliasExports AliasSource = "exports" // package.json `exports` subpath of a workspace package
+	AliasImports AliasSource = "imports" // package.json `imports` (`#name`) entry
+	AliasPaths   AliasSource = "paths"   // tsconfig `compilerOptions.paths` alias
+)
+
+// UnusedAlias is an alias entry that no request in the dependency tree uses.
+type UnusedAlias struct {
+	FilePath string // package.json or tsconfig.json declaring the entry
+	Source   AliasSource
+	Key      string
+	Line     int // 1-based position of the entry key in FilePath
+	Column   int
+	// Exports can also be consumed from outside the workspace, so unused exports are
+	// warnings; unused `#imports` entries and `paths` aliases are errors.
+	Severity Severity
+}
+
+// aliasFile is one alias section (exports, imports or paths) of one file, with the usage
+// gathered from the dependency tree.
+type aliasFile struct {
+	filePath string
+	dir      string // directory prefix (trailing slash) whose files the section applies to
+	source   AliasSource
+	doc      *JSONDocument
+	section  *JSONNode
+	keys     []string
+	used     map[string]bool
+}
+
+// findUnusedAliases reports the `exports` subpaths of workspace packages, the `#imports`
+// entries and the tsconfig `paths` aliases that no request in tree selects. Candidate files are
+// the package.json and tsconfig.json of the cwd, of every rule path and of every workspace
+// package. A request is matched the way Node.js and TypeScript match it: an exact key first,
+// then the wildcard key with the longest prefix.
+//
+//   - exports: requests of any file naming the package (`@org/ui/button` -> "./button");
+//   - #imports: `#` requests of files whose nearest package.json declares the entry;
+//   - paths: requests of files under the tsconfig's directory.
+func findUnusedAliases(tree model.MinimalDependencyTree, rm *resolve.ResolverManager, cfg *RevDepConfig, cwd, tsconfigJson string) []UnusedAlias {
+	normalizeDir := func(dir string) string {
+		return pathutil.NormalizePathForInternal(filepath.Clean(dir))
+	}
+
+	dirs := map[string]bool{normalizeDir(cwd): true}
+	for _, rule := range cfg.Rules {
+		dirs[normalizeDir(pathutil.JoinWithCwd(cwd, rule.Path))] = true
+	}
+	workspacePackages := map[string]string{} // dir -> package name
+	if rm != nil && rm.MonorepoContext() != nil {
+		for name, packagePath := range rm.MonorepoContext().PackageToPath {
+			dir := normalizeDir(packagePath)
+			dirs[dir] = true
+			workspacePackages[dir] = name
+		}
+	}
+	sortedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
+	}
+	sort.Strings(sortedDirs)
+
+	var sections []*aliasFile
+	exportsByPackage := map[string]*aliasFile{}
+	importsByDir := map[string]*aliasFile{}
+	packageJsonDirs := []string{} // directory prefixes with a package.json, longest first
+	var pathsSections []*aliasFile
+
+	for _, dir := range sortedDirs {
+		dirPrefix := pathutil.StandardiseDirPathInternal(dir)
+
+		packageJsonPath := dir + "/package.json"
+		if doc := readAliasDocument(packageJsonPath); doc != nil {
+			packageJsonDirs = append(packageJsonDirs, dirPrefix)
+			if name, isWorkspacePackage := workspacePackages[dir]; isWorkspacePackage {
+				if section := newAliasFile(packageJsonPath, dirPrefix, AliasExports, doc, doc.Root.Get("exports")); section != nil {
+					exportsByPackage[name] = section
+					sections = append(sections, section)
+				}
+			}
+			if section := newAliasFile(packageJsonPath, dirPrefix, AliasImports, doc, doc.Root.Get("imports")); section != nil {
+				importsByDir[dirPrefix] = section
+				sections = append(sections, section)
+			}
+		}
+
+		tsconfigPath := dir + "/tsconfig.json"
+		if dir == normalizeDir(cwd) && tsconfigJson != "" {
+			tsconfigPath = pathutil.NormalizePathForInternal(pathutil.JoinWithCwd(cwd, tsconfigJson))
+		}
+		if doc := readAliasDocument(tsconfigPath); doc != nil {
+			if section := newAliasFile(tsconfigPath, dirPrefix, AliasPaths, doc, doc.Root.Get("compilerOptions").Get("paths")); section != nil {
+				pathsSections = append(pathsSections, section)
+				sections = append(sections, section)
+			}
+		}
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	sort.SliceStable(packageJsonDirs, func(i, j int) bool { return len(packageJsonDirs[i]) > len(packageJsonDirs[j]) })
+
+	for file, deps := range tree {
+		var nearestImports *aliasFile
+		for _, dir := range packageJsonDirs {
+			if strings.HasPrefix(file, dir) {
+				nearestImports = importsByDir[dir]
+				break
+			}
+		}
+		for _, dep := range deps {
+			request, _, _ := strings.Cut(dep.Request, "?")
+			if request == "" || dep.IsLocalExport {
+				continue
+			}
+			if strings.HasPrefix(request, "#") && nearestImports != nil {
+				nearestImports.markUsed(request)
+			}
+			if name := module.GetNodeModuleName(request); name != "" {
+				if section, ok := exportsByPackage[name]; ok {
+					section.markUsed("." + request[len(name):])
+				}
+			}
+			for _, section := range pathsSections {
+				if strings.HasPrefix(file, section.dir) {
+					section.markUsed(request)
+				}
+			}
+		}
+	}
+
+	var unused []UnusedAlias
+	for _, section := range sections {
+		severity := SeverityError
+		if section.source == AliasExports {
+			severity = SeverityWarning
+		}
+		for _, member := range section.section.Members {
+			if !section.isEntry(member) || section.used[member.Name] {
+				continue
+			}
+			line, column := section.doc.Position(member.KeyStart)
+			unused = append(unused, UnusedAlias{
+				FilePath: section.filePath,
+				Source:   section.source,
+				Key:      member.Name,
+				Line:     line,
+				Column:   column,
+				Severity: severity,
+			})
+		}
+	}
+	sort.SliceStable(unused, func(i, j int) bool {
+		if unused[i].FilePath != unused[j].FilePath {
+			return unused[i].FilePath < unused[j].FilePath
+		}
+		return unused[i].Line < unused[j].Line
+	})
+	return unused
+}
+
+// readAliasDocument parses a package.json or tsconfig.json, returning nil when the file is
+// missing or cannot be parsed.
+func readAliasDocument(filePath string) *JSONDocument {
+	content, err := os.ReadFile(pathutil.DenormalizePathForOS(filePath))
+	if err != nil {
+		return nil
+	}
+	doc, err := ParseJSONC(content)
+	if err != nil || doc.Root.Kind != JSONObject {
+		return nil
+	}
+	return doc
+}
+
+// newAliasFile returns the alias section for an object node, or nil when it has no entries.
+func newAliasFile(filePath, dir string, source AliasSource, doc *JSONDocument, section *JSONNode) *aliasFile {
+	if section == nil || section.Kind != JSONObject {
+		return nil
+	}
+	f := &aliasFile{filePath: filePath, dir: dir, source: source, doc: doc, section: section, used: map[string]bool{}}
+	for _, member := range section.Members {
+		if f.isEntry(member) {
+			f.keys = append(f.keys, member.Name)
+		}
+	}
+	if len(f.keys) == 0 {
+		return nil
+	}
+	return f
+}
+
+// isEntry reports whether a member of the section is an alias entry. Condition-only exports
+// (no `.` keys), `null` exclusions and the conventional "./package.json" export are not.
+func (f *aliasFile) isEntry(member JSONMember) bool {
+	switch f.source {
+	case AliasExports:
+		if !strings.HasPrefix(member.Name, ".") || member.Name == "./package.json" {
+			return false
+		}
+	case AliasImports:
+		if !strings.HasPrefix(member.Name, "#") {
+			return false
+		}
+	}
+	return !(member.Value.Kind == JSONPrimitive && f.doc.RawText(member.Value) == "null")
+}
+
+func (f *aliasFile) markUsed(request string) {
+	if key := matchAliasKey(f.keys, request); key != "" {
+		f.used[key] = true
+	}
+}
+
+// matchAliasKey returns the key a request selects: an exact key, otherwise the wildcard (or
+// legacy trailing-slash folder) key with the longest prefix, then the longest key.
+func matchAliasKey(keys []string, request string) string {
+	best, bestPrefixLen := "", -1
+	for _, key := range keys {
+		if key == request {
+			return key
+		}
+		prefix, suffix, isWildcard := strings.Cut(key, "*")
+		if !isWildcard {
+			if strings.HasSuffix(key, "/") && strings.HasPrefix(request, key) && len(key) > bestPrefixLen {
+				best, bestPrefixLen = key, len(key)
+			}
+			continue
+		}
+		if len(request) < len(prefix)+len(suffix) || !strings.HasPrefix(request, prefix) || !strings.HasSuffix(request, suffix) {
+			continue
+		}
+		if len(prefix) > bestPrefixLen || (len(prefix) == bestPrefixLen && len(key) > len(best)) {
+			best, bestPrefixLen = key, len(prefix)
+		}
+	}
+	return best
+}
+
+// aliasSectionNodes navigates to an alias section and the object owning it.
+func aliasSectionNodes(doc *JSONDocument, source AliasSource) (owner *JSONNode, name string) {
+	if source == AliasPaths {
+		return doc.Root.Get("compilerOptions"), "paths"
+	}
+	return doc.Root, string(source)
+}
+
+// applyUnusedAliasesFix removes unused alias entries from their package.json and tsconfig
+// files, preserving comments and formatting. A section whose every entry is unused is removed
+// as a whole, except `exports`: dropping it would expose every file of the package, so those
+// entries are kept and counted in kept.
+func applyUnusedAliasesFix(aliases []UnusedAlias) (removed, kept int, err error) {
+	byFile := map[string][]UnusedAlias{}
+	var files []string
+	for _, alias := range aliases {
+		if _, seen := byFile[alias.FilePath]; !seen {
+			files = append(files, alias.FilePath)
+		}
+		byFile[alias.FilePath] = append(byFile[alias.FilePath], alias)
+	}
+
+	for _, filePath := range files {
+		osPath := pathutil.DenormalizePathForOS(filePath)
+		original, readErr := os.ReadFile(osPath)
+		if readErr != nil {
+			return removed, kept, readErr
+		}
+		current := original
+
+		// One section at a time, re-parsing in between, so edits never overlap.
+		for _, source := range []AliasSource{AliasExports, AliasImports, AliasPaths} {
+			dead := map[string]bool{}
+			for _, alias := range byFile[filePath] {
+				if alias.Source == source {
+					dead[alias.Key] = true
+				}
+			}
+			if len(dead) == 0 {
+				continue
+			}
+			doc, parseErr := ParseJSONC(current)
+			if parseErr != nil {
+				return removed, kept, fmt.Errorf("failed to parse %s for fixing: %w", filePath, parseErr)
+			}
+			owner, name := aliasSectionNodes(doc, source)
+			section := owner.Get(name)
+			if section == nil || section.Kind != JSONObject {
+				kept += len(dead)
+				continue
+			}
+			var deadIdx []int
+			for i, member := range section.Members {
+				if dead[member.Name] {
+					deadIdx = append(deadIdx, i)
+				}
+			}
+			kept += len(dead) - len(deadIdx)
+
+			var edits []Edit
+			if len(deadIdx) == len(section.Members) {
+				if source == AliasExports {
+					kept += len(deadIdx)
+					continue
+				}
+				edits, _ = RemoveMember(doc.Original, owner, name)
+			} else {
+				edits = RemoveObjectMembers(doc.Original, section, deadIdx)
+			}
+			if len(edits) == 0 {
+				kept += len(deadIdx)
+				continue
+			}
+			current = ApplyEdits(doc.Original, edits)
+			removed += len(deadIdx)
+		}
+
+		if string(current) == string(original) {
+			continue
+		}
+		// Never write a file that would no longer parse.
+		if _, parseErr := ParseJSONC(current); parseErr != nil {
+			return removed, kept, fmt.Errorf("fix would produce invalid JSON; leaving %s unchanged: %w", filePath, parseErr)
+		}
+		if writeErr := os.WriteFile(osPath, current, 0644); writeErr != nil {
+			return removed, kept, writeErr
+		}
+	}
+	return removed, kept, nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLintConfig_UnusedAliases(t *testing.T) {
+	dir, mustWrite := newTestProject(t)
+	mustWrite("package.json", `{"name":"root","private":true,"workspaces":["packages/*"]}`)
+	mustWrite("packages/ui/package.json", `{
+  "name": "@acme/ui",
+  "exports": {
+    ".": "./src/index.ts",
+    "./button": "./src/button.ts",
+    "./legacy": "./src/legacy.ts", // no longer imported
+    "./internal/*": null,
+    "./package.json": "./package.json"
+  },
+  "imports": {
+    "#utils/*": "./src/utils/*.ts",
+    "#old": "./src/old.ts"
+  }
+}`)
+	mustWrite("packages/ui/src/index.ts", "export const ui = 1\n")
+	mustWrite("packages/ui/src/button.ts", "import { format } from '#utils/format'\nexport const button = format\n")
+	mustWrite("packages/ui/src/utils/format.ts", "export const format = 1\n")
+	mustWrite("packages/app/package.json", `{"name":"@acme/app","dependencies":{"@acme/ui":"workspace:*"}}`)
+	mustWrite("packages/app/tsconfig.json", `{
+  // app aliases
+  "compilerOptions": {
+    "paths": {
+      "@old/*": ["./src/old/*"]
+    }
+  }
+}`)
+	mustWrite("packages/app/src/index.ts", "import { ui } from '@acme/ui'\nimport { button } from '@acme/ui/button'\nexport const app = [ui, button]\n")
+	mustWrite("rev-dep.config.json", `{"configVersion": "1.13", "rules": [{ "path": "." }]}`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	result, err := LintConfig(&cfg, dir, "", "", []LintRuleName{RuleUnusedAliases})
+	if err != nil {
+		t.Fatalf("LintConfig: %v", err)
+	}
+
+	got := map[string]UnusedAlias{}
+	for _, alias := range result.UnusedAliases {
+		got[string(alias.Source)+" "+alias.Key] = alias
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 unused aliases, got %+v", result.UnusedAliases)
+	}
+	legacy, ok := got["exports ./legacy"]
+	if !ok || legacy.Severity != SeverityWarning || legacy.Line != 6 || legacy.Column != 5 {
+		t.Errorf("unexpected ./legacy finding: %+v", legacy)
+	}
+	if old, ok := got["imports #old"]; !ok || old.Severity != SeverityError {
+		t.Errorf("expected #old to be reported as an error, got %+v", result.UnusedAliases)
+	}
+	if _, ok := got["paths @old/*"]; !ok {
+		t.Errorf("expected @old/* to be reported, got %+v", result.UnusedAliases)
+	}
+
+	fix, err := ApplyLintFix(result)
+	if err != nil {
+		t.Fatalf("ApplyLintFix: %v", err)
+	}
+	if fix.AliasesRemoved != 3 {
+		t.Errorf("AliasesRemoved = %d, want 3", fix.AliasesRemoved)
+	}
+	packageJson, _ := os.ReadFile(filepath.Join(dir, "packages/ui/package.json"))
+	if contains(string(packageJson), "./legacy") || contains(string(packageJson), "#old") || !contains(string(packageJson), "#utils/*") || !contains(string(packageJson), "./internal/*") {
+		t.Errorf("unexpected package.json after fix:\n%s", packageJson)
+	}
+	tsconfig, _ := os.ReadFile(filepath.Join(dir, "packages/app/tsconfig.json"))
+	if contains(string(tsconfig), "paths") || !contains(string(tsconfig), "// app aliases") {
+		t.Errorf("expected the emptied paths section to be removed with comments kept:\n%s", tsconfig)
+	}
+}
+
+func TestMatchAliasKey(t *testing.T) {
+	keys := []string{"./button", "./*", "./icons/*", "./icons/*.svg", "./legacy/"}
+	cases := map[string]string{
+		"./button":         "./button",
+		"./icons/star":     "./icons/*",
+		"./icons/star.svg": "./icons/*.svg",
+		"./legacy/a/b":     "./legacy/",
+		"./other":          "./*",
+		"other":            "",
+	}
+	for request, want := range cases {
+		if got := matchAliasKey(keys, request); got != want {
+			t.Errorf("matchAliasKey(%q) = %q, want %q", request, got, want)
+		}
+	}
+}
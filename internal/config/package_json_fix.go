@@ -0,0 +1,244 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/checks"
+	globutil "rev-dep-go/internal/glob"
+	"rev-dep-go/internal/graph"
+	"rev-dep-go/internal/model"
+	"rev-dep-go/internal/node"
+	"rev-dep-go/internal/pathutil"
+)
+
+// packageJsonDependencyFields are the package.json sections missing/unused node modules
+// detection reads declared modules from, in the order they are usually written.
+var packageJsonDependencyFields = []string{"dependencies", "devDependencies"}
+
+// prodReachableFiles returns a predicate telling whether a file is reachable from the rule's
+// prodEntryPoints. Without prodEntryPoints every file counts as production code, so modules
+// are added to dependencies rather than risk leaving them out of production installs.
+func prodReachableFiles(ruleTree model.MinimalDependencyTree, prodEntryPoints []string, rulePath string) func(filePath string) bool {
+	if len(prodEntryPoints) == 0 {
+		return func(string) bool { return true }
+	}
+	entryPointGlobs := globutil.CreateGlobMatchers(prodEntryPoints, rulePath)
+	entryPoints := []string{}
+	for filePath := range ruleTree {
+		if globutil.MatchesAnyGlobMatcher(filePath, entryPointGlobs, false) {
+			entryPoints = append(entryPoints, filePath)
+		}
+	}
+	slices.Sort(entryPoints)
+
+	reachable := graph.BuildDepsGraphForMultiple(ruleTree, entryPoints, nil, false, false).Vertices
+	return func(filePath string) bool {
+		_, ok := reachable[filePath]
+		return ok
+	}
+}
+
+// missingNodeModuleFixes returns the changes adding a missing module to the package.json of
+// every file importing it: to dependencies when one of those files is production code, to
+// devDependencies otherwise, with a caret range of the version found by lookup. It returns nil
+// when a package.json or a version is unknown, so an issue is either fixed fully or not at all.
+func missingNodeModuleFixes(
+	missing node.MissingNodeModuleResult,
+	packageJsonForFile func(filePath string) string,
+	isProdFile func(filePath string) bool,
+	lookup *node.InstalledVersionLookup,
+) []node.PackageJsonDependencyFix {
+	filesByPackageJson := map[string][]string{}
+	for _, filePath := range missing.ImportedFrom {
+		packageJsonPath := packageJsonForFile(filePath)
+		if packageJsonPath == "" {
+			return nil
+		}
+		filesByPackageJson[packageJsonPath] = append(filesByPackageJson[packageJsonPath], filePath)
+	}
+
+	packageJsonPaths := make([]string, 0, len(filesByPackageJson))
+	for packageJsonPath := range filesByPackageJson {
+		packageJsonPaths = append(packageJsonPaths, packageJsonPath)
+	}
+	slices.Sort(packageJsonPaths)
+
+	fixes := make([]node.PackageJsonDependencyFix, 0, len(packageJsonPaths))
+	for _, packageJsonPath := range packageJsonPaths {
+		version := lookup.Version(filepath.Dir(packageJsonPath), missing.ModuleName)
+		if version == "" {
+			return nil
+		}
+		field := "devDependencies"
+		if slices.ContainsFunc(filesByPackageJson[packageJsonPath], isProdFile) {
+			field = "dependencies"
+		}
+		fixes = append(fixes, node.PackageJsonDependencyFix{
+			PackageJsonPath: packageJsonPath,
+			ModuleName:      missing.ModuleName,
+			Field:           field,
+			Version:         "^" + version,
+		})
+	}
+	return fixes
+}
+
+// versionConsistencyFix returns the change aligning the range of a mismatching declaration on the
+// expected one.
+func versionConsistencyFix(v checks.VersionConsistencyViolation) node.PackageJsonDependencyFix {
+	return node.PackageJsonDependencyFix{
+		PackageJsonPath: pathutil.DenormalizePathForOS(v.PackageJsonPath),
+		ModuleName:      v.Dependency,
+		Field:           v.DependencyField,
+		Version:         v.ExpectedRange,
+		PreviousVersion: v.DeclaredRange,
+	}
+}
+
+// applyPackageJsonDependencyFixes applies the fixes to their package.json files, preserving
+// formatting and comments. Fixes that no longer apply (a module already added or removed by
+// another rule, or a range already changed) are skipped. It returns the number of modules added,
+// removed and updated.
+func applyPackageJsonDependencyFixes(fixes []node.PackageJsonDependencyFix) (int, int, int, error) {
+	fixesByPath := map[string][]node.PackageJsonDependencyFix{}
+	for _, fix := range fixes {
+		fixesByPath[fix.PackageJsonPath] = append(fixesByPath[fix.PackageJsonPath], fix)
+	}
+	paths := make([]string, 0, len(fixesByPath))
+	for path := range fixesByPath {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	added, removed, updated := 0, 0, 0
+	for _, path := range paths {
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return added, removed, updated, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		content := original
+		for _, fix := range fixesByPath[path] {
+			updatedContent, applied, err := applyPackageJsonDependencyFix(content, fix)
+			if err != nil {
+				return added, removed, updated, fmt.Errorf("failed to update %s: %w", path, err)
+			}
+			if !applied {
+				continue
+			}
+			content = updatedContent
+			switch {
+			case fix.IsUpdate():
+				updated++
+			case fix.IsRemoval():
+				removed++
+			default:
+				added++
+			}
+		}
+		if string(content) == string(original) {
+			continue
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return added, removed, updated, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return added, removed, updated, nil
+}
+
+// applyPackageJsonDependencyFix applies a single fix. Every edit is computed against a fresh
+// parse, so the edits of consecutive fixes never overlap.
+func applyPackageJsonDependencyFix(content []byte, fix node.PackageJsonDependencyFix) ([]byte, bool, error) {
+	if fix.IsUpdate() {
+		doc, err := ParseJSONC(content)
+		if err != nil {
+			return nil, false, err
+		}
+		section := doc.Root.Get(fix.Field)
+		if section == nil {
+			return content, false, nil
+		}
+		member := section.GetMember(fix.ModuleName)
+		if member == nil {
+			return content, false, nil
+		}
+		if current, ok := doc.StringValue(member.Value); !ok || strings.TrimSpace(current) != fix.PreviousVersion {
+			return content, false, nil
+		}
+		quotedVersion, _ := json.Marshal(fix.Version)
+		return ApplyEdits(content, []Edit{ReplaceNode(member.Value, string(quotedVersion))}), true, nil
+	}
+	if fix.IsRemoval() {
+		applied := false
+		for _, field := range packageJsonDependencyFields {
+			doc, err := ParseJSONC(content)
+			if err != nil {
+				return nil, false, err
+			}
+			edits, ok := RemoveMember(content, doc.Root.Get(field), fix.ModuleName)
+			if !ok {
+				continue
+			}
+			content = ApplyEdits(content, edits)
+			applied = true
+		}
+		return content, applied, nil
+	}
+
+	doc, err := ParseJSONC(content)
+	if err != nil {
+		return nil, false, err
+	}
+	if doc.Root.Kind != JSONObject {
+		return nil, false, fmt.Errorf("package.json is not an object")
+	}
+	for _, field := range packageJsonDependencyFields {
+		if section := doc.Root.Get(field); section != nil && section.GetMember(fix.ModuleName) != nil {
+			return content, false, nil
+		}
+	}
+
+	quotedName, _ := json.Marshal(fix.ModuleName)
+	quotedVersion, _ := json.Marshal(fix.Version)
+	indent := MemberIndent(content, doc.Root)
+
+	if section := doc.Root.Get(fix.Field); section != nil {
+		if section.Kind != JSONObject {
+			return nil, false, fmt.Errorf("%s is not an object", fix.Field)
+		}
+		// Keep alphabetically ordered sections (as npm, pnpm and yarn write them) ordered.
+		names := make([]string, len(section.Members))
+		for i, member := range section.Members {
+			names[i] = member.Name
+		}
+		index := len(names)
+		if slices.IsSorted(names) {
+			index, _ = slices.BinarySearch(names, fix.ModuleName)
+		}
+		sectionIndent := indent
+		if sectionIndent == "" {
+			sectionIndent = "  "
+		}
+		return ApplyEdits(content, InsertMember(content, section, index, fix.ModuleName, string(quotedVersion), sectionIndent)), true, nil
+	}
+
+	// The section is added next to the other one: dependencies before devDependencies.
+	index := len(doc.Root.Members)
+	for i, member := range doc.Root.Members {
+		if fix.Field == "dependencies" && member.Name == "devDependencies" {
+			index = i
+		}
+		if fix.Field == "devDependencies" && member.Name == "dependencies" {
+			index = i + 1
+		}
+	}
+	sectionText := fmt.Sprintf("{%s: %s}", quotedName, quotedVersion)
+	if indent != "" {
+		sectionText = fmt.Sprintf("{\n%s%s: %s\n%s}", strings.Repeat(indent, 2), quotedName, quotedVersion, indent)
+	}
+	return ApplyEdits(content, InsertMember(content, doc.Root, index, fix.Field, sectionText, "  ")), true, nil
+}
@@ -51,21 +51,39 @@ type RuleResult struct {
 	DependencyTree                                  model.MinimalDependencyTree
 	ModuleBoundaryViolations                        []checks.ModuleBoundaryViolation
 	CircularDependencies                            [][]string
+	CycleBudgets                                    []checks.CycleBudgetUsage        // Usage of the maxCycles and cycleBudgets limits, when set
+	CycleBreakingSuggestions                        []checks.CycleBreakingSuggestion // Imports breaking the components of the reported cycles
 	OrphanFiles                                     []string
 	OrphanFilesAutofixable                          []string
 	MissingNodeModules                              []node.MissingNodeModuleResult
 	MissingNodeModulesOutputType                    string
 	UnusedNodeModules                               []node.UnusedNodeModuleIssue
 	UnusedNodeModulesOutputType                     string
+	UntypedNodeModuleImports                        []node.UntypedNodeModuleImport
 	ImportConventionViolations                      []checks.ImportConventionViolation
 	UnusedExports                                   []checks.UnusedExport
 	UnresolvedImports                               []checks.UnresolvedImport
 	RestrictedDevDependenciesUsageViolations        []checks.RestrictedDevDependenciesUsageViolation
+	UnnecessaryProdDependencyViolations             []checks.UnnecessaryProdDependencyViolation
 	RestrictedImportsViolations                     []checks.RestrictedImportViolation
 	RestrictedImportersViolations                   []checks.RestrictedImporterViolation
 	RestrictedDirectImportersViolations             []checks.RestrictedDirectImporterViolation
+	WorkspaceProtocolViolations                     []checks.WorkspaceProtocolViolation
+	LayerViolations                                 []checks.LayerViolation
+	BarrelFileViolations                            []checks.BarrelFileViolation
+	TypeImportViolations                            []checks.TypeImportViolation
+	UnusedWorkspacePackageViolations                []checks.UnusedWorkspacePackageViolation
+	DeepImportViolations                            []checks.DeepImportViolation
+	ComplexityBudgetViolations                      []checks.ComplexityBudgetViolation
+	TeamDependencies                                []checks.TeamDependency
+	ReportTeamDependencies                          bool // an ownership boundaries detection is reportOnly
+	OwnershipBoundaryViolations                     []checks.OwnershipBoundaryViolation
+	TestIsolationViolations                         []checks.TestIsolationViolation
+	PeerDependencyViolations                        []checks.PeerDependencyViolation
+	VersionConsistencyViolations                    []checks.VersionConsistencyViolation
 	RestrictedImportsFollowMonorepoPackages         model.FollowMonorepoPackagesValue
 	ProcessIgnoredFiles                             []string
+	ConditionNames                                  []string
 	MissingPackageJson                              bool
 	ShouldWarnAboutImportConventionWithPJsonImports bool
 	UnmatchedEntryPointPatterns                     UnmatchedEntryPointPatterns
@@ -83,14 +101,17 @@ type UnmatchedEntryPointPatterns struct {
 
 // ConfigProcessingResult contains the results for processing an entire config
 type ConfigProcessingResult struct {
-	RuleResults            []RuleResult
-	HasFailures            bool
-	FixedFilesCount        int
-	FixedImportsCount      int
-	DeletedFilesCount      int
-	UnfixableAliasingCount int
-	FixableIssuesCount     int
-	FullTree               model.MinimalDependencyTree
+	RuleResults             []RuleResult
+	HasFailures             bool
+	FixedFilesCount         int
+	FixedImportsCount       int
+	DeletedFilesCount       int
+	AddedNodeModulesCount   int // missing node modules added to package.json files
+	RemovedNodeModulesCount int // unused node modules removed from package.json files
+	UpdatedNodeModulesCount int // dependency ranges aligned across workspace package.json files
+	UnfixableAliasingCount  int
+	FixableIssuesCount      int
+	FullTree                model.MinimalDependencyTree
 	// Discovery/resolver artifacts, exposed so a caller (e.g. `config run --lint-config`) can
 	// lint without redoing the expensive discovery + dependency-tree build.
 	DiscoveredFiles []string
@@ -174,6 +195,20 @@ func anyRuleChecksForUnusedExports(config *RevDepConfig) bool {
 	return false
 }
 
+// anyRuleNeedsDetailedParse reports whether any rule enables a check that reads export keywords
+// (detailed parse mode).
+func anyRuleNeedsDetailedParse(config *RevDepConfig) bool {
+	if anyRuleChecksForUnusedExports(config) {
+		return true
+	}
+	for _, rule := range config.Rules {
+		if anyEnabled(rule.getBarrelFilesDetections()) || anyEnabled(rule.getTypeImportsDetections()) {
+			return true
+		}
+	}
+	return false
+}
+
 type enabledOption interface {
 	IsEnabled() bool
 }
@@ -187,12 +222,57 @@ func anyEnabled[T enabledOption](items []T) bool {
 	return false
 }
 
+// loadCodeownersForConfig reads the CODEOWNERS files of the enabled ownership boundaries
+// detections, keyed by their codeownersPath ("" for the default locations). As on GitHub, the
+// default locations are looked up and patterns are matched relative to the repository root (the
+// closest directory with a `.git` from cwd up), or to cwd outside of a git repository. An explicit
+// codeownersPath is relative to cwd.
+func loadCodeownersForConfig(config *RevDepConfig, cwd string) (map[string]*checks.Codeowners, error) {
+	codeowners := map[string]*checks.Codeowners{}
+	repoRoot := fs.FindRepoRoot(cwd)
+	if repoRoot == "" {
+		repoRoot = cwd
+	}
+	root := pathutil.NormalizePathForInternal(filepath.Clean(repoRoot))
+	for _, rule := range config.Rules {
+		for _, detection := range rule.getOwnershipBoundariesDetections() {
+			if !detection.IsEnabled() {
+				continue
+			}
+			if _, loaded := codeowners[detection.CodeownersPath]; loaded {
+				continue
+			}
+			candidates, candidatesDir := checks.DefaultCodeownersLocations, repoRoot
+			if detection.CodeownersPath != "" {
+				candidates, candidatesDir = []string{detection.CodeownersPath}, cwd
+			}
+			var content []byte
+			for _, candidate := range candidates {
+				data, err := os.ReadFile(pathutil.JoinWithCwd(candidatesDir, candidate))
+				if err == nil {
+					content = data
+					break
+				}
+				if !os.IsNotExist(err) || detection.CodeownersPath != "" {
+					return nil, fmt.Errorf("ownershipBoundariesDetection: failed to read CODEOWNERS file '%s': %w", candidate, err)
+				}
+			}
+			if content == nil {
+				return nil, fmt.Errorf("ownershipBoundariesDetection: no CODEOWNERS file found (looked for %s)", strings.Join(candidates, ", "))
+			}
+			codeowners[detection.CodeownersPath] = checks.ParseCodeowners(content, root)
+		}
+	}
+	return codeowners, nil
+}
+
 // buildDependencyTreeForConfig builds dependency tree for config processing
 func buildDependencyTreeForConfig(
 	allFiles []string,
 	excludePatterns []globutil.GlobMatcher,
 	includePatterns []globutil.GlobMatcher,
 	conditionNames []string,
+	mainFields []string,
 	cwd string,
 	packageJson string,
 	tsconfigJson string,
@@ -200,14 +280,29 @@ func buildDependencyTreeForConfig(
 	parseMode model.ParseMode,
 	explicitPackageDirs []string,
 ) (model.MinimalDependencyTree, *resolve.ResolverManager, error) {
-	// For config processing, we always resolve type imports (we filter later per-check)
-	ignoreTypeImports := false
+	fileImportsArr := parseImportsForConfig(allFiles, parseMode)
 
-	// We always follow monorepo packages for comprehensive analysis
-	followMonorepoPackages := model.FollowMonorepoPackagesValue{FollowAll: true}
+	return resolveDependencyTreeForConfig(
+		fileImportsArr,
+		allFiles,
+		excludePatterns,
+		includePatterns,
+		conditionNames,
+		mainFields,
+		cwd,
+		packageJson,
+		tsconfigJson,
+		customAssetExtensions,
+		parseMode,
+		explicitPackageDirs,
+	)
+}
 
-	// Skip resolving missing files for performance
-	skipResolveMissing := false
+// parseImportsForConfig parses imports from all files and sorts allFiles in place, as
+// resolveDependencyTreeForConfig expects.
+func parseImportsForConfig(allFiles []string, parseMode model.ParseMode) []model.FileImports {
+	// For config processing, we always parse type imports (we filter later per-check)
+	ignoreTypeImports := false
 
 	// Parse imports from all files
 	doneParse := perf.Track("parse-imports")
@@ -218,11 +313,40 @@ func buildDependencyTreeForConfig(
 	slices.Sort(allFiles)
 	doneSort()
 
+	return fileImportsArr
+}
+
+// resolveDependencyTreeForConfig resolves parsed imports with the given condition names and
+// transforms them into a minimal dependency tree. Resolution mutates fileImportsArr, so callers
+// resolving the same parse result more than once pass a clone (see cloneFileImports).
+func resolveDependencyTreeForConfig(
+	fileImportsArr []model.FileImports,
+	sortedFiles []string,
+	excludePatterns []globutil.GlobMatcher,
+	includePatterns []globutil.GlobMatcher,
+	conditionNames []string,
+	mainFields []string,
+	cwd string,
+	packageJson string,
+	tsconfigJson string,
+	customAssetExtensions []string,
+	parseMode model.ParseMode,
+	explicitPackageDirs []string,
+) (model.MinimalDependencyTree, *resolve.ResolverManager, error) {
+	// For config processing, we always resolve type imports (we filter later per-check)
+	ignoreTypeImports := false
+
+	// We always follow monorepo packages for comprehensive analysis
+	followMonorepoPackages := model.FollowMonorepoPackagesValue{FollowAll: true}
+
+	// Skip resolving missing files for performance
+	skipResolveMissing := false
+
 	// Resolve imports using the existing resolver
 	doneResolve := perf.Track("resolve-imports")
 	fileImportsArr, _, resolverManager := resolve.ResolveImports(
 		fileImportsArr,
-		allFiles,
+		sortedFiles,
 		cwd,
 		ignoreTypeImports,
 		skipResolveMissing,
@@ -231,6 +355,7 @@ func buildDependencyTreeForConfig(
 		excludePatterns,
 		includePatterns,
 		conditionNames,
+		mainFields,
 		followMonorepoPackages,
 		explicitPackageDirs,
 		customAssetExtensions,
@@ -248,6 +373,32 @@ func buildDependencyTreeForConfig(
 	return minimalTree, resolverManager, nil
 }
 
+// cloneFileImports copies parsed imports deeply enough for an independent resolution pass:
+// resolution rewrites each Import in place, while Keywords is only read and stays shared.
+func cloneFileImports(fileImportsArr []model.FileImports) []model.FileImports {
+	cloned := make([]model.FileImports, len(fileImportsArr))
+	for i, fileImports := range fileImportsArr {
+		cloned[i] = model.FileImports{
+			FilePath: fileImports.FilePath,
+			Imports:  slices.Clone(fileImports.Imports),
+		}
+	}
+	return cloned
+}
+
+// conditionNamesKey identifies a set of condition names. Order matters: conditions are matched
+// in the order they are listed.
+func conditionNamesKey(conditionNames []string) string {
+	return strings.Join(conditionNames, "\x00")
+}
+
+// dependencyTreeForConditions is the dependency tree and resolver manager built for one set of
+// condition names.
+type dependencyTreeForConditions struct {
+	tree            model.MinimalDependencyTree
+	resolverManager *resolve.ResolverManager
+}
+
 func filterFilesForRule(
 	fullTree model.MinimalDependencyTree,
 	rulePath string,
@@ -363,6 +514,7 @@ func processRuleChecks(
 	fix bool,
 	nearestPackage bool,
 	includeDevDepsFromRoot bool,
+	codeowners map[string]*checks.Codeowners,
 ) RuleResult {
 	// Track enabled checks
 	enabledChecks := []string{}
@@ -401,6 +553,39 @@ func processRuleChecks(
 	if anyEnabled(rule.getRestrictedDirectImportersDetections()) {
 		enabledChecks = append(enabledChecks, "restricted-direct-importers")
 	}
+	if anyEnabled(rule.getWorkspaceProtocolDetections()) {
+		enabledChecks = append(enabledChecks, "workspace-protocol")
+	}
+	if anyEnabled(rule.getLayersDetections()) {
+		enabledChecks = append(enabledChecks, "layers")
+	}
+	if anyEnabled(rule.getBarrelFilesDetections()) {
+		enabledChecks = append(enabledChecks, "barrel-files")
+	}
+	if anyEnabled(rule.getTypeImportsDetections()) {
+		enabledChecks = append(enabledChecks, "type-imports")
+	}
+	if anyEnabled(rule.getUnusedWorkspacePackagesDetections()) {
+		enabledChecks = append(enabledChecks, "unused-workspace-packages")
+	}
+	if anyEnabled(rule.getDeepImportsDetections()) {
+		enabledChecks = append(enabledChecks, "deep-imports")
+	}
+	if anyEnabled(rule.getComplexityBudgetsDetections()) {
+		enabledChecks = append(enabledChecks, "complexity-budgets")
+	}
+	if anyEnabled(rule.getOwnershipBoundariesDetections()) {
+		enabledChecks = append(enabledChecks, "ownership-boundaries")
+	}
+	if anyEnabled(rule.getTestIsolationDetections()) {
+		enabledChecks = append(enabledChecks, "test-isolation")
+	}
+	if anyEnabled(rule.getPeerDependenciesDetections()) {
+		enabledChecks = append(enabledChecks, "peer-dependencies")
+	}
+	if anyEnabled(rule.getVersionConsistencyDetections()) {
+		enabledChecks = append(enabledChecks, "version-consistency")
+	}
 	if len(rule.ImportConventions) > 0 {
 		enabledChecks = append(enabledChecks, "import-conventions")
 	}
@@ -477,6 +662,8 @@ func processRuleChecks(
 			slices.Sort(sortedRuleFiles)
 
 			circularDeps := make([][]string, 0)
+			cycleBudgets := make([]checks.CycleBudgetUsage, 0)
+			cycleBreakingSuggestions := make([]checks.CycleBreakingSuggestion, 0)
 			for _, detection := range rule.getCircularImportsDetections() {
 				if !detection.Enabled {
 					continue
@@ -485,24 +672,48 @@ func processRuleChecks(
 				if algo == "" {
 					algo = "dfs"
 				}
+				var cycles [][]string
 				switch algo {
 				case "scc":
-					circularDeps = append(circularDeps, checks.FindCircularDependenciesSCC(
+					cycles = checks.FindCircularDependenciesSCC(
 						ruleTree,
 						sortedRuleFiles,
 						detection.IgnoreTypeImports,
-					)...)
+					)
 				default:
-					circularDeps = append(circularDeps, checks.FindCircularDependencies(
+					cycles = checks.FindCircularDependencies(
 						ruleTree,
 						sortedRuleFiles,
 						detection.IgnoreTypeImports,
-					)...)
+					)
+				}
+
+				// Cycles within their budget pass; only those of exceeded budgets are reported.
+				failingCycles, usage := checks.ApplyCycleBudgets(cycles, detection.MaxCycles, detection.CycleBudgets, fullRulePath)
+				circularDeps = append(circularDeps, failingCycles...)
+				if detection.MaxCycles > 0 || len(detection.CycleBudgets) > 0 {
+					cycleBudgets = append(cycleBudgets, usage...)
+				}
+				if len(failingCycles) == 0 || !detection.SuggestCycleBreaks {
+					continue
+				}
+
+				// Suggest imports to remove for the components the failing cycles belong to.
+				failingFiles := map[string]bool{}
+				for _, cycle := range failingCycles {
+					failingFiles[cycle[0]] = true
+				}
+				for _, suggestion := range checks.FindCycleBreakingEdges(ruleTree, sortedRuleFiles, detection.IgnoreTypeImports) {
+					if slices.ContainsFunc(suggestion.Files, func(file string) bool { return failingFiles[file] }) {
+						cycleBreakingSuggestions = append(cycleBreakingSuggestions, suggestion)
+					}
 				}
 			}
 
 			mu.Lock()
 			ruleResult.CircularDependencies = circularDeps
+			ruleResult.CycleBudgets = cycleBudgets
+			ruleResult.CycleBreakingSuggestions = cycleBreakingSuggestions
 			mu.Unlock()
 		}()
 	}
@@ -576,7 +787,10 @@ func processRuleChecks(
 			defer perf.Track("rules/checks/unused-node-modules")()
 			defer wg.Done()
 			unusedSet := map[string]bool{}
+			autofixSet := map[string]bool{}
 			unusedModules := make([]node.UnusedNodeModuleIssue, 0)
+			untypedSet := map[string]bool{}
+			untypedImports := make([]node.UntypedNodeModuleImport, 0)
 			outputType := ""
 
 			for _, detection := range rule.getUnusedNodeModulesDetections() {
@@ -604,17 +818,47 @@ func processRuleChecks(
 							PackageJsonPath: rulePathResolver.PackageJSONPath(),
 						})
 					}
+					if detection.Autofix {
+						autofixSet[moduleName] = true
+					}
+				}
+				if detection.ReportUntypedImports {
+					for _, untyped := range node.GetUntypedNodeModuleImports(
+						ruleTree,
+						rulePathNodeModules,
+						fullRulePath,
+						detection.IncludeModules,
+						detection.ExcludeModules,
+						entryOwnedFiles,
+					) {
+						if !untypedSet[untyped.ModuleName] {
+							untypedSet[untyped.ModuleName] = true
+							untypedImports = append(untypedImports, untyped)
+						}
+					}
 				}
 				if outputType == "" && detection.OutputType != "" {
 					outputType = detection.OutputType
 				}
 			}
+			slices.SortFunc(untypedImports, func(a, b node.UntypedNodeModuleImport) int {
+				return strings.Compare(a.ModuleName, b.ModuleName)
+			})
 			slices.SortFunc(unusedModules, func(a, b node.UnusedNodeModuleIssue) int {
 				return strings.Compare(a.ModuleName, b.ModuleName)
 			})
+			for i, issue := range unusedModules {
+				if autofixSet[issue.ModuleName] && issue.PackageJsonPath != "" {
+					unusedModules[i].Fix = &node.PackageJsonDependencyFix{
+						PackageJsonPath: issue.PackageJsonPath,
+						ModuleName:      issue.ModuleName,
+					}
+				}
+			}
 
 			mu.Lock()
 			ruleResult.UnusedNodeModules = unusedModules
+			ruleResult.UntypedNodeModuleImports = untypedImports
 			if outputType != "" {
 				ruleResult.UnusedNodeModulesOutputType = outputType
 			}
@@ -629,26 +873,55 @@ func processRuleChecks(
 			defer perf.Track("rules/checks/missing-node-modules")()
 			defer wg.Done()
 			missingModules := make([]node.MissingNodeModuleResult, 0)
+			autofixModules := map[string]bool{}
 			outputType := ""
 			for _, detection := range rule.getMissingNodeModulesDetections() {
 				if !detection.Enabled {
 					continue
 				}
 
-				missingModules = append(missingModules, node.GetMissingNodeModulesFromTree(
+				found := node.GetMissingNodeModulesFromTree(
 					ruleTree,
 					detection.IncludeModules,
 					detection.ExcludeModules,
 					rulePathNodeModules,
 					rootDevDependencies,
 					nearestPackage,
-				)...)
+				)
+				if detection.Autofix {
+					for _, missing := range found {
+						autofixModules[missing.ModuleName] = true
+					}
+				}
+				missingModules = append(missingModules, found...)
 
 				if outputType == "" && detection.OutputType != "" {
 					outputType = detection.OutputType
 				}
 			}
 
+			// Autofix adds each module to the package.json owning the importing files: the entry
+			// package in entry-package mode, each file's nearest package in nearest-package mode.
+			if len(autofixModules) > 0 {
+				packageJsonForFile := func(filePath string) string {
+					resolver := rulePathResolver
+					if nearestPackage {
+						resolver = resolverManager.GetResolverForFile(filePath)
+					}
+					if resolver == nil {
+						return ""
+					}
+					return resolver.PackageJSONPath()
+				}
+				isProdFile := prodReachableFiles(ruleTree, rule.ProdEntryPoints, fullRulePath)
+				lookup := node.NewInstalledVersionLookup()
+				for i := range missingModules {
+					if autofixModules[missingModules[i].ModuleName] {
+						missingModules[i].Fixes = missingNodeModuleFixes(missingModules[i], packageJsonForFile, isProdFile, lookup)
+					}
+				}
+			}
+
 			mu.Lock()
 			ruleResult.MissingNodeModules = missingModules
 			if outputType != "" {
@@ -801,13 +1074,27 @@ func processRuleChecks(
 				return merged
 			}
 
+			// The production dependencies checked by reportUnnecessaryProdDependencies follow the
+			// same resolution. Modules declared in both sections already are dev dependencies.
+			prodDepsOf := func(resolver *resolve.ModuleResolver) map[string]bool {
+				prodDeps := map[string]bool{}
+				for moduleName := range resolver.NodeModules() {
+					if !resolver.DevNodeModules()[moduleName] {
+						prodDeps[moduleName] = true
+					}
+				}
+				return prodDeps
+			}
+
 			var devDepsForFile func(filePath string) map[string]bool
+			var prodDepsForFile func(filePath string) (string, map[string]bool)
 			if nearestPackage {
 				// nearest-package: each file is checked against its own nearest package's
 				// devDependencies. Precompute one merged set per resolver root (keyed by the
 				// resolver root path) and attribute each file to a root via the canonical
 				// prefix-matching resolver lookup.
 				devDepsByResolverRoot := map[string]map[string]bool{}
+				prodDepsByResolverRoot := map[string]map[string]bool{}
 				registerResolver := func(resolver *resolve.ModuleResolver) {
 					if resolver == nil {
 						return
@@ -817,6 +1104,7 @@ func processRuleChecks(
 						return
 					}
 					devDepsByResolverRoot[root] = mergeWithRoot(resolver.DevNodeModules())
+					prodDepsByResolverRoot[root] = prodDepsOf(resolver)
 				}
 				registerResolver(resolverManager.RootResolver())
 				registerResolver(resolverManager.CwdResolver())
@@ -831,6 +1119,13 @@ func processRuleChecks(
 					}
 					return devDepsByResolverRoot[fileResolver.ResolverRoot()]
 				}
+				prodDepsForFile = func(filePath string) (string, map[string]bool) {
+					fileResolver := resolverManager.GetResolverForFile(filePath)
+					if fileResolver == nil {
+						return "", nil
+					}
+					return fileResolver.PackageJSONPath(), prodDepsByResolverRoot[fileResolver.ResolverRoot()]
+				}
 			} else {
 				// entry-package: every file in the rule is checked against the entry package's
 				// devDependencies, so the set is identical for all files and built once.
@@ -842,9 +1137,19 @@ func processRuleChecks(
 				devDepsForFile = func(filePath string) map[string]bool {
 					return entryMerged
 				}
+				var entryPackageJsonPath string
+				var entryProdDependencies map[string]bool
+				if rulePathResolver != nil {
+					entryPackageJsonPath = rulePathResolver.PackageJSONPath()
+					entryProdDependencies = prodDepsOf(rulePathResolver)
+				}
+				prodDepsForFile = func(filePath string) (string, map[string]bool) {
+					return entryPackageJsonPath, entryProdDependencies
+				}
 			}
 
 			violations := make([]checks.RestrictedDevDependenciesUsageViolation, 0)
+			unnecessaryProdDeps := make([]checks.UnnecessaryProdDependencyViolation, 0)
 			for _, detection := range rule.getDevDepsUsageOnProdDetections() {
 				if !detection.Enabled {
 					continue
@@ -856,10 +1161,20 @@ func processRuleChecks(
 					fullRulePath,
 					devDepsForFile,
 				)...)
+				if detection.ReportUnnecessaryProdDependencies {
+					unnecessaryProdDeps = append(unnecessaryProdDeps, checks.FindUnnecessaryProdDependencies(
+						ruleTree,
+						detection.ProdEntryPoints,
+						detection.IgnoreTypeImports,
+						fullRulePath,
+						prodDepsForFile,
+					)...)
+				}
 			}
 
 			mu.Lock()
 			ruleResult.RestrictedDevDependenciesUsageViolations = violations
+			ruleResult.UnnecessaryProdDependencyViolations = unnecessaryProdDeps
 			mu.Unlock()
 		}()
 	}
@@ -940,6 +1255,290 @@ func processRuleChecks(
 		}()
 	}
 
+	if anyEnabled(rule.getWorkspaceProtocolDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/workspace-protocol")()
+			defer wg.Done()
+			violations := make([]checks.WorkspaceProtocolViolation, 0)
+			for _, detection := range rule.getWorkspaceProtocolDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindWorkspaceProtocolViolations(
+					resolverManager.MonorepoContext(),
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.WorkspaceProtocolViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getLayersDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/layers")()
+			defer wg.Done()
+			violations := make([]checks.LayerViolation, 0)
+			for _, detection := range rule.getLayersDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.CheckLayersFromTree(
+					ruleTree,
+					ruleFiles,
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.LayerViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getBarrelFilesDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/barrel-files")()
+			defer wg.Done()
+			violations := make([]checks.BarrelFileViolation, 0)
+			for _, detection := range rule.getBarrelFilesDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindBarrelFileViolations(
+					ruleTree,
+					ruleFiles,
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.BarrelFileViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getTypeImportsDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/type-imports")()
+			defer wg.Done()
+			violations := make([]checks.TypeImportViolation, 0)
+			for _, detection := range rule.getTypeImportsDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindTypeImportViolations(
+					ruleTree,
+					ruleFiles,
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.TypeImportViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getUnusedWorkspacePackagesDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/unused-workspace-packages")()
+			defer wg.Done()
+			violations := make([]checks.UnusedWorkspacePackageViolation, 0)
+			for _, detection := range rule.getUnusedWorkspacePackagesDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindUnusedWorkspacePackages(
+					ruleTree,
+					ruleFiles,
+					resolverManager.MonorepoContext(),
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.UnusedWorkspacePackageViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getDeepImportsDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/deep-imports")()
+			defer wg.Done()
+			violations := make([]checks.DeepImportViolation, 0)
+			for _, detection := range rule.getDeepImportsDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindDeepImportViolations(
+					ruleTree,
+					ruleFiles,
+					resolverManager.MonorepoContext(),
+					resolverManager,
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.DeepImportViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getComplexityBudgetsDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/complexity-budgets")()
+			defer wg.Done()
+			violations := make([]checks.ComplexityBudgetViolation, 0)
+			for _, detection := range rule.getComplexityBudgetsDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindComplexityBudgetViolations(
+					ruleTree,
+					ruleFiles,
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.ComplexityBudgetViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getOwnershipBoundariesDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/ownership-boundaries")()
+			defer wg.Done()
+			violations := make([]checks.OwnershipBoundaryViolation, 0)
+			// Detections of the same rule share the dependency graph; keep the largest count per
+			// pair of teams rather than adding them up.
+			importsByTeams := map[[2]string]int{}
+			reportOnly := false
+			for _, detection := range rule.getOwnershipBoundariesDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				detectionViolations, matrix := checks.FindOwnershipBoundaryViolations(
+					ruleTree,
+					ruleFiles,
+					detection,
+					codeowners[detection.CodeownersPath],
+				)
+				violations = append(violations, detectionViolations...)
+				reportOnly = reportOnly || detection.ReportOnly
+				for _, dependency := range matrix {
+					teams := [2]string{dependency.From, dependency.To}
+					importsByTeams[teams] = max(importsByTeams[teams], dependency.Imports)
+				}
+			}
+			teamDependencies := make([]checks.TeamDependency, 0, len(importsByTeams))
+			for teams, imports := range importsByTeams {
+				teamDependencies = append(teamDependencies, checks.TeamDependency{From: teams[0], To: teams[1], Imports: imports})
+			}
+			checks.SortTeamDependencies(teamDependencies)
+
+			mu.Lock()
+			ruleResult.OwnershipBoundaryViolations = violations
+			ruleResult.TeamDependencies = teamDependencies
+			ruleResult.ReportTeamDependencies = reportOnly
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getTestIsolationDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/test-isolation")()
+			defer wg.Done()
+			violations := make([]checks.TestIsolationViolation, 0)
+			for _, detection := range rule.getTestIsolationDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindTestIsolationViolations(
+					ruleTree,
+					ruleFiles,
+					resolverManager.MonorepoContext(),
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.TestIsolationViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getPeerDependenciesDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/peer-dependencies")()
+			defer wg.Done()
+			violations := make([]checks.PeerDependencyViolation, 0)
+			for _, detection := range rule.getPeerDependenciesDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindPeerDependencyViolations(
+					ruleTree,
+					ruleFiles,
+					resolverManager.MonorepoContext(),
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.PeerDependencyViolations = violations
+			mu.Unlock()
+		}()
+	}
+
+	if anyEnabled(rule.getVersionConsistencyDetections()) {
+		wg.Add(1)
+		go func() {
+			defer perf.Track("rules/checks/version-consistency")()
+			defer wg.Done()
+			violations := make([]checks.VersionConsistencyViolation, 0)
+			for _, detection := range rule.getVersionConsistencyDetections() {
+				if !detection.Enabled {
+					continue
+				}
+				violations = append(violations, checks.FindVersionConsistencyViolations(
+					resolverManager.MonorepoContext(),
+					detection,
+					fullRulePath,
+				)...)
+			}
+
+			mu.Lock()
+			ruleResult.VersionConsistencyViolations = violations
+			mu.Unlock()
+		}()
+	}
+
 	wg.Wait()
 	return ruleResult
 }
@@ -961,9 +1560,14 @@ func ProcessConfig(
 		return nil, err
 	}
 
+	codeowners, err := loadCodeownersForConfig(config, cwd)
+	if err != nil {
+		return nil, err
+	}
+
 	// Step 2: Build dependency tree for config
 	parseMode := model.ParseModeBasic
-	if forceDetailed || anyRuleChecksForUnusedExports(config) {
+	if forceDetailed || anyRuleNeedsDetailedParse(config) {
 		parseMode = model.ParseModeDetailed
 	}
 
@@ -980,11 +1584,48 @@ func ProcessConfig(
 		rulePackageDirs = append(rulePackageDirs, pathutil.NormalizePathForInternal(filepath.Clean(pathutil.JoinWithCwd(cwd, rule.Path))))
 	}
 
-	fullTree, resolverManager, err := buildDependencyTreeForConfig(
+	fileImportsArr := parseImportsForConfig(allFiles, parseMode)
+
+	// Rules with their own conditionNames get a dependency tree resolved with those conditions.
+	// Parsing is shared; only resolution is repeated, on a copy of the parsed imports, once per
+	// distinct set of conditions.
+	treesByConditions := map[string]dependencyTreeForConditions{}
+	for _, rule := range config.Rules {
+		conditionNames := config.ConditionNamesForRule(rule)
+		key := conditionNamesKey(conditionNames)
+		if key == conditionNamesKey(config.ConditionNames) {
+			continue
+		}
+		if _, built := treesByConditions[key]; built {
+			continue
+		}
+		ruleTree, ruleResolverManager, err := resolveDependencyTreeForConfig(
+			cloneFileImports(fileImportsArr),
+			slices.Clone(allFiles),
+			excludePatterns,
+			includePatterns,
+			conditionNames,
+			config.MainFields,
+			cwd,
+			packageJson,
+			tsconfigJson,
+			config.CustomAssetExtensions,
+			parseMode,
+			rulePackageDirs,
+		)
+		if err != nil {
+			return nil, err
+		}
+		treesByConditions[key] = dependencyTreeForConditions{tree: ruleTree, resolverManager: ruleResolverManager}
+	}
+
+	fullTree, resolverManager, err := resolveDependencyTreeForConfig(
+		fileImportsArr,
 		allFiles,
 		excludePatterns,
 		includePatterns,
 		config.ConditionNames,
+		config.MainFields,
 		cwd,
 		packageJson,
 		tsconfigJson,
@@ -995,6 +1636,7 @@ func ProcessConfig(
 	if err != nil {
 		return nil, err
 	}
+	treesByConditions[conditionNamesKey(config.ConditionNames)] = dependencyTreeForConditions{tree: fullTree, resolverManager: resolverManager}
 
 	// Step 3: Process each rule in parallel
 	result := &ConfigProcessingResult{
@@ -1022,9 +1664,12 @@ func ProcessConfig(
 		go func(ruleIndex int, currentRule Rule) {
 			defer wg.Done()
 
+			conditionNames := config.ConditionNamesForRule(currentRule)
+			conditionsTree := treesByConditions[conditionNamesKey(conditionNames)]
+
 			// Step 3a: Filter files for this rule
 			doneFilter := perf.Track("rules/filter-files")
-			ruleFiles, ruleTree := filterFilesForRule(fullTree, currentRule.Path, cwd, currentRule.FollowMonorepoPackages, resolverManager)
+			ruleFiles, ruleTree := filterFilesForRule(conditionsTree.tree, currentRule.Path, cwd, currentRule.FollowMonorepoPackages, conditionsTree.resolverManager)
 			doneFilter()
 
 			// Step 3b: Execute enabled checks in parallel
@@ -1033,15 +1678,17 @@ func ProcessConfig(
 				currentRule,
 				ruleFiles,
 				ruleTree,
-				fullTree,
-				resolverManager,
+				conditionsTree.tree,
+				conditionsTree.resolverManager,
 				cwd,
 				fix,
 				config.UsesNearestPackage(),
 				config.IncludeDevDepsFromRoot(),
+				codeowners,
 			)
 			doneChecks()
 			ruleResult.ProcessIgnoredFiles = config.ProcessIgnoredFiles
+			ruleResult.ConditionNames = conditionNames
 
 			// Set the missing package.json flag
 			ruleResult.MissingPackageJson = missingPackageJsonResults[ruleIndex]
@@ -1051,14 +1698,27 @@ func ProcessConfig(
 				len(ruleResult.OrphanFiles) > 0 ||
 				len(ruleResult.ModuleBoundaryViolations) > 0 ||
 				len(ruleResult.UnusedNodeModules) > 0 ||
+				len(ruleResult.UntypedNodeModuleImports) > 0 ||
 				len(ruleResult.MissingNodeModules) > 0 ||
 				len(ruleResult.ImportConventionViolations) > 0 ||
 				len(ruleResult.UnusedExports) > 0 ||
 				len(ruleResult.UnresolvedImports) > 0 ||
 				len(ruleResult.RestrictedDevDependenciesUsageViolations) > 0 ||
+				len(ruleResult.UnnecessaryProdDependencyViolations) > 0 ||
 				len(ruleResult.RestrictedImportsViolations) > 0 ||
 				len(ruleResult.RestrictedImportersViolations) > 0 ||
-				len(ruleResult.RestrictedDirectImportersViolations) > 0
+				len(ruleResult.RestrictedDirectImportersViolations) > 0 ||
+				len(ruleResult.WorkspaceProtocolViolations) > 0 ||
+				len(ruleResult.LayerViolations) > 0 ||
+				len(ruleResult.BarrelFileViolations) > 0 ||
+				len(ruleResult.TypeImportViolations) > 0 ||
+				len(ruleResult.UnusedWorkspacePackageViolations) > 0 ||
+				len(ruleResult.DeepImportViolations) > 0 ||
+				len(ruleResult.ComplexityBudgetViolations) > 0 ||
+				len(ruleResult.OwnershipBoundaryViolations) > 0 ||
+				len(ruleResult.TestIsolationViolations) > 0 ||
+				len(ruleResult.PeerDependencyViolations) > 0 ||
+				len(ruleResult.VersionConsistencyViolations) > 0
 
 			mu.Lock()
 			result.RuleResults[ruleIndex] = ruleResult
@@ -1075,6 +1735,7 @@ func ProcessConfig(
 	// Step 4: Apply fixes if requested
 	if fix {
 		changesByFile := make(map[string][]sourceedit.Change)
+		packageJsonFixes := make([]node.PackageJsonDependencyFix, 0)
 
 		for i, ruleResult := range result.RuleResults {
 			ruleCfg := config.Rules[i]
@@ -1115,6 +1776,38 @@ func ProcessConfig(
 					result.FixedImportsCount++
 				}
 			}
+			for _, v := range ruleResult.BarrelFileViolations {
+				if orphanFilesToDelete[v.FilePath] {
+					continue
+				}
+				if v.Fix != nil {
+					changesByFile[v.FilePath] = append(changesByFile[v.FilePath], *v.Fix)
+					result.FixedImportsCount++
+				}
+			}
+			for _, v := range ruleResult.TypeImportViolations {
+				if orphanFilesToDelete[v.FilePath] {
+					continue
+				}
+				if v.Fix != nil {
+					changesByFile[v.FilePath] = append(changesByFile[v.FilePath], *v.Fix)
+					result.FixedImportsCount++
+				}
+			}
+
+			for _, v := range ruleResult.MissingNodeModules {
+				packageJsonFixes = append(packageJsonFixes, v.Fixes...)
+			}
+			for _, v := range ruleResult.UnusedNodeModules {
+				if v.Fix != nil {
+					packageJsonFixes = append(packageJsonFixes, *v.Fix)
+				}
+			}
+			for _, v := range ruleResult.VersionConsistencyViolations {
+				if v.Autofix {
+					packageJsonFixes = append(packageJsonFixes, versionConsistencyFix(v))
+				}
+			}
 
 			// Handle orphan files autofix: delete files when configured
 			if isOrphanFixEnabled {
@@ -1137,6 +1830,14 @@ func ProcessConfig(
 			}
 			result.FixedFilesCount += len(changesByFile)
 		}
+
+		added, removed, updated, err := applyPackageJsonDependencyFixes(packageJsonFixes)
+		result.AddedNodeModulesCount += added
+		result.RemovedNodeModulesCount += removed
+		result.UpdatedNodeModulesCount += updated
+		if err != nil {
+			return result, fmt.Errorf("failed to apply package.json autofixes: %w", err)
+		}
 	} else {
 		fixableIssuesCount := 0
 		for i, ruleResult := range result.RuleResults {
@@ -1150,6 +1851,31 @@ func ProcessConfig(
 					fixableIssuesCount++
 				}
 			}
+			for _, v := range ruleResult.BarrelFileViolations {
+				if v.Fix != nil {
+					fixableIssuesCount++
+				}
+			}
+			for _, v := range ruleResult.TypeImportViolations {
+				if v.Fix != nil {
+					fixableIssuesCount++
+				}
+			}
+			for _, v := range ruleResult.MissingNodeModules {
+				if len(v.Fixes) > 0 {
+					fixableIssuesCount++
+				}
+			}
+			for _, v := range ruleResult.UnusedNodeModules {
+				if v.Fix != nil {
+					fixableIssuesCount++
+				}
+			}
+			for _, v := range ruleResult.VersionConsistencyViolations {
+				if v.Autofix {
+					fixableIssuesCount++
+				}
+			}
 
 			// Add orphan files to fixable count if autofix is enabled for this rule
 			rule := config.Rules[i]
@@ -10,6 +10,25 @@ type RestrictedImportersDetectionOptions = rules.RestrictedImportersDetectionOpt
 
 type RestrictedDirectImportersDetectionOptions = rules.RestrictedDirectImportersDetectionOptions
 
+type WorkspaceProtocolDetectionOptions = rules.WorkspaceProtocolDetectionOptions
+
+type LayersDetectionOptions = rules.LayersDetectionOptions
+
+type LayerDefinition = rules.LayerDefinition
+
+type BarrelFilesDetectionOptions = rules.BarrelFilesDetectionOptions
+type TypeImportsDetectionOptions = rules.TypeImportsDetectionOptions
+type UnusedWorkspacePackagesDetectionOptions = rules.UnusedWorkspacePackagesDetectionOptions
+type DeepImportsDetectionOptions = rules.DeepImportsDetectionOptions
+type ComplexityBudgetsDetectionOptions = rules.ComplexityBudgetsDetectionOptions
+type ComplexityBudget = rules.ComplexityBudget
+type CycleBudget = rules.CycleBudget
+type OwnershipBoundariesDetectionOptions = rules.OwnershipBoundariesDetectionOptions
+type TeamDependencyRule = rules.TeamDependencyRule
+type TestIsolationDetectionOptions = rules.TestIsolationDetectionOptions
+type PeerDependenciesDetectionOptions = rules.PeerDependenciesDetectionOptions
+type VersionConsistencyDetectionOptions = rules.VersionConsistencyDetectionOptions
+
 type ImportConventionDomain = rules.ImportConventionDomain
 
 type ImportConventionRule = rules.ImportConventionRule
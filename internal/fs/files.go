@@ -80,6 +80,22 @@ func findAndProcessGitIgnoreFilesUpToRepoRoot(dirPath string, globMatchers []glo
 	return findAndProcessGitIgnoreFilesUpToRepoRoot(parent, globMatchers)
 }
 
+// FindRepoRoot returns the closest directory, from dirPath up, that contains a `.git` directory or
+// file (worktrees and submodules have a `.git` file), or "" when dirPath is not in a git repository.
+func FindRepoRoot(dirPath string) string {
+	dir := filepath.Clean(dirPath)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 // DiscoveryExclusions records the paths a discovery walk left out — individual files an
 // exclude pattern matched, and whole directories the walk pruned as fully excluded. Files
 // inside pruned directories are intentionally NOT enumerated (that is the point of
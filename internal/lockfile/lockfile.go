@@ -0,0 +1,156 @@
+// Package lockfile reads the package manager lockfiles (pnpm-lock.yaml, yarn.lock in the classic
+// v1 and the Berry format, package-lock.json / npm-shrinkwrap.json v2 and v3) into a single
+// dependency graph. It lets installed packages be listed and dependency chains be explained in a
+// fresh checkout, before dependencies are installed.
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	PnpmFileName       = "pnpm-lock.yaml"
+	YarnFileName       = "yarn.lock"
+	NpmShrinkwrapName  = "npm-shrinkwrap.json"
+	NpmPackageLockName = "package-lock.json"
+)
+
+// FileNames lists the supported lockfiles in lookup order. npm-shrinkwrap.json comes before
+// package-lock.json, the way npm prefers it.
+var FileNames = []string{PnpmFileName, YarnFileName, NpmShrinkwrapName, NpmPackageLockName}
+
+// Format identifies the package manager (and lockfile flavour) a lockfile was written by.
+type Format string
+
+const (
+	FormatPnpm        Format = "pnpm"
+	FormatYarnClassic Format = "yarn-classic"
+	FormatYarnBerry   Format = "yarn-berry"
+	FormatNpm         Format = "npm"
+)
+
+// Package is a single lockfile entry: either a third-party package or an importer (the project
+// root or one of its workspaces).
+type Package struct {
+	Name    string
+	Version string
+	// Key identifies the entry in the lockfile: the install path in package-lock.json
+	// (node_modules/a/node_modules/lodash), the package key in pnpm-lock.yaml
+	// (react-dom@18.2.0(react@18.2.0)), the resolution in a Berry yarn.lock (lodash@npm:4.17.21)
+	// or the first descriptor of the entry in a classic yarn.lock (lodash@^4.17.0). For importers
+	// it is the directory relative to the lockfile, "." for the project root.
+	Key string
+	// Workspace is set for the project root and its workspaces.
+	Workspace bool
+	// Dependencies maps each dependency name to the entry it resolves to. Importers include their
+	// dev dependencies. Dependencies missing from the lockfile (e.g. optional dependencies for
+	// another platform or unmet peer dependencies) are omitted.
+	Dependencies map[string]*Package
+}
+
+// Lockfile is a parsed lockfile.
+type Lockfile struct {
+	// Path is the absolute, OS-native path of the lockfile.
+	Path   string
+	Format Format
+	// Importers lists the project root and its workspaces, sorted by Key.
+	Importers []*Package
+	// Packages lists the third-party packages, sorted by Key. A package appears once per install
+	// location (npm) or peer dependency variant (pnpm), so the same name and version may repeat.
+	Packages []*Package
+}
+
+// Find walks up from dir and returns the path of the first lockfile found, or "" when there is
+// none. Within a directory FileNames decides which lockfile wins.
+func Find(dir string) string {
+	cur := filepath.Clean(dir)
+	for {
+		for _, name := range FileNames {
+			candidate := filepath.Join(cur, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			return ""
+		}
+		cur = parent
+	}
+}
+
+// Load reads and parses the lockfile at path. The format is picked from the file name.
+func Load(path string) (*Lockfile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := Parse(content, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+// Parse decodes lockfile content. path is the lockfile location; its base name selects the
+// parser and its directory is where importers (and, for classic yarn.lock, package.json files)
+// are looked up.
+func Parse(content []byte, path string) (*Lockfile, error) {
+	var lock *Lockfile
+	var err error
+	switch filepath.Base(path) {
+	case PnpmFileName:
+		lock, err = parsePnpmLockfile(content, filepath.Dir(path))
+	case YarnFileName:
+		lock, err = parseYarnLockfile(content, filepath.Dir(path))
+	case NpmShrinkwrapName, NpmPackageLockName:
+		lock, err = parseNpmLockfile(content)
+	default:
+		return nil, fmt.Errorf("unsupported lockfile %s", filepath.Base(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	lock.Path = path
+	sortPackages(lock.Importers)
+	sortPackages(lock.Packages)
+	return lock, nil
+}
+
+func sortPackages(packages []*Package) {
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packages[i].Key < packages[j].Key
+	})
+}
+
+// splitDescriptor splits "name@range" into its parts; scoped names keep their leading "@".
+func splitDescriptor(descriptor string) (string, string, bool) {
+	if len(descriptor) < 2 {
+		return "", "", false
+	}
+	idx := strings.Index(descriptor[1:], "@")
+	if idx < 0 {
+		return "", "", false
+	}
+	return descriptor[:idx+1], descriptor[idx+2:], true
+}
+
+// readPackageJsonName returns the "name" field of dir/package.json, or "" when it cannot be read.
+func readPackageJsonName(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkgJson struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(content, &pkgJson); err != nil {
+		return ""
+	}
+	return pkgJson.Name
+}
@@ -0,0 +1,199 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"rev-dep-go/internal/testutil"
+)
+
+func loadFixtureLockfile(t *testing.T, fixture string, fileName string) *Lockfile {
+	t.Helper()
+	dir, err := testutil.FixturePath(fixture)
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	lock, err := Load(filepath.Join(dir, fileName))
+	if err != nil {
+		t.Fatalf("Load(%s): %v", fixture, err)
+	}
+	return lock
+}
+
+func packageVersions(packages []*Package) []string {
+	result := []string{}
+	for _, pkg := range packages {
+		result = append(result, pkg.Name+"@"+pkg.Version)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func findImporter(lock *Lockfile, key string) *Package {
+	for _, importer := range lock.Importers {
+		if importer.Key == key {
+			return importer
+		}
+	}
+	return nil
+}
+
+// Every workspace fixture describes the same project: the root depends on lodash@4 and workspace
+// @repo/a, which depends on legacy-lib (pulling in lodash@3) and workspace @repo/b (lodash@3).
+func TestLoad_WorkspaceFixtures(t *testing.T) {
+	cases := []struct {
+		fixture  string
+		fileName string
+		format   Format
+		packages []string
+	}{
+		{"lockfileNpm", NpmPackageLockName, FormatNpm, []string{"legacy-lib@1.0.0", "lodash@3.10.1", "lodash@3.10.1", "lodash@4.17.21"}},
+		{"lockfilePnpm", PnpmFileName, FormatPnpm, []string{"legacy-lib@1.0.0", "lodash@3.10.1", "lodash@4.17.21", "react-dom@18.2.0", "react@18.2.0"}},
+		{"lockfileYarnClassic", YarnFileName, FormatYarnClassic, []string{"legacy-lib@1.0.0", "lodash@3.10.1", "lodash@4.17.21"}},
+		{"lockfileYarnBerry", YarnFileName, FormatYarnBerry, []string{"legacy-lib@1.0.0", "lodash@3.10.1", "lodash@4.17.21"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			lock := loadFixtureLockfile(t, tc.fixture, tc.fileName)
+			if lock.Format != tc.format {
+				t.Errorf("expected format %s, got %s", tc.format, lock.Format)
+			}
+			if got := packageVersions(lock.Packages); !reflect.DeepEqual(got, tc.packages) {
+				t.Errorf("unexpected packages:\n got: %v\nwant: %v", got, tc.packages)
+			}
+
+			importerNames := []string{}
+			for _, importer := range lock.Importers {
+				if !importer.Workspace {
+					t.Errorf("importer %s is not marked as a workspace", importer.Key)
+				}
+				importerNames = append(importerNames, importer.Key+"="+importer.Name)
+			}
+			expectedImporters := []string{".=lockfile-app", "packages/a=@repo/a", "packages/b=@repo/b"}
+			if !reflect.DeepEqual(importerNames, expectedImporters) {
+				t.Fatalf("unexpected importers:\n got: %v\nwant: %v", importerNames, expectedImporters)
+			}
+
+			root, a, b := lock.Importers[0], lock.Importers[1], lock.Importers[2]
+			if dep := root.Dependencies["lodash"]; dep == nil || dep.Version != "4.17.21" {
+				t.Errorf("expected the root to depend on lodash@4.17.21, got %+v", dep)
+			}
+			if root.Dependencies["@repo/a"] != a || a.Dependencies["@repo/b"] != b {
+				t.Errorf("expected workspace dependencies to point at the importers, got %v and %v", root.Dependencies, a.Dependencies)
+			}
+			legacy := a.Dependencies["legacy-lib"]
+			if legacy == nil || legacy.Version != "1.0.0" || legacy.Workspace {
+				t.Fatalf("expected @repo/a to depend on legacy-lib@1.0.0, got %+v", legacy)
+			}
+			if dep := legacy.Dependencies["lodash"]; dep == nil || dep.Version != "3.10.1" {
+				t.Errorf("expected legacy-lib to depend on lodash@3.10.1, got %+v", dep)
+			}
+			if dep := b.Dependencies["lodash"]; dep == nil || dep.Version != "3.10.1" {
+				t.Errorf("expected @repo/b to depend on lodash@3.10.1, got %+v", dep)
+			}
+		})
+	}
+}
+
+func TestLoad_NpmInstallPaths(t *testing.T) {
+	lock := loadFixtureLockfile(t, "lockfileNpm", NpmPackageLockName)
+
+	legacy := findImporter(lock, "packages/a").Dependencies["legacy-lib"]
+	if key := legacy.Dependencies["lodash"].Key; key != "node_modules/legacy-lib/node_modules/lodash" {
+		t.Errorf("expected legacy-lib to use its nested lodash, got %s", key)
+	}
+	if key := findImporter(lock, "packages/b").Dependencies["lodash"].Key; key != "packages/b/node_modules/lodash" {
+		t.Errorf("expected @repo/b to use its own node_modules, got %s", key)
+	}
+}
+
+func TestLoad_PnpmPeerVariantsAndV6(t *testing.T) {
+	lock := loadFixtureLockfile(t, "lockfilePnpm", PnpmFileName)
+	reactDom := findImporter(lock, "packages/a").Dependencies["react-dom"]
+	if reactDom == nil || reactDom.Key != "react-dom@18.2.0(react@18.2.0)" || reactDom.Name != "react-dom" || reactDom.Version != "18.2.0" {
+		t.Fatalf("unexpected react-dom entry %+v", reactDom)
+	}
+	if react := reactDom.Dependencies["react"]; react == nil || react.Key != "react@18.2.0" {
+		t.Errorf("expected react-dom to depend on react@18.2.0, got %+v", react)
+	}
+
+	// v6 lockfiles of projects without workspaces list the root dependencies at the top level.
+	v6 := loadFixtureLockfile(t, "lockfilePnpmV6", PnpmFileName)
+	if got := packageVersions(v6.Packages); !reflect.DeepEqual(got, []string{"legacy-lib@1.0.0", "lodash@3.10.1", "lodash@4.17.21"}) {
+		t.Errorf("unexpected v6 packages: %v", got)
+	}
+	if len(v6.Importers) != 1 || v6.Importers[0].Name != "lockfile-app" {
+		t.Fatalf("expected a single root importer, got %+v", v6.Importers)
+	}
+	legacy := v6.Importers[0].Dependencies["legacy-lib"]
+	if legacy == nil || legacy.Key != "legacy-lib@1.0.0" || legacy.Dependencies["lodash"].Version != "3.10.1" {
+		t.Errorf("unexpected legacy-lib entry %+v", legacy)
+	}
+}
+
+func TestParsePnpmPackageKey(t *testing.T) {
+	cases := []struct {
+		key     string
+		major   int
+		name    string
+		version string
+	}{
+		{"react-dom@18.2.0(react@18.2.0)", 9, "react-dom", "18.2.0"},
+		{"@babel/core@7.24.0", 9, "@babel/core", "7.24.0"},
+		{"/@babel/core@7.24.0(supports-color@8.1.1)", 6, "@babel/core", "7.24.0"},
+		{"/react-dom/18.2.0_react@18.2.0", 5, "react-dom", "18.2.0"},
+		{"/string_decoder/1.3.0", 5, "string_decoder", "1.3.0"},
+		{"/@babel/core/7.24.0_@babel+types@7.24.0", 5, "@babel/core", "7.24.0"},
+	}
+	for _, tc := range cases {
+		name, version := parsePnpmPackageKey(tc.key, tc.major)
+		if name != tc.name || version != tc.version {
+			t.Errorf("parsePnpmPackageKey(%s, %d) = %s, %s; want %s, %s", tc.key, tc.major, name, version, tc.name, tc.version)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]struct {
+		fileName string
+		content  string
+	}{
+		"npm v1":                 {NpmPackageLockName, `{"lockfileVersion": 1, "dependencies": {}}`},
+		"npm invalid json":       {NpmPackageLockName, `{`},
+		"pnpm v4":                {PnpmFileName, "lockfileVersion: 4\n"},
+		"yarn classic field":     {YarnFileName, "  version \"1.0.0\"\n"},
+		"yarn berry resolution":  {YarnFileName, "__metadata:\n  version: 8\n\n\"a@npm:^1\":\n  version: 1.0.0\n"},
+		"unsupported file name":  {"bun.lockb", ""},
+		"yarn classic entry key": {YarnFileName, "lodash@^4.17.21\n"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(tc.content), filepath.Join(t.TempDir(), tc.fileName)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir, err := testutil.FixturePath("lockfileNpm")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	if found := Find(filepath.Join(dir, "packages", "a")); found != filepath.Join(dir, NpmPackageLockName) {
+		t.Errorf("expected the lockfile of the parent directory, got %q", found)
+	}
+
+	tempDir := t.TempDir()
+	for _, name := range []string{NpmPackageLockName, PnpmFileName} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte{}, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if found := Find(tempDir); found != filepath.Join(tempDir, PnpmFileName) {
+		t.Errorf("expected pnpm-lock.yaml to win over package-lock.json, got %q", found)
+	}
+}
@@ -0,0 +1,119 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type npmLockfile struct {
+	LockfileVersion int                      `json:"lockfileVersion"`
+	Packages        map[string]npmLockedPath `json:"packages"`
+}
+
+type npmLockedPath struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Resolved             string            `json:"resolved"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// parseNpmLockfile reads the "packages" section of package-lock.json v2/v3, keyed by install
+// path. Dependencies are resolved the way Node resolves them from the install layout: the nearest
+// node_modules directory containing the package, walking up from the dependent package.
+func parseNpmLockfile(content []byte) (*Lockfile, error) {
+	var raw npmLockfile
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	if raw.LockfileVersion < 2 || raw.Packages == nil {
+		return nil, fmt.Errorf("lockfileVersion %d is not supported, regenerate the lockfile with npm 7 or newer", raw.LockfileVersion)
+	}
+
+	lock := &Lockfile{Format: FormatNpm}
+	byPath := map[string]*Package{}
+	links := map[string]string{}
+
+	for installPath, entry := range raw.Packages {
+		if entry.Link {
+			links[installPath] = entry.Resolved
+			continue
+		}
+		pkg := &Package{Name: entry.Name, Version: entry.Version, Key: installPath, Dependencies: map[string]*Package{}}
+		if isNpmInstallPath(installPath) {
+			if pkg.Name == "" {
+				pkg.Name = installPath[strings.LastIndex(installPath, "node_modules/")+len("node_modules/"):]
+			}
+			lock.Packages = append(lock.Packages, pkg)
+		} else {
+			pkg.Workspace = true
+			if installPath == "" {
+				pkg.Key = "."
+			}
+			if pkg.Name == "" {
+				pkg.Name = pkg.Key
+			}
+			lock.Importers = append(lock.Importers, pkg)
+		}
+		byPath[installPath] = pkg
+	}
+
+	lookup := func(fromPath string, name string) *Package {
+		cur := fromPath
+		for {
+			candidate := "node_modules/" + name
+			if cur != "" {
+				candidate = cur + "/" + candidate
+			}
+			if pkg, ok := byPath[candidate]; ok {
+				return pkg
+			}
+			if target, ok := links[candidate]; ok {
+				return byPath[target]
+			}
+			if cur == "" {
+				return nil
+			}
+			cur = npmParentPath(cur)
+		}
+	}
+
+	for installPath, entry := range raw.Packages {
+		pkg := byPath[installPath]
+		if pkg == nil {
+			continue
+		}
+		for _, deps := range []map[string]string{entry.Dependencies, entry.DevDependencies, entry.OptionalDependencies, entry.PeerDependencies} {
+			for name := range deps {
+				if _, done := pkg.Dependencies[name]; done {
+					continue
+				}
+				if dep := lookup(installPath, name); dep != nil {
+					pkg.Dependencies[name] = dep
+				}
+			}
+		}
+	}
+
+	return lock, nil
+}
+
+// isNpmInstallPath reports whether a package-lock.json key is a node_modules install location
+// rather than the project root or a workspace directory.
+func isNpmInstallPath(installPath string) bool {
+	return strings.HasPrefix(installPath, "node_modules/") || strings.Contains(installPath, "/node_modules/")
+}
+
+// npmParentPath returns the install path whose node_modules directory holds installPath
+// ("node_modules/a/node_modules/b" -> "node_modules/a"), or "" for the project root.
+func npmParentPath(installPath string) string {
+	idx := strings.LastIndex(installPath, "node_modules/")
+	if idx <= 0 {
+		return ""
+	}
+	return strings.TrimSuffix(installPath[:idx], "/")
+}
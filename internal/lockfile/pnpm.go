@@ -0,0 +1,179 @@
+package lockfile
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type pnpmLockfile struct {
+	LockfileVersion any                     `yaml:"lockfileVersion"`
+	Importers       map[string]pnpmImporter `yaml:"importers"`
+	// Lockfiles of projects without workspaces (before v9) list the root dependencies at the top
+	// level instead of under importers.
+	pnpmImporter `yaml:",inline"`
+	Packages     map[string]pnpmPackage `yaml:"packages"`
+	Snapshots    map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmImporterDependency `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmImporterDependency `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmImporterDependency `yaml:"optionalDependencies"`
+}
+
+// pnpmImporterDependency is the resolved version of an importer dependency: a plain string up to
+// v5, a {specifier, version} mapping since v6.
+type pnpmImporterDependency struct {
+	Version string
+}
+
+func (d *pnpmImporterDependency) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		d.Version = node.Value
+		return nil
+	}
+	var versioned struct {
+		Version string `yaml:"version"`
+	}
+	if err := node.Decode(&versioned); err != nil {
+		return err
+	}
+	d.Version = versioned.Version
+	return nil
+}
+
+type pnpmPackage struct {
+	Name                 string            `yaml:"name"`
+	Version              string            `yaml:"version"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// parsePnpmLockfile reads pnpm-lock.yaml v5, v6 and v9. Every package key is a separate entry, so
+// the peer dependency variants pnpm installs side by side (react-dom@18.2.0(react@18.2.0) and
+// react-dom@18.2.0(react@18.3.1)) are separate packages with the same version.
+func parsePnpmLockfile(content []byte, dir string) (*Lockfile, error) {
+	var raw pnpmLockfile
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	version := fmt.Sprint(raw.LockfileVersion)
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil || major < 5 {
+		return nil, fmt.Errorf("lockfileVersion %s is not supported", version)
+	}
+
+	// v9 moved the dependencies of each package instance to "snapshots".
+	entries := raw.Packages
+	if major >= 9 && raw.Snapshots != nil {
+		entries = raw.Snapshots
+	}
+	// keyFor builds the package key a dependency version points at in this lockfile version.
+	keyFor := func(name string, ref string) string {
+		switch {
+		case major >= 9:
+			return name + "@" + ref
+		case major >= 6:
+			return "/" + name + "@" + ref
+		default:
+			return "/" + name + "/" + ref
+		}
+	}
+
+	lock := &Lockfile{Format: FormatPnpm}
+	byKey := map[string]*Package{}
+	for key, entry := range entries {
+		name, pkgVersion := parsePnpmPackageKey(key, major)
+		if entry.Name != "" {
+			name = entry.Name
+		}
+		if entry.Version != "" {
+			pkgVersion = entry.Version
+		}
+		pkg := &Package{Name: name, Version: pkgVersion, Key: strings.TrimPrefix(key, "/"), Dependencies: map[string]*Package{}}
+		byKey[key] = pkg
+		lock.Packages = append(lock.Packages, pkg)
+	}
+
+	importers := raw.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{".": raw.pnpmImporter}
+	}
+	byImporterPath := map[string]*Package{}
+	for importerPath := range importers {
+		name := readPackageJsonName(filepath.Join(dir, filepath.FromSlash(importerPath)))
+		if name == "" {
+			name = importerPath
+		}
+		pkg := &Package{Name: name, Key: importerPath, Workspace: true, Dependencies: map[string]*Package{}}
+		byImporterPath[importerPath] = pkg
+		lock.Importers = append(lock.Importers, pkg)
+	}
+
+	// resolve maps a dependency version to its entry: "link:" versions point at another importer,
+	// aliased dependencies ("string-width-cjs": "string-width@4.2.3") carry the full package key.
+	resolve := func(fromImporter string, name string, ref string) *Package {
+		if target, ok := strings.CutPrefix(ref, "link:"); ok {
+			return byImporterPath[path.Clean(path.Join(fromImporter, target))]
+		}
+		if pkg, ok := byKey[keyFor(name, ref)]; ok {
+			return pkg
+		}
+		if major >= 9 {
+			return byKey[ref]
+		}
+		return byKey["/"+ref]
+	}
+	addDependencies := func(pkg *Package, fromImporter string, deps map[string]string) {
+		for name, ref := range deps {
+			if dep := resolve(fromImporter, name, ref); dep != nil {
+				pkg.Dependencies[name] = dep
+			}
+		}
+	}
+
+	for importerPath, importer := range importers {
+		for _, deps := range []map[string]pnpmImporterDependency{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+			refs := make(map[string]string, len(deps))
+			for name, dep := range deps {
+				refs[name] = dep.Version
+			}
+			addDependencies(byImporterPath[importerPath], importerPath, refs)
+		}
+	}
+	for key, entry := range entries {
+		addDependencies(byKey[key], ".", entry.Dependencies)
+		addDependencies(byKey[key], ".", entry.OptionalDependencies)
+	}
+
+	return lock, nil
+}
+
+// parsePnpmPackageKey extracts the package name and version from a package key:
+// "react-dom@18.2.0(react@18.2.0)" (v9), "/react-dom@18.2.0(react@18.2.0)" (v6) or
+// "/react-dom/18.2.0_react@18.2.0" (v5).
+func parsePnpmPackageKey(key string, major int) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if major < 6 {
+		// The peer suffix uses "+" instead of "/", so the last "/" separates name and version.
+		idx := strings.LastIndex(key, "/")
+		if idx < 0 {
+			return key, ""
+		}
+		version, _, _ := strings.Cut(key[idx+1:], "_")
+		return key[:idx], version
+	}
+	if idx := strings.Index(key, "("); idx >= 0 {
+		key = key[:idx]
+	}
+	name, version, ok := splitDescriptor(key)
+	if !ok {
+		return key, ""
+	}
+	return name, version
+}
@@ -0,0 +1,276 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+)
+
+// yarnEntry is a yarn.lock entry shared by every descriptor listed in its key.
+type yarnEntry struct {
+	// Descriptors is only read from classic lockfiles; Berry entries are keyed by them instead.
+	Descriptors []string `yaml:"-"`
+	Version     string   `yaml:"version"`
+	// Resolution is only written by Berry ("lodash@npm:4.17.21", "app@workspace:packages/app").
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// parseYarnLockfile reads a Berry (YAML, with a __metadata entry) or a classic v1 yarn.lock.
+func parseYarnLockfile(content []byte, dir string) (*Lockfile, error) {
+	if bytes.Contains(content, []byte("\n__metadata:")) || bytes.HasPrefix(content, []byte("__metadata:")) {
+		return parseYarnBerryLockfile(content)
+	}
+	return parseYarnClassicLockfile(content, dir)
+}
+
+// parseYarnBerryLockfile reads a Yarn 2+ lockfile. Workspaces are entries resolved with the
+// workspace: protocol, so no package.json has to be read.
+func parseYarnBerryLockfile(content []byte) (*Lockfile, error) {
+	var raw map[string]*yarnEntry
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	delete(raw, "__metadata")
+
+	lock := &Lockfile{Format: FormatYarnBerry}
+	byDescriptor := map[string]*Package{}
+	packages := map[*yarnEntry]*Package{}
+	for key, entry := range raw {
+		if entry == nil {
+			continue
+		}
+		name, reference, ok := splitDescriptor(entry.Resolution)
+		if !ok {
+			return nil, fmt.Errorf("entry %q has no valid resolution", key)
+		}
+		pkg := &Package{Name: name, Version: entry.Version, Key: entry.Resolution, Dependencies: map[string]*Package{}}
+		if workspacePath, isWorkspace := strings.CutPrefix(reference, "workspace:"); isWorkspace {
+			pkg.Key = workspacePath
+			pkg.Workspace = true
+			lock.Importers = append(lock.Importers, pkg)
+		} else {
+			lock.Packages = append(lock.Packages, pkg)
+		}
+		for _, descriptor := range splitYarnKey(key) {
+			byDescriptor[descriptor] = pkg
+		}
+		packages[entry] = pkg
+	}
+
+	// Dependency ranges without a protocol default to npm:, which Yarn adds to the entry keys.
+	resolve := func(name string, rangeSpec string) *Package {
+		if pkg, ok := byDescriptor[name+"@"+rangeSpec]; ok {
+			return pkg
+		}
+		return byDescriptor[name+"@npm:"+rangeSpec]
+	}
+	for entry, pkg := range packages {
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for name, rangeSpec := range deps {
+				if dep := resolve(name, rangeSpec); dep != nil {
+					pkg.Dependencies[name] = dep
+				}
+			}
+		}
+	}
+
+	return lock, nil
+}
+
+// parseYarnClassicLockfile reads a Yarn 1 lockfile. It does not record workspaces, so the
+// importers are read from the package.json files of the project root (dir) and its workspaces.
+func parseYarnClassicLockfile(content []byte, dir string) (*Lockfile, error) {
+	entries, err := readYarnClassicEntries(content)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lockfile{Format: FormatYarnClassic}
+	byDescriptor := map[string]*Package{}
+	packages := map[*yarnEntry]*Package{}
+	for _, entry := range entries {
+		name, rangeSpec, ok := splitDescriptor(entry.Descriptors[0])
+		if !ok {
+			return nil, fmt.Errorf("invalid descriptor %q", entry.Descriptors[0])
+		}
+		// Aliased dependencies ("foo@npm:bar@^1.0.0") install the aliased package.
+		if aliased, isAlias := strings.CutPrefix(rangeSpec, "npm:"); isAlias {
+			if aliasedName, _, ok := splitDescriptor(aliased); ok {
+				name = aliasedName
+			}
+		}
+		pkg := &Package{Name: name, Version: entry.Version, Key: entry.Descriptors[0], Dependencies: map[string]*Package{}}
+		lock.Packages = append(lock.Packages, pkg)
+		for _, descriptor := range entry.Descriptors {
+			byDescriptor[descriptor] = pkg
+		}
+		packages[entry] = pkg
+	}
+	for entry, pkg := range packages {
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for name, rangeSpec := range deps {
+				if dep, ok := byDescriptor[name+"@"+rangeSpec]; ok {
+					pkg.Dependencies[name] = dep
+				}
+			}
+		}
+	}
+
+	root := pathutil.NormalizePathForInternal(filepath.Clean(dir))
+	importerDirs := map[string]string{".": root}
+	ctx := monorepo.DetectMonorepo(dir)
+	if ctx != nil && ctx.WorkspaceRoot == root {
+		ctx.FindWorkspacePackages(nil, nil)
+		for _, packageDir := range ctx.PackageToPath {
+			rel, err := filepath.Rel(dir, pathutil.DenormalizePathForOS(packageDir))
+			if err != nil || rel == "." {
+				continue
+			}
+			importerDirs[filepath.ToSlash(rel)] = packageDir
+		}
+	} else {
+		ctx = monorepo.NewMonorepoContext(root)
+	}
+
+	workspaces := map[string]*Package{}
+	configs := map[*Package]*monorepo.PackageJsonConfig{}
+	for importerPath, importerDir := range importerDirs {
+		config, err := ctx.GetPackageConfig(importerDir)
+		if err != nil {
+			if importerPath == "." {
+				continue
+			}
+			return nil, err
+		}
+		name := config.Name
+		if name == "" {
+			name = importerPath
+		}
+		pkg := &Package{Name: name, Version: config.Version, Key: importerPath, Workspace: true, Dependencies: map[string]*Package{}}
+		lock.Importers = append(lock.Importers, pkg)
+		workspaces[name] = pkg
+		configs[pkg] = config
+	}
+	// Dependencies on workspaces are linked and never written to the lockfile.
+	for pkg, config := range configs {
+		for _, deps := range []map[string]string{config.Dependencies, config.DevDependencies, config.OptionalDependencies} {
+			for name, rangeSpec := range deps {
+				if dep, ok := byDescriptor[name+"@"+rangeSpec]; ok {
+					pkg.Dependencies[name] = dep
+				} else if workspace, ok := workspaces[name]; ok {
+					pkg.Dependencies[name] = workspace
+				}
+			}
+		}
+	}
+
+	return lock, nil
+}
+
+// readYarnClassicEntries parses the Yarn 1 lockfile syntax: unindented entry keys listing the
+// descriptors, fields indented by two spaces and dependency maps indented by four.
+func readYarnClassicEntries(content []byte) ([]*yarnEntry, error) {
+	var entries []*yarnEntry
+	var current *yarnEntry
+	var section map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+
+		switch {
+		case indent == 0:
+			if !strings.HasSuffix(trimmed, ":") {
+				return nil, fmt.Errorf("line %d: expected an entry key", lineNumber)
+			}
+			current = &yarnEntry{Descriptors: splitYarnKey(strings.TrimSuffix(trimmed, ":"))}
+			if len(current.Descriptors) == 0 {
+				return nil, fmt.Errorf("line %d: entry without descriptors", lineNumber)
+			}
+			section = nil
+			entries = append(entries, current)
+		case current == nil:
+			return nil, fmt.Errorf("line %d: field outside of an entry", lineNumber)
+		case indent == 2 && strings.HasSuffix(trimmed, ":"):
+			section = nil
+			switch strings.TrimSuffix(trimmed, ":") {
+			case "dependencies":
+				current.Dependencies = map[string]string{}
+				section = current.Dependencies
+			case "optionalDependencies":
+				current.OptionalDependencies = map[string]string{}
+				section = current.OptionalDependencies
+			}
+		case indent == 2:
+			section = nil
+			key, value := splitYarnClassicField(trimmed)
+			if key == "version" {
+				current.Version = value
+			}
+		default:
+			if section != nil {
+				key, value := splitYarnClassicField(trimmed)
+				section[key] = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// splitYarnKey splits an entry key ("a@^1.0.0", "a@^1.1.0" or "a@npm:^1.0.0, a@npm:^1.1.0") into
+// its descriptors.
+func splitYarnKey(key string) []string {
+	var descriptors []string
+	for _, part := range strings.Split(key, ",") {
+		if descriptor := unquoteYarnValue(strings.TrimSpace(part)); descriptor != "" {
+			descriptors = append(descriptors, descriptor)
+		}
+	}
+	return descriptors
+}
+
+// splitYarnClassicField splits a `key value` line; both parts may be quoted.
+func splitYarnClassicField(line string) (string, string) {
+	var key, rest string
+	if strings.HasPrefix(line, `"`) {
+		end := strings.Index(line[1:], `"`)
+		if end < 0 {
+			return unquoteYarnValue(line), ""
+		}
+		key, rest = line[1:end+1], line[end+2:]
+	} else {
+		key, rest, _ = strings.Cut(line, " ")
+	}
+	return key, unquoteYarnValue(strings.TrimSpace(rest))
+}
+
+func unquoteYarnValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -35,6 +35,16 @@ const (
 	NodeModulesMatchingStrategyCwdResolver
 )
 
+// NodeModulesLookupStrategy selects where installed third-party packages are looked up on disk.
+type NodeModulesLookupStrategy uint8
+
+const (
+	NodeModulesLookupStrategyAuto        NodeModulesLookupStrategy = iota // Yarn PnP when a manifest is found, the lockfile when nothing is installed, node_modules otherwise
+	NodeModulesLookupStrategyNodeModules                                  // Physical node_modules directories
+	NodeModulesLookupStrategyPnP                                          // Yarn Plug'n'Play manifest (.pnp.cjs / .pnp.data.json)
+	NodeModulesLookupStrategyLockfile                                     // Lockfile (pnpm-lock.yaml, yarn.lock, package-lock.json), without installed packages
+)
+
 type KeywordInfo struct {
 	Name       string // Original name ("default" for default imports, "*" for namespace)
 	Alias      string // Local alias if "as" used, empty otherwise
@@ -11,7 +11,6 @@ import (
 
 	"github.com/gobwas/glob"
 	"github.com/tidwall/jsonc"
-	"gopkg.in/yaml.v3"
 
 	"rev-dep-go/internal/fs"
 	globutil "rev-dep-go/internal/glob"
@@ -24,10 +23,27 @@ type PackageJsonConfig struct {
 	Exports interface{}            `json:"exports"`
 	Imports map[string]interface{} `json:"imports"`
 	// We might need dependencies to check versions
-	Dependencies    map[string]string `json:"dependencies"`
-	DevDependencies map[string]string `json:"devDependencies"`
-	Main            string            `json:"main"`
-	Module          string            `json:"module"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	Main                 string            `json:"main"`
+	Module               string            `json:"module"`
+	// Fields holds every top-level field of the package.json, for lookups of fields that are
+	// not modelled above (e.g. the configured mainFields).
+	Fields map[string]interface{} `json:"-"`
+}
+
+func parsePackageJsonConfig(content []byte) (PackageJsonConfig, error) {
+	var config PackageJsonConfig
+	normalized := jsonc.ToJSON(content)
+	if err := json.Unmarshal(normalized, &config); err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(normalized, &config.Fields); err != nil {
+		return config, err
+	}
+	return config, nil
 }
 
 type MonorepoContext struct {
@@ -35,6 +51,10 @@ type MonorepoContext struct {
 	// PackageToPath is fully populated during discovery and read-only afterwards, so it
 	// needs no synchronization.
 	PackageToPath map[string]string
+	// Catalogs holds the pnpm catalogs (pnpm-workspace.yaml "catalog" / "catalogs"), keyed by
+	// catalog name and then dependency name. The "catalog" field is the "default" catalog. Like
+	// PackageToPath it is filled during discovery and read-only afterwards.
+	Catalogs map[string]map[string]string
 	// The two caches below are different: they are lazily filled during the concurrent
 	// import-resolution phase, so every access goes through cacheMu. Reads dominate
 	// (one entry per workspace package, hit repeatedly), hence RWMutex.
@@ -69,6 +89,7 @@ func NewMonorepoContext(root string) *MonorepoContext {
 	return &MonorepoContext{
 		WorkspaceRoot:       root,
 		PackageToPath:       make(map[string]string),
+		Catalogs:            make(map[string]map[string]string),
 		PackageConfigCache:  make(map[string]*PackageJsonConfig),
 		PackageExportsCache: make(map[string]*PackageJsonExports),
 	}
@@ -83,15 +104,9 @@ func DetectMonorepo(cwd string) *MonorepoContext {
 			pnpmWorkspacePath := filepath.Join(currentDir, fname)
 			if _, err := os.Stat(pnpmWorkspacePath); err == nil {
 				// Only treat as monorepo root if pnpm-workspace.* contains non-empty "packages"
-				content, err := os.ReadFile(pnpmWorkspacePath)
-				if err == nil {
-					var pnpmWorkspace struct {
-						Packages []string `yaml:"packages"`
-					}
-					if err := yaml.Unmarshal(content, &pnpmWorkspace); err == nil {
-						if len(pnpmWorkspace.Packages) > 0 {
-							return NewMonorepoContext(currentDir)
-						}
+				if pnpmWorkspace, err := readPnpmWorkspaceFile(pnpmWorkspacePath); err == nil {
+					if len(pnpmWorkspace.Packages) > 0 {
+						return NewMonorepoContext(currentDir)
 					}
 				}
 			}
@@ -155,15 +170,11 @@ func (ctx *MonorepoContext) FindWorkspacePackages(excludeFilePatterns []globutil
 	pnpmFilenames := []string{"pnpm-workspace.yaml", "pnpm-workspace.yml"}
 	for _, fname := range pnpmFilenames {
 		pnpmWorkspacePath := filepath.Join(pathutil.DenormalizePathForOS(ctx.WorkspaceRoot), fname)
-		if pnpmContent, err := os.ReadFile(pnpmWorkspacePath); err == nil {
-			var pnpmWorkspace struct {
-				Packages []string `yaml:"packages"`
-			}
-			if err := yaml.Unmarshal(pnpmContent, &pnpmWorkspace); err == nil {
-				if len(pnpmWorkspace.Packages) > 0 {
-					patterns = append(patterns, pnpmWorkspace.Packages...)
-					break
-				}
+		if pnpmWorkspace, err := readPnpmWorkspaceFile(pnpmWorkspacePath); err == nil {
+			if len(pnpmWorkspace.Packages) > 0 {
+				patterns = append(patterns, pnpmWorkspace.Packages...)
+				ctx.Catalogs = pnpmWorkspace.catalogsByName()
+				break
 			}
 		}
 	}
@@ -504,8 +515,8 @@ func (ctx *MonorepoContext) processPossiblePackage(path string) {
 		return
 	}
 
-	var config PackageJsonConfig
-	if err := json.Unmarshal(jsonc.ToJSON(content), &config); err != nil {
+	config, err := parsePackageJsonConfig(content)
+	if err != nil {
 		return
 	}
 
@@ -543,8 +554,8 @@ func (ctx *MonorepoContext) GetPackageConfig(packageRoot string) (*PackageJsonCo
 		return nil, err
 	}
 
-	var parsed PackageJsonConfig
-	if err := json.Unmarshal(jsonc.ToJSON(content), &parsed); err != nil {
+	parsed, err := parsePackageJsonConfig(content)
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,90 @@
+package monorepo
+
+import (
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCatalogName is the catalog referenced by a bare "catalog:" specifier.
+const DefaultCatalogName = "default"
+
+// CatalogProtocol is the dependency specifier prefix referencing a pnpm catalog entry.
+const CatalogProtocol = "catalog:"
+
+// WorkspaceProtocol is the dependency specifier prefix referencing a sibling workspace package.
+const WorkspaceProtocol = "workspace:"
+
+type pnpmWorkspaceFile struct {
+	Packages []string                     `yaml:"packages"`
+	Catalog  map[string]string            `yaml:"catalog"`
+	Catalogs map[string]map[string]string `yaml:"catalogs"`
+}
+
+func readPnpmWorkspaceFile(path string) (*pnpmWorkspaceFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var workspace pnpmWorkspaceFile
+	if err := yaml.Unmarshal(content, &workspace); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// catalogsByName merges the "catalog" field into the "default" named catalog, the way pnpm
+// treats them as the same catalog.
+func (w *pnpmWorkspaceFile) catalogsByName() map[string]map[string]string {
+	catalogs := make(map[string]map[string]string, len(w.Catalogs)+1)
+	for name, entries := range w.Catalogs {
+		catalogs[name] = entries
+	}
+	if len(w.Catalog) > 0 {
+		merged := make(map[string]string, len(w.Catalog)+len(catalogs[DefaultCatalogName]))
+		for dependency, version := range catalogs[DefaultCatalogName] {
+			merged[dependency] = version
+		}
+		for dependency, version := range w.Catalog {
+			merged[dependency] = version
+		}
+		catalogs[DefaultCatalogName] = merged
+	}
+	return catalogs
+}
+
+// ParseCatalogSpecifier returns the catalog name referenced by a "catalog:" / "catalog:<name>"
+// dependency specifier.
+func ParseCatalogSpecifier(specifier string) (string, bool) {
+	if !strings.HasPrefix(specifier, CatalogProtocol) {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(specifier, CatalogProtocol))
+	if name == "" {
+		name = DefaultCatalogName
+	}
+	return name, true
+}
+
+// ResolveCatalogVersion returns the version range a "catalog:" specifier of dependency stands
+// for. ok is false when the specifier does not use the catalog protocol or when the referenced
+// catalog has no entry for the dependency.
+func (ctx *MonorepoContext) ResolveCatalogVersion(dependency string, specifier string) (version string, catalogName string, ok bool) {
+	catalogName, isCatalog := ParseCatalogSpecifier(specifier)
+	if !isCatalog {
+		return "", "", false
+	}
+	version, ok = ctx.Catalogs[catalogName][dependency]
+	return version, catalogName, ok
+}
+
+// ResolveDependencyVersion returns the version range declared for dependency, following
+// "catalog:" references. Other specifiers, and catalog references without an entry, are
+// returned unchanged.
+func (ctx *MonorepoContext) ResolveDependencyVersion(dependency string, specifier string) string {
+	if version, _, ok := ctx.ResolveCatalogVersion(dependency, specifier); ok {
+		return version
+	}
+	return specifier
+}
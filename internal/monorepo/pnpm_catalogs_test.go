@@ -0,0 +1,67 @@
+package monorepo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"rev-dep-go/internal/pathutil"
+)
+
+func TestFindWorkspacePackages_ParsesPnpmCatalogs(t *testing.T) {
+	root := t.TempDir()
+
+	workspaceYaml := `packages:
+  - packages/*
+catalog:
+  react: ^18.2.0
+catalogs:
+  default:
+    lodash: ^4.17.21
+  react17:
+    react: ^17.0.2
+`
+	if err := os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte(workspaceYaml), 0644); err != nil {
+		t.Fatalf("write pnpm-workspace.yaml: %v", err)
+	}
+	writePkg(t, filepath.Join(root, "packages", "app"), "app")
+
+	ctx := NewMonorepoContext(pathutil.NormalizePathForInternal(filepath.Clean(root)))
+	ctx.FindWorkspacePackages(nil, nil)
+
+	if _, ok := ctx.PackageToPath["app"]; !ok {
+		t.Fatalf("expected workspace package app to be discovered, got %v", ctx.PackageToPath)
+	}
+
+	tests := []struct {
+		specifier   string
+		dependency  string
+		wantVersion string
+		wantCatalog string
+		wantOk      bool
+	}{
+		{"catalog:", "react", "^18.2.0", DefaultCatalogName, true},
+		{"catalog:default", "react", "^18.2.0", DefaultCatalogName, true},
+		// "catalog" and "catalogs.default" are the same catalog.
+		{"catalog:", "lodash", "^4.17.21", DefaultCatalogName, true},
+		{"catalog:react17", "react", "^17.0.2", "react17", true},
+		{"catalog:react17", "lodash", "", "react17", false},
+		{"catalog:missing", "react", "", "missing", false},
+		{"^1.0.0", "react", "", "", false},
+	}
+
+	for _, tt := range tests {
+		version, catalog, ok := ctx.ResolveCatalogVersion(tt.dependency, tt.specifier)
+		if version != tt.wantVersion || catalog != tt.wantCatalog || ok != tt.wantOk {
+			t.Errorf("ResolveCatalogVersion(%q, %q) = (%q, %q, %v), want (%q, %q, %v)",
+				tt.dependency, tt.specifier, version, catalog, ok, tt.wantVersion, tt.wantCatalog, tt.wantOk)
+		}
+	}
+
+	if got := ctx.ResolveDependencyVersion("react", "catalog:react17"); got != "^17.0.2" {
+		t.Errorf("expected catalog reference to resolve to ^17.0.2, got %q", got)
+	}
+	if got := ctx.ResolveDependencyVersion("react", "workspace:*"); got != "workspace:*" {
+		t.Errorf("expected non-catalog specifier to be returned unchanged, got %q", got)
+	}
+}
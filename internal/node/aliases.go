@@ -27,3 +27,12 @@ const (
 	MonorepoModule         = model.MonorepoModule
 	LocalExportDeclaration = model.LocalExportDeclaration
 )
+
+type NodeModulesLookupStrategy = model.NodeModulesLookupStrategy
+
+const (
+	NodeModulesLookupStrategyAuto        = model.NodeModulesLookupStrategyAuto
+	NodeModulesLookupStrategyNodeModules = model.NodeModulesLookupStrategyNodeModules
+	NodeModulesLookupStrategyPnP         = model.NodeModulesLookupStrategyPnP
+	NodeModulesLookupStrategyLockfile    = model.NodeModulesLookupStrategyLockfile
+)
@@ -36,7 +36,7 @@ func TestNodeModulesPruneDocsCmd_DefaultPatterns(t *testing.T) {
 	mustWriteFile(t, filepath.Join(pkgDir, "docs", "guide.txt"), "docs")
 	mustWriteFile(t, filepath.Join(pkgDir, "index.js"), "module.exports = 1")
 
-	_, err := NodeModulesPruneDocsCmd(tmpDir, []string{}, true)
+	_, err := NodeModulesPruneDocsCmd(tmpDir, []string{}, true, false, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -64,7 +64,7 @@ func TestNodeModulesPruneDocsCmd_CustomPatterns(t *testing.T) {
 	mustWriteFile(t, filepath.Join(pkgDir, "README.md"), "readme")
 	mustWriteFile(t, filepath.Join(pkgDir, "LICENSE"), "license")
 
-	_, err := NodeModulesPruneDocsCmd(tmpDir, []string{"*.md"}, false)
+	_, err := NodeModulesPruneDocsCmd(tmpDir, []string{"*.md"}, false, false, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -82,7 +82,7 @@ func TestNodeModulesPruneDocsCmd_CustomPatterns(t *testing.T) {
 
 func TestNodeModulesPruneDocsCmd_RequiresPatterns(t *testing.T) {
 	tmpDir := t.TempDir()
-	_, err := NodeModulesPruneDocsCmd(tmpDir, []string{}, false)
+	_, err := NodeModulesPruneDocsCmd(tmpDir, []string{}, false, false, nil)
 	if err == nil {
 		t.Fatalf("expected error when no patterns are provided")
 	}
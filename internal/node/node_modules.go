@@ -54,8 +54,13 @@ func parseBinField(raw json.RawMessage, moduleName string) []string {
 }
 
 // FindNodeModuleBinaries maps each declared dependency to the binaries it provides, by
-// reading the "bin" field of its package.json in the nearest node_modules that has it.
+// reading the "bin" field of its package.json in the nearest node_modules that has it. Projects
+// installed with Yarn PnP have no node_modules, so packages are resolved through the PnP manifest.
 func FindNodeModuleBinaries(nodeModules map[string]bool, cwd string) map[string][]string {
+	if manifest, _ := LoadPnPManifest(cwd, NodeModulesLookupStrategyAuto); manifest != nil {
+		return findPnPModuleBinaries(manifest, nodeModules, cwd)
+	}
+
 	nodeModuleDirs := []string{}
 	// Walk up the directory tree from cwd and collect any "node_modules" dirs
 	cur := filepath.Clean(cwd)
@@ -113,38 +118,35 @@ func FindNodeModuleBinaries(nodeModules map[string]bool, cwd string) map[string]
 	return result
 }
 
-func NodeModulesCmd(
+// nodeModulesAnalysis is the dependency tree of cwd along with the node modules declared for it,
+// shared by the used, unused and missing reports.
+type nodeModulesAnalysis struct {
+	cwd                       string
+	minimalTree               MinimalDependencyTree
+	absolutePathToEntryPoints []string
+	cwdNodeModules            map[string]bool
+	// entryOwnedFiles restricts the files counted as using cwd dependencies; nil means all files.
+	entryOwnedFiles map[string]bool
+	// rootDevDependencies are the monorepo root devDependencies available to package code.
+	rootDevDependencies map[string]bool
+}
+
+func analyzeNodeModules(
 	inputCwd string,
 	ignoreType bool,
 	entryPoints []string,
-	countFlag bool,
-	listUnused bool,
-	listMissing bool,
-	groupByModule bool,
-	groupByFile bool,
-	groupByModuleFilesCount bool,
-	groupByEntryPoint bool,
-	groupByEntryPointModulesCount bool,
-	groupByModuleShowEntryPoints bool,
-	groupByModuleEntryPointsCount bool,
-	pkgJsonFieldsWithBinaries []string,
-	filesWithBinaries []string,
-	filesWithModules []string,
-	modulesToInclude []string,
-	modulesToExclude []string,
+	detectEntryPoints bool,
 	packageJson string,
 	tsconfigJson string,
 	conditionNames []string,
 	followMonorepoPackages FollowMonorepoPackagesValue,
 	nearestPackage bool,
 	includeDevDepsFromRoot bool,
-) (string, int) {
+) nodeModulesAnalysis {
 	cwd := pathutil.StandardiseDirPath(inputCwd)
 	excludeFiles := []string{}
 	absolutePathToEntryPoints, discoveredFiles := resolve.ResolveEntryPointsFromPatterns(cwd, entryPoints, excludeFiles, nil)
 
-	shouldIncludeModule := createShouldModuleByIncluded(modulesToInclude, modulesToExclude)
-
 	upfrontFilesList := absolutePathToEntryPoints
 	if len(discoveredFiles) > 0 {
 		upfrontFilesList = discoveredFiles
@@ -159,7 +161,7 @@ func NodeModulesCmd(
 
 	minimalTree, _, resolverManager := resolve.GetMinimalDepsTreeForCwd(cwd, ignoreType, excludeFiles, nil, upfrontFilesList, packageJson, tsconfigJson, conditionNames, followMonorepoPackages, nil, nodeModulesMatchingStrategy)
 
-	if len(absolutePathToEntryPoints) == 0 && (groupByEntryPoint || groupByEntryPointModulesCount || groupByModuleShowEntryPoints || groupByModuleEntryPointsCount) {
+	if len(absolutePathToEntryPoints) == 0 && detectEntryPoints {
 		absolutePathToEntryPoints = graph.GetEntryPoints(minimalTree, []string{}, []string{}, cwd)
 	}
 
@@ -193,15 +195,57 @@ func NodeModulesCmd(
 		}
 	}
 
+	return nodeModulesAnalysis{
+		cwd:                       cwd,
+		minimalTree:               minimalTree,
+		absolutePathToEntryPoints: absolutePathToEntryPoints,
+		cwdNodeModules:            cwdNodeModules,
+		entryOwnedFiles:           entryOwnedFiles,
+		rootDevDependencies:       rootDevDependencies,
+	}
+}
+
+func NodeModulesCmd(
+	inputCwd string,
+	ignoreType bool,
+	entryPoints []string,
+	countFlag bool,
+	listUnused bool,
+	listMissing bool,
+	groupByModule bool,
+	groupByFile bool,
+	groupByModuleFilesCount bool,
+	groupByEntryPoint bool,
+	groupByEntryPointModulesCount bool,
+	groupByModuleShowEntryPoints bool,
+	groupByModuleEntryPointsCount bool,
+	pkgJsonFieldsWithBinaries []string,
+	filesWithBinaries []string,
+	filesWithModules []string,
+	modulesToInclude []string,
+	modulesToExclude []string,
+	packageJson string,
+	tsconfigJson string,
+	conditionNames []string,
+	followMonorepoPackages FollowMonorepoPackagesValue,
+	nearestPackage bool,
+	includeDevDepsFromRoot bool,
+) (string, int) {
+	detectEntryPoints := groupByEntryPoint || groupByEntryPointModulesCount || groupByModuleShowEntryPoints || groupByModuleEntryPointsCount
+	analysis := analyzeNodeModules(inputCwd, ignoreType, entryPoints, detectEntryPoints, packageJson, tsconfigJson, conditionNames, followMonorepoPackages, nearestPackage, includeDevDepsFromRoot)
+	cwd := analysis.cwd
+
+	shouldIncludeModule := createShouldModuleByIncluded(modulesToInclude, modulesToExclude)
+
 	if listMissing {
-		missingResults := GetMissingNodeModulesFromTree(minimalTree, modulesToInclude, modulesToExclude, cwdNodeModules, rootDevDependencies, nearestPackage)
+		missingResults := GetMissingNodeModulesFromTree(analysis.minimalTree, modulesToInclude, modulesToExclude, analysis.cwdNodeModules, analysis.rootDevDependencies, nearestPackage)
 		return FormatMissingNodeModulesResults(missingResults, cwd, countFlag, groupByModule, groupByFile, groupByModuleFilesCount)
 	}
 
 	if listUnused {
 		unusedModules := GetUnusedNodeModulesFromTree(
-			minimalTree,
-			cwdNodeModules,
+			analysis.minimalTree,
+			analysis.cwdNodeModules,
 			cwd,
 			pkgJsonFieldsWithBinaries,
 			filesWithBinaries,
@@ -210,12 +254,12 @@ func NodeModulesCmd(
 			tsconfigJson,
 			modulesToInclude,
 			modulesToExclude,
-			entryOwnedFiles,
+			analysis.entryOwnedFiles,
 		)
 		return FormatUnusedNodeModulesResults(unusedModules, countFlag)
 	}
 
-	usedNodeModules := GetUsedNodeModulesFromTree(minimalTree, cwdNodeModules, cwd, pkgJsonFieldsWithBinaries, filesWithBinaries, filesWithModules, packageJson, tsconfigJson, nil)
+	usedNodeModules := GetUsedNodeModulesFromTree(analysis.minimalTree, analysis.cwdNodeModules, cwd, pkgJsonFieldsWithBinaries, filesWithBinaries, filesWithModules, packageJson, tsconfigJson, nil)
 	return formatUsedNodeModulesResult(
 		usedNodeModules,
 		cwd,
@@ -227,20 +271,97 @@ func NodeModulesCmd(
 		groupByEntryPointModulesCount,
 		groupByModuleShowEntryPoints,
 		groupByModuleEntryPointsCount,
-		absolutePathToEntryPoints,
-		minimalTree,
+		analysis.absolutePathToEntryPoints,
+		analysis.minimalTree,
 		shouldIncludeModule,
 	)
 }
 
+// NodeModulesUsage is the data behind the used, unused and missing node modules reports. Only
+// the part asked for is set.
+type NodeModulesUsage struct {
+	// Used maps each imported module to the files importing it.
+	Used map[string]map[string]bool
+	// UsedByEntryPoint maps entry points to the modules reachable from them.
+	UsedByEntryPoint map[string]map[string]bool
+	Unused           []string
+	Missing          []MissingNodeModuleResult
+}
+
+// GetNodeModulesUsage runs the analysis of NodeModulesCmd and returns its result instead of
+// formatting it: the missing modules with listMissing, the unused ones with listUnused, and the
+// used ones otherwise, grouped by entry point as well with byEntryPoint.
+func GetNodeModulesUsage(
+	inputCwd string,
+	ignoreType bool,
+	entryPoints []string,
+	listUnused bool,
+	listMissing bool,
+	byEntryPoint bool,
+	pkgJsonFieldsWithBinaries []string,
+	filesWithBinaries []string,
+	filesWithModules []string,
+	modulesToInclude []string,
+	modulesToExclude []string,
+	packageJson string,
+	tsconfigJson string,
+	conditionNames []string,
+	followMonorepoPackages FollowMonorepoPackagesValue,
+	nearestPackage bool,
+	includeDevDepsFromRoot bool,
+) NodeModulesUsage {
+	analysis := analyzeNodeModules(inputCwd, ignoreType, entryPoints, byEntryPoint, packageJson, tsconfigJson, conditionNames, followMonorepoPackages, nearestPackage, includeDevDepsFromRoot)
+
+	if listMissing {
+		missing := GetMissingNodeModulesFromTree(analysis.minimalTree, modulesToInclude, modulesToExclude, analysis.cwdNodeModules, analysis.rootDevDependencies, nearestPackage)
+		return NodeModulesUsage{Missing: missing}
+	}
+
+	if listUnused {
+		unused := GetUnusedNodeModulesFromTree(
+			analysis.minimalTree,
+			analysis.cwdNodeModules,
+			analysis.cwd,
+			pkgJsonFieldsWithBinaries,
+			filesWithBinaries,
+			filesWithModules,
+			packageJson,
+			tsconfigJson,
+			modulesToInclude,
+			modulesToExclude,
+			analysis.entryOwnedFiles,
+		)
+		return NodeModulesUsage{Unused: unused}
+	}
+
+	shouldIncludeModule := createShouldModuleByIncluded(modulesToInclude, modulesToExclude)
+	usedNodeModules := GetUsedNodeModulesFromTree(analysis.minimalTree, analysis.cwdNodeModules, analysis.cwd, pkgJsonFieldsWithBinaries, filesWithBinaries, filesWithModules, packageJson, tsconfigJson, nil)
+	used := map[string]map[string]bool{}
+	for moduleName, files := range usedNodeModules {
+		if shouldIncludeModule(moduleName) && module.IsValidNodeModuleName(moduleName) {
+			used[module.GetNodeModuleName(moduleName)] = files
+		}
+	}
+
+	usage := NodeModulesUsage{Used: used}
+	if byEntryPoint {
+		usage.UsedByEntryPoint = getNodeModulesByEntryPoint(analysis.minimalTree, analysis.absolutePathToEntryPoints, usedNodeModules, shouldIncludeModule)
+	}
+	return usage
+}
+
 type MissingNodeModuleResult struct {
 	ModuleName   string
 	ImportedFrom []string
+	// Fixes add the module to the package.json files of ImportedFrom; set by the config
+	// processor when autofix is enabled and a version to declare was found.
+	Fixes []PackageJsonDependencyFix
 }
 
 type UnusedNodeModuleIssue struct {
 	ModuleName      string
 	PackageJsonPath string
+	Fix             *PackageJsonDependencyFix
 }
 
 func GetUsedNodeModulesFromTree(
@@ -379,8 +500,18 @@ func GetUsedNodeModulesFromTree(
 						if typesSlice, ok4 := typesArr.([]interface{}); ok4 {
 							for _, typesModule := range typesSlice {
 								if typesModuleStr, ok5 := typesModule.(string); ok5 {
-									nodeModuleName := "@types/" + typesModuleStr
-									setFilePathInNodeModuleFilesMap(&usedNodeModules, nodeModuleName, tsconfigPath)
+									markTypesReference(&usedNodeModules, cwdNodeModules, typesModuleStr, tsconfigPath)
+								}
+							}
+						}
+					}
+					if typeRootsArr, ok3 := compilerOptions["typeRoots"]; ok3 {
+						if typeRootsSlice, ok4 := typeRootsArr.([]interface{}); ok4 {
+							for _, typeRoot := range typeRootsSlice {
+								if typeRootStr, ok5 := typeRoot.(string); ok5 {
+									if nodeModuleName := typeRootPackageName(typeRootStr); nodeModuleName != "" {
+										setFilePathInNodeModuleFilesMap(&usedNodeModules, nodeModuleName, tsconfigPath)
+									}
 								}
 							}
 						}
@@ -443,17 +574,49 @@ func GetUnusedNodeModulesFromTree(
 		entryOwnedFiles,
 	)
 
+	// A `@types/x` package is used when `x` is, and `@types/node` when a Node.js built-in is imported.
+	for filePath, fileDeps := range minimalTree {
+		if entryOwnedFiles != nil && !entryOwnedFiles[filePath] {
+			continue
+		}
+		if slices.ContainsFunc(fileDeps, func(dependency MinimalDependency) bool { return dependency.ResolvedType == BuiltInModule }) {
+			setFilePathInNodeModuleFilesMap(&usedNodeModules, "node", filePath)
+			break
+		}
+	}
+	isUsed := func(moduleName string) bool {
+		if _, has := usedNodeModules[moduleName]; has {
+			return true
+		}
+		if typedModule, isTypes := TypedPackageName(moduleName); isTypes {
+			_, hasTyped := usedNodeModules[typedModule]
+			return hasTyped
+		}
+		return false
+	}
+
 	unused := []string{}
 
 	for moduleName := range cwdNodeModules {
-		_, has := usedNodeModules[moduleName]
-		_, hasTypes := usedNodeModules[strings.Replace(moduleName, "@types/", "", 1)]
-
-		if !has && !hasTypes && shouldIncludeModule(moduleName) {
+		if !isUsed(moduleName) && shouldIncludeModule(moduleName) {
 			unused = append(unused, module.GetNodeModuleName(moduleName))
 		}
 	}
 
+	// Triple-slash `types` references are read only when something is left to be found used,
+	// as it takes reading the head of every file.
+	if len(unused) > 0 {
+		for filePath := range minimalTree {
+			if entryOwnedFiles != nil && !entryOwnedFiles[filePath] {
+				continue
+			}
+			for _, reference := range readTripleSlashTypesReferences(pathutil.DenormalizePathForOS(filePath)) {
+				markTypesReference(&usedNodeModules, cwdNodeModules, reference, filePath)
+			}
+		}
+		unused = slices.DeleteFunc(unused, isUsed)
+	}
+
 	slices.Sort(unused)
 	return unused
 }
@@ -815,6 +978,24 @@ func getNodeModulesByEntryPoint(
 	shouldIncludeModule func(moduleName string) bool,
 ) map[string]map[string]bool {
 	grouped := map[string]map[string]bool{}
+	for entryPoint, moduleFiles := range getNodeModuleFilesByEntryPoint(minimalTree, absolutePathToEntryPoints, usedNodeModules, shouldIncludeModule) {
+		grouped[entryPoint] = make(map[string]bool, len(moduleFiles))
+		for moduleName := range moduleFiles {
+			grouped[entryPoint][moduleName] = true
+		}
+	}
+	return grouped
+}
+
+// getNodeModuleFilesByEntryPoint maps each entry point to the modules reachable from it, and each
+// module to the files reachable from the entry point that import it.
+func getNodeModuleFilesByEntryPoint(
+	minimalTree MinimalDependencyTree,
+	absolutePathToEntryPoints []string,
+	usedNodeModules map[string]map[string]bool,
+	shouldIncludeModule func(moduleName string) bool,
+) map[string]map[string]map[string]bool {
+	grouped := map[string]map[string]map[string]bool{}
 
 	// Group-by-entry-point output should be based on explicit entry points only.
 	if len(absolutePathToEntryPoints) == 0 {
@@ -828,7 +1009,7 @@ func getNodeModulesByEntryPoint(
 		if _, has := minimalTree[entryPoint]; has {
 			entryPoints = append(entryPoints, entryPoint)
 			entryPointsSet[entryPoint] = true
-			grouped[entryPoint] = map[string]bool{}
+			grouped[entryPoint] = map[string]map[string]bool{}
 		}
 	}
 
@@ -934,9 +1115,12 @@ func getNodeModulesByEntryPoint(
 			entryPointsForFile := fileToEntryPoints[filePath]
 			for entryPoint := range entryPointsForFile {
 				if grouped[entryPoint] == nil {
-					grouped[entryPoint] = map[string]bool{}
+					grouped[entryPoint] = map[string]map[string]bool{}
+				}
+				if grouped[entryPoint][moduleName] == nil {
+					grouped[entryPoint][moduleName] = map[string]bool{}
 				}
-				grouped[entryPoint][moduleName] = true
+				grouped[entryPoint][moduleName][filePath] = true
 			}
 		}
 	}
@@ -14,7 +14,9 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 
+	"rev-dep-go/internal/lockfile"
 	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/pnp"
 	"rev-dep-go/internal/source"
 )
 
@@ -69,9 +71,19 @@ func CheckDirForNodeModuleDirs(dirName string, cwd string, nodeModulesDirChan ch
 	}
 }
 
-func GetInstalledModules(cwd string, modulesToInclude []string, modulesToExclude []string) (map[string][]PackageInfo, []string) {
+// GetInstalledModules lists installed packages by name along with the node_modules directories
+// found under cwd. When pnpManifest (or lock) is set, packages are read from the Yarn PnP manifest
+// (or the lockfile) instead and no node_modules directories are returned.
+func GetInstalledModules(cwd string, modulesToInclude []string, modulesToExclude []string, pnpManifest *pnp.Manifest, lock *lockfile.Lockfile) (map[string][]PackageInfo, []string) {
 	shouldIncludeModule := createShouldModuleByIncluded(modulesToInclude, modulesToExclude)
 
+	if pnpManifest != nil {
+		return getInstalledModulesFromPnP(pnpManifest, cwd, shouldIncludeModule), []string{}
+	}
+	if lock != nil {
+		return getInstalledModulesFromLockfile(lock, cwd, shouldIncludeModule), []string{}
+	}
+
 	packageInfoChan := make(chan PackageInfo)
 	nodeModulesDirChan := make(chan string)
 	var wg sync.WaitGroup
@@ -175,10 +187,83 @@ func SortPathsToNodeModulesByNestingLevel(paths []string) {
 	})
 }
 
-func GetDuplicatedModulesCmd(cwd string, shouldOptimize bool, verbose bool, sizeStats bool, isolate bool) string {
-	modules, nodeModuleDirs := GetInstalledModules(cwd, []string{}, []string{})
+// DuplicatedModules is the result of looking for packages installed more than once, and of
+// replacing the duplicates with symlinks when optimizing.
+type DuplicatedModules struct {
+	// ByVersion maps package names to the versions installed more than once and the package.json
+	// paths (relative to cwd) of each copy.
+	ByVersion map[string]map[string][]string
+	Optimized bool
+	// OptimizeSkippedReason explains why optimizing was asked for but not done.
+	OptimizeSkippedReason string
+	SymlinksCreated       int
+	SymlinksErrored       int
+	// Skipped lists the package directories left in place because they have nested node_modules.
+	Skipped []string
+	// DirSizes holds the node_modules directory sizes before and after optimizing, with SizeStats.
+	DirSizes []NodeModulesDirSizeChange
+	// DryRun is set when the symlinks were only planned and node_modules was left unchanged.
+	DryRun bool
+	// Symlinks are the duplicate directories replaced with symlinks, or to be replaced with DryRun.
+	Symlinks []NodeModulesSymlink
+}
+
+// NodeModulesSymlink is a duplicate package directory replaced with a symlink to the copy kept.
+type NodeModulesSymlink struct {
+	Path   string
+	Target string
+	// Size is the size of the files of the replaced directory. It is only measured with DryRun or
+	// a Journal, and 0 otherwise.
+	Size int64
+}
+
+// NodeModulesDirSizeChange is the size of a node_modules directory before and after optimizing.
+type NodeModulesDirSizeChange struct {
+	Path       string
+	SizeBefore int64
+	SizeAfter  int64
+}
+
+// DuplicatedModulesOptions configures FindDuplicatedModules. The zero value lists the duplicates
+// found in node_modules directories without changing anything.
+type DuplicatedModulesOptions struct {
+	// Optimize replaces every copy but the least nested one with a symlink to it.
+	Optimize bool
+	// Verbose prints every symlink while optimizing.
+	Verbose bool
+	// SizeStats measures the node_modules directories before and after optimizing.
+	SizeStats bool
+	// Isolate only creates symlinks within the same top-level node_modules directory.
+	Isolate bool
+	// DryRun only lists the symlinks Optimize would create.
+	DryRun bool
+	// Journal, when set, records the symlinks and keeps the replaced directories in its trash
+	// instead of deleting them; the caller saves the journal.
+	Journal *NodeModulesJournal
+	// PnPManifest or Lockfile, when set, are read instead of node_modules directories.
+	PnPManifest *pnp.Manifest
+	Lockfile    *lockfile.Lockfile
+}
 
-	duplicatedModulesByVersion := make(map[string]map[string][]string)
+// FindDuplicatedModules lists the packages installed more than once with the same version and,
+// with opts.Optimize, replaces every copy but the least nested one with a symlink to it.
+func FindDuplicatedModules(cwd string, opts DuplicatedModulesOptions) DuplicatedModules {
+	modules, nodeModuleDirs := GetInstalledModules(cwd, []string{}, []string{}, opts.PnPManifest, opts.Lockfile)
+	result := DuplicatedModules{ByVersion: map[string]map[string][]string{}, Skipped: []string{}, DryRun: opts.DryRun, Symlinks: []NodeModulesSymlink{}}
+
+	shouldOptimize := opts.Optimize
+	// PnP packages live in read-only cache archives, there is nothing to symlink.
+	if shouldOptimize && opts.PnPManifest != nil {
+		result.OptimizeSkippedReason = "packages are installed with Yarn PnP and cannot be symlinked"
+		shouldOptimize = false
+	}
+	// Packages read from a lockfile are not installed at all.
+	if shouldOptimize && opts.Lockfile != nil {
+		result.OptimizeSkippedReason = fmt.Sprintf("packages are read from %s and are not installed", filepath.Base(opts.Lockfile.Path))
+		shouldOptimize = false
+	}
+
+	duplicatedModulesByVersion := result.ByVersion
 
 	for name, installations := range modules {
 		moduleInfo := map[string][]string{}
@@ -203,74 +288,112 @@ func GetDuplicatedModulesCmd(cwd string, shouldOptimize bool, verbose bool, size
 		}
 	}
 
+	if !shouldOptimize {
+		return result
+	}
+	result.Optimized = true
+
 	nodeModuleDirsWithoutCwd := []string{}
 
 	for _, nodeModuleDir := range nodeModuleDirs {
 		nodeModuleDirsWithoutCwd = append(nodeModuleDirsWithoutCwd, strings.Replace(nodeModuleDir, cwd, "", 1))
 	}
 
-	count := 0
-	errorC := 0
-	skipped := []string{}
 	installedSizeBefore := map[string]int64{}
+	// Sizes before and after are only measured when node_modules changes.
+	sizeStats := opts.SizeStats && !opts.DryRun
+	// Each symlink's size is only reported by a dry run and recorded in a journal.
+	measureSymlinks := opts.DryRun || opts.Journal != nil
 
-	if shouldOptimize {
-		if sizeStats {
-			for _, modulePath := range nodeModuleDirs {
-				size, _ := dirSizeWithoutSymlinkSize(modulePath)
-				installedSizeBefore[modulePath] = size
-			}
+	if sizeStats {
+		for _, modulePath := range nodeModuleDirs {
+			size, _ := dirSizeWithoutSymlinkSize(modulePath)
+			installedSizeBefore[modulePath] = size
 		}
-		// It's only safe to create symlinks to leaf packages
-		for _, data := range duplicatedModulesByVersion {
-			for version, paths := range data {
-				SortPathsToNodeModulesByNestingLevel(paths)
-				pathsGroups := groupNodeModulePathsByNodeModuleDirs(paths, nodeModuleDirsWithoutCwd, isolate)
+	}
+	// It's only safe to create symlinks to leaf packages
+	for _, data := range duplicatedModulesByVersion {
+		for version, paths := range data {
+			SortPathsToNodeModulesByNestingLevel(paths)
+			pathsGroups := groupNodeModulePathsByNodeModuleDirs(paths, nodeModuleDirsWithoutCwd, opts.Isolate)
 
-				for _, paths := range pathsGroups {
-					stored := paths[0]
-					rest := paths[1:]
+			for _, paths := range pathsGroups {
+				stored := paths[0]
+				rest := paths[1:]
 
-					storedDir := strings.Replace(stored, pathutil.OSSeparator+"package.json", "", 1)
-					storedDirAbsPath := filepath.Join(cwd, storedDir)
+				storedDir := strings.Replace(stored, pathutil.OSSeparator+"package.json", "", 1)
+				storedDirAbsPath := filepath.Join(cwd, storedDir)
 
-					nestedNodeModules, err := os.Stat(filepath.Join(storedDirAbsPath, "node_modules"))
+				nestedNodeModules, err := os.Stat(filepath.Join(storedDirAbsPath, "node_modules"))
+
+				if err == nil && nestedNodeModules.IsDir() {
+					result.Skipped = append(result.Skipped, storedDirAbsPath)
+					continue
+				}
 
+				for _, pathToSymlink := range rest {
+					pathToSymlinkDir := strings.Replace(pathToSymlink, pathutil.OSSeparator+"package.json", "", 1)
+					symlinkDirAbsPath := filepath.Join(cwd, pathToSymlinkDir)
+
+					nestedNodeModules, err = os.Stat(filepath.Join(symlinkDirAbsPath, "node_modules"))
 					if err == nil && nestedNodeModules.IsDir() {
-						skipped = append(skipped, storedDirAbsPath)
+						result.Skipped = append(result.Skipped, symlinkDirAbsPath)
 						continue
 					}
 
-					for _, pathToSymlink := range rest {
-						pathToSymlinkDir := strings.Replace(pathToSymlink, pathutil.OSSeparator+"package.json", "", 1)
-						symlinkDirAbsPath := filepath.Join(cwd, pathToSymlinkDir)
-
-						nestedNodeModules, err = os.Stat(filepath.Join(symlinkDirAbsPath, "node_modules"))
-						if err == nil && nestedNodeModules.IsDir() {
-							skipped = append(skipped, symlinkDirAbsPath)
-							continue
-						}
+					symlink := NodeModulesSymlink{Path: symlinkDirAbsPath, Target: storedDirAbsPath}
+					if measureSymlinks {
+						symlink.Size, _ = dirSizeWithoutSymlinkSize(symlinkDirAbsPath)
+					}
+					if opts.DryRun {
+						result.Symlinks = append(result.Symlinks, symlink)
+						continue
+					}
 
+					var symlinkErr error
+					if opts.Journal != nil {
+						symlinkErr = opts.Journal.replaceWithSymlink(symlinkDirAbsPath, storedDirAbsPath, symlink.Size)
+					} else {
 						os.RemoveAll(symlinkDirAbsPath)
-						symlinkErr := os.Symlink(storedDirAbsPath, symlinkDirAbsPath)
+						symlinkErr = os.Symlink(storedDirAbsPath, symlinkDirAbsPath)
+					}
 
-						if verbose {
-							fmt.Println("Symlink", version, storedDirAbsPath, "in", symlinkDirAbsPath)
-						}
+					if opts.Verbose {
+						fmt.Println("Symlink", version, storedDirAbsPath, "in", symlinkDirAbsPath)
+					}
 
-						if symlinkErr != nil {
-							if verbose {
-								fmt.Println(symlinkErr)
-							}
-							errorC++
+					if symlinkErr != nil {
+						if opts.Verbose {
+							fmt.Println(symlinkErr)
 						}
-						count++
+						result.SymlinksErrored++
+					} else {
+						result.Symlinks = append(result.Symlinks, symlink)
 					}
+					result.SymlinksCreated++
 				}
 			}
 		}
 	}
 
+	if sizeStats {
+		for _, modulePath := range nodeModuleDirs {
+			size, _ := dirSizeWithoutSymlinkSize(modulePath)
+			result.DirSizes = append(result.DirSizes, NodeModulesDirSizeChange{
+				Path:       modulePath,
+				SizeBefore: installedSizeBefore[modulePath],
+				SizeAfter:  size,
+			})
+		}
+	}
+
+	return result
+}
+
+func GetDuplicatedModulesCmd(cwd string, opts DuplicatedModulesOptions) string {
+	duplicated := FindDuplicatedModules(cwd, opts)
+	duplicatedModulesByVersion := duplicated.ByVersion
+
 	sortedDuplicatedModuleNames := make([]string, len(duplicatedModulesByVersion))
 
 	for name := range duplicatedModulesByVersion {
@@ -301,11 +424,23 @@ func GetDuplicatedModulesCmd(cwd string, shouldOptimize bool, verbose bool, size
 		}
 	}
 
-	if shouldOptimize {
-		result += fmt.Sprintln("\nSymlinks", "Created:", (count), "Skipped:", len(skipped), "Errored:", errorC, "\n", "")
+	if duplicated.Optimized && duplicated.DryRun {
+		var plannedSize int64
+		result += "\nPlanned symlinks (dry run, node_modules is left unchanged):\n"
+		for _, symlink := range duplicated.Symlinks {
+			plannedSize += symlink.Size
+			result += fmt.Sprintf("   %s -> %s %s\n", relativeToCwd(cwd, symlink.Path), relativeToCwd(cwd, symlink.Target), formatSize(symlink.Size))
+		}
+		result += fmt.Sprintln("\nSymlinks", "Planned:", len(duplicated.Symlinks), "Skipped:", len(duplicated.Skipped), "Size:", formatSize(plannedSize))
+	} else if duplicated.Optimized {
+		result += fmt.Sprintln("\nSymlinks", "Created:", duplicated.SymlinksCreated, "Skipped:", len(duplicated.Skipped), "Errored:", duplicated.SymlinksErrored, "\n", "")
+	}
+
+	if duplicated.OptimizeSkippedReason != "" {
+		result += fmt.Sprintf("\nOptimization skipped: %s\n", duplicated.OptimizeSkippedReason)
 	}
 
-	if shouldOptimize && sizeStats {
+	if duplicated.Optimized && opts.SizeStats && !duplicated.DryRun {
 
 		var builder strings.Builder
 		var sumBefore int64 = 0
@@ -316,13 +451,11 @@ func GetDuplicatedModulesCmd(cwd string, shouldOptimize bool, verbose bool, size
 		fmt.Fprintln(writer, "DIR NAME\tBEFORE(MB)\tAFTER(MB)\tREDUCTION(MB)")
 		fmt.Fprintln(writer, "\t\t\t\t")
 
-		for _, modulePath := range nodeModuleDirs {
-			size, _ := dirSizeWithoutSymlinkSize(modulePath)
-			sumBefore += installedSizeBefore[modulePath]
-			sumAfter += size
-			// fmt.Fprintln(writer, modulePath, "\t", installedSizeBefore[modulePath], "After:", size, "Reduced:", installedSizeBefore[modulePath]-size)
+		for _, dirSize := range duplicated.DirSizes {
+			sumBefore += dirSize.SizeBefore
+			sumAfter += dirSize.SizeAfter
 			fmt.Fprintf(writer, "%s\t%.2f\t%.2f\t%.2f\n",
-				strings.Replace(modulePath, cwd, "", 1), bytesToMB(installedSizeBefore[modulePath]), bytesToMB(size), bytesToMB(installedSizeBefore[modulePath]-size),
+				strings.Replace(dirSize.Path, cwd, "", 1), bytesToMB(dirSize.SizeBefore), bytesToMB(dirSize.SizeAfter), bytesToMB(dirSize.SizeBefore-dirSize.SizeAfter),
 			)
 		}
 
@@ -368,8 +501,8 @@ func groupNodeModulePathsByNodeModuleDirs(paths []string, nodeModuleDirs []strin
 	}
 }
 
-func GetInstalledModulesCmd(cwd string, modulesToInclude []string, modulesToExclude []string) string {
-	modules, _ := GetInstalledModules(cwd, modulesToInclude, modulesToExclude)
+func GetInstalledModulesCmd(cwd string, modulesToInclude []string, modulesToExclude []string, pnpManifest *pnp.Manifest, lock *lockfile.Lockfile) string {
+	modules, _ := GetInstalledModules(cwd, modulesToInclude, modulesToExclude, pnpManifest, lock)
 
 	sortedModules := source.GetSortedMap(modules)
 	result := ""
@@ -409,9 +542,25 @@ func GetInstalledModulesCmd(cwd string, modulesToInclude []string, modulesToExcl
 	return result
 }
 
-func ModulesDiskSizeCmd(cwd string) string {
+// NodeModulesDirSize is the size of the files of a node_modules directory.
+type NodeModulesDirSize struct {
+	Path string
+	Size int64
+}
+
+// GetNodeModulesDirSizes returns the size of every top-level node_modules directory under cwd,
+// leaving symlinks out.
+func GetNodeModulesDirSizes(cwd string) []NodeModulesDirSize {
 	nodeModuleDirs := GetNodeModuleDirs(cwd)
+	sizes := make([]NodeModulesDirSize, 0, len(nodeModuleDirs))
+	for _, modulePath := range nodeModuleDirs {
+		size, _ := dirSizeWithoutSymlinkSize(modulePath)
+		sizes = append(sizes, NodeModulesDirSize{Path: modulePath, Size: size})
+	}
+	return sizes
+}
 
+func ModulesDiskSizeCmd(cwd string) string {
 	var builder strings.Builder
 	var sum int64 = 0
 
@@ -420,11 +569,10 @@ func ModulesDiskSizeCmd(cwd string) string {
 	fmt.Fprintln(writer, "DIR NAME\tSIZE(MB)")
 	fmt.Fprintln(writer, "\t\t\t\t")
 
-	for _, modulePath := range nodeModuleDirs {
-		size, _ := dirSizeWithoutSymlinkSize(modulePath)
-		sum += size
+	for _, dirSize := range GetNodeModulesDirSizes(cwd) {
+		sum += dirSize.Size
 		fmt.Fprintf(writer, "%s\t%.2f\n",
-			strings.Replace(modulePath, cwd, "", 1), bytesToMB(size),
+			strings.Replace(dirSize.Path, cwd, "", 1), bytesToMB(dirSize.Size),
 		)
 	}
 
@@ -452,38 +600,90 @@ type ModuleReport struct {
 
 // AnalyzeNodeModules performs dependency-aware size breakdown with semver-aware exclusivity.
 func AnalyzeNodeModules(cwd string, modules map[string][]PackageInfo) ([]ModuleReport, error) {
-	realPath := func(p string) string {
-		if rp, err := filepath.EvalSymlinks(p); err == nil {
-			return rp
-		}
-		return p
+	absCwd, err := resolveAbsoluteRealCwd(cwd)
+	if err != nil {
+		return nil, err
 	}
 
+	graph := buildInstalledPackagesGraph(absCwd, modules)
+
+	// ---------- Size calculation (follow symlinks) ----------
+	dirSizeWithSymlinksSize := func(root string) (int64, error) {
+		var size int64
+		visited := make(map[string]bool)
+
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			real := realPath(path)
+			if visited[real] {
+				return nil
+			}
+			visited[real] = true
+
+			info, err := os.Stat(real)
+			if err != nil {
+				return nil
+			}
+			if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+			return nil
+		})
+		return size, err
+	}
+
+	for _, n := range graph.installedByPkgJSON {
+		n.Size, _ = dirSizeWithSymlinksSize(n.Dir)
+	}
+
+	// ---------- Compute exclusive/shared deps ----------
+	return computeModuleReports(graph.installedByPkgJSON, graph.graph, graph.incoming, graph.rootToInstalled, graph.rootsReferencingCount), nil
+}
+
+// installedPackagesGraph is the dependency graph of the packages installed in node_modules,
+// keyed by the real absolute path of each package.json. Project roots are the package.json files
+// outside node_modules.
+type installedPackagesGraph struct {
+	rootPkgFiles          []string
+	installedByPkgJSON    map[string]*installedPackageNode
+	graph                 map[string][]string
+	incoming              map[string]int
+	rootToInstalled       map[string][]string
+	rootsReferencingCount map[string]int
+}
+
+func realPath(p string) string {
+	if rp, err := filepath.EvalSymlinks(p); err == nil {
+		return rp
+	}
+	return p
+}
+
+// resolveAbsoluteRealCwd returns cwd (the working directory when empty) as an absolute path with
+// symlinks resolved.
+func resolveAbsoluteRealCwd(cwd string) (string, error) {
 	if cwd == "" {
 		wd, err := os.Getwd()
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		cwd = wd
 	}
 	absCwd, err := filepath.Abs(cwd)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	absCwd = realPath(absCwd)
+	return realPath(absCwd), nil
+}
 
+// buildInstalledPackagesGraph links every installed package to the installed copies of its
+// declared dependencies, resolved the way Node does (nearest node_modules walking up) and matched
+// against the declared semver range. Sizes are left empty.
+func buildInstalledPackagesGraph(absCwd string, modules map[string][]PackageInfo) *installedPackagesGraph {
 	// ---------- STEP 1: Build installed module index ----------
-	type node struct {
-		Key     string
-		Name    string
-		Version string
-		Dir     string
-		Size    int64
-		Deps    []DeclaredDep
-	}
-
-	installedByPkgJSON := make(map[string]*node)
-	installedPkgJSONSet := make(map[string]bool)
+	installedByPkgJSON := make(map[string]*installedPackageNode)
 
 	for _, arr := range modules {
 		for _, pi := range arr {
@@ -497,46 +697,17 @@ func AnalyzeNodeModules(cwd string, modules map[string][]PackageInfo) ([]ModuleR
 			absPath = realPath(absPath)
 			dir := realPath(filepath.Dir(absPath))
 
-			installedByPkgJSON[absPath] = &node{
+			installedByPkgJSON[absPath] = &installedPackageNode{
 				Key:     absPath,
 				Name:    pi.Name,
 				Version: pi.Version,
 				Dir:     dir,
 			}
-			installedPkgJSONSet[absPath] = true
 		}
 	}
 
-	// ---------- STEP 1.5: Size calculation (follow symlinks) ----------
-	dirSizeWithSymlinksSize := func(root string) (int64, error) {
-		var size int64
-		visited := make(map[string]bool)
-
-		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return nil
-			}
-			real := realPath(path)
-			if visited[real] {
-				return nil
-			}
-			visited[real] = true
-
-			info, err := os.Stat(real)
-			if err != nil {
-				return nil
-			}
-			if info.Mode().IsRegular() {
-				size += info.Size()
-			}
-			return nil
-		})
-		return size, err
-	}
-
-	// Fill in sizes and declared deps
+	// Fill in declared deps
 	for pkgJSONPath, n := range installedByPkgJSON {
-		n.Size, _ = dirSizeWithSymlinksSize(n.Dir)
 		deps, _ := readDeclaredDeps(pkgJSONPath)
 		n.Deps = deps
 	}
@@ -623,7 +794,36 @@ func AnalyzeNodeModules(cwd string, modules map[string][]PackageInfo) ([]ModuleR
 		}
 	}
 
-	// ---------- STEP 5: Compute exclusive/shared deps ----------
+	return &installedPackagesGraph{
+		rootPkgFiles:          rootPkgFiles,
+		installedByPkgJSON:    installedByPkgJSON,
+		graph:                 graph,
+		incoming:              incoming,
+		rootToInstalled:       rootToInstalled,
+		rootsReferencingCount: rootsReferencingCount,
+	}
+}
+
+type installedPackageNode struct {
+	Key     string
+	Name    string
+	Version string
+	Dir     string
+	Size    int64
+	Deps    []DeclaredDep
+}
+
+// computeModuleReports derives the own/exclusive/shared size breakdown for every package referenced
+// by a project root. graph maps a package key to the keys of the packages it depends on, incoming
+// counts references to each key (from packages and roots), and rootToInstalled lists the packages
+// each root depends on directly.
+func computeModuleReports(
+	installedByPkgJSON map[string]*installedPackageNode,
+	graph map[string][]string,
+	incoming map[string]int,
+	rootToInstalled map[string][]string,
+	rootsReferencingCount map[string]int,
+) []ModuleReport {
 	var reachableFrom = func(start string) (map[string]bool, int64) {
 		visited := make(map[string]bool)
 		var total int64
@@ -740,7 +940,7 @@ func AnalyzeNodeModules(cwd string, modules map[string][]PackageInfo) ([]ModuleR
 		return results[i].TotalSize > results[j].TotalSize
 	})
 
-	return results, nil
+	return results
 }
 
 // ---------- Helper functions ----------
@@ -843,3 +1043,14 @@ func PrintAnalysis(reports []ModuleReport) {
 }
 
 func bytesToMB(b int64) float64 { return float64(b) / (1024 * 1024) }
+
+// formatSize formats a size in bytes with the largest unit keeping it above 1.
+func formatSize(b int64) string {
+	switch {
+	case b >= 1024*1024:
+		return fmt.Sprintf("%.2f MB", bytesToMB(b))
+	case b >= 1024:
+		return fmt.Sprintf("%.2f KB", float64(b)/1024)
+	}
+	return fmt.Sprintf("%d B", b)
+}
@@ -0,0 +1,117 @@
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/Masterminds/semver/v3"
+
+	"rev-dep-go/internal/lockfile"
+)
+
+// PackageJsonDependencyFix is the package.json change fixing a missing or unused node module.
+// Field names the section a missing module is added to ("dependencies" or "devDependencies"),
+// with Version as its range; a fix with an empty Field removes the module from every section
+// declaring it. A fix with PreviousVersion set changes the range the module is declared with in
+// Field from PreviousVersion to Version.
+type PackageJsonDependencyFix struct {
+	PackageJsonPath string
+	ModuleName      string
+	Field           string
+	Version         string
+	PreviousVersion string
+}
+
+// IsRemoval reports whether the fix removes the module from package.json.
+func (f PackageJsonDependencyFix) IsRemoval() bool {
+	return f.Field == ""
+}
+
+// IsUpdate reports whether the fix changes the range of a declared module.
+func (f PackageJsonDependencyFix) IsUpdate() bool {
+	return f.PreviousVersion != ""
+}
+
+// InstalledVersionLookup finds the version a package.json should declare for a module. Lockfiles
+// are loaded once per path, so a single lookup should serve all modules of a run; it is not safe
+// for concurrent use.
+type InstalledVersionLookup struct {
+	lockfiles map[string]*lockfile.Lockfile
+}
+
+func NewInstalledVersionLookup() *InstalledVersionLookup {
+	return &InstalledVersionLookup{lockfiles: map[string]*lockfile.Lockfile{}}
+}
+
+// Version returns the version of moduleName available to the package in packageDir: the copy
+// Node resolves from there (packageDir/node_modules, then the node_modules of its parents) or,
+// when it is not installed, the highest version of it recorded in the nearest lockfile. Returns
+// "" when the module is neither installed nor locked, or is a workspace package of the lockfile.
+func (l *InstalledVersionLookup) Version(packageDir string, moduleName string) string {
+	for dir := packageDir; ; dir = filepath.Dir(dir) {
+		if version := readInstalledVersion(filepath.Join(dir, "node_modules", moduleName, "package.json")); version != "" {
+			return version
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	lock := l.lockfileFor(packageDir)
+	if lock == nil {
+		return ""
+	}
+	for _, importer := range lock.Importers {
+		if importer.Name == moduleName {
+			return ""
+		}
+	}
+	var highest *semver.Version
+	for _, pkg := range lock.Packages {
+		if pkg.Name != moduleName {
+			continue
+		}
+		version, err := semver.NewVersion(pkg.Version)
+		if err != nil {
+			continue
+		}
+		if highest == nil || version.GreaterThan(highest) {
+			highest = version
+		}
+	}
+	if highest == nil {
+		return ""
+	}
+	return highest.Original()
+}
+
+func (l *InstalledVersionLookup) lockfileFor(packageDir string) *lockfile.Lockfile {
+	lockfilePath := lockfile.Find(packageDir)
+	if lockfilePath == "" {
+		return nil
+	}
+	if lock, loaded := l.lockfiles[lockfilePath]; loaded {
+		return lock
+	}
+	lock, err := lockfile.Load(lockfilePath)
+	if err != nil {
+		lock = nil
+	}
+	l.lockfiles[lockfilePath] = lock
+	return lock
+}
+
+func readInstalledVersion(packageJsonPath string) string {
+	content, err := os.ReadFile(packageJsonPath)
+	if err != nil {
+		return ""
+	}
+	var pkgJson struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &pkgJson); err != nil {
+		return ""
+	}
+	return pkgJson.Version
+}
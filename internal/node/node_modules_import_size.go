@@ -0,0 +1,238 @@
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/pnp"
+)
+
+// ModuleImportSize is an installed copy of a node module imported by the files reachable from an
+// entry point.
+type ModuleImportSize struct {
+	Name    string
+	Version string
+	// Path is the directory of the installed copy, as in ModuleReport.
+	Path string
+	// Size is the ModuleReport.OwnPlusExclusive size of the copy: the package with the
+	// dependencies no other package uses.
+	Size int64
+	// ImportedFrom are the files reachable from the entry point importing the module.
+	ImportedFrom []string
+}
+
+// EntryPointImportSize approximates the size of the node modules an entry point pulls in.
+type EntryPointImportSize struct {
+	EntryPoint string
+	// Size is the sum of the sizes of Modules.
+	Size int64
+	// Modules are ordered from the heaviest.
+	Modules []ModuleImportSize
+	// Unmeasured are the imported modules without an installed copy analyze-size reports, such as
+	// workspace packages, missing packages or transitive dependencies imported directly.
+	Unmeasured []string
+}
+
+// GetEntryPointImportSizes sums, for each entry point, the exclusive sizes analyze-size reports
+// (ModuleReport.OwnPlusExclusive) of the node modules imported by the files reachable from it.
+// Entry points are detected when none are given. Each import is matched with the installed copy
+// Node resolves from the importing file, walking up the node_modules directories; with a Yarn PnP
+// manifest, or when no copy is found that way, the heaviest copy of the module is used.
+// Dependencies shared by several packages are not counted for any of them, so sizes are a lower
+// bound of what a bundle of the entry point would contain.
+func GetEntryPointImportSizes(
+	inputCwd string,
+	ignoreType bool,
+	entryPoints []string,
+	modulesToInclude []string,
+	modulesToExclude []string,
+	packageJson string,
+	tsconfigJson string,
+	conditionNames []string,
+	followMonorepoPackages FollowMonorepoPackagesValue,
+	nearestPackage bool,
+	pnpManifest *pnp.Manifest,
+) ([]EntryPointImportSize, error) {
+	var reports []ModuleReport
+	var err error
+	if pnpManifest != nil {
+		reports, err = AnalyzePnPModules(pnpManifest)
+	} else {
+		modules, _ := GetInstalledModules(inputCwd, []string{}, []string{}, nil, nil)
+		reports, err = AnalyzeNodeModules(inputCwd, modules)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := analyzeNodeModules(inputCwd, ignoreType, entryPoints, true, packageJson, tsconfigJson, conditionNames, followMonorepoPackages, nearestPackage, false)
+	shouldIncludeModule := createShouldModuleByIncluded(modulesToInclude, modulesToExclude)
+	usedNodeModules := GetUsedNodeModulesFromTree(analysis.minimalTree, analysis.cwdNodeModules, analysis.cwd, nil, nil, nil, packageJson, tsconfigJson, nil)
+	filesByEntryPoint := getNodeModuleFilesByEntryPoint(analysis.minimalTree, analysis.absolutePathToEntryPoints, usedNodeModules, shouldIncludeModule)
+
+	return computeEntryPointImportSizes(filesByEntryPoint, reports, pnpManifest == nil), nil
+}
+
+// computeEntryPointImportSizes matches the modules imported under each entry point with the size
+// reports of their installed copies. With resolveFromFiles, the copy is looked up in the
+// node_modules directories above each importing file before falling back to the heaviest copy.
+func computeEntryPointImportSizes(filesByEntryPoint map[string]map[string]map[string]bool, reports []ModuleReport, resolveFromFiles bool) []EntryPointImportSize {
+	reportsByDir := map[string]*ModuleReport{}
+	heaviestByName := map[string]*ModuleReport{}
+	for i := range reports {
+		report := &reports[i]
+		reportsByDir[report.Path] = report
+		if heaviest := heaviestByName[report.Name]; heaviest == nil || report.OwnPlusExclusive > heaviest.OwnPlusExclusive {
+			heaviestByName[report.Name] = report
+		}
+	}
+
+	resolvedFromDir := map[string]*ModuleReport{}
+	resolveReport := func(filePath string, moduleName string) *ModuleReport {
+		if !resolveFromFiles {
+			return heaviestByName[moduleName]
+		}
+		dir := filepath.Dir(pathutil.DenormalizePathForOS(filePath))
+		cacheKey := dir + "\x00" + moduleName
+		if report, ok := resolvedFromDir[cacheKey]; ok {
+			return report
+		}
+		report := heaviestByName[moduleName]
+		for cur := dir; ; {
+			if installed := reportsByDir[realPath(filepath.Join(cur, "node_modules", moduleName))]; installed != nil {
+				report = installed
+				break
+			}
+			parent := filepath.Dir(cur)
+			if parent == cur {
+				break
+			}
+			cur = parent
+		}
+		resolvedFromDir[cacheKey] = report
+		return report
+	}
+
+	results := make([]EntryPointImportSize, 0, len(filesByEntryPoint))
+	for entryPoint, moduleFiles := range filesByEntryPoint {
+		result := EntryPointImportSize{EntryPoint: entryPoint, Modules: []ModuleImportSize{}, Unmeasured: []string{}}
+		byCopy := map[string]*ModuleImportSize{}
+		for moduleName, files := range moduleFiles {
+			measured := false
+			for filePath := range files {
+				report := resolveReport(filePath, moduleName)
+				if report == nil {
+					continue
+				}
+				measured = true
+				importSize := byCopy[report.Path]
+				if importSize == nil {
+					importSize = &ModuleImportSize{Name: report.Name, Version: report.Version, Path: report.Path, Size: report.OwnPlusExclusive}
+					byCopy[report.Path] = importSize
+				}
+				importSize.ImportedFrom = append(importSize.ImportedFrom, filePath)
+			}
+			if !measured {
+				result.Unmeasured = append(result.Unmeasured, moduleName)
+			}
+		}
+		for _, importSize := range byCopy {
+			slices.Sort(importSize.ImportedFrom)
+			result.Modules = append(result.Modules, *importSize)
+			result.Size += importSize.Size
+		}
+		slices.SortFunc(result.Modules, func(a, b ModuleImportSize) int {
+			if a.Size != b.Size {
+				if a.Size > b.Size {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(a.Name, b.Name); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Path, b.Path)
+		})
+		slices.Sort(result.Unmeasured)
+		results = append(results, result)
+	}
+
+	slices.SortFunc(results, func(a, b EntryPointImportSize) int {
+		if a.Size != b.Size {
+			if a.Size > b.Size {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.EntryPoint, b.EntryPoint)
+	})
+	return results
+}
+
+// NodeModulesImportSizeCmd prints the result of GetEntryPointImportSizes, listing the top heaviest
+// modules of each entry point with the files importing them (all of them when top is 0).
+func NodeModulesImportSizeCmd(
+	inputCwd string,
+	ignoreType bool,
+	entryPoints []string,
+	modulesToInclude []string,
+	modulesToExclude []string,
+	packageJson string,
+	tsconfigJson string,
+	conditionNames []string,
+	followMonorepoPackages FollowMonorepoPackagesValue,
+	nearestPackage bool,
+	pnpManifest *pnp.Manifest,
+	top int,
+) (string, error) {
+	sizes, err := GetEntryPointImportSizes(inputCwd, ignoreType, entryPoints, modulesToInclude, modulesToExclude, packageJson, tsconfigJson, conditionNames, followMonorepoPackages, nearestPackage, pnpManifest)
+	if err != nil {
+		return "", err
+	}
+	return FormatEntryPointImportSizes(sizes, inputCwd, top), nil
+}
+
+// FormatEntryPointImportSizes lists the entry points from the heaviest, each with its top heaviest
+// modules and the files importing them; paths are relative to cwd.
+func FormatEntryPointImportSizes(sizes []EntryPointImportSize, cwd string, top int) string {
+	if len(sizes) == 0 {
+		return "No entry points found.\n"
+	}
+	absCwd, err := resolveAbsoluteRealCwd(cwd)
+	if err != nil {
+		absCwd = cwd
+	}
+
+	var b strings.Builder
+	for i, entryPointSize := range sizes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s %.2f MB\n", relativeToCwd(cwd, pathutil.DenormalizePathForOS(entryPointSize.EntryPoint)), bytesToMB(entryPointSize.Size))
+
+		listed := entryPointSize.Modules
+		if top > 0 && len(listed) > top {
+			listed = listed[:top]
+		}
+		for _, importSize := range listed {
+			fmt.Fprintf(&b, "  %s@%s %.2f MB %s\n", importSize.Name, importSize.Version, bytesToMB(importSize.Size), relativeToCwd(absCwd, importSize.Path))
+			for _, filePath := range importSize.ImportedFrom {
+				fmt.Fprintf(&b, "    %s\n", relativeToCwd(cwd, pathutil.DenormalizePathForOS(filePath)))
+			}
+		}
+		if rest := entryPointSize.Modules[len(listed):]; len(rest) > 0 {
+			var restSize int64
+			for _, importSize := range rest {
+				restSize += importSize.Size
+			}
+			fmt.Fprintf(&b, "  ... %d more modules, %.2f MB\n", len(rest), bytesToMB(restSize))
+		}
+		if len(entryPointSize.Unmeasured) > 0 {
+			fmt.Fprintf(&b, "  not measured: %s\n", strings.Join(entryPointSize.Unmeasured, ", "))
+		}
+	}
+	return b.String()
+}
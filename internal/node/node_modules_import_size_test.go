@@ -0,0 +1,83 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/pathutil"
+)
+
+func TestComputeEntryPointImportSizes(t *testing.T) {
+	root := realPath(t.TempDir())
+	for _, dir := range []string{"node_modules/lodash", "node_modules/aws-sdk", "packages/app/node_modules/lodash"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	file := func(rel string) string {
+		return pathutil.NormalizePathForInternal(filepath.Join(root, rel))
+	}
+	reports := []ModuleReport{
+		{Name: "aws-sdk", Version: "2.0.0", Path: filepath.Join(root, "node_modules/aws-sdk"), OwnPlusExclusive: 1000},
+		{Name: "lodash", Version: "4.17.21", Path: filepath.Join(root, "node_modules/lodash"), OwnPlusExclusive: 100},
+		{Name: "lodash", Version: "3.10.1", Path: filepath.Join(root, "packages/app/node_modules/lodash"), OwnPlusExclusive: 50},
+	}
+	filesByEntryPoint := map[string]map[string]map[string]bool{
+		file("src/handler.ts"): {
+			"aws-sdk": {file("src/clients/s3.ts"): true, file("src/clients/dynamo.ts"): true},
+			"lodash":  {file("src/utils.ts"): true},
+			"zod":     {file("src/schema.ts"): true},
+		},
+		file("packages/app/src/index.ts"): {
+			"lodash": {file("packages/app/src/index.ts"): true},
+		},
+	}
+
+	sizes := computeEntryPointImportSizes(filesByEntryPoint, reports, true)
+	expected := []EntryPointImportSize{
+		{
+			EntryPoint: file("src/handler.ts"),
+			Size:       1100,
+			Modules: []ModuleImportSize{
+				{Name: "aws-sdk", Version: "2.0.0", Path: reports[0].Path, Size: 1000, ImportedFrom: []string{file("src/clients/dynamo.ts"), file("src/clients/s3.ts")}},
+				{Name: "lodash", Version: "4.17.21", Path: reports[1].Path, Size: 100, ImportedFrom: []string{file("src/utils.ts")}},
+			},
+			Unmeasured: []string{"zod"},
+		},
+		{
+			// The workspace package resolves its own copy of lodash.
+			EntryPoint: file("packages/app/src/index.ts"),
+			Size:       50,
+			Modules: []ModuleImportSize{
+				{Name: "lodash", Version: "3.10.1", Path: reports[2].Path, Size: 50, ImportedFrom: []string{file("packages/app/src/index.ts")}},
+			},
+			Unmeasured: []string{},
+		},
+	}
+	if !reflect.DeepEqual(sizes, expected) {
+		t.Errorf("unexpected sizes\n%+v\nwant\n%+v", sizes, expected)
+	}
+
+	// Without resolving from the importing files (Yarn PnP), the heaviest copy is used.
+	pnpSizes := computeEntryPointImportSizes(filesByEntryPoint, reports, false)
+	if pnpSizes[1].Modules[0].Version != "4.17.21" {
+		t.Errorf("expected the heaviest lodash copy without resolution, got %+v", pnpSizes[1].Modules[0])
+	}
+
+	output := FormatEntryPointImportSizes(sizes, root, 1)
+	for _, line := range []string{
+		"src/handler.ts 0.00 MB",
+		"  aws-sdk@2.0.0 0.00 MB node_modules/aws-sdk",
+		"    src/clients/dynamo.ts",
+		"  ... 1 more modules, 0.00 MB",
+		"  not measured: zod",
+		"  lodash@3.10.1 0.00 MB packages/app/node_modules/lodash",
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const nodeModulesJournalVersion = 1
+
+const (
+	// JournalOperationSymlink: a duplicate package directory was replaced with a symlink to the
+	// copy kept.
+	JournalOperationSymlink = "symlink"
+	// JournalOperationRemove: a file was pruned.
+	JournalOperationRemove = "remove"
+)
+
+// NodeModulesJournalOperation is a change made to node_modules. The original file or directory is
+// kept at TrashPath until the operation is restored.
+type NodeModulesJournalOperation struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	// Target is the directory the symlink points to.
+	Target    string `json:"target,omitempty"`
+	TrashPath string `json:"trashPath"`
+	Size      int64  `json:"size"`
+}
+
+// NodeModulesJournal records the changes `installed-duplicates --optimize` and `prune-docs` make to
+// node_modules, so `node-modules restore` can undo them. Instead of being deleted, replaced
+// directories and pruned files are moved to TrashDir, next to the journal file; moving needs the
+// trash directory to be on the same file system as node_modules.
+type NodeModulesJournal struct {
+	path       string
+	Version    int                           `json:"version"`
+	TrashDir   string                        `json:"trashDir"`
+	NextID     int                           `json:"nextId"`
+	Operations []NodeModulesJournalOperation `json:"operations"`
+}
+
+// OpenNodeModulesJournal reads the journal at path, or starts a new one when the file does not
+// exist yet, so several runs can record into the same journal.
+func OpenNodeModulesJournal(path string) (*NodeModulesJournal, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := readNodeModulesJournal(absPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &NodeModulesJournal{
+			path:       absPath,
+			Version:    nodeModulesJournalVersion,
+			TrashDir:   absPath + ".trash",
+			Operations: []NodeModulesJournalOperation{},
+		}, nil
+	}
+	return journal, err
+}
+
+func readNodeModulesJournal(absPath string) (*NodeModulesJournal, error) {
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+	journal := &NodeModulesJournal{}
+	if err := json.Unmarshal(content, journal); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %v", absPath, err)
+	}
+	if journal.Version != nodeModulesJournalVersion {
+		return nil, fmt.Errorf("unsupported journal version %d in %s", journal.Version, absPath)
+	}
+	journal.path = absPath
+	return journal, nil
+}
+
+// Path returns the absolute path of the journal file.
+func (j *NodeModulesJournal) Path() string {
+	return j.path
+}
+
+// Save writes the journal file.
+func (j *NodeModulesJournal) Save() error {
+	content, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(j.path, append(content, '\n'), 0644)
+}
+
+// moveToTrash moves path to a directory of its own in the trash, so files with the same name
+// never collide.
+func (j *NodeModulesJournal) moveToTrash(path string) (string, error) {
+	trashDir := filepath.Join(j.TrashDir, strconv.Itoa(j.NextID))
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return "", err
+	}
+	trashPath := filepath.Join(trashDir, filepath.Base(path))
+	if err := os.Rename(path, trashPath); err != nil {
+		os.Remove(trashDir)
+		return "", err
+	}
+	j.NextID++
+	return trashPath, nil
+}
+
+// replaceWithSymlink moves dir to the trash and replaces it with a symlink to target.
+func (j *NodeModulesJournal) replaceWithSymlink(dir string, target string, size int64) error {
+	trashPath, err := j.moveToTrash(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.Symlink(target, dir); err != nil {
+		// Put the original back, there is nothing to restore later.
+		os.Rename(trashPath, dir)
+		return err
+	}
+	j.Operations = append(j.Operations, NodeModulesJournalOperation{Type: JournalOperationSymlink, Path: dir, Target: target, TrashPath: trashPath, Size: size})
+	return nil
+}
+
+// removeFile moves path to the trash.
+func (j *NodeModulesJournal) removeFile(path string, size int64) error {
+	trashPath, err := j.moveToTrash(path)
+	if err != nil {
+		return err
+	}
+	j.Operations = append(j.Operations, NodeModulesJournalOperation{Type: JournalOperationRemove, Path: path, TrashPath: trashPath, Size: size})
+	return nil
+}
+
+// NodeModulesRestoreResult is the outcome of restoring a journal.
+type NodeModulesRestoreResult struct {
+	Restored int
+	// Failed lists the operations left in the journal, with the reason they were not restored.
+	Failed []string
+}
+
+// RestoreNodeModulesJournal undoes the operations of the journal at path, the last one first:
+// symlinks are replaced with the original directories and pruned files are put back. Paths
+// changed since the operation (a symlink replaced with a directory, a file installed again) are
+// left alone. When everything is restored the journal file and its trash directory are deleted,
+// otherwise the journal keeps the operations that failed.
+func RestoreNodeModulesJournal(path string) (NodeModulesRestoreResult, error) {
+	result := NodeModulesRestoreResult{Failed: []string{}}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return result, err
+	}
+	journal, err := readNodeModulesJournal(absPath)
+	if err != nil {
+		return result, err
+	}
+
+	remaining := []NodeModulesJournalOperation{}
+	for i := len(journal.Operations) - 1; i >= 0; i-- {
+		operation := journal.Operations[i]
+		if err := restoreNodeModulesOperation(operation); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s %s: %v", operation.Type, operation.Path, err))
+			remaining = append([]NodeModulesJournalOperation{operation}, remaining...)
+			continue
+		}
+		result.Restored++
+	}
+
+	if len(remaining) == 0 {
+		if err := os.RemoveAll(journal.TrashDir); err != nil {
+			return result, err
+		}
+		return result, os.Remove(absPath)
+	}
+	journal.Operations = remaining
+	return result, journal.Save()
+}
+
+func restoreNodeModulesOperation(operation NodeModulesJournalOperation) error {
+	if _, err := os.Lstat(operation.TrashPath); err != nil {
+		return fmt.Errorf("original not found in trash: %v", err)
+	}
+
+	info, err := os.Lstat(operation.Path)
+	switch operation.Type {
+	case JournalOperationSymlink:
+		if err == nil {
+			if info.Mode()&os.ModeSymlink == 0 {
+				return errors.New("path is no longer a symlink")
+			}
+			if err := os.Remove(operation.Path); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	case JournalOperationRemove:
+		if err == nil {
+			return errors.New("file exists again")
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", operation.Type)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(operation.Path), 0755); err != nil {
+		return err
+	}
+	return os.Rename(operation.TrashPath, operation.Path)
+}
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeJournalFixture(t *testing.T, root string) {
+	t.Helper()
+	mustWriteFile(t, filepath.Join(root, "package.json"), `{"name":"root","dependencies":{"a":"1.0.0","b":"1.0.0"}}`)
+	for _, dir := range []string{"node_modules/a/node_modules/lodash", "node_modules/b/node_modules/lodash"} {
+		mustWriteFile(t, filepath.Join(root, dir, "package.json"), `{"name":"lodash","version":"4.17.21"}`)
+		mustWriteFile(t, filepath.Join(root, dir, "index.js"), "module.exports = {}")
+		mustWriteFile(t, filepath.Join(root, dir, "README.md"), "lodash readme")
+	}
+	mustWriteFile(t, filepath.Join(root, "node_modules/a/package.json"), `{"name":"a","version":"1.0.0","dependencies":{"lodash":"^4.0.0"}}`)
+	mustWriteFile(t, filepath.Join(root, "node_modules/b/package.json"), `{"name":"b","version":"1.0.0","dependencies":{"lodash":"^4.0.0"}}`)
+}
+
+func isSymlink(t *testing.T, path string) bool {
+	t.Helper()
+	info, err := os.Lstat(path)
+	if err != nil {
+		t.Fatalf("lstat %s: %v", path, err)
+	}
+	return info.Mode()&os.ModeSymlink != 0
+}
+
+func TestNodeModulesDryRun(t *testing.T) {
+	root := t.TempDir()
+	writeJournalFixture(t, root)
+
+	pruned, err := NodeModulesPruneDocsCmd(root, []string{"README.md"}, false, true, nil)
+	if err != nil {
+		t.Fatalf("prune-docs: %v", err)
+	}
+	for _, expected := range []string{
+		"   node_modules/a/node_modules/lodash/README.md 13 B\n",
+		"   node_modules/b/node_modules/lodash/README.md 13 B\n",
+		"Files to remove: 2\nSize: 26 B\n",
+	} {
+		if !strings.Contains(pruned, expected) {
+			t.Errorf("expected dry run output to contain %q, got:\n%s", expected, pruned)
+		}
+	}
+	if !exists(filepath.Join(root, "node_modules/a/node_modules/lodash/README.md")) {
+		t.Errorf("expected --dry-run not to remove files")
+	}
+
+	duplicated := FindDuplicatedModules(root, DuplicatedModulesOptions{Optimize: true, DryRun: true})
+	if !duplicated.DryRun || len(duplicated.Symlinks) != 1 {
+		t.Fatalf("expected one planned symlink, got %+v", duplicated)
+	}
+	if !strings.HasSuffix(duplicated.Symlinks[0].Path, filepath.Join("b", "node_modules", "lodash")) || duplicated.Symlinks[0].Size == 0 {
+		t.Errorf("unexpected planned symlink %+v", duplicated.Symlinks[0])
+	}
+	if isSymlink(t, filepath.Join(root, "node_modules/b/node_modules/lodash")) {
+		t.Errorf("expected --dry-run not to create symlinks")
+	}
+
+	// A plain optimize does not report sizes, so it does not measure the replaced directories.
+	optimized := FindDuplicatedModules(root, DuplicatedModulesOptions{Optimize: true})
+	if len(optimized.Symlinks) != 1 || optimized.Symlinks[0].Size != 0 {
+		t.Errorf("expected one unmeasured symlink, got %+v", optimized.Symlinks)
+	}
+	if !isSymlink(t, filepath.Join(root, "node_modules/b/node_modules/lodash")) {
+		t.Errorf("expected optimize to create the symlink")
+	}
+}
+
+func TestNodeModulesJournalRestore(t *testing.T) {
+	root := t.TempDir()
+	writeJournalFixture(t, root)
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+
+	journal, err := OpenNodeModulesJournal(journalPath)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	if _, err := NodeModulesPruneDocsCmd(root, []string{"README.md"}, false, false, journal); err != nil {
+		t.Fatalf("prune-docs: %v", err)
+	}
+	duplicated := FindDuplicatedModules(root, DuplicatedModulesOptions{Optimize: true, Journal: journal})
+	if len(duplicated.Symlinks) != 1 || duplicated.SymlinksErrored != 0 {
+		t.Fatalf("expected one symlink, got %+v", duplicated)
+	}
+	if err := journal.Save(); err != nil {
+		t.Fatalf("save journal: %v", err)
+	}
+
+	duplicateDir := filepath.Join(root, "node_modules/b/node_modules/lodash")
+	if !isSymlink(t, duplicateDir) || exists(filepath.Join(root, "node_modules/a/node_modules/lodash/README.md")) {
+		t.Fatalf("expected the duplicate to be symlinked and README.md files to be pruned")
+	}
+
+	// A later run appends to the same journal.
+	reopened, err := OpenNodeModulesJournal(journalPath)
+	if err != nil {
+		t.Fatalf("reopen journal: %v", err)
+	}
+	if len(reopened.Operations) != 3 {
+		t.Fatalf("expected 3 recorded operations, got %+v", reopened.Operations)
+	}
+
+	result, err := RestoreNodeModulesJournal(journalPath)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if result.Restored != 3 || len(result.Failed) != 0 {
+		t.Fatalf("unexpected restore result %+v", result)
+	}
+	if isSymlink(t, duplicateDir) {
+		t.Errorf("expected the duplicate directory to be restored")
+	}
+	for _, path := range []string{
+		"node_modules/a/node_modules/lodash/README.md",
+		"node_modules/b/node_modules/lodash/README.md",
+		"node_modules/b/node_modules/lodash/index.js",
+	} {
+		if !exists(filepath.Join(root, path)) {
+			t.Errorf("expected %s to be restored", path)
+		}
+	}
+	if exists(journalPath) || exists(journalPath+".trash") {
+		t.Errorf("expected the journal and its trash to be deleted once everything is restored")
+	}
+}
+
+func TestNodeModulesJournalRestore_KeepsConflicts(t *testing.T) {
+	root := t.TempDir()
+	writeJournalFixture(t, root)
+	journalPath := filepath.Join(t.TempDir(), "journal.json")
+
+	journal, err := OpenNodeModulesJournal(journalPath)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	if _, err := NodeModulesPruneDocsCmd(root, []string{"README.md"}, false, false, journal); err != nil {
+		t.Fatalf("prune-docs: %v", err)
+	}
+	if err := journal.Save(); err != nil {
+		t.Fatalf("save journal: %v", err)
+	}
+
+	// A reinstall put one of the files back.
+	reinstalled := filepath.Join(root, "node_modules/a/node_modules/lodash/README.md")
+	mustWriteFile(t, reinstalled, "reinstalled readme")
+
+	result, err := RestoreNodeModulesJournal(journalPath)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if result.Restored != 1 || len(result.Failed) != 1 || !strings.Contains(result.Failed[0], "file exists again") {
+		t.Fatalf("unexpected restore result %+v", result)
+	}
+	if content, _ := os.ReadFile(reinstalled); string(content) != "reinstalled readme" {
+		t.Errorf("expected the reinstalled file to be left alone, got %q", content)
+	}
+
+	remaining, err := OpenNodeModulesJournal(journalPath)
+	if err != nil {
+		t.Fatalf("reopen journal: %v", err)
+	}
+	if len(remaining.Operations) != 1 || remaining.Operations[0].Path != reinstalled || !exists(remaining.Operations[0].TrashPath) {
+		t.Errorf("expected the conflicting operation to stay in the journal, got %+v", remaining.Operations)
+	}
+}
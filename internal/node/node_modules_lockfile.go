@@ -0,0 +1,82 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"rev-dep-go/internal/lockfile"
+)
+
+// LoadLockfile returns the lockfile to read installed packages from, or nil when they should be
+// read from node_modules directories (or a PnP manifest). With the auto strategy the lockfile is
+// only used when no directory from cwd up to the lockfile has a node_modules directory, e.g. in a
+// fresh checkout before install; packages hoisted to the root of a workspace count as installed.
+// The lockfile strategy requires a lockfile in cwd or one of its parents.
+func LoadLockfile(cwd string, strategy NodeModulesLookupStrategy) (*lockfile.Lockfile, error) {
+	if strategy == NodeModulesLookupStrategyNodeModules || strategy == NodeModulesLookupStrategyPnP {
+		return nil, nil
+	}
+
+	lockfilePath := lockfile.Find(cwd)
+	if lockfilePath == "" {
+		if strategy == NodeModulesLookupStrategyLockfile {
+			return nil, fmt.Errorf("no lockfile (%s) found in %s or its parents", strings.Join(lockfile.FileNames, ", "), cwd)
+		}
+		return nil, nil
+	}
+	if strategy == NodeModulesLookupStrategyAuto && hasNodeModulesDirUpTo(cwd, filepath.Dir(lockfilePath)) {
+		return nil, nil
+	}
+
+	lock, err := lockfile.Load(lockfilePath)
+	if err != nil {
+		if strategy == NodeModulesLookupStrategyLockfile {
+			return nil, err
+		}
+		// Auto: an unsupported lockfile should not turn an empty listing into an error.
+		return nil, nil
+	}
+	return lock, nil
+}
+
+// hasNodeModulesDirUpTo reports whether dir or one of its parents up to (and including) stopDir
+// contains a node_modules directory.
+func hasNodeModulesDirUpTo(dir string, stopDir string) bool {
+	cur := filepath.Clean(dir)
+	stopDir = filepath.Clean(stopDir)
+	for {
+		if info, err := os.Stat(filepath.Join(cur, "node_modules")); err == nil && info.IsDir() {
+			return true
+		}
+		parent := filepath.Dir(cur)
+		if cur == stopDir || parent == cur {
+			return false
+		}
+		cur = parent
+	}
+}
+
+// getInstalledModulesFromLockfile lists the third-party packages of a lockfile in the same shape
+// as the node_modules scan. Nothing is installed, so FilePath points at the lockfile entry instead:
+// the lockfile path relative to cwd followed by "#" and the entry key
+// (package-lock.json#node_modules/a/node_modules/lodash, ../../package-lock.json#... for the
+// lockfile of a parent directory).
+func getInstalledModulesFromLockfile(lock *lockfile.Lockfile, cwd string, shouldIncludeModule func(moduleName string) bool) map[string][]PackageInfo {
+	modules := map[string][]PackageInfo{}
+	lockfilePath := relativeToCwd(cwd, lock.Path)
+
+	for _, pkg := range lock.Packages {
+		if pkg.Version == "" || !shouldIncludeModule(pkg.Name) {
+			continue
+		}
+		modules[pkg.Name] = append(modules[pkg.Name], PackageInfo{
+			Name:     pkg.Name,
+			Version:  pkg.Version,
+			FilePath: lockfilePath + "#" + pkg.Key,
+		})
+	}
+
+	return modules
+}
@@ -0,0 +1,102 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/testutil"
+)
+
+func TestLoadLockfile(t *testing.T) {
+	cwd, err := testutil.FixturePath("lockfileNpm")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+
+	if lock, err := LoadLockfile(cwd, NodeModulesLookupStrategyAuto); err != nil || lock == nil {
+		t.Fatalf("expected auto lookup without node_modules to pick up the lockfile, got %v, %v", lock, err)
+	}
+	if lock, err := LoadLockfile(cwd, NodeModulesLookupStrategyNodeModules); err != nil || lock != nil {
+		t.Fatalf("expected node-modules lookup to ignore the lockfile, got %v, %v", lock, err)
+	}
+
+	installed, err := testutil.FixturePath("nodeModulesCmdSmoke")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	if lock, err := LoadLockfile(installed, NodeModulesLookupStrategyAuto); err != nil || lock != nil {
+		t.Fatalf("expected auto lookup with node_modules to ignore lockfiles, got %v, %v", lock, err)
+	}
+
+	// A hoisted workspace keeps node_modules at the root, next to the lockfile.
+	workspace := t.TempDir()
+	mustWriteFile(t, filepath.Join(workspace, "package-lock.json"), `{"lockfileVersion": 3, "packages": {}}`)
+	mustMkdirAll(t, filepath.Join(workspace, "node_modules"))
+	mustMkdirAll(t, filepath.Join(workspace, "packages", "a"))
+	if lock, err := LoadLockfile(filepath.Join(workspace, "packages", "a"), NodeModulesLookupStrategyAuto); err != nil || lock != nil {
+		t.Fatalf("expected auto lookup in a package of a hoisted workspace to ignore the lockfile, got %v, %v", lock, err)
+	}
+	if lock, err := LoadLockfile(filepath.Join(workspace, "packages", "a"), NodeModulesLookupStrategyLockfile); err != nil || lock == nil {
+		t.Fatalf("expected lockfile lookup to use the root lockfile, got %v, %v", lock, err)
+	}
+
+	emptyDir := t.TempDir()
+	if _, err := LoadLockfile(emptyDir, NodeModulesLookupStrategyLockfile); err == nil {
+		t.Fatalf("expected lockfile lookup without a lockfile to fail")
+	}
+	if err := os.WriteFile(filepath.Join(emptyDir, "package-lock.json"), []byte(`{"lockfileVersion": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLockfile(emptyDir, NodeModulesLookupStrategyLockfile); err == nil {
+		t.Fatalf("expected an unsupported lockfile to fail the lockfile lookup")
+	}
+	if lock, err := LoadLockfile(emptyDir, NodeModulesLookupStrategyAuto); err != nil || lock != nil {
+		t.Fatalf("expected auto lookup to ignore an unsupported lockfile, got %v, %v", lock, err)
+	}
+}
+
+func TestInstalledModulesCmds_Lockfile(t *testing.T) {
+	dir, err := testutil.FixturePath("lockfileNpm")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	cwd := pathutil.StandardiseDirPath(dir)
+	lock, err := LoadLockfile(cwd, NodeModulesLookupStrategyLockfile)
+	if err != nil {
+		t.Fatalf("LoadLockfile: %v", err)
+	}
+
+	installed := GetInstalledModulesCmd(cwd, []string{}, []string{"legacy-lib"}, nil, lock)
+	expectedInstalled := `
+lodash@4.17.21 package-lock.json#node_modules/lodash
+lodash@3.10.1 package-lock.json#node_modules/legacy-lib/node_modules/lodash
+lodash@3.10.1 package-lock.json#packages/b/node_modules/lodash
+
+Total count:  3
+`
+	if installed != expectedInstalled {
+		t.Errorf("unexpected installed output:\n%s\nwant:\n%s", installed, expectedInstalled)
+	}
+
+	duplicates := GetDuplicatedModulesCmd(cwd, DuplicatedModulesOptions{Optimize: true, Lockfile: lock})
+	if !strings.Contains(duplicates, "lodash\n   3.10.1:\n      package-lock.json#node_modules/legacy-lib/node_modules/lodash\n      package-lock.json#packages/b/node_modules/lodash\n") {
+		t.Errorf("expected the nested lodash@3.10.1 copies to be reported, got:\n%s", duplicates)
+	}
+	if !strings.Contains(duplicates, "Optimization skipped: packages are read from package-lock.json and are not installed") {
+		t.Errorf("expected optimization to be skipped, got:\n%s", duplicates)
+	}
+
+	// Entries of a parent directory lockfile are relative to cwd too.
+	packageCwd := pathutil.StandardiseDirPath(filepath.Join(dir, "packages", "a"))
+	lock, err = LoadLockfile(packageCwd, NodeModulesLookupStrategyLockfile)
+	if err != nil {
+		t.Fatalf("LoadLockfile: %v", err)
+	}
+	installed = GetInstalledModulesCmd(packageCwd, []string{"lodash"}, []string{}, nil, lock)
+	if !strings.Contains(installed, "\nlodash@4.17.21 ../../package-lock.json#node_modules/lodash\n") {
+		t.Errorf("expected lockfile entries relative to the package, got:\n%s", installed)
+	}
+}
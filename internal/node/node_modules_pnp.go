@@ -0,0 +1,189 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"rev-dep-go/internal/pnp"
+)
+
+// ParseNodeModulesLookupStrategy maps the --node-modules-lookup flag value to a lookup strategy.
+func ParseNodeModulesLookupStrategy(value string) (NodeModulesLookupStrategy, error) {
+	switch value {
+	case "", "auto":
+		return NodeModulesLookupStrategyAuto, nil
+	case "node-modules":
+		return NodeModulesLookupStrategyNodeModules, nil
+	case "pnp":
+		return NodeModulesLookupStrategyPnP, nil
+	case "lockfile":
+		return NodeModulesLookupStrategyLockfile, nil
+	default:
+		return NodeModulesLookupStrategyAuto, fmt.Errorf("invalid node modules lookup %q: expected one of auto, node-modules, pnp, lockfile", value)
+	}
+}
+
+// LoadPnPManifest returns the Yarn PnP manifest to look installed packages up with, or nil when
+// packages should be read from node_modules directories. With the auto strategy the manifest is
+// used whenever one is found in cwd or one of its parents; the pnp strategy requires one.
+func LoadPnPManifest(cwd string, strategy NodeModulesLookupStrategy) (*pnp.Manifest, error) {
+	if strategy == NodeModulesLookupStrategyNodeModules || strategy == NodeModulesLookupStrategyLockfile {
+		return nil, nil
+	}
+
+	manifestDir := pnp.Find(cwd)
+	if manifestDir == "" {
+		if strategy == NodeModulesLookupStrategyPnP {
+			return nil, fmt.Errorf("no Yarn PnP manifest (%s or %s) found in %s or its parents", pnp.RuntimeFileName, pnp.DataFileName, cwd)
+		}
+		return nil, nil
+	}
+
+	manifest, err := pnp.Load(manifestDir)
+	if err != nil {
+		if strategy == NodeModulesLookupStrategyPnP {
+			return nil, err
+		}
+		// Auto: an unreadable manifest should not break projects that still have node_modules.
+		return nil, nil
+	}
+	return manifest, nil
+}
+
+// findPnPModuleBinaries is the PnP counterpart of the node_modules walk in FindNodeModuleBinaries:
+// each dependency is resolved from the package that owns cwd and its "bin" field is read from the
+// package location (usually inside a zip archive in the Yarn cache).
+func findPnPModuleBinaries(manifest *pnp.Manifest, nodeModules map[string]bool, cwd string) map[string][]string {
+	result := make(map[string][]string, len(nodeModules))
+	issuer := manifest.FindPackageForPath(cwd)
+
+	for nodeModule := range nodeModules {
+		result[nodeModule] = []string{}
+
+		pkg := manifest.ResolveDependency(issuer, nodeModule)
+		if pkg == nil {
+			continue
+		}
+		fileContent, err := pnp.ReadFile(filepath.Join(pkg.Location, "package.json"))
+		if err != nil {
+			continue
+		}
+
+		var pkgJson struct {
+			Bin json.RawMessage `json:"bin"`
+		}
+		if err := json.Unmarshal(fileContent, &pkgJson); err != nil {
+			continue
+		}
+
+		result[nodeModule] = append(result[nodeModule], parseBinField(pkgJson.Bin, nodeModule)...)
+	}
+
+	return result
+}
+
+// getInstalledModulesFromPnP lists the third-party packages of a PnP manifest in the same shape as
+// the node_modules scan. FilePath points at the package.json inside the package location, relative
+// to cwd, and the version is read from that package.json.
+func getInstalledModulesFromPnP(manifest *pnp.Manifest, cwd string, shouldIncludeModule func(moduleName string) bool) map[string][]PackageInfo {
+	modules := map[string][]PackageInfo{}
+
+	for _, pkg := range manifest.InstalledPackages() {
+		if !shouldIncludeModule(pkg.Name) {
+			continue
+		}
+		pkgJsonPath := filepath.Join(pkg.Location, "package.json")
+		name, version := readPnPPackageNameAndVersion(pkgJsonPath)
+		if name == "" {
+			name = pkg.Name
+		}
+		if version == "" {
+			continue
+		}
+		modules[name] = append(modules[name], PackageInfo{
+			Name:     name,
+			Version:  version,
+			FilePath: strings.Replace(pkgJsonPath, cwd, "", 1),
+		})
+	}
+
+	return modules
+}
+
+func readPnPPackageNameAndVersion(pkgJsonPath string) (string, string) {
+	content, err := pnp.ReadFile(pkgJsonPath)
+	if err != nil {
+		return "", ""
+	}
+	var pkgJson struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &pkgJson); err != nil {
+		return "", ""
+	}
+	return pkgJson.Name, pkgJson.Version
+}
+
+// AnalyzePnPModules is the PnP counterpart of AnalyzeNodeModules. Package sizes are the
+// uncompressed sizes of the package files (inside the cache archive or the unplugged directory),
+// and the dependency graph comes straight from the manifest instead of semver matching against
+// nested node_modules directories. Workspaces act as the roots.
+func AnalyzePnPModules(manifest *pnp.Manifest) ([]ModuleReport, error) {
+	installed := map[string]*installedPackageNode{}
+
+	for _, pkg := range manifest.InstalledPackages() {
+		name, version := readPnPPackageNameAndVersion(filepath.Join(pkg.Location, "package.json"))
+		if name == "" {
+			name = pkg.Name
+		}
+		size, _ := pnp.DirSize(pkg.Location)
+		installed[pkg.Location] = &installedPackageNode{
+			Key:     pkg.Location,
+			Name:    name,
+			Version: version,
+			Dir:     pkg.Location,
+			Size:    size,
+		}
+	}
+
+	graph := make(map[string][]string)
+	incoming := make(map[string]int)
+	for key := range installed {
+		incoming[key] = 0
+	}
+	rootToInstalled := make(map[string][]string)
+	rootsReferencingCount := make(map[string]int)
+
+	// Virtual instances of a package share its real location, so edges are de-duplicated per
+	// location pair.
+	edges := map[[2]string]bool{}
+	for _, pkg := range manifest.Packages {
+		for depName := range pkg.Dependencies {
+			dep := manifest.ResolveDependency(pkg, depName)
+			if dep == nil || dep.IsWorkspace() || dep.Location == pkg.Location {
+				continue
+			}
+			if installed[dep.Location] == nil {
+				continue
+			}
+			edge := [2]string{pkg.Location, dep.Location}
+			if edges[edge] {
+				continue
+			}
+			edges[edge] = true
+
+			if pkg.IsWorkspace() {
+				rootToInstalled[pkg.Location] = append(rootToInstalled[pkg.Location], dep.Location)
+				rootsReferencingCount[dep.Location]++
+			} else {
+				graph[pkg.Location] = append(graph[pkg.Location], dep.Location)
+			}
+			incoming[dep.Location]++
+		}
+	}
+
+	return computeModuleReports(installed, graph, incoming, rootToInstalled, rootsReferencingCount), nil
+}
@@ -0,0 +1,148 @@
+package node
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/testutil"
+)
+
+func yarnPnPFixture(t *testing.T) string {
+	t.Helper()
+	cwd, err := testutil.FixturePath("yarnPnP")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	return pathutil.StandardiseDirPath(cwd)
+}
+
+func TestLoadPnPManifest(t *testing.T) {
+	cwd := yarnPnPFixture(t)
+
+	if manifest, err := LoadPnPManifest(cwd, NodeModulesLookupStrategyAuto); err != nil || manifest == nil {
+		t.Fatalf("expected auto lookup to pick up the PnP manifest, got %v, %v", manifest, err)
+	}
+	if manifest, err := LoadPnPManifest(cwd, NodeModulesLookupStrategyNodeModules); err != nil || manifest != nil {
+		t.Fatalf("expected node-modules lookup to ignore the PnP manifest, got %v, %v", manifest, err)
+	}
+
+	nonPnP, err := testutil.FixturePath("nodeModulesCmd")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	if manifest, err := LoadPnPManifest(nonPnP, NodeModulesLookupStrategyAuto); err != nil || manifest != nil {
+		t.Fatalf("expected auto lookup without a manifest to fall back to node_modules, got %v, %v", manifest, err)
+	}
+	if _, err := LoadPnPManifest(nonPnP, NodeModulesLookupStrategyPnP); err == nil {
+		t.Fatalf("expected pnp lookup without a manifest to fail")
+	}
+
+	if _, err := ParseNodeModulesLookupStrategy("yarn"); err == nil {
+		t.Fatalf("expected invalid lookup strategy to fail")
+	}
+}
+
+func TestFindNodeModuleBinaries_YarnPnP(t *testing.T) {
+	cwd := yarnPnPFixture(t)
+
+	binaries := FindNodeModuleBinaries(map[string]bool{"eslint": true, "esbuild": true, "lodash": true, "missing": true}, cwd)
+
+	if !slices.Equal(binaries["eslint"], []string{"eslint"}) {
+		t.Errorf("expected eslint binary from cache archive, got %v", binaries["eslint"])
+	}
+	if !slices.Equal(binaries["esbuild"], []string{"esbuild"}) {
+		t.Errorf("expected esbuild binary from unplugged dir, got %v", binaries["esbuild"])
+	}
+	if len(binaries["lodash"]) != 0 || len(binaries["missing"]) != 0 {
+		t.Errorf("expected no binaries for lodash and missing, got %v", binaries)
+	}
+}
+
+func TestGetInstalledModules_YarnPnP(t *testing.T) {
+	cwd := yarnPnPFixture(t)
+	manifest, err := LoadPnPManifest(cwd, NodeModulesLookupStrategyPnP)
+	if err != nil {
+		t.Fatalf("LoadPnPManifest: %v", err)
+	}
+
+	modules, nodeModuleDirs := GetInstalledModules(cwd, []string{}, []string{"@repo/*"}, manifest, nil)
+	if len(nodeModuleDirs) != 0 {
+		t.Errorf("expected no node_modules dirs for PnP, got %v", nodeModuleDirs)
+	}
+
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if got := strings.Join(names, ","); got != "debug,esbuild,eslint,left-pad,lodash" {
+		t.Fatalf("unexpected installed modules %s", got)
+	}
+
+	// The virtual instance of debug shares the real package location, so it is listed once.
+	if len(modules["debug"]) != 1 {
+		t.Errorf("expected a single debug installation, got %+v", modules["debug"])
+	}
+	lodash := modules["lodash"][0]
+	if lodash.Version != "4.17.21" || !strings.HasPrefix(lodash.FilePath, ".yarn/cache/lodash-npm-4.17.21") {
+		t.Errorf("unexpected lodash installation %+v", lodash)
+	}
+}
+
+func TestAnalyzePnPModules(t *testing.T) {
+	cwd := yarnPnPFixture(t)
+	manifest, err := LoadPnPManifest(cwd, NodeModulesLookupStrategyPnP)
+	if err != nil {
+		t.Fatalf("LoadPnPManifest: %v", err)
+	}
+
+	reports, err := AnalyzePnPModules(manifest)
+	if err != nil {
+		t.Fatalf("AnalyzePnPModules: %v", err)
+	}
+
+	byName := map[string]ModuleReport{}
+	for _, r := range reports {
+		byName[r.Name] = r
+	}
+
+	// debug is only reachable through eslint, so it is not a root dependency of its own...
+	if _, ok := byName["debug"]; ok {
+		t.Errorf("expected debug not to be reported as a direct dependency")
+	}
+	// ...and its size counts as exclusive to eslint.
+	eslint, ok := byName["eslint"]
+	if !ok {
+		t.Fatalf("expected eslint report, got %+v", reports)
+	}
+	if eslint.OwnSize == 0 || eslint.ExclusiveDepsSize == 0 || eslint.SharedDepsSize != 0 {
+		t.Errorf("unexpected eslint sizes %+v", eslint)
+	}
+	if len(eslint.RemovedPaths) != 1 || !strings.Contains(eslint.RemovedPaths[0], "debug-npm-4.3.4") {
+		t.Errorf("expected debug to be removed with eslint, got %v", eslint.RemovedPaths)
+	}
+	if byName["lodash"].Version != "4.17.21" || byName["lodash"].OwnSize == 0 {
+		t.Errorf("unexpected lodash report %+v", byName["lodash"])
+	}
+}
+
+func TestGetUnusedNodeModules_YarnPnPBinaries(t *testing.T) {
+	cwd := yarnPnPFixture(t)
+	tree := MinimalDependencyTree{
+		cwd + "src/index.ts": {
+			{ID: "lodash/get", Request: "lodash/get", ResolvedType: NodeModule},
+			{ID: "left-pad", Request: "left-pad", ResolvedType: NodeModule},
+		},
+	}
+	declared := map[string]bool{"lodash": true, "left-pad": true, "eslint": true, "esbuild": true, "@repo/tsconfig": true}
+
+	unused := GetUnusedNodeModulesFromTree(tree, declared, cwd, nil, nil, nil, "", "", nil, nil, nil)
+
+	// eslint and esbuild are used through package.json scripts; their binaries come from the PnP
+	// manifest since there is no node_modules directory.
+	if !slices.Equal(unused, []string{"@repo/tsconfig"}) {
+		t.Fatalf("expected only @repo/tsconfig to be unused, got %v", unused)
+	}
+}
@@ -39,7 +39,7 @@ func mergePrunePatterns(patterns []string, useDefaults bool) []string {
 }
 
 func getInstalledModulePackageDirs(cwd string) []string {
-	modules, _ := GetInstalledModules(cwd, []string{}, []string{})
+	modules, _ := GetInstalledModules(cwd, []string{}, []string{}, nil, nil)
 	dirsSet := make(map[string]bool)
 
 	for _, installations := range modules {
@@ -68,7 +68,10 @@ func getInstalledModulePackageDirs(cwd string) []string {
 	return result
 }
 
-func NodeModulesPruneDocsCmd(cwd string, patterns []string, useDefaults bool) (string, error) {
+// NodeModulesPruneDocsCmd removes the files of the installed packages matching the prune
+// patterns. With dryRun the files are only listed with their sizes. With a journal, files are moved
+// to its trash and recorded instead of being deleted; the caller saves the journal.
+func NodeModulesPruneDocsCmd(cwd string, patterns []string, useDefaults bool, dryRun bool, journal *NodeModulesJournal) (string, error) {
 	patternsToUse := mergePrunePatterns(patterns, useDefaults)
 	if len(patternsToUse) == 0 {
 		return "", errors.New("no prune patterns specified; use --patterns or --defaults")
@@ -80,6 +83,11 @@ func NodeModulesPruneDocsCmd(cwd string, patterns []string, useDefaults bool) (s
 	removedCount := 0
 	errorsCount := 0
 
+	// Package directories include the ones nested in other packages, so a file can be matched
+	// from several of them.
+	filesToRemove := []string{}
+	fileSizes := map[string]int64{}
+
 	for _, packageDir := range packageDirs {
 		walkErr := filepath.WalkDir(packageDir, func(path string, d os.DirEntry, err error) error {
 			if err != nil {
@@ -103,11 +111,15 @@ func NodeModulesPruneDocsCmd(cwd string, patterns []string, useDefaults bool) (s
 				return nil
 			}
 
-			if removeErr := os.Remove(path); removeErr != nil {
-				errorsCount++
+			if _, seen := fileSizes[path]; seen {
 				return nil
 			}
-			removedCount++
+			var size int64
+			if info, infoErr := d.Info(); infoErr == nil {
+				size = info.Size()
+			}
+			fileSizes[path] = size
+			filesToRemove = append(filesToRemove, path)
 			return nil
 		})
 
@@ -116,6 +128,32 @@ func NodeModulesPruneDocsCmd(cwd string, patterns []string, useDefaults bool) (s
 		}
 	}
 
+	if dryRun {
+		var totalSize int64
+		var b strings.Builder
+		fmt.Fprintf(&b, "Files to prune in node_modules packages (dry run, nothing is removed)\nPatterns: %s\n", strings.Join(patternsToUse, ", "))
+		for _, path := range filesToRemove {
+			totalSize += fileSizes[path]
+			fmt.Fprintf(&b, "   %s %s\n", relativeToCwd(cwd, path), formatSize(fileSizes[path]))
+		}
+		fmt.Fprintf(&b, "Packages scanned: %d\nFiles to remove: %d\nSize: %s\nErrors: %d\n", len(packageDirs), len(filesToRemove), formatSize(totalSize), errorsCount)
+		return b.String(), nil
+	}
+
+	for _, path := range filesToRemove {
+		var removeErr error
+		if journal != nil {
+			removeErr = journal.removeFile(path, fileSizes[path])
+		} else {
+			removeErr = os.Remove(path)
+		}
+		if removeErr != nil {
+			errorsCount++
+			continue
+		}
+		removedCount++
+	}
+
 	result := fmt.Sprintf(
 		"Pruned files in node_modules packages\nPatterns: %s\nPackages scanned: %d\nFiles removed: %d\nErrors: %d\n",
 		strings.Join(patternsToUse, ", "),
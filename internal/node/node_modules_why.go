@@ -0,0 +1,461 @@
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"rev-dep-go/internal/lockfile"
+	"rev-dep-go/internal/pathutil"
+	"rev-dep-go/internal/pnp"
+	"rev-dep-go/internal/resolve"
+)
+
+// packageGraphNode is a node of the graph `node-modules why` walks: a project root or workspace
+// package.json, or an installed (or locked) copy of a package.
+type packageGraphNode struct {
+	Name    string
+	Version string
+	// Path identifies the node in the output, relative to cwd: the package.json path, or the
+	// lockfile entry (package-lock.json#node_modules/lodash) for packages read from a lockfile.
+	Path string
+	// Dir is the absolute directory of a root in internal form with a trailing slash; source
+	// files are attributed to the innermost root containing them. Empty for packages.
+	Dir          string
+	Dependencies []*packageGraphNode
+}
+
+type packageGraph struct {
+	Roots    []*packageGraphNode
+	Packages []*packageGraphNode
+}
+
+func (n *packageGraphNode) addDependency(dep *packageGraphNode) {
+	if dep != nil && dep != n && !slices.Contains(n.Dependencies, dep) {
+		n.Dependencies = append(n.Dependencies, dep)
+	}
+}
+
+func comparePackageGraphNodes(a, b *packageGraphNode) int {
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Path, b.Path)
+}
+
+// sort orders roots by path and dependencies by name, so chains are listed deterministically.
+func (g *packageGraph) sort() {
+	slices.SortFunc(g.Roots, func(a, b *packageGraphNode) int { return strings.Compare(a.Path, b.Path) })
+	slices.SortFunc(g.Packages, comparePackageGraphNodes)
+	for _, node := range append(slices.Clone(g.Roots), g.Packages...) {
+		slices.SortFunc(node.Dependencies, comparePackageGraphNodes)
+	}
+}
+
+func relativeToCwd(cwd string, path string) string {
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// packageGraphFromInstalled converts the node_modules graph used by AnalyzeNodeModules. Its keys
+// are real paths, so they are made relative to absCwd (the real cwd) and roots are re-anchored
+// at cwd, where the source files of the dependency tree live.
+func packageGraphFromInstalled(cwd string, absCwd string, installed *installedPackagesGraph) *packageGraph {
+	graph := &packageGraph{}
+	nodes := map[string]*packageGraphNode{}
+	for key, pkg := range installed.installedByPkgJSON {
+		node := &packageGraphNode{Name: pkg.Name, Version: pkg.Version, Path: relativeToCwd(absCwd, key)}
+		nodes[key] = node
+		graph.Packages = append(graph.Packages, node)
+	}
+	for key, deps := range installed.graph {
+		for _, dep := range deps {
+			nodes[key].addDependency(nodes[dep])
+		}
+	}
+	for _, rootPkgFile := range installed.rootPkgFiles {
+		rel := relativeToCwd(absCwd, rootPkgFile)
+		root := &packageGraphNode{
+			Path: rel,
+			Dir:  pathutil.StandardiseDirPathInternal(filepath.Join(cwd, filepath.Dir(filepath.FromSlash(rel)))),
+		}
+		for _, dep := range installed.rootToInstalled[rootPkgFile] {
+			root.addDependency(nodes[dep])
+		}
+		graph.Roots = append(graph.Roots, root)
+	}
+	graph.sort()
+	return graph
+}
+
+// packageGraphFromPnP builds the graph from a PnP manifest: workspaces are the roots and virtual
+// instances of a package are merged into their real location.
+func packageGraphFromPnP(manifest *pnp.Manifest, cwd string) *packageGraph {
+	graph := &packageGraph{}
+	nodes := map[string]*packageGraphNode{}
+	for _, pkg := range manifest.Packages {
+		if nodes[pkg.Location] != nil {
+			continue
+		}
+		pkgJsonPath := filepath.Join(pkg.Location, "package.json")
+		name, version := readPnPPackageNameAndVersion(pkgJsonPath)
+		if name == "" {
+			name = pkg.Name
+		}
+		node := &packageGraphNode{Name: name, Version: version, Path: relativeToCwd(cwd, pkgJsonPath)}
+		nodes[pkg.Location] = node
+		if pkg.IsWorkspace() {
+			node.Version = ""
+			node.Dir = pathutil.StandardiseDirPathInternal(pkg.Location)
+			graph.Roots = append(graph.Roots, node)
+		} else {
+			graph.Packages = append(graph.Packages, node)
+		}
+	}
+	for _, pkg := range manifest.Packages {
+		for depName := range pkg.Dependencies {
+			dep := manifest.ResolveDependency(pkg, depName)
+			if dep == nil || dep.IsWorkspace() {
+				continue
+			}
+			nodes[pkg.Location].addDependency(nodes[dep.Location])
+		}
+	}
+	graph.sort()
+	return graph
+}
+
+// packageGraphFromLockfile builds the graph from a lockfile: importers are the roots.
+func packageGraphFromLockfile(lock *lockfile.Lockfile, cwd string) *packageGraph {
+	graph := &packageGraph{}
+	nodes := map[*lockfile.Package]*packageGraphNode{}
+	lockfileDir := filepath.Dir(lock.Path)
+	lockfilePath := relativeToCwd(cwd, lock.Path)
+	for _, importer := range lock.Importers {
+		importerDir := filepath.Join(lockfileDir, filepath.FromSlash(importer.Key))
+		node := &packageGraphNode{
+			Name: importer.Name,
+			Path: relativeToCwd(cwd, filepath.Join(importerDir, "package.json")),
+			Dir:  pathutil.StandardiseDirPathInternal(importerDir),
+		}
+		nodes[importer] = node
+		graph.Roots = append(graph.Roots, node)
+	}
+	for _, pkg := range lock.Packages {
+		node := &packageGraphNode{Name: pkg.Name, Version: pkg.Version, Path: lockfilePath + "#" + pkg.Key}
+		nodes[pkg] = node
+		graph.Packages = append(graph.Packages, node)
+	}
+	for pkg, node := range nodes {
+		for _, dep := range pkg.Dependencies {
+			if !dep.Workspace {
+				node.addDependency(nodes[dep])
+			}
+		}
+	}
+	graph.sort()
+	return graph
+}
+
+// WhyPackage is a package on a dependency chain.
+type WhyPackage struct {
+	Name    string
+	Version string
+}
+
+// WhyChain is one dependency chain from a project root or workspace package.json to an installed
+// copy of a package.
+type WhyChain struct {
+	// Root is the package.json the chain starts from, relative to cwd.
+	Root    string
+	rootDir string
+	// Packages lists the packages on the chain, from a direct dependency of Root to the copy.
+	Packages []WhyPackage
+	// UsedBy lists the files of Root's package (relative to cwd) that use the direct dependency.
+	UsedBy []string
+}
+
+// WhyInstallation is an installed copy of the package asked about, with the chains leading to it.
+type WhyInstallation struct {
+	Name    string
+	Version string
+	// Path is the package.json path relative to cwd, or the lockfile entry.
+	Path   string
+	Chains []WhyChain
+	// MoreChains is set when chains were left out because of the maxChains limit.
+	MoreChains bool
+}
+
+// findDependencyChains lists the copies of packageName whose version satisfies versionRange (any
+// version when empty) and every chain from a root to each of them. Chains stop at workspaces,
+// which are roots of their own. maxChains limits the chains per copy; 0 lists all of them.
+func findDependencyChains(graph *packageGraph, packageName string, versionRange string, maxChains int) ([]WhyInstallation, error) {
+	var constraint *semver.Constraints
+	if versionRange != "" {
+		parsed, err := semver.NewConstraint(versionRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", versionRange, err)
+		}
+		constraint = parsed
+	}
+
+	installations := []WhyInstallation{}
+	targetIndex := map[*packageGraphNode]int{}
+	for _, pkg := range graph.Packages {
+		if pkg.Name != packageName {
+			continue
+		}
+		if constraint != nil {
+			version, err := semver.NewVersion(pkg.Version)
+			if err != nil || !constraint.Check(version) {
+				continue
+			}
+		}
+		targetIndex[pkg] = len(installations)
+		installations = append(installations, WhyInstallation{Name: pkg.Name, Version: pkg.Version, Path: pkg.Path})
+	}
+	if len(installations) == 0 {
+		return installations, nil
+	}
+
+	// leadsTo[i] holds the packages leading to the i-th copy. Only packages that lead to a copy
+	// which has not reached maxChains yet are walked, so the number of chains to a capped copy
+	// does not slow down the search for the others.
+	dependents := map[*packageGraphNode][]*packageGraphNode{}
+	for _, pkg := range graph.Packages {
+		for _, dep := range pkg.Dependencies {
+			dependents[dep] = append(dependents[dep], pkg)
+		}
+	}
+	leadsTo := make([]map[*packageGraphNode]bool, len(installations))
+	for target, idx := range targetIndex {
+		leadsTo[idx] = map[*packageGraphNode]bool{target: true}
+		queue := []*packageGraphNode{target}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, dependent := range dependents[current] {
+				if !leadsTo[idx][dependent] {
+					leadsTo[idx][dependent] = true
+					queue = append(queue, dependent)
+				}
+			}
+		}
+	}
+	leadsToOpenTarget := func(node *packageGraphNode) bool {
+		for idx := range installations {
+			if !installations[idx].MoreChains && leadsTo[idx][node] {
+				return true
+			}
+		}
+		return false
+	}
+
+	capped := 0
+	onChain := map[*packageGraphNode]bool{}
+	var walk func(root *packageGraphNode, node *packageGraphNode, chain []WhyPackage)
+	walk = func(root *packageGraphNode, node *packageGraphNode, chain []WhyPackage) {
+		for _, dep := range node.Dependencies {
+			if capped == len(installations) {
+				return
+			}
+			if onChain[dep] || !leadsToOpenTarget(dep) {
+				continue
+			}
+			next := append(slices.Clone(chain), WhyPackage{Name: dep.Name, Version: dep.Version})
+			if idx, isTarget := targetIndex[dep]; isTarget {
+				installation := &installations[idx]
+				if maxChains > 0 && len(installation.Chains) >= maxChains {
+					if !installation.MoreChains {
+						installation.MoreChains = true
+						capped++
+					}
+				} else {
+					installation.Chains = append(installation.Chains, WhyChain{Root: root.Path, rootDir: root.Dir, Packages: next})
+				}
+			}
+			onChain[dep] = true
+			walk(root, dep, next)
+			onChain[dep] = false
+		}
+	}
+	for _, root := range graph.Roots {
+		walk(root, root, nil)
+	}
+
+	slices.SortStableFunc(installations, func(a, b WhyInstallation) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return installations, nil
+}
+
+// attachUsingFiles fills WhyChain.UsedBy from the files using each node module (as returned by
+// GetUsedNodeModulesFromTree). A file belongs to the innermost root directory containing it.
+func attachUsingFiles(installations []WhyInstallation, roots []*packageGraphNode, usedNodeModules map[string]map[string]bool, cwd string) {
+	rootDirs := []string{}
+	for _, root := range roots {
+		if root.Dir != "" {
+			rootDirs = append(rootDirs, root.Dir)
+		}
+	}
+	slices.SortFunc(rootDirs, func(a, b string) int { return len(b) - len(a) })
+	rootDirOf := func(filePath string) string {
+		for _, dir := range rootDirs {
+			if strings.HasPrefix(filePath, dir) {
+				return dir
+			}
+		}
+		return ""
+	}
+
+	cwdInternal := pathutil.StandardiseDirPathInternal(cwd)
+	for i := range installations {
+		for j := range installations[i].Chains {
+			chain := &installations[i].Chains[j]
+			usedBy := []string{}
+			for filePath := range usedNodeModules[chain.Packages[0].Name] {
+				if rootDirOf(pathutil.NormalizePathForInternal(filePath)) == chain.rootDir {
+					usedBy = append(usedBy, strings.TrimPrefix(pathutil.NormalizePathForInternal(filePath), cwdInternal))
+				}
+			}
+			slices.Sort(usedBy)
+			chain.UsedBy = usedBy
+		}
+	}
+}
+
+// parsePackageSpec splits "lodash@^3" into the package name and the version range; the range is
+// empty for a bare name. Scoped names keep their leading "@".
+func parsePackageSpec(spec string) (string, string) {
+	if len(spec) > 1 {
+		if idx := strings.Index(spec[1:], "@"); idx >= 0 {
+			return spec[:idx+1], spec[idx+2:]
+		}
+	}
+	return spec, ""
+}
+
+// FindWhyInstallations returns every installed copy of packageSpec ("name" or "name@range") with
+// the chains from the project root and workspace package.json files leading to it, and the source
+// files using the direct dependency each chain starts with. Packages are read from the PnP
+// manifest or the lockfile when one is given, and from node_modules otherwise.
+func FindWhyInstallations(
+	inputCwd string,
+	packageSpec string,
+	ignoreType bool,
+	maxChains int,
+	pnpManifest *pnp.Manifest,
+	lock *lockfile.Lockfile,
+	packageJson string,
+	tsconfigJson string,
+	conditionNames []string,
+	followMonorepoPackages FollowMonorepoPackagesValue,
+) ([]WhyInstallation, error) {
+	cwd := pathutil.StandardiseDirPath(inputCwd)
+	packageName, versionRange := parsePackageSpec(packageSpec)
+	if packageName == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	var graph *packageGraph
+	switch {
+	case pnpManifest != nil:
+		graph = packageGraphFromPnP(pnpManifest, cwd)
+	case lock != nil:
+		graph = packageGraphFromLockfile(lock, cwd)
+	default:
+		absCwd, err := resolveAbsoluteRealCwd(cwd)
+		if err != nil {
+			return nil, err
+		}
+		modules, _ := GetInstalledModules(cwd, []string{}, []string{}, nil, nil)
+		for name, infos := range modules {
+			for i := range infos {
+				infos[i].FilePath = filepath.Join(cwd, infos[i].FilePath)
+			}
+			modules[name] = infos
+		}
+		graph = packageGraphFromInstalled(cwd, absCwd, buildInstalledPackagesGraph(absCwd, modules))
+	}
+
+	installations, err := findDependencyChains(graph, packageName, versionRange, maxChains)
+	if err != nil || len(installations) == 0 {
+		return installations, err
+	}
+
+	minimalTree, _, resolverManager := resolve.GetMinimalDepsTreeForCwd(cwd, ignoreType, []string{}, nil, nil, packageJson, tsconfigJson, conditionNames, followMonorepoPackages, nil, resolve.NodeModulesMatchingStrategyCwdResolver)
+	cwdNodeModules := map[string]bool{}
+	if resolverForCwd := resolverManager.GetResolverForFile(cwd); resolverForCwd != nil {
+		cwdNodeModules = resolverForCwd.NodeModules()
+	}
+	usedNodeModules := GetUsedNodeModulesFromTree(minimalTree, cwdNodeModules, cwd, []string{}, []string{}, []string{}, packageJson, tsconfigJson, nil)
+	attachUsingFiles(installations, graph.Roots, usedNodeModules, cwd)
+
+	return installations, nil
+}
+
+// NodeModulesWhyCmd explains why a package is installed, formatting the result of
+// FindWhyInstallations.
+func NodeModulesWhyCmd(
+	inputCwd string,
+	packageSpec string,
+	ignoreType bool,
+	maxChains int,
+	pnpManifest *pnp.Manifest,
+	lock *lockfile.Lockfile,
+	packageJson string,
+	tsconfigJson string,
+	conditionNames []string,
+	followMonorepoPackages FollowMonorepoPackagesValue,
+) (string, error) {
+	installations, err := FindWhyInstallations(inputCwd, packageSpec, ignoreType, maxChains, pnpManifest, lock, packageJson, tsconfigJson, conditionNames, followMonorepoPackages)
+	if err != nil {
+		return "", err
+	}
+	if len(installations) == 0 {
+		return fmt.Sprintf("No installed copies of %s found\n", packageSpec), nil
+	}
+	return FormatWhyResult(installations), nil
+}
+
+// FormatWhyResult prints each copy followed by its chains and the files using the direct
+// dependency of every chain.
+func FormatWhyResult(installations []WhyInstallation) string {
+	var builder strings.Builder
+	for i, installation := range installations {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "%s@%s %s\n", installation.Name, installation.Version, installation.Path)
+		if len(installation.Chains) == 0 {
+			builder.WriteString("  No dependency chain from a package.json leads to this copy\n")
+			continue
+		}
+		for _, chain := range installation.Chains {
+			links := []string{chain.Root}
+			for _, pkg := range chain.Packages {
+				links = append(links, pkg.Name+"@"+pkg.Version)
+			}
+			fmt.Fprintf(&builder, "  %s\n", strings.Join(links, " ➞ "))
+			direct := chain.Packages[0].Name
+			if len(chain.UsedBy) == 0 {
+				fmt.Fprintf(&builder, "    %s is not used by source files of %s\n", direct, chain.Root)
+				continue
+			}
+			fmt.Fprintf(&builder, "    %s used by:\n", direct)
+			for _, file := range chain.UsedBy {
+				fmt.Fprintf(&builder, "      %s\n", file)
+			}
+		}
+		if installation.MoreChains {
+			builder.WriteString("  ... more chains not shown, raise --max-chains to list them\n")
+		}
+	}
+	return builder.String()
+}
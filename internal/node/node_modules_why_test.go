@@ -0,0 +1,152 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/testutil"
+)
+
+func TestParsePackageSpec(t *testing.T) {
+	cases := map[string][2]string{
+		"lodash":           {"lodash", ""},
+		"lodash@^3":        {"lodash", "^3"},
+		"@babel/core":      {"@babel/core", ""},
+		"@babel/core@>=7 ": {"@babel/core", ">=7 "},
+	}
+	for spec, expected := range cases {
+		if name, versionRange := parsePackageSpec(spec); name != expected[0] || versionRange != expected[1] {
+			t.Errorf("parsePackageSpec(%q) = %q, %q; want %q, %q", spec, name, versionRange, expected[0], expected[1])
+		}
+	}
+}
+
+func TestFindDependencyChains_Lockfile(t *testing.T) {
+	cwd, err := testutil.FixturePath("lockfilePnpm")
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	lock, err := LoadLockfile(cwd, NodeModulesLookupStrategyLockfile)
+	if err != nil {
+		t.Fatalf("LoadLockfile: %v", err)
+	}
+	graph := packageGraphFromLockfile(lock, cwd)
+
+	installations, err := findDependencyChains(graph, "lodash", "^3", 0)
+	if err != nil {
+		t.Fatalf("findDependencyChains: %v", err)
+	}
+	if len(installations) != 1 || installations[0].Path != "pnpm-lock.yaml#lodash@3.10.1" {
+		t.Fatalf("expected only the lodash@3 copy, got %+v", installations)
+	}
+	chains := []string{}
+	for _, chain := range installations[0].Chains {
+		chainDescription := chain.Root
+		for _, pkg := range chain.Packages {
+			chainDescription += " > " + pkg.Name + "@" + pkg.Version
+		}
+		chains = append(chains, chainDescription)
+	}
+	// Chains stop at workspaces: the root reaches lodash@3 only through @repo/a, which is a root.
+	expectedChains := []string{
+		"packages/a/package.json > legacy-lib@1.0.0 > lodash@3.10.1",
+		"packages/b/package.json > lodash@3.10.1",
+	}
+	if !reflect.DeepEqual(chains, expectedChains) {
+		t.Errorf("unexpected chains:\n got: %v\nwant: %v", chains, expectedChains)
+	}
+
+	limited, _ := findDependencyChains(graph, "lodash", "^3", 1)
+	if len(limited[0].Chains) != 1 || !limited[0].MoreChains {
+		t.Errorf("expected a single chain and MoreChains, got %+v", limited[0])
+	}
+
+	if _, err := findDependencyChains(graph, "lodash", "not a range", 0); err == nil {
+		t.Errorf("expected an invalid version range to fail")
+	}
+}
+
+// One copy is reached through 2^40 chains: once it has maxChains of them, the search must skip the
+// packages only leading to it and still find the chain of the other copy.
+func TestFindDependencyChains_PrunesCappedCopies(t *testing.T) {
+	root := &packageGraphNode{Name: "root", Path: "package.json"}
+	lodash4 := &packageGraphNode{Name: "lodash", Version: "4.17.21", Path: "node_modules/lodash/package.json"}
+	lodash3 := &packageGraphNode{Name: "lodash", Version: "3.10.1", Path: "node_modules/legacy/node_modules/lodash/package.json"}
+	legacy := &packageGraphNode{Name: "legacy", Version: "1.0.0", Path: "node_modules/legacy/package.json", Dependencies: []*packageGraphNode{lodash3}}
+	graph := &packageGraph{Roots: []*packageGraphNode{root}, Packages: []*packageGraphNode{lodash4, lodash3, legacy}}
+
+	layer := []*packageGraphNode{lodash4}
+	for depth := 0; depth < 40; depth++ {
+		next := []*packageGraphNode{}
+		for i := 0; i < 2; i++ {
+			pkg := &packageGraphNode{Name: fmt.Sprintf("layer-%d-%d", depth, i), Version: "1.0.0", Dependencies: layer}
+			graph.Packages = append(graph.Packages, pkg)
+			next = append(next, pkg)
+		}
+		layer = next
+	}
+	root.Dependencies = append(layer, legacy)
+
+	installations, err := findDependencyChains(graph, "lodash", "", 5)
+	if err != nil {
+		t.Fatalf("findDependencyChains: %v", err)
+	}
+	if len(installations) != 2 {
+		t.Fatalf("expected both copies, got %+v", installations)
+	}
+	legacyCopy, rootCopy := installations[0], installations[1]
+	if len(rootCopy.Chains) != 5 || !rootCopy.MoreChains {
+		t.Errorf("expected 5 chains and MoreChains for lodash@4, got %d chains", len(rootCopy.Chains))
+	}
+	if len(legacyCopy.Chains) != 1 || legacyCopy.MoreChains || legacyCopy.Chains[0].Packages[0].Name != "legacy" {
+		t.Errorf("expected the single legacy chain for lodash@3, got %+v", legacyCopy)
+	}
+}
+
+func TestNodeModulesWhyCmd_NodeModules(t *testing.T) {
+	cwd := t.TempDir()
+	files := map[string]string{
+		"package.json":                                    `{"name": "app", "dependencies": {"a": "^1.0.0", "lodash": "^4.0.0"}}`,
+		"node_modules/a/package.json":                     `{"name": "a", "version": "1.0.0", "dependencies": {"lodash": "^3.0.0"}}`,
+		"node_modules/a/node_modules/lodash/package.json": `{"name": "lodash", "version": "3.10.1"}`,
+		"node_modules/lodash/package.json":                `{"name": "lodash", "version": "4.17.21"}`,
+		"src/index.ts":                                    "import a from 'a';\n",
+		"src/util.ts":                                     "import _ from 'lodash';\nimport a from 'a';\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(cwd, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := NodeModulesWhyCmd(cwd, "lodash", false, 0, nil, nil, "", "", nil, FollowMonorepoPackagesValue{})
+	if err != nil {
+		t.Fatalf("NodeModulesWhyCmd: %v", err)
+	}
+	expected := `lodash@3.10.1 node_modules/a/node_modules/lodash/package.json
+  package.json ➞ a@1.0.0 ➞ lodash@3.10.1
+    a used by:
+      src/index.ts
+      src/util.ts
+
+lodash@4.17.21 node_modules/lodash/package.json
+  package.json ➞ lodash@4.17.21
+    lodash used by:
+      src/util.ts
+`
+	if result != expected {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", result, expected)
+	}
+
+	missing, err := NodeModulesWhyCmd(cwd, "lodash@^2", false, 0, nil, nil, "", "", nil, FollowMonorepoPackagesValue{})
+	if err != nil || missing != "No installed copies of lodash@^2 found\n" {
+		t.Errorf("expected no copies for lodash@^2, got %q, %v", missing, err)
+	}
+}
@@ -0,0 +1,293 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/module"
+)
+
+// TypesPackageName returns the DefinitelyTyped package providing the types of moduleName:
+// "react" -> "@types/react", "@babel/core" -> "@types/babel__core".
+func TypesPackageName(moduleName string) string {
+	if scope, name, scoped := strings.Cut(strings.TrimPrefix(moduleName, "@"), "/"); scoped && strings.HasPrefix(moduleName, "@") {
+		return "@types/" + scope + "__" + name
+	}
+	return "@types/" + moduleName
+}
+
+// TypedPackageName returns the package typed by a `@types` package, the inverse of
+// TypesPackageName. The second result is false for packages outside the `@types` scope.
+func TypedPackageName(typesPackage string) (string, bool) {
+	typed, isTypes := strings.CutPrefix(typesPackage, "@types/")
+	if !isTypes || typed == "" {
+		return "", false
+	}
+	if scope, name, scoped := strings.Cut(typed, "__"); scoped {
+		return "@" + scope + "/" + name, true
+	}
+	return typed, true
+}
+
+// markTypesReference marks the package a `types` entry of tsconfig or a triple-slash directive
+// refers to. TypeScript looks such references up in `@types` first, so the `@types` package is
+// marked when it is declared, and the referenced package itself otherwise ("vite/client" -> vite).
+func markTypesReference(usedNodeModules *map[string]map[string]bool, cwdNodeModules map[string]bool, reference string, filePath string) {
+	moduleName := module.GetNodeModuleName(reference)
+	if moduleName == "" {
+		return
+	}
+	if typesPackage := TypesPackageName(moduleName); cwdNodeModules[typesPackage] {
+		setFilePathInNodeModuleFilesMap(usedNodeModules, typesPackage, filePath)
+		return
+	}
+	setFilePathInNodeModuleFilesMap(usedNodeModules, moduleName, filePath)
+}
+
+// typeRootPackageName returns the package a tsconfig `typeRoots` entry points into, e.g.
+// "./node_modules/@company/types" -> "@company/types". The default "node_modules/@types" root and
+// roots outside node_modules do not name a package and return "".
+func typeRootPackageName(typeRoot string) string {
+	normalized := strings.Trim(filepath.ToSlash(typeRoot), "/")
+	index := strings.LastIndex("/"+normalized, "/node_modules/")
+	if index == -1 {
+		return ""
+	}
+	rest := normalized[index+len("node_modules/"):]
+	if rest == "" || strings.HasPrefix(rest, "@") && !strings.Contains(rest, "/") {
+		return ""
+	}
+	return module.GetNodeModuleName(rest)
+}
+
+var tripleSlashTypesReferenceRegex = regexp.MustCompile(`^///\s*<reference\s+types\s*=\s*["']([^"']+)["']`)
+
+// readTripleSlashTypesReferences returns the packages referenced by `/// <reference types="..." />`
+// directives of a file. Directives are only valid before the first statement, so reading stops
+// at the first line that is not blank or a comment.
+func readTripleSlashTypesReferences(filePath string) []string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	references := []string{}
+	inBlockComment := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case inBlockComment:
+			inBlockComment = !strings.Contains(line, "*/")
+		case line == "":
+		case strings.HasPrefix(line, "///"):
+			if match := tripleSlashTypesReferenceRegex.FindStringSubmatch(line); match != nil {
+				references = append(references, match[1])
+			}
+		case strings.HasPrefix(line, "//"):
+		case strings.HasPrefix(line, "/*"):
+			inBlockComment = !strings.Contains(line[2:], "*/")
+		default:
+			return references
+		}
+	}
+	return references
+}
+
+var ambientModuleDeclarationRegex = regexp.MustCompile(`declare\s+module\s+["']([^"']+)["']`)
+
+// readAmbientModuleDeclarations returns the packages a declaration file types with
+// `declare module "x"`. Wildcard declarations such as "*.svg" are skipped.
+func readAmbientModuleDeclarations(filePath string) []string {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	modules := []string{}
+	for _, match := range ambientModuleDeclarationRegex.FindAllStringSubmatch(string(content), -1) {
+		if strings.Contains(match[1], "*") {
+			continue
+		}
+		modules = append(modules, module.GetNodeModuleName(match[1]))
+	}
+	return modules
+}
+
+// UntypedNodeModuleImport is a package imported from TypeScript files that neither ships its own
+// types nor has its `@types` package declared or installed.
+type UntypedNodeModuleImport struct {
+	ModuleName   string
+	TypesPackage string
+	ImportedFrom []string
+}
+
+func isTypeScriptFile(filePath string) bool {
+	switch filepath.Ext(filePath) {
+	case ".ts", ".tsx", ".mts", ".cts":
+		return true
+	}
+	return false
+}
+
+func isDeclarationFile(filePath string) bool {
+	return strings.HasSuffix(filePath, ".d.ts") || strings.HasSuffix(filePath, ".d.mts") || strings.HasSuffix(filePath, ".d.cts")
+}
+
+// GetUntypedNodeModuleImports returns the packages imported from TypeScript files of the tree
+// that ship no types and have no `@types` package declared in cwdNodeModules or installed. Packages
+// typed by a `declare module` of a project declaration file, and packages that are not installed
+// (their types cannot be inspected), are not reported. entryOwnedFiles restricts the importing
+// files like in GetUsedNodeModulesFromTree; nil means all files.
+func GetUntypedNodeModuleImports(
+	minimalTree MinimalDependencyTree,
+	cwdNodeModules map[string]bool,
+	cwd string,
+	modulesToInclude []string,
+	modulesToExclude []string,
+	entryOwnedFiles map[string]bool,
+) []UntypedNodeModuleImport {
+	shouldIncludeModule := createShouldModuleByIncluded(modulesToInclude, modulesToExclude)
+	imports := map[string]map[string]bool{}
+
+	for filePath, fileDeps := range minimalTree {
+		if !isTypeScriptFile(filePath) || entryOwnedFiles != nil && !entryOwnedFiles[filePath] {
+			continue
+		}
+		for _, dependency := range fileDeps {
+			if dependency.ResolvedType != NodeModule {
+				continue
+			}
+			moduleName := module.GetNodeModuleName(dependency.Request)
+			if strings.HasPrefix(moduleName, "@types/") || !shouldIncludeModule(moduleName) || cwdNodeModules[TypesPackageName(moduleName)] {
+				continue
+			}
+			setFilePathInNodeModuleFilesMap(&imports, moduleName, filePath)
+		}
+	}
+
+	if len(imports) == 0 {
+		return []UntypedNodeModuleImport{}
+	}
+
+	declaredModules := map[string]bool{}
+	for filePath := range minimalTree {
+		if isDeclarationFile(filePath) {
+			for _, moduleName := range readAmbientModuleDeclarations(filePath) {
+				declaredModules[moduleName] = true
+			}
+		}
+	}
+
+	results := []UntypedNodeModuleImport{}
+	for moduleName, importedFromFiles := range imports {
+		if declaredModules[moduleName] {
+			continue
+		}
+		packageJsonPath := findInstalledPackageJson(cwd, moduleName)
+		if packageJsonPath == "" || packageShipsTypes(packageJsonPath) || findInstalledPackageJson(cwd, TypesPackageName(moduleName)) != "" {
+			continue
+		}
+		importedFrom := make([]string, 0, len(importedFromFiles))
+		for file := range importedFromFiles {
+			importedFrom = append(importedFrom, file)
+		}
+		slices.Sort(importedFrom)
+		results = append(results, UntypedNodeModuleImport{
+			ModuleName:   moduleName,
+			TypesPackage: TypesPackageName(moduleName),
+			ImportedFrom: importedFrom,
+		})
+	}
+
+	slices.SortFunc(results, func(a, b UntypedNodeModuleImport) int {
+		return strings.Compare(a.ModuleName, b.ModuleName)
+	})
+	return results
+}
+
+// findInstalledPackageJson returns the package.json of the copy of moduleName Node resolves from
+// cwd, or "" when it is not installed.
+func findInstalledPackageJson(cwd string, moduleName string) string {
+	for dir := filepath.Clean(cwd); ; dir = filepath.Dir(dir) {
+		path := filepath.Join(dir, "node_modules", moduleName, "package.json")
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		if filepath.Dir(dir) == dir {
+			return ""
+		}
+	}
+}
+
+// packageShipsTypes reports whether an installed package provides its own type declarations:
+// through the types, typings or typesVersions fields, a "types" condition in exports, an
+// index.d.ts, or a declaration file next to its main entry.
+func packageShipsTypes(packageJsonPath string) bool {
+	content, err := os.ReadFile(packageJsonPath)
+	if err != nil {
+		return false
+	}
+	var pkgJson struct {
+		Types         string          `json:"types"`
+		Typings       string          `json:"typings"`
+		TypesVersions json.RawMessage `json:"typesVersions"`
+		Exports       json.RawMessage `json:"exports"`
+		Main          string          `json:"main"`
+	}
+	if err := json.Unmarshal(content, &pkgJson); err != nil {
+		return false
+	}
+	if pkgJson.Types != "" || pkgJson.Typings != "" || len(pkgJson.TypesVersions) > 0 || exportsHaveTypesCondition(pkgJson.Exports) {
+		return true
+	}
+
+	packageDir := filepath.Dir(packageJsonPath)
+	candidates := []string{"index.d.ts"}
+	if pkgJson.Main != "" {
+		main := strings.TrimSuffix(filepath.FromSlash(pkgJson.Main), filepath.Ext(pkgJson.Main))
+		candidates = append(candidates, main+".d.ts", filepath.Join(main, "index.d.ts"))
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(filepath.Join(packageDir, candidate)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// exportsHaveTypesCondition reports whether a package.json exports map has a "types" condition at
+// any depth.
+func exportsHaveTypesCondition(raw json.RawMessage) bool {
+	if len(raw) == 0 {
+		return false
+	}
+	var exports interface{}
+	if err := json.Unmarshal(raw, &exports); err != nil {
+		return false
+	}
+	var walk func(value interface{}) bool
+	walk = func(value interface{}) bool {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			for key, nested := range typed {
+				if key == "types" || walk(nested) {
+					return true
+				}
+			}
+		case []interface{}:
+			for _, nested := range typed {
+				if walk(nested) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return walk(exports)
+}
@@ -0,0 +1,171 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"rev-dep-go/internal/pathutil"
+)
+
+func writeTypesFixtureFile(t *testing.T, root string, rel string, content string) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func TestTypesPackageName(t *testing.T) {
+	cases := []struct {
+		moduleName   string
+		typesPackage string
+	}{
+		{"react", "@types/react"},
+		{"@babel/core", "@types/babel__core"},
+		{"google.maps", "@types/google.maps"},
+	}
+	for _, c := range cases {
+		if got := TypesPackageName(c.moduleName); got != c.typesPackage {
+			t.Errorf("TypesPackageName(%q) = %q, want %q", c.moduleName, got, c.typesPackage)
+		}
+		if got, ok := TypedPackageName(c.typesPackage); !ok || got != c.moduleName {
+			t.Errorf("TypedPackageName(%q) = %q, %v, want %q", c.typesPackage, got, ok, c.moduleName)
+		}
+	}
+	if _, ok := TypedPackageName("react"); ok {
+		t.Errorf("expected react not to be a @types package")
+	}
+}
+
+func TestTypeRootPackageName(t *testing.T) {
+	cases := map[string]string{
+		"./node_modules/@types":          "",
+		"./node_modules/@company":        "",
+		"./node_modules/@company/types":  "@company/types",
+		"../../node_modules/my-types/":   "my-types",
+		"./typings":                      "",
+		"node_modules/types-pkg/globals": "types-pkg",
+	}
+	for typeRoot, expected := range cases {
+		if got := typeRootPackageName(typeRoot); got != expected {
+			t.Errorf("typeRootPackageName(%q) = %q, want %q", typeRoot, got, expected)
+		}
+	}
+}
+
+func TestReadTripleSlashTypesReferences(t *testing.T) {
+	root := t.TempDir()
+	writeTypesFixtureFile(t, root, "env.d.ts", `/*
+ * License header
+ */
+// regular comment
+/// <reference types="vite/client" />
+/// <reference path="./globals.d.ts" />
+
+///<reference types='node'/>
+import "./setup";
+/// <reference types="ignored-after-statement" />
+`)
+
+	references := readTripleSlashTypesReferences(filepath.Join(root, "env.d.ts"))
+	expected := []string{"vite/client", "node"}
+	if !reflect.DeepEqual(references, expected) {
+		t.Errorf("unexpected references %v, want %v", references, expected)
+	}
+}
+
+func TestGetUnusedNodeModules_TypesPairing(t *testing.T) {
+	root := t.TempDir()
+	writeTypesFixtureFile(t, root, "tsconfig.json", `{
+		"compilerOptions": {
+			"types": ["vitest/globals"],
+			"typeRoots": ["./node_modules/@types", "./node_modules/@company/types"]
+		}
+	}`)
+	writeTypesFixtureFile(t, root, "src/env.d.ts", "/// <reference types=\"vite/client\" />\n/// <reference types=\"jest\" />\n")
+	writeTypesFixtureFile(t, root, "src/index.ts", "import React from 'react';\n")
+	cwd := pathutil.NormalizePathForInternal(root) + "/"
+
+	tree := MinimalDependencyTree{
+		cwd + "src/index.ts": {
+			{ID: "react", Request: "react", ResolvedType: NodeModule},
+			{ID: "@babel/core", Request: "@babel/core", ResolvedType: NodeModule},
+			{ID: "fs", Request: "node:fs", ResolvedType: BuiltInModule},
+		},
+		cwd + "src/env.d.ts": {},
+	}
+	declared := map[string]bool{
+		"react":              true,
+		"@babel/core":        true,
+		"@types/react":       true,
+		"@types/babel__core": true,
+		"@types/node":        true,
+		"@types/jest":        true,
+		"@types/lodash":      true,
+		"@types/babel__cli":  true,
+		"vitest":             true,
+		"vite":               true,
+		"@company/types":     true,
+		"unused-package":     true,
+	}
+
+	unused := GetUnusedNodeModulesFromTree(tree, declared, cwd, nil, nil, nil, "", "", nil, nil, nil)
+
+	// @types/node is used through the built-in import, @types/jest through the triple-slash
+	// reference, vite through "vite/client" and vitest through the tsconfig types.
+	expected := []string{"@types/babel__cli", "@types/lodash", "unused-package"}
+	if !reflect.DeepEqual(unused, expected) {
+		t.Errorf("unexpected unused modules %v, want %v", unused, expected)
+	}
+}
+
+func TestGetUntypedNodeModuleImports(t *testing.T) {
+	root := t.TempDir()
+	writeTypesFixtureFile(t, root, "node_modules/untyped/package.json", `{"name":"untyped","main":"lib/index.js"}`)
+	writeTypesFixtureFile(t, root, "node_modules/js-only/package.json", `{"name":"js-only"}`)
+	writeTypesFixtureFile(t, root, "node_modules/typed-field/package.json", `{"name":"typed-field","types":"dist/index.d.ts"}`)
+	writeTypesFixtureFile(t, root, "node_modules/typed-exports/package.json", `{"name":"typed-exports","exports":{".":{"import":{"types":"./index.d.mts","default":"./index.mjs"}}}}`)
+	writeTypesFixtureFile(t, root, "node_modules/typed-main/package.json", `{"name":"typed-main","main":"lib/main.js"}`)
+	writeTypesFixtureFile(t, root, "node_modules/typed-main/lib/main.d.ts", "export {};\n")
+	writeTypesFixtureFile(t, root, "node_modules/@scope/installed-types/package.json", `{"name":"@scope/installed-types"}`)
+	writeTypesFixtureFile(t, root, "node_modules/@types/scope__installed-types/package.json", `{"name":"@types/scope__installed-types"}`)
+	writeTypesFixtureFile(t, root, "node_modules/declared-types/package.json", `{"name":"declared-types"}`)
+	writeTypesFixtureFile(t, root, "node_modules/ambient/package.json", `{"name":"ambient"}`)
+	writeTypesFixtureFile(t, root, "src/modules.d.ts", "declare module 'ambient' {\n  export const value: string;\n}\ndeclare module '*.svg';\n")
+	cwd := pathutil.NormalizePathForInternal(root) + "/"
+
+	tree := MinimalDependencyTree{
+		cwd + "src/index.ts": {
+			{ID: "untyped", Request: "untyped/sub", ResolvedType: NodeModule},
+			{ID: "typed-field", Request: "typed-field", ResolvedType: NodeModule},
+			{ID: "typed-exports", Request: "typed-exports", ResolvedType: NodeModule},
+			{ID: "typed-main", Request: "typed-main", ResolvedType: NodeModule},
+			{ID: "@scope/installed-types", Request: "@scope/installed-types", ResolvedType: NodeModule},
+			{ID: "declared-types", Request: "declared-types", ResolvedType: NodeModule},
+			{ID: "ambient", Request: "ambient", ResolvedType: NodeModule},
+			{ID: "not-installed", Request: "not-installed", ResolvedType: NodeModule},
+		},
+		cwd + "src/view.tsx":     {{ID: "untyped", Request: "untyped", ResolvedType: NodeModule}},
+		cwd + "src/script.js":    {{ID: "js-only", Request: "js-only", ResolvedType: NodeModule}},
+		cwd + "src/modules.d.ts": {},
+	}
+	declared := map[string]bool{"@types/declared-types": true}
+
+	untyped := GetUntypedNodeModuleImports(tree, declared, cwd, nil, nil, nil)
+	expected := []UntypedNodeModuleImport{
+		{ModuleName: "untyped", TypesPackage: "@types/untyped", ImportedFrom: []string{cwd + "src/index.ts", cwd + "src/view.tsx"}},
+	}
+	if !reflect.DeepEqual(untyped, expected) {
+		t.Errorf("unexpected untyped imports %+v, want %+v", untyped, expected)
+	}
+
+	excluded := GetUntypedNodeModuleImports(tree, declared, cwd, nil, []string{"untyped"}, nil)
+	if len(excluded) != 0 {
+		t.Errorf("expected excluded module not to be reported, got %+v", excluded)
+	}
+}
@@ -0,0 +1,360 @@
+// Package pnp reads Yarn Plug'n'Play manifests (.pnp.data.json or the runtime state embedded in
+// .pnp.cjs) and maps package locators to their on-disk locations. Under PnP there is no physical
+// node_modules tree: packages live in zip archives under .yarn/cache (or in .yarn/unplugged when
+// they had to be extracted), so lookups go through the manifest instead of walking directories.
+package pnp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DataFileName    = ".pnp.data.json"
+	RuntimeFileName = ".pnp.cjs"
+)
+
+// Locator uniquely identifies a package in the manifest. The top-level (project root) locator has
+// an empty name and reference.
+type Locator struct {
+	Name      string
+	Reference string
+}
+
+// Package is a single entry of the manifest package registry.
+type Package struct {
+	Locator
+	// Location is the absolute, OS-native package directory without a trailing separator. Virtual
+	// paths are already mapped to their real location, so it may point inside a zip archive
+	// (e.g. /repo/.yarn/cache/lodash-npm-4.17.21-abc.zip/node_modules/lodash).
+	Location string
+	// LinkType is "HARD" for packages owned by the package manager and "SOFT" for workspaces and
+	// portals that live in the project itself.
+	LinkType string
+	// Dependencies maps each dependency name visible to this package to the locator it resolves
+	// to. Aliased dependencies ("foo": "npm:bar@1") point at the aliased package locator. Unmet
+	// peer dependencies are omitted.
+	Dependencies map[string]Locator
+}
+
+// IsWorkspace reports whether the package is the project root or one of its workspaces, as
+// opposed to a third-party package installed by Yarn.
+func (p *Package) IsWorkspace() bool {
+	return p.Name == "" || strings.HasPrefix(p.Reference, "workspace:")
+}
+
+// Manifest is a parsed PnP manifest.
+type Manifest struct {
+	// Root is the directory holding the manifest; package locations are relative to it.
+	Root string
+	// Packages lists every registry entry in manifest order.
+	Packages []*Package
+
+	byLocator      map[Locator]*Package
+	fallbackPool   map[string]Locator
+	topLevelLookup bool
+}
+
+// Find walks up from dir and returns the directory containing a PnP manifest, or "" when the
+// project does not use Plug'n'Play.
+func Find(dir string) string {
+	cur := filepath.Clean(dir)
+	for {
+		for _, name := range []string{DataFileName, RuntimeFileName} {
+			if info, err := os.Stat(filepath.Join(cur, name)); err == nil && !info.IsDir() {
+				return cur
+			}
+		}
+		parent := filepath.Dir(cur)
+		if parent == cur {
+			return ""
+		}
+		cur = parent
+	}
+}
+
+// Load reads the manifest stored in dir. .pnp.data.json (written when pnpEnableInlining is off) is
+// preferred; otherwise the runtime state is extracted from .pnp.cjs.
+func Load(dir string) (*Manifest, error) {
+	if content, err := os.ReadFile(filepath.Join(dir, DataFileName)); err == nil {
+		return Parse(content, dir)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, RuntimeFileName))
+	if err != nil {
+		return nil, fmt.Errorf("no Yarn PnP manifest found in %s", dir)
+	}
+	data, err := ExtractRuntimeState(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Join(dir, RuntimeFileName), err)
+	}
+	return Parse(data, dir)
+}
+
+// ExtractRuntimeState returns the JSON document that Yarn inlines into .pnp.cjs as the
+// RAW_RUNTIME_STATE string literal.
+func ExtractRuntimeState(cjs []byte) ([]byte, error) {
+	src := string(cjs)
+	idx := strings.Index(src, "RAW_RUNTIME_STATE")
+	if idx < 0 {
+		return nil, errors.New("RAW_RUNTIME_STATE not found")
+	}
+	rest := src[idx:]
+	eq := strings.Index(rest, "=")
+	if eq < 0 {
+		return nil, errors.New("RAW_RUNTIME_STATE is not assigned")
+	}
+	rest = strings.TrimLeft(rest[eq+1:], " \t\r\n")
+	if rest == "" || (rest[0] != '\'' && rest[0] != '"' && rest[0] != '`') {
+		return nil, errors.New("RAW_RUNTIME_STATE is not a string literal")
+	}
+
+	quote := rest[0]
+	var b strings.Builder
+	for i := 1; i < len(rest); i++ {
+		c := rest[i]
+		if c == quote {
+			return []byte(b.String()), nil
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(rest) {
+			break
+		}
+		switch esc := rest[i]; esc {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '\n':
+			// Line continuation: Yarn splits the literal over several lines.
+		case '\r':
+			if i+1 < len(rest) && rest[i+1] == '\n' {
+				i++
+			}
+		case 'u':
+			if i+4 < len(rest) {
+				if r, err := strconv.ParseUint(rest[i+1:i+5], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte(esc)
+		default:
+			b.WriteByte(esc)
+		}
+	}
+	return nil, errors.New("unterminated RAW_RUNTIME_STATE string literal")
+}
+
+type rawManifest struct {
+	EnableTopLevelFallback *bool               `json:"enableTopLevelFallback"`
+	FallbackPool           [][2]*string        `json:"fallbackPool"`
+	PackageRegistryData    []json.RawMessage   `json:"packageRegistryData"`
+	DependencyTreeRoots    []map[string]string `json:"dependencyTreeRoots"`
+}
+
+type rawPackageInformation struct {
+	PackageLocation     string              `json:"packageLocation"`
+	PackageDependencies [][]json.RawMessage `json:"packageDependencies"`
+	LinkType            string              `json:"linkType"`
+}
+
+// Parse decodes manifest JSON. root is the directory the manifest was read from.
+func Parse(data []byte, root string) (*Manifest, error) {
+	var raw rawManifest
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse PnP manifest: %w", err)
+	}
+
+	m := &Manifest{
+		Root:           filepath.Clean(root),
+		byLocator:      map[Locator]*Package{},
+		fallbackPool:   map[string]Locator{},
+		topLevelLookup: raw.EnableTopLevelFallback == nil || *raw.EnableTopLevelFallback,
+	}
+
+	// packageRegistryData: [[name|null, [[reference|null, packageInformation], ...]], ...]
+	for _, entry := range raw.PackageRegistryData {
+		var pair []json.RawMessage
+		if err := json.Unmarshal(entry, &pair); err != nil || len(pair) != 2 {
+			return nil, errors.New("invalid packageRegistryData entry")
+		}
+		var name *string
+		if err := json.Unmarshal(pair[0], &name); err != nil {
+			return nil, errors.New("invalid package name in packageRegistryData")
+		}
+		var stores [][]json.RawMessage
+		if err := json.Unmarshal(pair[1], &stores); err != nil {
+			return nil, errors.New("invalid package store in packageRegistryData")
+		}
+
+		for _, store := range stores {
+			if len(store) != 2 {
+				return nil, errors.New("invalid package store entry in packageRegistryData")
+			}
+			var reference *string
+			var info rawPackageInformation
+			if err := json.Unmarshal(store[0], &reference); err != nil {
+				return nil, errors.New("invalid package reference in packageRegistryData")
+			}
+			if err := json.Unmarshal(store[1], &info); err != nil {
+				return nil, fmt.Errorf("invalid package information for %s: %w", derefString(name), err)
+			}
+
+			pkg := &Package{
+				Locator:      Locator{Name: derefString(name), Reference: derefString(reference)},
+				Location:     m.absoluteLocation(info.PackageLocation),
+				LinkType:     info.LinkType,
+				Dependencies: make(map[string]Locator, len(info.PackageDependencies)),
+			}
+			for _, dep := range info.PackageDependencies {
+				depName, target, ok := parseDependency(dep)
+				if ok {
+					pkg.Dependencies[depName] = target
+				}
+			}
+
+			m.Packages = append(m.Packages, pkg)
+			m.byLocator[pkg.Locator] = pkg
+		}
+	}
+
+	for _, entry := range raw.FallbackPool {
+		if entry[0] == nil || entry[1] == nil {
+			continue
+		}
+		m.fallbackPool[*entry[0]] = Locator{Name: *entry[0], Reference: *entry[1]}
+	}
+
+	return m, nil
+}
+
+// parseDependency decodes a single packageDependencies entry: [name, reference], where the
+// reference is a string, an [aliasName, reference] pair or null for an unmet peer dependency.
+func parseDependency(dep []json.RawMessage) (string, Locator, bool) {
+	if len(dep) != 2 {
+		return "", Locator{}, false
+	}
+	var name string
+	if err := json.Unmarshal(dep[0], &name); err != nil || name == "" {
+		return "", Locator{}, false
+	}
+
+	var reference *string
+	if err := json.Unmarshal(dep[1], &reference); err == nil {
+		if reference == nil {
+			return "", Locator{}, false
+		}
+		return name, Locator{Name: name, Reference: *reference}, true
+	}
+
+	var alias []string
+	if err := json.Unmarshal(dep[1], &alias); err == nil && len(alias) == 2 {
+		return name, Locator{Name: alias[0], Reference: alias[1]}, true
+	}
+	return "", Locator{}, false
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// absoluteLocation turns a manifest packageLocation into an absolute OS-native path, mapping Yarn
+// virtual paths (.yarn/__virtual__/<hash>/<depth>/<subpath>) onto the real package directory.
+func (m *Manifest) absoluteLocation(location string) string {
+	location = filepath.ToSlash(location)
+	if idx := strings.Index(location, "__virtual__/"); idx >= 0 {
+		base := strings.TrimSuffix(location[:idx], "/")
+		parts := strings.SplitN(location[idx+len("__virtual__/"):], "/", 3)
+		if len(parts) == 3 {
+			if depth, err := strconv.Atoi(parts[1]); err == nil {
+				for i := 0; i < depth; i++ {
+					base += "/.."
+				}
+				location = base + "/" + parts[2]
+			}
+		}
+	}
+	return filepath.Join(m.Root, filepath.FromSlash(location))
+}
+
+// FindPackage returns the package registered under locator, or nil.
+func (m *Manifest) FindPackage(locator Locator) *Package {
+	return m.byLocator[locator]
+}
+
+// FindPackageForPath returns the package whose location is the closest parent of path, mirroring
+// how the PnP runtime picks the issuer of a require call. Returns nil when no package owns path.
+func (m *Manifest) FindPackageForPath(path string) *Package {
+	path = filepath.Clean(path)
+	var best *Package
+	for _, pkg := range m.Packages {
+		if path != pkg.Location && !strings.HasPrefix(path, pkg.Location+string(filepath.Separator)) {
+			continue
+		}
+		if best == nil || len(pkg.Location) > len(best.Location) {
+			best = pkg
+		}
+	}
+	return best
+}
+
+// ResolveDependency returns the package that name resolves to when required from issuer: first
+// the issuer's own dependencies, then (if enabled) the top-level dependencies, then the fallback
+// pool. Returns nil when the dependency cannot be resolved.
+func (m *Manifest) ResolveDependency(issuer *Package, name string) *Package {
+	if issuer != nil {
+		if locator, ok := issuer.Dependencies[name]; ok {
+			return m.byLocator[locator]
+		}
+	}
+	if m.topLevelLookup {
+		if topLevel := m.byLocator[Locator{}]; topLevel != nil && topLevel != issuer {
+			if locator, ok := topLevel.Dependencies[name]; ok {
+				return m.byLocator[locator]
+			}
+		}
+	}
+	if locator, ok := m.fallbackPool[name]; ok {
+		return m.byLocator[locator]
+	}
+	return nil
+}
+
+// InstalledPackages returns the third-party packages of the manifest, one per real location, in a
+// stable order. Workspaces and the top-level locator are skipped, and virtual instances of the same
+// package collapse into one entry.
+func (m *Manifest) InstalledPackages() []*Package {
+	seen := map[string]bool{}
+	result := []*Package{}
+	for _, pkg := range m.Packages {
+		if pkg.IsWorkspace() || seen[pkg.Location] {
+			continue
+		}
+		seen[pkg.Location] = true
+		result = append(result, pkg)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Location < result[j].Location
+	})
+	return result
+}
@@ -0,0 +1,152 @@
+package pnp
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rev-dep-go/internal/testutil"
+)
+
+func loadFixtureManifest(t *testing.T, fixture string) *Manifest {
+	t.Helper()
+	dir, err := testutil.FixturePath(fixture)
+	if err != nil {
+		t.Fatalf("FixturePath: %v", err)
+	}
+	manifest, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load(%s): %v", fixture, err)
+	}
+	return manifest
+}
+
+func TestLoad_RuntimeStateAndDataFileAgree(t *testing.T) {
+	fromCjs := loadFixtureManifest(t, "yarnPnP")
+	fromData := loadFixtureManifest(t, "yarnPnPDataFile")
+
+	if len(fromCjs.Packages) != 9 {
+		t.Fatalf("expected 9 registry entries from .pnp.cjs, got %d", len(fromCjs.Packages))
+	}
+	if len(fromCjs.Packages) != len(fromData.Packages) {
+		t.Fatalf("expected .pnp.cjs and .pnp.data.json to have the same entries, got %d and %d", len(fromCjs.Packages), len(fromData.Packages))
+	}
+	for i, pkg := range fromCjs.Packages {
+		other := fromData.Packages[i]
+		if pkg.Locator != other.Locator {
+			t.Errorf("entry %d: locator %+v != %+v", i, pkg.Locator, other.Locator)
+		}
+		relCjs, _ := filepath.Rel(fromCjs.Root, pkg.Location)
+		relData, _ := filepath.Rel(fromData.Root, other.Location)
+		if relCjs != relData {
+			t.Errorf("entry %d: location %s != %s", i, relCjs, relData)
+		}
+	}
+}
+
+func TestExtractRuntimeState_Errors(t *testing.T) {
+	cases := map[string]string{
+		"missing":      "module.exports = {};",
+		"not a string": "const RAW_RUNTIME_STATE = {};",
+		"unterminated": "const RAW_RUNTIME_STATE = '{\"a\":1}",
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ExtractRuntimeState([]byte(src)); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestManifest_ResolveDependency(t *testing.T) {
+	manifest := loadFixtureManifest(t, "yarnPnP")
+
+	issuer := manifest.FindPackageForPath(filepath.Join(manifest.Root, "src", "index.ts"))
+	if issuer == nil || !issuer.IsWorkspace() {
+		t.Fatalf("expected src/index.ts to belong to a workspace, got %+v", issuer)
+	}
+
+	lodash := manifest.ResolveDependency(issuer, "lodash")
+	if lodash == nil {
+		t.Fatalf("expected lodash to resolve")
+	}
+	if !IsZipPath(lodash.Location) || !strings.HasSuffix(filepath.ToSlash(lodash.Location), ".zip/node_modules/lodash") {
+		t.Errorf("expected lodash to live in a cache archive, got %s", lodash.Location)
+	}
+
+	if missing := manifest.ResolveDependency(issuer, "not-installed"); missing != nil {
+		t.Errorf("expected unknown dependency not to resolve, got %+v", missing)
+	}
+
+	// eslint depends on a virtual instance of debug; it must map to the real archive location.
+	eslint := manifest.ResolveDependency(issuer, "eslint")
+	debug := manifest.ResolveDependency(eslint, "debug")
+	if debug == nil {
+		t.Fatalf("expected debug to resolve from eslint")
+	}
+	if strings.Contains(debug.Location, "__virtual__") {
+		t.Errorf("expected virtual path to be mapped to the real location, got %s", debug.Location)
+	}
+	real := manifest.FindPackage(Locator{Name: "debug", Reference: "npm:4.3.4"})
+	if real == nil || real.Location != debug.Location {
+		t.Errorf("expected virtual and real debug to share a location, got %+v and %+v", debug, real)
+	}
+	if _, hasPeer := debug.Dependencies["supports-color"]; hasPeer {
+		t.Errorf("expected unmet peer dependency to be omitted")
+	}
+
+	// Files inside a package archive are owned by that package.
+	owner := manifest.FindPackageForPath(filepath.Join(lodash.Location, "lodash.js"))
+	if owner == nil || owner.Name != "lodash" {
+		t.Errorf("expected lodash.js to be owned by lodash, got %+v", owner)
+	}
+}
+
+func TestManifest_InstalledPackages(t *testing.T) {
+	manifest := loadFixtureManifest(t, "yarnPnP")
+
+	names := []string{}
+	for _, pkg := range manifest.InstalledPackages() {
+		names = append(names, pkg.Name)
+	}
+	expected := "@repo/tsconfig,debug,esbuild,eslint,left-pad,lodash"
+	if got := strings.Join(names, ","); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestReadFileAndDirSize(t *testing.T) {
+	manifest := loadFixtureManifest(t, "yarnPnP")
+
+	leftPad := manifest.FindPackage(Locator{Name: "left-pad", Reference: "npm:1.3.0"})
+	content, err := ReadFile(filepath.Join(leftPad.Location, "package.json"))
+	if err != nil {
+		t.Fatalf("ReadFile from archive: %v", err)
+	}
+	if !strings.Contains(string(content), `"version": "1.3.0"`) {
+		t.Errorf("unexpected package.json content: %s", content)
+	}
+	if _, err := ReadFile(leftPad.Location); err == nil {
+		t.Errorf("expected reading a directory inside an archive to fail")
+	}
+	if !IsFile(filepath.Join(leftPad.Location, "index.js")) || IsFile(filepath.Join(leftPad.Location, "missing.js")) {
+		t.Errorf("unexpected IsFile result inside archive")
+	}
+
+	size, err := DirSize(leftPad.Location)
+	if err != nil {
+		t.Fatalf("DirSize: %v", err)
+	}
+	if size != int64(len(content))+int64(len("module.exports = function leftPad() {};\n")) {
+		t.Errorf("unexpected archive package size %d", size)
+	}
+
+	esbuild := manifest.FindPackage(Locator{Name: "esbuild", Reference: "npm:0.19.0"})
+	if IsZipPath(esbuild.Location) {
+		t.Fatalf("expected unplugged esbuild to live on disk, got %s", esbuild.Location)
+	}
+	if _, err := ReadFile(filepath.Join(esbuild.Location, "package.json")); err != nil {
+		t.Errorf("ReadFile from unplugged dir: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package pnp
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// splitZipPath splits a path that points inside a zip archive into the archive path and the
+// slash-separated path of the entry within it. ok is false for regular filesystem paths.
+func splitZipPath(path string) (archive string, inner string, ok bool) {
+	slashed := filepath.ToSlash(path)
+	idx := strings.Index(slashed, ".zip/")
+	if idx < 0 {
+		if strings.HasSuffix(slashed, ".zip") {
+			return path, "", true
+		}
+		return "", "", false
+	}
+	return filepath.FromSlash(slashed[:idx+len(".zip")]), strings.Trim(slashed[idx+len(".zip/"):], "/"), true
+}
+
+// IsZipPath reports whether path points inside a zip archive.
+func IsZipPath(path string) bool {
+	_, _, ok := splitZipPath(path)
+	return ok
+}
+
+// ReadFile reads a file from disk or, for paths pointing inside a zip archive, from the archive.
+func ReadFile(path string) ([]byte, error) {
+	archive, inner, ok := splitZipPath(path)
+	if !ok {
+		return os.ReadFile(path)
+	}
+
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	file, err := reader.Open(inner)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, errors.New("is a directory: " + path)
+	}
+	return io.ReadAll(file)
+}
+
+// IsFile reports whether path is a regular file, looking inside zip archives when needed.
+func IsFile(path string) bool {
+	archive, inner, ok := splitZipPath(path)
+	if !ok {
+		info, err := os.Stat(path)
+		return err == nil && !info.IsDir()
+	}
+
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return false
+	}
+	defer reader.Close()
+
+	info, err := fs.Stat(reader, inner)
+	return err == nil && !info.IsDir()
+}
+
+// DirSize returns the total uncompressed size of the files under dir, looking inside zip archives
+// when needed. Symlinks are not followed.
+func DirSize(dir string) (int64, error) {
+	archive, inner, ok := splitZipPath(dir)
+	if !ok {
+		var total int64
+		err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.Type()&os.ModeSymlink != 0 || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+			return nil
+		})
+		return total, err
+	}
+
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	prefix := ""
+	if inner != "" {
+		prefix = inner + "/"
+	}
+	var total int64
+	for _, file := range reader.File {
+		if strings.HasPrefix(file.Name, prefix) && !file.FileInfo().IsDir() {
+			total += int64(file.UncompressedSize64)
+		}
+	}
+	return total, nil
+}
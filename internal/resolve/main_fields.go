@@ -0,0 +1,171 @@
+package resolve
+
+import (
+	"path/filepath"
+	"strings"
+
+	"rev-dep-go/internal/pathutil"
+)
+
+// aliasFieldNames are the package.json fields whose object form remaps modules and files of the
+// package, as implemented by bundlers (https://github.com/defunctzombie/package-browser-field-spec).
+var aliasFieldNames = map[string]bool{
+	"browser":      true,
+	"react-native": true,
+}
+
+// aliasFieldReplacement is a single entry of a "browser" / "react-native" object map. Exactly one
+// of Empty, Path and Module is set: Empty for `false` (the module is replaced by an empty module),
+// Path for an absolute, internal-form path inside the package and Module for a bare module name.
+type aliasFieldReplacement struct {
+	Empty  bool
+	Path   string
+	Module string
+}
+
+// mainFieldsInfo is what the configured mainFields select from one package.json.
+type mainFieldsInfo struct {
+	// entry is the package entry point relative to the package root, taken from the first listed
+	// field with a string value. Empty when none of the fields is set.
+	entry string
+	// files maps absolute, internal-form file paths of the package (with and without extension)
+	// to their replacement.
+	files map[string]aliasFieldReplacement
+	// modules maps bare module requests made from inside the package to their replacement.
+	modules map[string]aliasFieldReplacement
+}
+
+// newMainFieldsInfo reads the listed mainFields from a parsed package.json. Object values are only
+// honoured for "browser" and "react-native"; when several are listed, the earlier field wins per key.
+func newMainFieldsInfo(pkgRoot string, pkgJson map[string]interface{}, mainFields []string) *mainFieldsInfo {
+	info := &mainFieldsInfo{
+		files:   map[string]aliasFieldReplacement{},
+		modules: map[string]aliasFieldReplacement{},
+	}
+
+	for _, field := range mainFields {
+		switch value := pkgJson[field].(type) {
+		case string:
+			if info.entry == "" && value != "" {
+				info.entry = value
+			}
+		case map[string]interface{}:
+			if !aliasFieldNames[field] {
+				continue
+			}
+			for key, target := range value {
+				replacement, ok := parseAliasFieldTarget(pkgRoot, target)
+				if !ok {
+					continue
+				}
+				if isRelativeRequest(key) {
+					if replacement.Module != "" {
+						// Replacing a file with a third-party module is not supported.
+						continue
+					}
+					keyPath := pathutil.NormalizePathForInternal(filepath.Join(pkgRoot, key))
+					for _, k := range []string{keyPath, trimFileExtension(keyPath)} {
+						if _, exists := info.files[k]; !exists {
+							info.files[k] = replacement
+						}
+					}
+					continue
+				}
+				if _, exists := info.modules[key]; !exists {
+					info.modules[key] = replacement
+				}
+			}
+		}
+	}
+
+	return info
+}
+
+func parseAliasFieldTarget(pkgRoot string, target interface{}) (aliasFieldReplacement, bool) {
+	switch value := target.(type) {
+	case bool:
+		if value {
+			return aliasFieldReplacement{}, false
+		}
+		return aliasFieldReplacement{Empty: true}, true
+	case string:
+		if value == "" {
+			return aliasFieldReplacement{}, false
+		}
+		if isRelativeRequest(value) {
+			return aliasFieldReplacement{Path: pathutil.NormalizePathForInternal(filepath.Join(pkgRoot, value))}, true
+		}
+		return aliasFieldReplacement{Module: value}, true
+	}
+	return aliasFieldReplacement{}, false
+}
+
+func isRelativeRequest(request string) bool {
+	return strings.HasPrefix(request, "./") || strings.HasPrefix(request, "../") || request == "." || request == ".."
+}
+
+func trimFileExtension(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path))
+}
+
+// replaceFile returns the replacement for a resolved file of the package, if it is mapped.
+func (info *mainFieldsInfo) replaceFile(path string) (aliasFieldReplacement, bool) {
+	if info == nil || len(info.files) == 0 {
+		return aliasFieldReplacement{}, false
+	}
+	if replacement, ok := info.files[path]; ok {
+		return replacement, true
+	}
+	replacement, ok := info.files[trimFileExtension(path)]
+	return replacement, ok
+}
+
+// replaceModule returns the replacement for a bare module request made from the package.
+func (info *mainFieldsInfo) replaceModule(request string) (aliasFieldReplacement, bool) {
+	if info == nil || len(info.modules) == 0 {
+		return aliasFieldReplacement{}, false
+	}
+	replacement, ok := info.modules[request]
+	return replacement, ok
+}
+
+// mainFieldsForPackage returns the mainFields view of a workspace package, or nil when mainFields
+// are not configured. Results are cached per package root.
+func (rm *ResolverManager) mainFieldsForPackage(pkgPath string) *mainFieldsInfo {
+	if len(rm.rootParams.MainFields) == 0 || rm.monorepoContext == nil {
+		return nil
+	}
+
+	rm.mainFieldsMu.RLock()
+	info, ok := rm.mainFieldsByPackage[pkgPath]
+	rm.mainFieldsMu.RUnlock()
+	if ok {
+		return info
+	}
+
+	config, err := rm.monorepoContext.GetPackageConfig(pkgPath)
+	if err == nil {
+		info = newMainFieldsInfo(pkgPath, config.Fields, rm.rootParams.MainFields)
+	}
+
+	rm.mainFieldsMu.Lock()
+	rm.mainFieldsByPackage[pkgPath] = info
+	rm.mainFieldsMu.Unlock()
+	return info
+}
+
+// applyFileReplacement swaps a resolved file of a package for its "browser" / "react-native"
+// replacement. A file mapped to false resolves to itself, typed ExcludedByUser.
+func (f *ModuleResolver) applyFileReplacement(info *mainFieldsInfo, path string, rtype ResolvedImportType) (string, ResolvedImportType, *ResolutionError) {
+	replacement, ok := info.replaceFile(path)
+	if !ok {
+		return path, rtype, nil
+	}
+	if replacement.Empty {
+		f.trace.add(TraceStrategyMainFields, "matched", path+" is mapped to false (empty module)")
+		return path, ExcludedByUser, nil
+	}
+	f.trace.add(TraceStrategyMainFields, "matched", path+" is mapped to "+replacement.Path)
+	resolved, err := f.getModulePathWithExtension(replacement.Path)
+	return resolved, rtype, err
+}
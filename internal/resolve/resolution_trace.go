@@ -0,0 +1,177 @@
+package resolve
+
+import (
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/fs"
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/pathutil"
+)
+
+// Resolution strategies reported in a ResolutionTrace, in the order ResolveModule tries them.
+const (
+	TraceStrategyBuiltIn           = "built-in module"
+	TraceStrategyMainFields        = "main fields"
+	TraceStrategyRelative          = "relative path"
+	TraceStrategyPackageJsonImport = "package.json imports"
+	TraceStrategyWorkspacePackage  = "workspace package"
+	TraceStrategyExports           = "exports conditions"
+	TraceStrategyTsAlias           = "tsconfig alias"
+	TraceStrategyExtensionProbing  = "extension probing"
+	TraceStrategyFileSystem        = "file system lookup"
+	TraceStrategyNodeModule        = "node module"
+)
+
+// ResolutionTraceStep is a single strategy attempt recorded while resolving a request.
+type ResolutionTraceStep struct {
+	Strategy string `json:"strategy"`
+	// Outcome is a short verdict: "matched", "no match", "skipped", "resolved" or "not found".
+	Outcome string `json:"outcome"`
+	Detail  string `json:"detail,omitempty"`
+	// Candidates lists the paths tried, in order. Extension probing candidates are lookup keys
+	// without an extension: each one matches any supported source extension.
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// ResolutionTrace explains how a single import request of a file was resolved.
+type ResolutionTrace struct {
+	FilePath        string                `json:"filePath"`
+	Request         string                `json:"request"`
+	ResolverRoot    string                `json:"resolverRoot"`
+	PackageJsonPath string                `json:"packageJsonPath,omitempty"`
+	Steps           []ResolutionTraceStep `json:"steps"`
+	ResolvedPath    string                `json:"resolvedPath,omitempty"`
+	ResolvedType    ResolvedImportType    `json:"resolvedType"`
+	Error           *ResolutionError      `json:"error,omitempty"`
+}
+
+func (t *ResolutionTrace) add(strategy, outcome, detail string, candidates ...string) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, ResolutionTraceStep{Strategy: strategy, Outcome: outcome, Detail: detail, Candidates: candidates})
+}
+
+// ResolutionErrorToString returns the name of a resolution error.
+func ResolutionErrorToString(err ResolutionError) string {
+	switch err {
+	case AliasNotResolved:
+		return "AliasNotResolved"
+	case FileNotFound:
+		return "FileNotFound"
+	default:
+		return "Unknown"
+	}
+}
+
+// TraceResolution resolves request as imported from filePath and records every strategy tried
+// along the way. It classifies the request the way the dependency tree does: built-in modules first,
+// then ResolveModule, then a lookup in the node modules of the package selected by strategy.
+//
+// Tracing sets per-resolver state, so TraceResolution must not run concurrently with other
+// resolutions on the same manager.
+func (rm *ResolverManager) TraceResolution(filePath string, request string, strategy NodeModulesMatchingStrategy) *ResolutionTrace {
+	filePath = pathutil.NormalizePathForInternal(filePath)
+	resolver := rm.GetResolverForFile(filePath)
+
+	trace := &ResolutionTrace{
+		FilePath:        filePath,
+		Request:         request,
+		ResolverRoot:    resolver.resolverRoot,
+		PackageJsonPath: resolver.packageJsonPath,
+		Steps:           []ResolutionTraceStep{},
+	}
+
+	effectiveRequest := request
+	replacedWithFile := false
+	if replacement, ok := resolver.mainFields.replaceModule(request); ok {
+		if replacement.Empty {
+			trace.add(TraceStrategyMainFields, "matched", request+" is mapped to false (empty module)")
+			trace.ResolvedPath = request
+			trace.ResolvedType = ExcludedByUser
+			return trace
+		}
+		replacedWithFile = replacement.Path != ""
+		if replacement.Module != "" {
+			effectiveRequest = replacement.Module
+		}
+	}
+
+	moduleName := module.GetNodeModuleName(effectiveRequest)
+	if _, isBuiltIn := module.BuiltInModules[moduleName]; isBuiltIn && !replacedWithFile {
+		trace.add(TraceStrategyBuiltIn, "matched", moduleName+" is a Node.js built-in module")
+		trace.ResolvedPath = moduleName
+		trace.ResolvedType = BuiltInModule
+		return trace
+	}
+	trace.add(TraceStrategyBuiltIn, "no match", "")
+
+	resolver.trace = trace
+	path, rtype, err := resolver.ResolveModule(request, filePath)
+	resolver.trace = nil
+
+	trace.ResolvedPath = path
+	trace.ResolvedType = rtype
+	trace.Error = err
+
+	if err == nil || replacedWithFile {
+		return trace
+	}
+
+	if *err == FileNotFound {
+		// The dependency tree retries files missing from the discovered files index (outside cwd or
+		// ignored) directly on disk.
+		if missingFilePath := fs.GetMissingFile(path, resolver.tsConfigParsed.ModuleSuffixes); missingFilePath != "" {
+			trace.add(TraceStrategyFileSystem, "resolved", missingFilePath+" exists outside of the analysed files", path)
+			trace.ResolvedPath = missingFilePath
+			trace.Error = nil
+			return trace
+		}
+		trace.add(TraceStrategyFileSystem, "not found", "", path)
+	}
+
+	if isRelativeRequest(request) {
+		return trace
+	}
+
+	nodeModules := resolver.nodeModules
+	source := resolver.packageJsonPath
+	switch strategy {
+	case NodeModulesMatchingStrategyRootResolver:
+		nodeModules = rm.rootResolver.nodeModules
+		source = rm.rootResolver.packageJsonPath
+	case NodeModulesMatchingStrategyCwdResolver:
+		nodeModules = rm.cwdResolver.nodeModules
+		source = rm.cwdResolver.packageJsonPath
+	}
+
+	if path != request {
+		if localModuleName := module.GetNodeModuleName(path); nodeModules[localModuleName] {
+			moduleName = localModuleName
+		}
+	}
+
+	if !nodeModules[moduleName] {
+		trace.add(TraceStrategyNodeModule, "no match", moduleName+" is not a dependency in "+source)
+		return trace
+	}
+	if rm.followMonorepoPackages.ShouldFollowPackage(moduleName) && rm.monorepoContext != nil {
+		if _, isWorkspace := rm.monorepoContext.PackageToPath[moduleName]; isWorkspace {
+			trace.add(TraceStrategyNodeModule, "skipped", moduleName+" is a followed workspace package")
+			return trace
+		}
+	}
+
+	trace.add(TraceStrategyNodeModule, "matched", moduleName+" is a dependency in "+source)
+	trace.ResolvedPath = moduleName
+	trace.ResolvedType = NodeModule
+	trace.Error = nil
+	return trace
+}
+
+// formatConditionNames lists the conditions tried for exports / imports maps; "default" is
+// always tried last.
+func formatConditionNames(conditionNames []string) string {
+	return "[" + strings.Join(append(slices.Clone(conditionNames), "default"), ", ") + "]"
+}
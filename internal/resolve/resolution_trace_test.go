@@ -0,0 +1,91 @@
+package resolve
+
+import (
+	"path/filepath"
+	"testing"
+
+	"rev-dep-go/internal/fs"
+	"rev-dep-go/internal/model"
+)
+
+func traceStrategies(trace *ResolutionTrace) []string {
+	strategies := make([]string, 0, len(trace.Steps))
+	for _, step := range trace.Steps {
+		strategies = append(strategies, step.Strategy+": "+step.Outcome)
+	}
+	return strategies
+}
+
+func TestTraceResolution_WorkspacePackageWithMainFields(t *testing.T) {
+	cwd := filepath.Join(repoRoot(t), "__fixtures__", "mainFieldsMonorepo") + string(filepath.Separator)
+	files := fs.GetFiles(cwd, []string{}, nil, nil)
+
+	manager := NewResolverManagerForCwd(cwd, "", "", nil, []string{"browser", "main"}, model.FollowMonorepoPackagesValue{FollowAll: true}, files, nil, nil)
+
+	trace := manager.TraceResolution(filepath.Join(cwd, "packages", "app", "src", "index.ts"), "web-lib", model.NodeModulesMatchingStrategySelfResolver)
+
+	expected := []string{
+		"built-in module: no match",
+		"package.json imports: skipped",
+		"workspace package: matched",
+		"exports conditions: skipped",
+		"workspace package: matched",
+		"extension probing: resolved",
+		"main fields: matched",
+		"extension probing: resolved",
+	}
+	got := traceStrategies(trace)
+	if len(got) != len(expected) {
+		t.Fatalf("expected steps %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("step %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+
+	if toRepoRelativePath(t, trace.ResolvedPath) != "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/browser.ts" {
+		t.Errorf("expected browser entry, got %s", trace.ResolvedPath)
+	}
+	if trace.ResolvedType != MonorepoModule || trace.Error != nil {
+		t.Errorf("expected MonorepoModule without error, got %s / %v", model.ResolvedImportTypeToString(trace.ResolvedType), trace.Error)
+	}
+	if toRepoRelativePath(t, trace.ResolverRoot) != "__fixtures__/mainFieldsMonorepo/packages/app" {
+		t.Errorf("expected the app package resolver, got %s", trace.ResolverRoot)
+	}
+}
+
+func TestTraceResolution_EmptyModuleMapping(t *testing.T) {
+	cwd := filepath.Join(repoRoot(t), "__fixtures__", "mainFieldsMonorepo") + string(filepath.Separator)
+	files := fs.GetFiles(cwd, []string{}, nil, nil)
+
+	manager := NewResolverManagerForCwd(cwd, "", "", nil, []string{"browser", "main"}, model.FollowMonorepoPackagesValue{FollowAll: true}, files, nil, nil)
+
+	trace := manager.TraceResolution(filepath.Join(cwd, "packages", "web-lib", "src", "browser.ts"), "fs", model.NodeModulesMatchingStrategySelfResolver)
+
+	if len(trace.Steps) != 1 || trace.Steps[0].Strategy != TraceStrategyMainFields {
+		t.Fatalf("expected a single main fields step, got %v", traceStrategies(trace))
+	}
+	if trace.ResolvedType != ExcludedByUser {
+		t.Errorf("expected ExcludedByUser, got %s", model.ResolvedImportTypeToString(trace.ResolvedType))
+	}
+}
+
+func TestTraceResolution_ExportsConditionsOfResolver(t *testing.T) {
+	cwd := filepath.Join(repoRoot(t), "__fixtures__", "mockMonorepo") + string(filepath.Separator)
+	files := fs.GetFiles(cwd, []string{}, nil, nil)
+
+	manager := NewResolverManagerForCwd(cwd, "", "", []string{"development"}, nil, model.FollowMonorepoPackagesValue{FollowAll: true}, files, nil, nil)
+
+	trace := manager.TraceResolution(filepath.Join(cwd, "packages", "consumer-package", "index.ts"), "exported-package", model.NodeModulesMatchingStrategySelfResolver)
+
+	for _, step := range trace.Steps {
+		if step.Strategy == TraceStrategyExports {
+			if step.Detail != "subpath . with conditions [development, default]" {
+				t.Errorf("expected the conditions used to resolve exports, got %q", step.Detail)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected an exports step, got %v", traceStrategies(trace))
+}
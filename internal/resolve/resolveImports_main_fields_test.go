@@ -0,0 +1,129 @@
+package resolve
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"rev-dep-go/internal/fs"
+	"rev-dep-go/internal/model"
+	"rev-dep-go/internal/parser"
+)
+
+func resolveMainFieldsFixture(t *testing.T, mainFields []string) model.MinimalDependencyTree {
+	t.Helper()
+	cwd := filepath.Join(repoRoot(t), "__fixtures__", "mainFieldsMonorepo") + string(filepath.Separator)
+
+	files := fs.GetFiles(cwd, []string{}, nil, nil)
+	slices.Sort(files)
+	fileImportsArr, _ := parser.ParseImportsFromFiles(files, true, model.ParseModeBasic)
+
+	fileImportsArr, _, _ = ResolveImports(fileImportsArr, files, cwd, true, false, "", "", nil, nil, nil, mainFields, model.FollowMonorepoPackagesValue{FollowAll: true}, nil, nil, model.ParseModeBasic, model.NodeModulesMatchingStrategySelfResolver)
+
+	return normalizeTreeRelative(t, model.TransformToMinimalDependencyTreeCustomParser(fileImportsArr))
+}
+
+func assertResolvedImport(t *testing.T, tree model.MinimalDependencyTree, file string, request string, expectedID string, expectedType ResolvedImportType) {
+	t.Helper()
+	imp := findImportByRequest(tree[file], request)
+	if imp == nil {
+		t.Fatalf("import %q not found in %s", request, file)
+	}
+	if imp.ID != expectedID {
+		t.Errorf("import %q in %s: expected ID %q, got %q", request, file, expectedID, imp.ID)
+	}
+	if imp.ResolvedType != expectedType {
+		t.Errorf("import %q in %s: expected type %s, got %s", request, file, model.ResolvedImportTypeToString(expectedType), model.ResolvedImportTypeToString(imp.ResolvedType))
+	}
+}
+
+func TestMainFields_DefaultKeepsModuleMainFallback(t *testing.T) {
+	tree := resolveMainFieldsFixture(t, nil)
+
+	app := "__fixtures__/mainFieldsMonorepo/packages/app/src/index.ts"
+	assertResolvedImport(t, tree, app, "web-lib", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/module.ts", MonorepoModule)
+	assertResolvedImport(t, tree, app, "native-lib", "__fixtures__/mainFieldsMonorepo/packages/native-lib/src/index.ts", MonorepoModule)
+
+	browser := "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/browser.ts"
+	assertResolvedImport(t, tree, browser, "./server", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/server.ts", UserModule)
+	assertResolvedImport(t, tree, browser, "fs", "fs", BuiltInModule)
+}
+
+func TestMainFields_BrowserFieldReplacesWorkspaceEntryAndFiles(t *testing.T) {
+	tree := resolveMainFieldsFixture(t, []string{"browser", "main"})
+
+	app := "__fixtures__/mainFieldsMonorepo/packages/app/src/index.ts"
+	// "browser" is an object, so "main" picks the entry, which the browser map then replaces.
+	assertResolvedImport(t, tree, app, "web-lib", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/browser.ts", MonorepoModule)
+	assertResolvedImport(t, tree, app, "native-lib", "__fixtures__/mainFieldsMonorepo/packages/native-lib/src/index.ts", MonorepoModule)
+
+	browser := "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/browser.ts"
+	assertResolvedImport(t, tree, browser, "./server", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/client.ts", UserModule)
+	assertResolvedImport(t, tree, browser, "./legacy", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/legacy.ts", ExcludedByUser)
+	assertResolvedImport(t, tree, browser, "fs", "fs", ExcludedByUser)
+	assertResolvedImport(t, tree, browser, "crypto", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/crypto-shim.ts", UserModule)
+}
+
+func TestMainFields_SelfResolutionHonoursBrowserField(t *testing.T) {
+	self := "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/self.ts"
+
+	tree := resolveMainFieldsFixture(t, nil)
+	assertResolvedImport(t, tree, self, "web-lib", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/module.ts", MonorepoModule)
+	assertResolvedImport(t, tree, self, "web-lib/src/server", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/server.ts", MonorepoModule)
+	assertResolvedImport(t, tree, self, "web-lib/src/legacy", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/legacy.ts", MonorepoModule)
+
+	tree = resolveMainFieldsFixture(t, []string{"browser", "main"})
+	assertResolvedImport(t, tree, self, "web-lib", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/browser.ts", MonorepoModule)
+	assertResolvedImport(t, tree, self, "web-lib/src/server", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/client.ts", MonorepoModule)
+	assertResolvedImport(t, tree, self, "web-lib/src/legacy", "__fixtures__/mainFieldsMonorepo/packages/web-lib/src/legacy.ts", ExcludedByUser)
+}
+
+func TestMainFields_StringFieldSelectsEntry(t *testing.T) {
+	tree := resolveMainFieldsFixture(t, []string{"react-native", "browser", "main"})
+
+	app := "__fixtures__/mainFieldsMonorepo/packages/app/src/index.ts"
+	assertResolvedImport(t, tree, app, "native-lib", "__fixtures__/mainFieldsMonorepo/packages/native-lib/src/native.ts", MonorepoModule)
+}
+
+func TestMainFields_ParseAliasFieldMap(t *testing.T) {
+	pkgJson := map[string]interface{}{
+		"main": "./index.js",
+		"browser": map[string]interface{}{
+			"./server.js": "./client.js",
+			"fs":          false,
+			"lodash":      "lodash-es",
+			"./ignored":   true,
+		},
+		"react-native": map[string]interface{}{
+			"fs":   "./fs-native.js",
+			"path": false,
+		},
+		"module": map[string]interface{}{
+			"not-an-alias-field": false,
+		},
+	}
+
+	info := newMainFieldsInfo("/pkg", pkgJson, []string{"browser", "react-native", "module", "main"})
+
+	if info.entry != "./index.js" {
+		t.Errorf("expected entry ./index.js, got %q", info.entry)
+	}
+	if r, ok := info.replaceFile("/pkg/server.ts"); !ok || r.Path != "/pkg/client.js" {
+		t.Errorf("expected server.ts to map to client.js ignoring extension, got %+v (ok=%v)", r, ok)
+	}
+	if _, ok := info.replaceFile("/pkg/ignored.js"); ok {
+		t.Errorf("expected `true` targets to be ignored")
+	}
+	if r, ok := info.replaceModule("fs"); !ok || !r.Empty {
+		t.Errorf("expected earlier field to win for fs, got %+v (ok=%v)", r, ok)
+	}
+	if r, ok := info.replaceModule("lodash"); !ok || r.Module != "lodash-es" {
+		t.Errorf("expected lodash to map to lodash-es, got %+v (ok=%v)", r, ok)
+	}
+	if r, ok := info.replaceModule("path"); !ok || !r.Empty {
+		t.Errorf("expected react-native map to apply for path, got %+v (ok=%v)", r, ok)
+	}
+	if _, ok := info.replaceModule("not-an-alias-field"); ok {
+		t.Errorf("expected object values of non alias fields to be ignored")
+	}
+}
@@ -71,6 +71,12 @@ type ModuleResolver struct {
 	nodeModules     map[string]bool
 	devNodeModules  map[string]bool
 	packageJsonPath string
+	// mainFields is the mainFields view of the resolver's own package.json, nil unless
+	// RootParams.MainFields is set.
+	mainFields *mainFieldsInfo
+	// trace records the strategies tried by ResolveModule. It is only set by
+	// ResolverManager.TraceResolution and is nil during regular, concurrent resolution.
+	trace *ResolutionTrace
 }
 
 // cachedAlias returns a previously resolved alias for request, if one was recorded.
@@ -135,6 +141,10 @@ type ResolverManager struct {
 	// through lookupFileExtension / AddFilePathToFilesAndExtensions.
 	filesAndExtensionsMu sync.RWMutex
 	filesAndExtensions   *map[string]string
+	// mainFieldsByPackage caches the mainFields view of workspace packages resolved into. It is
+	// filled lazily from the resolution goroutines; access it only through mainFieldsForPackage.
+	mainFieldsMu        sync.RWMutex
+	mainFieldsByPackage map[string]*mainFieldsInfo
 }
 
 // lookupFileExtension returns the recorded extension for an extension-less module path.
@@ -157,6 +167,11 @@ type RootParams struct {
 	// paths by the caller) so that setups with package subdirectories but no workspace-aware
 	// root manifest still resolve per-package node_modules dependencies.
 	ExplicitPackageDirs []string
+	// MainFields opts into bundler-style package entry selection: the listed package.json
+	// fields are tried in order for a package entry point, and the object form of "browser" /
+	// "react-native" (when listed) replaces or empties modules. Empty keeps the default
+	// module -> main fallback and ignores those maps.
+	MainFields []string
 }
 
 func NewResolverManager(followMonorepoPackages FollowMonorepoPackagesValue, conditionNames []string, rootParams RootParams, excludeFilePatterns []globutil.GlobMatcher, includeFilePatterns []globutil.GlobMatcher) *ResolverManager {
@@ -172,6 +187,7 @@ func NewResolverManager(followMonorepoPackages FollowMonorepoPackagesValue, cond
 		conditionNames:         conditionNames,
 		rootParams:             rootParams,
 		filesAndExtensions:     &map[string]string{},
+		mainFieldsByPackage:    map[string]*mainFieldsInfo{},
 	}
 
 	for _, filePath := range rootParams.SortedFiles {
@@ -670,6 +686,10 @@ func NewImportsResolver(dirPath string, tsconfigContent []byte, packageJsonConte
 
 	factory.nodeModules = mergeNodeModules(deps, devDeps)
 
+	if manager != nil && len(manager.rootParams.MainFields) > 0 {
+		factory.mainFields = newMainFieldsInfo(dirPath, rawPackageJson, manager.rootParams.MainFields)
+	}
+
 	return factory
 }
 
@@ -754,11 +774,34 @@ func applyWildcardValue(target string, wildcardValue string) string {
 }
 
 func (f *ModuleResolver) getModulePathWithExtension(modulePath string) (path string, err *ResolutionError) {
+	if f.trace == nil {
+		return f.probeModulePathWithExtension(modulePath, nil)
+	}
+	candidates := []string{}
+	path, err = f.probeModulePathWithExtension(modulePath, &candidates)
+	if err != nil {
+		f.trace.add(TraceStrategyExtensionProbing, "not found", modulePath, candidates...)
+	} else {
+		f.trace.add(TraceStrategyExtensionProbing, "resolved", path, candidates...)
+	}
+	return path, err
+}
+
+// probeModulePathWithExtension looks modulePath up in the discovered files index. When candidates
+// is not nil, every lookup key tried is appended to it.
+func (f *ModuleResolver) probeModulePathWithExtension(modulePath string, candidates *[]string) (path string, err *ResolutionError) {
+	probe := func(key string) (string, bool) {
+		if candidates != nil && (len(*candidates) == 0 || (*candidates)[len(*candidates)-1] != key) {
+			*candidates = append(*candidates, key)
+		}
+		return f.manager.lookupFileExtension(key)
+	}
+
 	match := extensionRegExp.FindString(modulePath)
 	if match != "" {
 		// Explicit extension import, modulePath contains extension
 		explicitBase := strings.TrimSuffix(modulePath, match)
-		explicitExt, hasExplicitBase := f.manager.lookupFileExtension(explicitBase)
+		explicitExt, hasExplicitBase := probe(explicitBase)
 		if hasExplicitBase && explicitExt == match {
 			return modulePath, nil
 		}
@@ -766,7 +809,7 @@ func (f *ModuleResolver) getModulePathWithExtension(modulePath string) (path str
 		// Explicit extension import, modulePath contains extension, special-case explicit index imports like ".../dir/index.ts".
 		if strings.HasPrefix(match, "/index") {
 			indexBase := explicitBase + "/index"
-			indexExt, hasIndexBase := f.manager.lookupFileExtension(indexBase)
+			indexExt, hasIndexBase := probe(indexBase)
 			expectedIndexExt := strings.TrimPrefix(match, "/index")
 			if hasIndexBase && indexExt == expectedIndexExt {
 				return modulePath, nil
@@ -785,7 +828,7 @@ func (f *ModuleResolver) getModulePathWithExtension(modulePath string) (path str
 	suffixes := f.tsConfigParsed.ModuleSuffixes
 	if len(suffixes) == 0 {
 		// No suffixes configured - direct lookup
-		extension, has := f.manager.lookupFileExtension(modulePath)
+		extension, has := probe(modulePath)
 		if has {
 			return modulePath + extension, nil
 		}
@@ -796,7 +839,7 @@ func (f *ModuleResolver) getModulePathWithExtension(modulePath string) (path str
 	// Try each suffix in order
 	for _, suffix := range suffixes {
 		suffixedPath := modulePath + suffix
-		extension, has := f.manager.lookupFileExtension(suffixedPath)
+		extension, has := probe(suffixedPath)
 		if has {
 			return suffixedPath + extension, nil
 		}
@@ -804,7 +847,7 @@ func (f *ModuleResolver) getModulePathWithExtension(modulePath string) (path str
 		// For non-empty suffixes, also try index files: basePath + "/index" + suffix
 		if suffix != "" {
 			indexPath := modulePath + "/index" + suffix
-			extension, has := f.manager.lookupFileExtension(indexPath)
+			extension, has := probe(indexPath)
 			if has {
 				return indexPath + extension, nil
 			}
@@ -865,6 +908,7 @@ var NotResolvedPath = "NotResolvedPath"
 
 func (f *ModuleResolver) tryResolvePackageJsonImport(request string, root string) (requestMatched bool, resolvedPath string, rtype ResolvedImportType, err *ResolutionError) {
 	if !strings.HasPrefix(request, "#") {
+		f.trace.add(TraceStrategyPackageJsonImport, "skipped", "request does not start with #")
 		return false, NotResolvedPath, NotResolvedModule, nil
 	}
 
@@ -896,6 +940,7 @@ func (f *ModuleResolver) tryResolvePackageJsonImport(request string, root string
 	}
 
 	if resolvedTarget == "" {
+		f.trace.add(TraceStrategyPackageJsonImport, "no match", "no imports entry of "+f.packageJsonPath+" matches with conditions "+formatConditionNames(f.packageJsonImports.ConditionNames))
 		return false, NotResolvedPath, NotResolvedModule, nil
 	}
 
@@ -903,6 +948,7 @@ func (f *ModuleResolver) tryResolvePackageJsonImport(request string, root string
 	if strings.HasPrefix(resolvedTarget, "./") {
 		resolvedTarget = filepath.Join(root, resolvedTarget)
 	}
+	f.trace.add(TraceStrategyPackageJsonImport, "matched", "imports entry of "+f.packageJsonPath+" with conditions "+formatConditionNames(f.packageJsonImports.ConditionNames), resolvedTarget)
 
 	modulePath := pathutil.NormalizePathForInternal(resolvedTarget)
 	actualFilePath, e := f.getModulePathWithExtension(modulePath)
@@ -928,6 +974,7 @@ func (f *ModuleResolver) tryResolveTsAlias(request string) (requestMatched bool,
 		resolvedTarget := alias
 		modulePath := filepath.Join(root, resolvedTarget)
 		modulePath = pathutil.NormalizePathForInternal(modulePath)
+		f.trace.add(TraceStrategyTsAlias, "matched", "paths entry "+aliasKey, modulePath)
 
 		actualFilePath, e := f.getModulePathWithExtension(modulePath)
 		if e != nil {
@@ -974,6 +1021,7 @@ func (f *ModuleResolver) tryResolveTsAlias(request string) (requestMatched bool,
 
 		modulePath := filepath.Join(root, resolvedTarget)
 		modulePath = pathutil.NormalizePathForInternal(modulePath)
+		f.trace.add(TraceStrategyTsAlias, "matched", "paths entry "+aliasKey, modulePath)
 
 		actualFilePath, e := f.getModulePathWithExtension(modulePath)
 
@@ -987,6 +1035,7 @@ func (f *ModuleResolver) tryResolveTsAlias(request string) (requestMatched bool,
 		return true, actualFilePath, UserModule, nil
 	}
 
+	f.trace.add(TraceStrategyTsAlias, "no match", "no paths entry matches")
 	return false, NotResolvedPath, NotResolvedModule, nil
 }
 
@@ -1024,25 +1073,30 @@ func (f *ModuleResolver) tryResolveWorkspacePackageImport(request string, root s
 	// Check if it is a workspace package import (Monorepo support)
 	// Only if manager is present and monorepo is enabled
 	if f.manager == nil || !f.manager.followMonorepoPackages.IsEnabled() || f.manager.monorepoContext == nil {
+		f.trace.add(TraceStrategyWorkspacePackage, "skipped", "monorepo packages are not followed")
 		return false, NotResolvedPath, NotResolvedModule, nil
 	}
 
 	pkgName := module.GetNodeModuleName(request)
 
 	if !f.manager.followMonorepoPackages.ShouldFollowPackage(pkgName) {
+		f.trace.add(TraceStrategyWorkspacePackage, "skipped", pkgName+" is not in the followed packages")
 		return false, NotResolvedPath, NotResolvedModule, nil
 	}
 
 	pkgPath, ok := f.manager.monorepoContext.PackageToPath[pkgName]
 
 	if !ok {
+		f.trace.add(TraceStrategyWorkspacePackage, "no match", pkgName+" is not a workspace package")
 		return false, NotResolvedPath, NotResolvedModule, nil
 	}
 
 	// Validate dependency relationship
 	if !f.validateWorkspaceDependency(root, pkgName) {
+		f.trace.add(TraceStrategyWorkspacePackage, "no match", pkgName+" is not declared as a dependency of "+root)
 		return false, NotResolvedPath, NotResolvedModule, nil
 	}
+	f.trace.add(TraceStrategyWorkspacePackage, "matched", pkgName+" at "+pkgPath)
 
 	// Extract subpath from request
 	subpath := "."
@@ -1062,8 +1116,10 @@ func (f *ModuleResolver) tryResolveWorkspacePackageImport(request string, root s
 		exports, _ := f.manager.monorepoContext.GetPackageExports(pkgPath, f.manager.conditionNames)
 		if exports != nil && len(exports.Exports) > 0 {
 			// Package has exports but the subpath wasn't found, so fail
+			f.trace.add(TraceStrategyExports, "no match", "subpath "+subpath+" is not exported with conditions "+formatConditionNames(f.manager.conditionNames))
 			return false, NotResolvedPath, NotResolvedModule, nil
 		}
+		f.trace.add(TraceStrategyExports, "skipped", pkgName+" has no exports field")
 
 		// No exports, try fallback
 		actualFilePath, err = f.resolvePackageFallback(pkgPath, subpath)
@@ -1074,8 +1130,15 @@ func (f *ModuleResolver) tryResolveWorkspacePackageImport(request string, root s
 
 	// Cache and return the result
 	if actualFilePath != "" {
-		f.cacheAlias(request, ResolvedModuleInfo{Path: actualFilePath, Type: MonorepoModule})
-		return true, actualFilePath, MonorepoModule, nil
+		resolvedType := MonorepoModule
+		if info := f.manager.mainFieldsForPackage(pkgPath); info != nil {
+			actualFilePath, resolvedType, err = f.applyFileReplacement(info, actualFilePath, resolvedType)
+			if err != nil {
+				return true, actualFilePath, MonorepoModule, err
+			}
+		}
+		f.cacheAlias(request, ResolvedModuleInfo{Path: actualFilePath, Type: resolvedType})
+		return true, actualFilePath, resolvedType, nil
 	}
 
 	return false, NotResolvedPath, NotResolvedModule, nil
@@ -1102,11 +1165,13 @@ func (f *ModuleResolver) resolvePackageExports(pkgPath, subpath string) (string,
 	// resolvedExport is relative to target package root
 	fullPath := filepath.Join(pkgPath, resolvedExport)
 	modulePath := pathutil.NormalizePathForInternal(fullPath)
+	f.trace.add(TraceStrategyExports, "matched", "subpath "+subpath+" with conditions "+formatConditionNames(f.manager.conditionNames), modulePath)
 	actualFilePath, resolveErr := f.getModulePathWithExtension(modulePath)
 	return actualFilePath, resolveErr
 }
 
-// resolvePackageFallback resolves the subpath using main/module fallback when no exports are defined
+// resolvePackageFallback resolves the subpath using main/module fallback when no exports are defined.
+// With mainFields configured, the first listed field with a string value is the entry instead.
 func (f *ModuleResolver) resolvePackageFallback(pkgPath, subpath string) (string, *ResolutionError) {
 	config, err := f.manager.monorepoContext.GetPackageConfig(pkgPath)
 	if err != nil {
@@ -1115,7 +1180,11 @@ func (f *ModuleResolver) resolvePackageFallback(pkgPath, subpath string) (string
 	}
 
 	resolvedSubpath := subpath
-	if subpath == "." {
+	if info := f.manager.mainFieldsForPackage(pkgPath); subpath == "." && info != nil {
+		if info.entry != "" {
+			resolvedSubpath = info.entry
+		}
+	} else if subpath == "." {
 		if config.Module != "" {
 			resolvedSubpath = config.Module
 		} else if config.Main != "" {
@@ -1125,6 +1194,7 @@ func (f *ModuleResolver) resolvePackageFallback(pkgPath, subpath string) (string
 
 	fullPath := filepath.Join(pkgPath, resolvedSubpath)
 	modulePath := pathutil.NormalizePathForInternal(fullPath)
+	f.trace.add(TraceStrategyWorkspacePackage, "matched", "package entry "+resolvedSubpath, modulePath)
 	actualFilePath, resolveErr := f.getModulePathWithExtension(modulePath)
 	return actualFilePath, resolveErr
 }
@@ -1169,12 +1239,28 @@ func (f *ModuleResolver) ResolveModule(request string, filePath string) (path st
 
 	cached, ok := f.cachedAlias(requestWithoutQuery)
 
-	if ok {
+	if ok && f.trace == nil {
 		return cached.Path, cached.Type, nil
 	}
 
 	root := f.resolverRoot
 
+	// "browser" / "react-native" module replacements apply before any other resolution step.
+	if replacement, ok := f.mainFields.replaceModule(requestWithoutQuery); ok {
+		switch {
+		case replacement.Empty:
+			f.trace.add(TraceStrategyMainFields, "matched", requestWithoutQuery+" is mapped to false (empty module)")
+			return "", ExcludedByUser, nil
+		case replacement.Path != "":
+			f.trace.add(TraceStrategyMainFields, "matched", requestWithoutQuery+" is mapped to "+replacement.Path)
+			p, e := f.getModulePathWithExtension(replacement.Path)
+			return p, UserModule, e
+		default:
+			f.trace.add(TraceStrategyMainFields, "matched", requestWithoutQuery+" is mapped to "+replacement.Module)
+			requestWithoutQuery = replacement.Module
+		}
+	}
+
 	// Relative path. filepath.Rel is only needed here, and it is one of the more expensive
 	// helpers in path/filepath (it cleans both arguments and walks them segment by segment),
 	// so it stays inside this branch rather than running for every bare specifier too.
@@ -1183,8 +1269,12 @@ func (f *ModuleResolver) ResolveModule(request string, filePath string) (path st
 		// filepath.Join already cleans its result, so no second Clean is needed.
 		modulePath := filepath.Join(root, relativeFileName, "../"+requestWithoutQuery)
 		modulePathInternal := pathutil.NormalizePathForInternal(modulePath)
+		f.trace.add(TraceStrategyRelative, "matched", "relative to "+filePath, modulePathInternal)
 
 		p, e := f.getModulePathWithExtension(modulePathInternal)
+		if e == nil && f.mainFields != nil {
+			return f.applyFileReplacement(f.mainFields, p, UserModule)
+		}
 
 		return p, UserModule, e
 	}
@@ -1207,6 +1297,8 @@ func (f *ModuleResolver) ResolveModule(request string, filePath string) (path st
 		if requestMatched, resolvedPath, rtype, err := f.tryResolveWorkspacePackageImport(requestWithoutQuery, root); requestMatched {
 			return resolvedPath, rtype, err
 		}
+	} else {
+		f.trace.add(TraceStrategyWorkspacePackage, "skipped", "a package.json imports entry matched the request")
 	}
 
 	if requestMatched, resolvedPath, rtype, err := f.tryResolveTsAlias(requestWithoutQuery); requestMatched {
@@ -1223,6 +1315,7 @@ func (f *ModuleResolver) ResolveModule(request string, filePath string) (path st
 	// Only try workspace package resolution again if we have a different target to resolve
 	// This avoids redundant calls when the target is the same as the original request
 	if aliasMatchedButFileNotFound != "" && aliasMatchedButFileNotFound != requestWithoutQuery {
+		f.trace.add(TraceStrategyWorkspacePackage, "retry", "retrying with the alias target "+aliasMatchedButFileNotFound)
 		if requestMatched, resolvedPath, rtype, err := f.tryResolveWorkspacePackageImport(aliasMatchedButFileNotFound, root); requestMatched {
 			return resolvedPath, rtype, err
 		}
@@ -1237,10 +1330,11 @@ func (f *ModuleResolver) ResolveModule(request string, filePath string) (path st
 	return "", NotResolvedModule, &e
 }
 
-func ResolveImports(fileImportsArr []FileImports, sortedFiles []string, cwd string, ignoreTypeImports bool, skipResolveMissing bool, packageJson string, tsconfigJson string, excludeFilePatterns []globutil.GlobMatcher, includeFilePatterns []globutil.GlobMatcher, conditionNames []string, followMonorepoPackages FollowMonorepoPackagesValue, explicitPackageDirs []string, customAssetExtensions []string, parseMode ParseMode, nodeModulesMatchingStrategy NodeModulesMatchingStrategy) (fileImports []FileImports, adjustedSortedFiles []string, resolverManager *ResolverManager) {
-
+// readRootConfigFiles reads the tsconfig (with extends merged) and package.json the root resolver
+// is built from. Exits when an explicitly passed tsconfig cannot be parsed.
+func readRootConfigFiles(cwd string, packageJson string, tsconfigJson string) (tsconfigContent []byte, pkgJsonContent []byte, pkgJsonPath string) {
 	tsConfigPath := pathutil.JoinWithCwd(cwd, tsconfigJson)
-	pkgJsonPath := pathutil.JoinWithCwd(cwd, packageJson)
+	pkgJsonPath = pathutil.JoinWithCwd(cwd, packageJson)
 
 	if tsconfigJson == "" {
 		tsConfigPath = filepath.Join(cwd, "tsconfig.json")
@@ -1257,7 +1351,7 @@ func ResolveImports(fileImportsArr []FileImports, sortedFiles []string, cwd stri
 	merged, err := ParseTsConfig(tsConfigPath)
 	doneTsconfig()
 
-	tsconfigContent := []byte("")
+	tsconfigContent = []byte("")
 	if err == nil {
 		tsconfigContent = merged
 	} else {
@@ -1267,12 +1361,34 @@ func ResolveImports(fileImportsArr []FileImports, sortedFiles []string, cwd stri
 		}
 	}
 
-	pkgJsonContent, err := os.ReadFile(pkgJsonPath)
+	pkgJsonContent, err = os.ReadFile(pkgJsonPath)
 
 	if err != nil {
 		pkgJsonContent = []byte("")
 	}
 
+	return tsconfigContent, pkgJsonContent, pkgJsonPath
+}
+
+// NewResolverManagerForCwd builds the resolver manager ResolveImports would use for cwd, without
+// resolving any file. sortedFiles seeds the discovered files index used for extension probing.
+func NewResolverManagerForCwd(cwd string, packageJson string, tsconfigJson string, conditionNames []string, mainFields []string, followMonorepoPackages FollowMonorepoPackagesValue, sortedFiles []string, excludeFilePatterns []globutil.GlobMatcher, includeFilePatterns []globutil.GlobMatcher) *ResolverManager {
+	tsconfigContent, pkgJsonContent, pkgJsonPath := readRootConfigFiles(cwd, packageJson, tsconfigJson)
+
+	return NewResolverManager(followMonorepoPackages, conditionNames, RootParams{
+		TsConfigContent: tsconfigContent,
+		PkgJsonContent:  pkgJsonContent,
+		PkgJsonPath:     pkgJsonPath,
+		SortedFiles:     sortedFiles,
+		Cwd:             cwd,
+		MainFields:      mainFields,
+	}, excludeFilePatterns, includeFilePatterns)
+}
+
+func ResolveImports(fileImportsArr []FileImports, sortedFiles []string, cwd string, ignoreTypeImports bool, skipResolveMissing bool, packageJson string, tsconfigJson string, excludeFilePatterns []globutil.GlobMatcher, includeFilePatterns []globutil.GlobMatcher, conditionNames []string, mainFields []string, followMonorepoPackages FollowMonorepoPackagesValue, explicitPackageDirs []string, customAssetExtensions []string, parseMode ParseMode, nodeModulesMatchingStrategy NodeModulesMatchingStrategy) (fileImports []FileImports, adjustedSortedFiles []string, resolverManager *ResolverManager) {
+
+	tsconfigContent, pkgJsonContent, pkgJsonPath := readRootConfigFiles(cwd, packageJson, tsconfigJson)
+
 	doneRM := perf.Track("resolve-imports/resolver-manager")
 	resolverManager = NewResolverManager(followMonorepoPackages, conditionNames, RootParams{
 		TsConfigContent:     tsconfigContent,
@@ -1281,6 +1397,7 @@ func ResolveImports(fileImportsArr []FileImports, sortedFiles []string, cwd stri
 		SortedFiles:         sortedFiles,
 		Cwd:                 cwd,
 		ExplicitPackageDirs: explicitPackageDirs,
+		MainFields:          mainFields,
 	}, excludeFilePatterns, includeFilePatterns)
 
 	doneRM()
@@ -1397,7 +1514,22 @@ func resolveSingleFileImports(resolverManager *ResolverManager, missingResolutio
 			continue
 		}
 
-		moduleName := module.GetNodeModuleName(imp.Request)
+		request := imp.Request
+		replacedWithFile := false
+		if replacement, ok := importsResolver.mainFields.replaceModule(request); ok {
+			if replacement.Empty {
+				// Mapped to false in "browser" / "react-native": bundlers substitute an empty module.
+				imports[impIdx].PathOrName = request
+				imports[impIdx].ResolvedType = ExcludedByUser
+				continue
+			}
+			replacedWithFile = replacement.Path != ""
+			if replacement.Module != "" {
+				request = replacement.Module
+			}
+		}
+
+		moduleName := module.GetNodeModuleName(request)
 
 		// No lock from here through the end of the classification below. Everything read
 		// in this stretch is either immutable for the whole resolution phase
@@ -1408,7 +1540,7 @@ func resolveSingleFileImports(resolverManager *ResolverManager, missingResolutio
 		// each index is pushed to ch_idx exactly once — so no two goroutines write the
 		// same element. The shared discovery bookkeeping further down still takes mu.
 		_, isBuiltInModule := builtInModules[moduleName]
-		if isBuiltInModule {
+		if isBuiltInModule && !replacedWithFile {
 			imports[impIdx].PathOrName = moduleName
 			imports[impIdx].ResolvedType = BuiltInModule
 			continue
@@ -1435,6 +1567,7 @@ func resolveSingleFileImports(resolverManager *ResolverManager, missingResolutio
 		}
 
 		_, isNodeModule := nodeModulesList[moduleName]
+		isNodeModule = isNodeModule && !replacedWithFile
 
 		if isNodeModule && resolutionErr != nil {
 			// Check if it's a followed workspace package, only if not, consider package a node module
@@ -1547,7 +1680,14 @@ func resolveSingleFileImports(resolverManager *ResolverManager, missingResolutio
 
 		} else {
 			// resolved to a path; if it's excluded by user, mark and do not add to discovery
-			if globutil.IsExcludedByPatterns(importPath, excludeFilePatterns, includeFilePatterns) {
+			if resolvedType == ExcludedByUser {
+				// Replaced by an empty module through the "browser" / "react-native" field.
+				imports[impIdx].PathOrName = importPath
+				if importPath == "" {
+					imports[impIdx].PathOrName = request
+				}
+				imports[impIdx].ResolvedType = ExcludedByUser
+			} else if globutil.IsExcludedByPatterns(importPath, excludeFilePatterns, includeFilePatterns) {
 				imports[impIdx].PathOrName = importPath
 				imports[impIdx].ResolvedType = ExcludedByUser
 			} else {
@@ -8,6 +8,9 @@ import (
 	"strings"
 
 	"github.com/tidwall/jsonc"
+
+	"rev-dep-go/internal/module"
+	"rev-dep-go/internal/pnp"
 )
 
 // ParseTsConfig reads tsconfig from disk (JSON or JSONC) at tsconfigPath and
@@ -92,11 +95,10 @@ func resolveExtends(cfg map[string]interface{}, baseDir string, seen map[string]
 	var baseCfg map[string]interface{}
 	var foundPath string
 	for _, cand := range candidates {
-		// try exact file
-		fi, err := os.Stat(cand)
-		if err == nil && !fi.IsDir() {
+		// try exact file (Yarn PnP packages are read from their cache archive)
+		if pnp.IsFile(cand) {
 			// read file
-			bb, err := os.ReadFile(cand)
+			bb, err := pnp.ReadFile(cand)
 			if err != nil {
 				continue
 			}
@@ -198,13 +200,43 @@ func tsConfigPackageExtendsCandidates(baseDir, extStr string) []string {
 		}
 		dir = parent
 	}
-	return candidates
+	return append(candidates, tsConfigPnPExtendsCandidates(baseDir, extStr)...)
+}
+
+// tsConfigPnPExtendsCandidates returns candidate file paths for a bare `extends`
+// specifier in a Yarn Plug'n'Play project, where the package is located through
+// the PnP manifest (usually inside a zip archive) instead of node_modules.
+func tsConfigPnPExtendsCandidates(baseDir, extStr string) []string {
+	manifestDir := pnp.Find(baseDir)
+	if manifestDir == "" {
+		return nil
+	}
+	manifest, err := pnp.Load(manifestDir)
+	if err != nil {
+		return nil
+	}
+
+	pkgName := module.GetNodeModuleName(extStr)
+	pkg := manifest.ResolveDependency(manifest.FindPackageForPath(baseDir), pkgName)
+	if pkg == nil {
+		return nil
+	}
+
+	target := pkg.Location
+	if subPath := strings.TrimPrefix(strings.TrimPrefix(extStr, pkgName), "/"); subPath != "" {
+		target = filepath.Join(pkg.Location, subPath)
+	}
+	candidates := []string{target, target + ".json"}
+	if main := readPackageJSONMain(target); main != "" {
+		candidates = append(candidates, filepath.Join(target, main))
+	}
+	return append(candidates, filepath.Join(target, "tsconfig.json"))
 }
 
 // readPackageJSONMain returns the "main" field of pkgDir/package.json, or "" if
 // the file is missing or unparsable.
 func readPackageJSONMain(pkgDir string) string {
-	content, err := os.ReadFile(filepath.Join(pkgDir, "package.json"))
+	content, err := pnp.ReadFile(filepath.Join(pkgDir, "package.json"))
 	if err != nil {
 		return ""
 	}
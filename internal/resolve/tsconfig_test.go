@@ -274,3 +274,29 @@ func TestParseTsConfig_Extends_RebasePaths(t *testing.T) {
 		t.Fatalf("expected rebased utils path %q got %v", expected, arr3)
 	}
 }
+
+func TestParseTsConfig_Extends_YarnPnPPackage(t *testing.T) {
+	// The fixture has no node_modules: "@repo/tsconfig/base.json" is located through .pnp.cjs and
+	// read from the zip archive in .yarn/cache.
+	cfgPath := filepath.Join("..", "..", "__fixtures__", "yarnPnP", "tsconfig.json")
+	merged, err := ParseTsConfig(cfgPath)
+	if err != nil {
+		t.Fatalf("ParseTsConfig error: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(merged, &out); err != nil {
+		t.Fatalf("unmarshal merged: %v", err)
+	}
+
+	co, ok := out["compilerOptions"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("compilerOptions missing in merged")
+	}
+	if co["strict"] != true {
+		t.Fatalf("expected strict from PnP base config, got %v", co["strict"])
+	}
+	if typesArr, ok := co["types"].([]interface{}); !ok || len(typesArr) != 1 || typesArr[0] != "node" {
+		t.Fatalf("expected types [node] from PnP base config, got %v", co["types"])
+	}
+}
@@ -0,0 +1,106 @@
+package resolve
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"rev-dep-go/internal/monorepo"
+	"rev-dep-go/internal/pathutil"
+)
+
+// ResolveWorkspaceExport resolves subpath ("." or "./button") of the workspace package at
+// packagePath through its `exports`, using the conditions of the resolver owning importerPath.
+// hasExports is false when the package defines no exports, in which case every file is public.
+func (rm *ResolverManager) ResolveWorkspaceExport(importerPath, packagePath, subpath string) (filePath string, hasExports bool) {
+	exports, resolver := rm.workspaceExports(importerPath, packagePath)
+	if exports == nil {
+		return "", false
+	}
+	return resolver.resolveWorkspaceExport(exports, packagePath, subpath), true
+}
+
+// WorkspaceExportSubpath returns the subpath of the workspace package at packagePath that its
+// `exports` map to filePath, preferring exact keys over wildcard keys. It returns "" when the
+// package has no exports or none of them leads to filePath.
+func (rm *ResolverManager) WorkspaceExportSubpath(importerPath, packagePath, filePath string) string {
+	exports, resolver := rm.workspaceExports(importerPath, packagePath)
+	if exports == nil {
+		return ""
+	}
+
+	exactKeys := []string{"."}
+	if exports.HasDotPrefix {
+		exactKeys = exactKeys[:0]
+		for key := range exports.Exports {
+			if !strings.Contains(key, "*") {
+				exactKeys = append(exactKeys, key)
+			}
+		}
+		slices.Sort(exactKeys)
+	}
+	for _, key := range exactKeys {
+		if resolver.resolveWorkspaceExport(exports, packagePath, key) == filePath {
+			return key
+		}
+	}
+
+	relativePath, inPackage := strings.CutPrefix(filePath, pathutil.StandardiseDirPathInternal(pathutil.NormalizePathForInternal(packagePath)))
+	if !inPackage {
+		return ""
+	}
+	// Extensionless subpaths first, they are what a request would normally spell.
+	candidates := []string{strings.TrimSuffix(relativePath, filepath.Ext(relativePath)), relativePath}
+
+	patterns := slices.Clone(exports.WildcardPatterns)
+	slices.SortFunc(patterns, func(a, b monorepo.WildcardPattern) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	for _, pattern := range patterns {
+		target := strings.TrimPrefix(resolver.resolveCondition(exports.Exports[pattern.Key]), "./")
+		prefix, suffix, found := strings.Cut(target, "*")
+		if !found {
+			continue
+		}
+		for _, candidate := range candidates {
+			if len(candidate) <= len(prefix)+len(suffix) || !strings.HasPrefix(candidate, prefix) || !strings.HasSuffix(candidate, suffix) {
+				continue
+			}
+			subpath := pattern.Prefix + candidate[len(prefix):len(candidate)-len(suffix)] + pattern.Suffix
+			if resolver.resolveWorkspaceExport(exports, packagePath, subpath) == filePath {
+				return subpath
+			}
+		}
+	}
+	return ""
+}
+
+// workspaceExports returns the exports of the package at packagePath together with the resolver
+// whose conditions apply to importerPath, or nil when the package defines no exports.
+func (rm *ResolverManager) workspaceExports(importerPath, packagePath string) (*PackageJsonExports, *ModuleResolver) {
+	if rm.monorepoContext == nil {
+		return nil, nil
+	}
+	exports, err := rm.monorepoContext.GetPackageExports(packagePath, rm.conditionNames)
+	if err != nil || exports == nil || len(exports.Exports) == 0 {
+		return nil, nil
+	}
+	resolver := rm.GetResolverForFile(importerPath)
+	if resolver == nil {
+		return nil, nil
+	}
+	return exports, resolver
+}
+
+// resolveWorkspaceExport resolves subpath through exports to a file on disk, "" if it is not exported.
+func (f *ModuleResolver) resolveWorkspaceExport(exports *PackageJsonExports, packagePath, subpath string) string {
+	resolved := f.resolveExportsCached(exports, subpath)
+	if resolved == "" || strings.Contains(resolved, "*") {
+		return ""
+	}
+	filePath, err := f.getModulePathWithExtension(pathutil.NormalizePathForInternal(filepath.Join(packagePath, resolved)))
+	if err != nil {
+		return ""
+	}
+	return filePath
+}
@@ -81,6 +81,219 @@ type RestrictedDirectImportersDetectionOptions struct {
 
 func (o *RestrictedDirectImportersDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
 
+// WorkspaceProtocolDetectionOptions configures validation of dependency specifiers between
+// workspace packages. For every workspace package under the rule path it reports:
+//   - a dependency on a sibling workspace package that does not use the `workspace:` protocol;
+//   - a `workspace:<range>` (or, with AllowVersionRanges, plain range) that the sibling's version
+//     does not satisfy;
+//   - a `catalog:` / `catalog:<name>` specifier with no matching entry in pnpm-workspace.yaml.
+//
+// AllowVersionRanges accepts plain ranges for siblings (npm and yarn classic workspaces do not
+// support the protocol); the ranges are still checked against the sibling's version.
+// IgnoreDependencies holds dependency name globs excluded from the check.
+type WorkspaceProtocolDetectionOptions struct {
+	Enabled            bool     `json:"enabled"`
+	AllowVersionRanges bool     `json:"allowVersionRanges,omitempty"`
+	IgnoreDependencies []string `json:"ignoreDependencies,omitempty"`
+}
+
+func (o *WorkspaceProtocolDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// LayerDefinition is one named layer of a LayersDetectionOptions. A file belongs to the first
+// layer (in order) whose Patterns match it. AllowImports lists globs of files the layer may import
+// regardless of the layer order (per-layer exceptions).
+type LayerDefinition struct {
+	Name         string   `json:"name"`
+	Patterns     []string `json:"patterns"`
+	AllowImports []string `json:"allowImports,omitempty"`
+}
+
+// LayersDetectionOptions configures a layered architecture check. Layers are ordered from the top
+// (e.g. ui) to the bottom (e.g. infrastructure) and a layer may import only from layers below it.
+// AllowSameLayer also permits imports between files of the same layer, and Strict narrows the
+// allowed targets to the immediate lower layer. Files matching no layer are not checked.
+type LayersDetectionOptions struct {
+	Enabled        bool              `json:"enabled"`
+	Layers         []LayerDefinition `json:"layers,omitempty"`
+	AllowSameLayer bool              `json:"allowSameLayer,omitempty"`
+	Strict         bool              `json:"strict,omitempty"`
+}
+
+func (o *LayersDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// BarrelFilesDetectionOptions configures barrel file analysis. A barrel is a file made only of
+// re-exports (`export ... from`); its fan-out is the number of distinct modules it re-exports from.
+//   - ReportBarrelFiles reports barrels whose fan-out exceeds MaxFanOut (every barrel when 0).
+//   - PublicBarrels are globs of barrels forming the public API of their directory: files outside
+//     that directory must import through the barrel instead of deep-importing files inside it.
+//   - NoBarrelImportsWithinFeature is the opposite policy: files inside a barrel's directory must
+//     not import through that barrel. With Autofix such imports are rewritten to the file that
+//     declares the imported names, when they can be traced through the re-exports.
+//
+// IgnoreFiles holds globs of files excluded from the check (as barrels and as importers).
+type BarrelFilesDetectionOptions struct {
+	Enabled                      bool     `json:"enabled"`
+	ReportBarrelFiles            bool     `json:"reportBarrelFiles,omitempty"`
+	MaxFanOut                    int      `json:"maxFanOut,omitempty"`
+	PublicBarrels                []string `json:"publicBarrels,omitempty"`
+	NoBarrelImportsWithinFeature bool     `json:"noBarrelImportsWithinFeature,omitempty"`
+	IgnoreFiles                  []string `json:"ignoreFiles,omitempty"`
+	Autofix                      bool     `json:"autofix,omitempty"`
+}
+
+func (o *BarrelFilesDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// TypeImportsDetectionOptions configures the check for value imports of names that the target
+// module exports only as types, or that the importing file only re-exports with `export type`.
+// Such imports should use `import type` (or inline `type` specifiers) so they are erased at compile
+// time, as required by `verbatimModuleSyntax`, and do not create runtime edges between modules.
+//   - Autofix converts a statement whose bindings are all type-only to `import type`, and adds
+//     inline `type` specifiers otherwise. PreferInline uses inline specifiers in both cases.
+//
+// IgnoreFiles holds globs of importing files excluded from the check.
+type TypeImportsDetectionOptions struct {
+	Enabled      bool     `json:"enabled"`
+	IgnoreFiles  []string `json:"ignoreFiles,omitempty"`
+	Autofix      bool     `json:"autofix,omitempty"`
+	PreferInline bool     `json:"preferInline,omitempty"`
+}
+
+func (o *TypeImportsDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// UnusedWorkspacePackagesDetectionOptions configures the monorepo check for dead workspace
+// packages (no other package imports them) and declared workspace dependencies that are never
+// imported. RootPackages are package name globs of roots and deployables (apps, services) that are
+// never reported as unused; packages with a `bin` field or containing a file matching EntryPoints
+// are treated the same way. EntryPoints defaults to the rule's prodEntryPoints and devEntryPoints.
+// IgnorePackages are package name globs excluded from both reports.
+type UnusedWorkspacePackagesDetectionOptions struct {
+	Enabled        bool     `json:"enabled"`
+	RootPackages   []string `json:"rootPackages,omitempty"`
+	EntryPoints    []string `json:"entryPoints,omitempty"`
+	IgnorePackages []string `json:"ignorePackages,omitempty"`
+}
+
+func (o *UnusedWorkspacePackagesDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// DeepImportsDetectionOptions configures the monorepo check for imports that resolve to files of a
+// workspace package that its `exports` do not expose, such as `@org/ui/src/internal/button` or a
+// relative path into a sibling package. Packages without an `exports` field are not checked.
+// IgnorePackages are target package name globs and IgnoreFiles are importer globs to skip.
+type DeepImportsDetectionOptions struct {
+	Enabled        bool     `json:"enabled"`
+	IgnorePackages []string `json:"ignorePackages,omitempty"`
+	IgnoreFiles    []string `json:"ignoreFiles,omitempty"`
+}
+
+func (o *DeepImportsDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// ComplexityBudget sets thresholds for the files matching Files. A zero threshold is not checked.
+//   - MaxTransitiveDependencies: files reachable from an entry point, the entry point included
+//     (the count printed by `entry-points --print-deps-count`).
+//   - MaxImportChainDepth: import hops from an entry point to the deepest file it reaches.
+//   - MaxDirectImports: distinct files and node modules a file imports (fan-out).
+//   - MaxImporters: distinct files importing a file (fan-in).
+type ComplexityBudget struct {
+	Files                     []string `json:"files"`
+	MaxTransitiveDependencies int      `json:"maxTransitiveDependencies,omitempty"`
+	MaxImportChainDepth       int      `json:"maxImportChainDepth,omitempty"`
+	MaxDirectImports          int      `json:"maxDirectImports,omitempty"`
+	MaxImporters              int      `json:"maxImporters,omitempty"`
+}
+
+// ComplexityBudgetsDetectionOptions configures dependency depth and fan-in/fan-out budgets. For
+// each threshold a file uses the first budget (in order) that matches it and sets the threshold,
+// so specific budgets go before catch-all ones.
+//
+// EntryPoints are globs of the files the entry point thresholds apply to; by default these are the
+// files no other file imports (as listed by `rev-dep entry-points`). TopContributors caps the
+// contributors listed per violation (5 when 0). IgnoreFiles are globs of files never checked.
+type ComplexityBudgetsDetectionOptions struct {
+	Enabled           bool               `json:"enabled"`
+	Budgets           []ComplexityBudget `json:"budgets,omitempty"`
+	EntryPoints       []string           `json:"entryPoints,omitempty"`
+	IgnoreFiles       []string           `json:"ignoreFiles,omitempty"`
+	IgnoreTypeImports bool               `json:"ignoreTypeImports,omitempty"`
+	TopContributors   int                `json:"topContributors,omitempty"`
+}
+
+func (o *ComplexityBudgetsDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// CycleBudget freezes the number of circular dependencies in an area: cycles whose files all
+// match Files count against MaxCycles instead of the rule-wide maxCycles of circularImportsDetection.
+type CycleBudget struct {
+	Files     []string `json:"files"`
+	MaxCycles int      `json:"maxCycles"`
+}
+
+// TeamDependencyRule allows the files owned by From to import the files owned by any of To. Team
+// names are CODEOWNERS owners (e.g. "@org/web"); "*" matches any team.
+type TeamDependencyRule struct {
+	From string   `json:"from"`
+	To   []string `json:"to"`
+}
+
+// OwnershipBoundariesDetectionOptions configures the check of imports between files owned by
+// different teams. Owners come from the CODEOWNERS file at CodeownersPath (by default
+// .github/CODEOWNERS, CODEOWNERS or docs/CODEOWNERS, relative to the config directory); files
+// without owners are not checked. An import between files sharing an owner is within a team; any
+// other import is reported unless AllowedDependencies lets one of the importer's owners depend on
+// one of the imported file's owners. ReportOnly reports the team dependency matrix without
+// failing on disallowed imports.
+type OwnershipBoundariesDetectionOptions struct {
+	Enabled             bool                 `json:"enabled"`
+	CodeownersPath      string               `json:"codeownersPath,omitempty"`
+	AllowedDependencies []TeamDependencyRule `json:"allowedDependencies,omitempty"`
+	ReportOnly          bool                 `json:"reportOnly,omitempty"`
+	IgnoreTypeImports   bool                 `json:"ignoreTypeImports,omitempty"`
+}
+
+func (o *OwnershipBoundariesDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// TestIsolationDetectionOptions configures the check that test-only code stays out of production.
+// TestFiles are globs of test files, mocks and test utilities (common test globs when empty) and
+// FixtureFiles globs of fixtures; both are test-only. It reports:
+//   - production files (reachable from ProdEntryPoints, or every other file when there are none)
+//     importing a test-only file;
+//   - test files importing a test-only file of another workspace package;
+//   - fixtures that no test file reaches.
+//
+// ProdEntryPoints defaults to the rule's prodEntryPoints.
+type TestIsolationDetectionOptions struct {
+	Enabled           bool     `json:"enabled"`
+	TestFiles         []string `json:"testFiles,omitempty"`
+	FixtureFiles      []string `json:"fixtureFiles,omitempty"`
+	ProdEntryPoints   []string `json:"prodEntryPoints,omitempty"`
+	IgnoreTypeImports bool     `json:"ignoreTypeImports,omitempty"`
+}
+
+func (o *TestIsolationDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// PeerDependenciesDetectionOptions configures the monorepo check of peer dependencies. A workspace
+// package is a library when another workspace package declares it as a dependency or imports it.
+// PeerPackages are module name globs (e.g. "react") libraries must declare as peerDependencies
+// rather than dependencies. IgnorePackages are module name globs skipped by every part of the check.
+type PeerDependenciesDetectionOptions struct {
+	Enabled        bool     `json:"enabled"`
+	PeerPackages   []string `json:"peerPackages,omitempty"`
+	IgnorePackages []string `json:"ignorePackages,omitempty"`
+}
+
+func (o *PeerDependenciesDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
+// VersionConsistencyDetectionOptions configures the monorepo check comparing the ranges workspace
+// packages declare for the same external dependency. IgnorePackages are module name globs allowed
+// to differ between workspaces. PinnedVersions maps module names to the policy range every
+// workspace must declare. With Autofix mismatching ranges are rewritten to the expected one.
+type VersionConsistencyDetectionOptions struct {
+	Enabled        bool              `json:"enabled"`
+	IgnorePackages []string          `json:"ignorePackages,omitempty"`
+	PinnedVersions map[string]string `json:"pinnedVersions,omitempty"`
+	Autofix        bool              `json:"autofix,omitempty"`
+}
+
+func (o *VersionConsistencyDetectionOptions) IsEnabled() bool { return o != nil && o.Enabled }
+
 // ImportConventionDomain represents a single domain definition.
 type ImportConventionDomain struct {
 	Path    string `json:"path,omitempty"`
@@ -21,6 +21,17 @@ type Metrics struct {
 	RestrictedImports         int `json:"restrictedImports"`
 	RestrictedImporters       int `json:"restrictedImporters"`
 	RestrictedDirectImporters int `json:"restrictedDirectImporters"`
+	WorkspaceProtocol         int `json:"workspaceProtocol"`
+	Layers                    int `json:"layers"`
+	BarrelFiles               int `json:"barrelFiles"`
+	TypeImports               int `json:"typeImports"`
+	UnusedWorkspacePackages   int `json:"unusedWorkspacePackages"`
+	DeepImports               int `json:"deepImports"`
+	ComplexityBudgets         int `json:"complexityBudgets"`
+	OwnershipBoundaries       int `json:"ownershipBoundaries"`
+	TestIsolation             int `json:"testIsolation"`
+	PeerDependencies          int `json:"peerDependencies"`
+	VersionConsistency        int `json:"versionConsistency"`
 	ModuleBoundaries          int `json:"moduleBoundaries"`
 	ImportConventions         int `json:"importConventions"`
 
@@ -67,9 +78,23 @@ func BuildMetrics(cfg *config.RevDepConfig, fileCount int) Metrics {
 		m.RestrictedImports = max(m.RestrictedImports, countEnabled(rule.RestrictedImportsDetections))
 		m.RestrictedImporters = max(m.RestrictedImporters, countEnabled(rule.RestrictedImportersDetections))
 		m.RestrictedDirectImporters = max(m.RestrictedDirectImporters, countEnabled(rule.RestrictedDirectImportersDetections))
+		m.WorkspaceProtocol = max(m.WorkspaceProtocol, countEnabled(rule.WorkspaceProtocolDetections))
+		m.Layers = max(m.Layers, countEnabled(rule.LayersDetections))
+		m.BarrelFiles = max(m.BarrelFiles, countEnabled(rule.BarrelFilesDetections))
+		m.TypeImports = max(m.TypeImports, countEnabled(rule.TypeImportsDetections))
+		m.UnusedWorkspacePackages = max(m.UnusedWorkspacePackages, countEnabled(rule.UnusedWorkspacePackagesDetections))
+		m.DeepImports = max(m.DeepImports, countEnabled(rule.DeepImportsDetections))
+		m.ComplexityBudgets = max(m.ComplexityBudgets, countEnabled(rule.ComplexityBudgetsDetections))
+		m.OwnershipBoundaries = max(m.OwnershipBoundaries, countEnabled(rule.OwnershipBoundariesDetections))
+		m.TestIsolation = max(m.TestIsolation, countEnabled(rule.TestIsolationDetections))
+		m.PeerDependencies = max(m.PeerDependencies, countEnabled(rule.PeerDependenciesDetections))
+		m.VersionConsistency = max(m.VersionConsistency, countEnabled(rule.VersionConsistencyDetections))
 		// ModuleBoundaries and ImportConventions are presence-based (no enabled flag).
 		m.ModuleBoundaries = max(m.ModuleBoundaries, len(rule.ModuleBoundaries))
 		m.ImportConventions = max(m.ImportConventions, len(rule.ImportConventions))
+		if len(rule.ConditionNames) > 0 {
+			m.UsesConditionNames = 1
+		}
 	}
 
 	if cfg.UsesNearestPackage() {
@@ -109,6 +134,17 @@ func (m Metrics) asMeasurements() map[string]float64 {
 		"restrictedImports":            float64(m.RestrictedImports),
 		"restrictedImporters":          float64(m.RestrictedImporters),
 		"restrictedDirectImporters":    float64(m.RestrictedDirectImporters),
+		"workspaceProtocol":            float64(m.WorkspaceProtocol),
+		"layers":                       float64(m.Layers),
+		"barrelFiles":                  float64(m.BarrelFiles),
+		"typeImports":                  float64(m.TypeImports),
+		"unusedWorkspacePackages":      float64(m.UnusedWorkspacePackages),
+		"deepImports":                  float64(m.DeepImports),
+		"complexityBudgets":            float64(m.ComplexityBudgets),
+		"ownershipBoundaries":          float64(m.OwnershipBoundaries),
+		"testIsolation":                float64(m.TestIsolation),
+		"peerDependencies":             float64(m.PeerDependencies),
+		"versionConsistency":           float64(m.VersionConsistency),
 		"moduleBoundaries":             float64(m.ModuleBoundaries),
 		"importConventions":            float64(m.ImportConventions),
 		"usesNearestPackageResolution": float64(m.UsesNearestPackageResolution),